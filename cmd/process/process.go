@@ -88,6 +88,55 @@ func (c *CmdProcess) initPersistentFlags() {
 		filepath.Join(workDir, "report"),
 		cmd.FlagReportTRXPathUsage,
 	)
+
+	c.c.PersistentFlags().Float64Var(
+		&cmd.FlagAmountToleranceValue,
+		cmd.FlagAmountTolerance,
+		0,
+		cmd.FlagAmountToleranceUsage,
+	)
+
+	c.c.PersistentFlags().Float64Var(
+		&cmd.FlagAmountTolerancePercentageValue,
+		cmd.FlagAmountTolerancePercentage,
+		0,
+		cmd.FlagAmountTolerancePercentageUsage,
+	)
+
+	c.c.PersistentFlags().BoolVar(
+		&cmd.FlagIsAggregateMatchValue,
+		cmd.FlagIsAggregateMatch,
+		false,
+		cmd.FlagIsAggregateMatchUsage,
+	)
+
+	c.c.PersistentFlags().IntVar(
+		&cmd.FlagSplitMatchMaxPartsValue,
+		cmd.FlagSplitMatchMaxParts,
+		0,
+		cmd.FlagSplitMatchMaxPartsUsage,
+	)
+
+	c.c.PersistentFlags().Float64Var(
+		&cmd.FlagReviewConfidenceThresholdValue,
+		cmd.FlagReviewConfidenceThreshold,
+		0,
+		cmd.FlagReviewConfidenceThresholdUsage,
+	)
+
+	c.c.PersistentFlags().IntVar(
+		&cmd.FlagSuggestionCountValue,
+		cmd.FlagSuggestionCount,
+		3,
+		cmd.FlagSuggestionCountUsage,
+	)
+
+	c.c.PersistentFlags().BoolVar(
+		&cmd.FlagIsFailOnCoverageGapValue,
+		cmd.FlagIsFailOnCoverageGap,
+		false,
+		cmd.FlagIsFailOnCoverageGapUsage,
+	)
 }
 
 func (c *CmdProcess) Runner(_ *cobra.Command, _ []string) (er error) {
@@ -117,6 +166,106 @@ func (c *CmdProcess) Runner(_ *cobra.Command, _ []string) (er error) {
 		conf.Reconciliation.IsDeleteCurrentReportDirectory = cmd.FlagIsDeleteCurrentReportDirectoryValue
 		conf.Reconciliation.ReportTRXPath = cmd.FlagReportTRXPathValue
 
+		// Matching flags build the default matching passes, declared match_rules replace those passes so the flags
+		// would be silently ignored
+		if len(conf.Reconciliation.MatchRules) > 0 {
+			for _, flag := range []string{
+				cmd.FlagAmountTolerance,
+				cmd.FlagAmountTolerancePercentage,
+				cmd.FlagIsAggregateMatch,
+				cmd.FlagSplitMatchMaxParts,
+			} {
+				if c.c.PersistentFlags().Changed(flag) {
+					return fmt.Errorf("flag --%s can not be used with match_rules, set it in the match rules instead", flag)
+				}
+			}
+		}
+
+		// Tolerance flags only override the config file when explicitly given
+		if c.c.PersistentFlags().Changed(cmd.FlagAmountTolerance) {
+			conf.Reconciliation.AmountTolerance = cmd.FlagAmountToleranceValue
+		}
+
+		if c.c.PersistentFlags().Changed(cmd.FlagAmountTolerancePercentage) {
+			conf.Reconciliation.AmountTolerancePercentage = cmd.FlagAmountTolerancePercentageValue
+		}
+
+		if c.c.PersistentFlags().Changed(cmd.FlagIsAggregateMatch) {
+			conf.Reconciliation.IsAggregateMatch = cmd.FlagIsAggregateMatchValue
+		}
+
+		if c.c.PersistentFlags().Changed(cmd.FlagSplitMatchMaxParts) {
+			conf.Reconciliation.SplitMatchMaxParts = cmd.FlagSplitMatchMaxPartsValue
+		}
+
+		if c.c.PersistentFlags().Changed(cmd.FlagReviewConfidenceThreshold) {
+			conf.Reconciliation.ReviewConfidenceThreshold = cmd.FlagReviewConfidenceThresholdValue
+		}
+
+		if c.c.PersistentFlags().Changed(cmd.FlagSuggestionCount) {
+			conf.Reconciliation.Suggestion.MaxCount = cmd.FlagSuggestionCountValue
+		}
+
+		if c.c.PersistentFlags().Changed(cmd.FlagIsFailOnCoverageGap) {
+			conf.Reconciliation.IsFailOnCoverageGap = cmd.FlagIsFailOnCoverageGapValue
+		}
+
+		if e = conf.Reconciliation.ValidateCurrencyDecimalPlaces(); e != nil {
+			return e
+		}
+
+		if e = conf.Reconciliation.Suggestion.Validate(); e != nil {
+			return e
+		}
+
+		if e = conf.Reconciliation.FX.Validate(); e != nil {
+			return e
+		}
+
+		if e = conf.Reconciliation.ValidateBankFee(); e != nil {
+			return e
+		}
+
+		if e = conf.Reconciliation.ValidateBankParser(); e != nil {
+			return e
+		}
+
+		if e = conf.Reconciliation.ValidateBankFormat(); e != nil {
+			return e
+		}
+
+		if e = conf.Reconciliation.ValidateLocale(); e != nil {
+			return e
+		}
+
+		if e = conf.Reconciliation.Duplicate.Validate(); e != nil {
+			return e
+		}
+
+		if e = conf.Reconciliation.Reversal.Validate(); e != nil {
+			return e
+		}
+
+		if e = conf.Reconciliation.Transfer.Validate(); e != nil {
+			return e
+		}
+
+		if e = conf.Reconciliation.Gateway.Validate(); e != nil {
+			return e
+		}
+
+		if e = conf.Reconciliation.Account.Validate(); e != nil {
+			return e
+		}
+
+		if e = conf.Reconciliation.Balance.Validate(); e != nil {
+			return e
+		}
+
+		if e = conf.Reconciliation.ValidateMatchRules(); e != nil {
+			return e
+		}
+
 		return app.Start()
 	} else {
 		return e
@@ -124,6 +273,26 @@ func (c *CmdProcess) Runner(_ *cobra.Command, _ []string) (er error) {
 }
 
 func (c *CmdProcess) PersistentPreRunner(cCmd *cobra.Command, args []string) (er error) {
+	if cmd.FlagAmountToleranceValue < 0 {
+		return fmt.Errorf("'--%s': %v should not be negative", cmd.FlagAmountTolerance, cmd.FlagAmountToleranceValue)
+	}
+
+	if cmd.FlagAmountTolerancePercentageValue < 0 || cmd.FlagAmountTolerancePercentageValue > 100 {
+		return fmt.Errorf("'--%s': %v should between 0 and 100", cmd.FlagAmountTolerancePercentage, cmd.FlagAmountTolerancePercentageValue)
+	}
+
+	if cmd.FlagSplitMatchMaxPartsValue < 0 || cmd.FlagSplitMatchMaxPartsValue == 1 {
+		return fmt.Errorf("'--%s': %v should be 0 or at least 2", cmd.FlagSplitMatchMaxParts, cmd.FlagSplitMatchMaxPartsValue)
+	}
+
+	if cmd.FlagReviewConfidenceThresholdValue < 0 || cmd.FlagReviewConfidenceThresholdValue > 1 {
+		return fmt.Errorf("'--%s': %v should between 0 and 1", cmd.FlagReviewConfidenceThreshold, cmd.FlagReviewConfidenceThresholdValue)
+	}
+
+	if cmd.FlagSuggestionCountValue < 0 {
+		return fmt.Errorf("'--%s': %v should not be negative", cmd.FlagSuggestionCount, cmd.FlagSuggestionCountValue)
+	}
+
 	return helper.CommonPersistentPreRunner(cCmd, args)
 }
 
@@ -213,6 +213,77 @@ func TestCmdProcessPersistentPreRunner(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Error - negative amount tolerance",
+			args: args{
+				cCmd: nil,
+				args: nil,
+			},
+			trigger: func() {
+				cmd.FlagTZValue = time.UTC.String()
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+				cmd.FlagAmountToleranceValue = -1
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - amount tolerance percentage above 100",
+			args: args{
+				cCmd: nil,
+				args: nil,
+			},
+			trigger: func() {
+				cmd.FlagTZValue = time.UTC.String()
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+				cmd.FlagAmountToleranceValue = 0
+				cmd.FlagAmountTolerancePercentageValue = 101
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - split match max parts of 1",
+			args: args{
+				cCmd: nil,
+				args: nil,
+			},
+			trigger: func() {
+				cmd.FlagTZValue = time.UTC.String()
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+				cmd.FlagSplitMatchMaxPartsValue = 1
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - review confidence threshold above 1",
+			args: args{
+				cCmd: nil,
+				args: nil,
+			},
+			trigger: func() {
+				cmd.FlagTZValue = time.UTC.String()
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+				cmd.FlagReviewConfidenceThresholdValue = 1.5
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - negative suggestion count",
+			args: args{
+				cCmd: nil,
+				args: nil,
+			},
+			trigger: func() {
+				cmd.FlagTZValue = time.UTC.String()
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+				cmd.FlagSuggestionCountValue = -1
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -227,6 +298,13 @@ func TestCmdProcessPersistentPreRunner(t *testing.T) {
 			}
 
 			tt.trigger()
+			t.Cleanup(func() {
+				cmd.FlagAmountToleranceValue = 0
+				cmd.FlagAmountTolerancePercentageValue = 0
+				cmd.FlagSplitMatchMaxPartsValue = 0
+				cmd.FlagReviewConfidenceThresholdValue = 0
+				cmd.FlagSuggestionCountValue = 0
+			})
 
 			if err := c.PersistentPreRunner(tt.args.cCmd, tt.args.args); (err != nil) != tt.wantErr {
 				t.Errorf("PersistentPreRunner() error = %v, wantErr %v", err, tt.wantErr)
@@ -283,8 +361,19 @@ func TestCmdProcessRunner(t *testing.T) {
 							Logger: logger,
 							Config: &cconfig.Config{
 								Data: &config.Data{
-									App:            core2.App{},
-									Reconciliation: reconciliation.Reconciliation{},
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										FX: reconciliation.FX{
+											BaseCurrency: "IDR",
+										},
+										Duplicate: reconciliation.Duplicate{
+											Policy:        reconciliation.DuplicatePolicyKeepFirst,
+											ContentPolicy: reconciliation.DuplicatePolicyKeepAll,
+										},
+										Balance: reconciliation.Balance{
+											Policy: reconciliation.BalancePolicyWarn,
+										},
+									},
 								},
 							},
 							Profiler: cprofiler.NewProfiler(logger),
@@ -333,6 +422,1038 @@ func TestCmdProcessRunner(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Error - invalid match rule",
+			fields: fields{
+				c: func() *cobra.Command {
+					r := &cobra.Command{}
+					r.SetContext(ctx)
+					return r
+				}(),
+				appName: "",
+				wireApp: func(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz cconfig.TimeZone, errType []core.ErrorType, isShowLog clogger.IsShowLog, dBPath csqlite.DBPath) (*appcontext.AppContext, func(), error) {
+					app, cancel := appcontext.NewAppContext(
+						ctx,
+						nil,
+						nil,
+						nil,
+						&component.Components{
+							Logger: logger,
+							Config: &cconfig.Config{
+								Data: &config.Data{
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										FX: reconciliation.FX{
+											BaseCurrency: "IDR",
+										},
+										Duplicate: reconciliation.Duplicate{
+											Policy:        reconciliation.DuplicatePolicyKeepFirst,
+											ContentPolicy: reconciliation.DuplicatePolicyKeepAll,
+										},
+										Balance: reconciliation.Balance{
+											Policy: reconciliation.BalancePolicyWarn,
+										},
+										MatchRules: []reconciliation.MatchRule{
+											{
+												Name: "foo",
+												Type: "bar",
+											},
+										},
+									},
+								},
+							},
+							Profiler: cprofiler.NewProfiler(logger),
+						},
+						server.NewServer(
+							func() server.IServer {
+								m, _ := cli.NewCli(
+									&component.Components{
+										Logger: logger,
+										Config: &cconfig.Config{
+											Data: &config.Data{
+												Reconciliation: reconciliation.Reconciliation{
+													Action: "noop",
+												},
+											},
+										},
+									},
+									nil,
+									nil,
+									[]hcli.Handler{
+										noop.NewHandler(&bf),
+									},
+								)
+								return m
+							}(),
+						),
+					)
+
+					return app, cancel, nil
+				},
+				embedFS:      nil,
+				outPutWriter: nil,
+				errWriter:    nil,
+			},
+			args: args{},
+			trigger: func() {
+				cmd.FlagIsVerboseValue = true
+				cmd.FlagIsDebugValue = true
+				cmd.FlagIsProfilerActiveValue = true
+				cmd.FlagSystemTRXPathValue = "/tmp/sample/system"
+				cmd.FlagBankTRXPathValue = "/tmp/sample/bank"
+				cmd.FlagReportTRXPathValue = "/tmp/report"
+				cmd.FlagListBankValue = []string{"foo", "bar"}
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - amount tolerance flag with match rules",
+			fields: fields{
+				c: func() *cobra.Command {
+					r := &cobra.Command{}
+					r.SetContext(ctx)
+					r.PersistentFlags().Float64(cmd.FlagAmountTolerance, 0, "")
+					_ = r.PersistentFlags().Set(cmd.FlagAmountTolerance, "100")
+					return r
+				}(),
+				appName: "",
+				wireApp: func(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz cconfig.TimeZone, errType []core.ErrorType, isShowLog clogger.IsShowLog, dBPath csqlite.DBPath) (*appcontext.AppContext, func(), error) {
+					app, cancel := appcontext.NewAppContext(
+						ctx,
+						nil,
+						nil,
+						nil,
+						&component.Components{
+							Logger: logger,
+							Config: &cconfig.Config{
+								Data: &config.Data{
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										FX: reconciliation.FX{
+											BaseCurrency: "IDR",
+										},
+										Duplicate: reconciliation.Duplicate{
+											Policy:        reconciliation.DuplicatePolicyKeepFirst,
+											ContentPolicy: reconciliation.DuplicatePolicyKeepAll,
+										},
+										Balance: reconciliation.Balance{
+											Policy: reconciliation.BalancePolicyWarn,
+										},
+										MatchRules: []reconciliation.MatchRule{
+											{
+												Name: reconciliation.MatchRuleTypeReference,
+												Type: reconciliation.MatchRuleTypeReference,
+											},
+										},
+									},
+								},
+							},
+							Profiler: cprofiler.NewProfiler(logger),
+						},
+						server.NewServer(
+							func() server.IServer {
+								m, _ := cli.NewCli(
+									&component.Components{
+										Logger: logger,
+										Config: &cconfig.Config{
+											Data: &config.Data{
+												Reconciliation: reconciliation.Reconciliation{
+													Action: "noop",
+												},
+											},
+										},
+									},
+									nil,
+									nil,
+									[]hcli.Handler{
+										noop.NewHandler(&bf),
+									},
+								)
+								return m
+							}(),
+						),
+					)
+
+					return app, cancel, nil
+				},
+				embedFS:      nil,
+				outPutWriter: nil,
+				errWriter:    nil,
+			},
+			args: args{},
+			trigger: func() {
+				cmd.FlagIsVerboseValue = true
+				cmd.FlagIsDebugValue = true
+				cmd.FlagIsProfilerActiveValue = true
+				cmd.FlagSystemTRXPathValue = "/tmp/sample/system"
+				cmd.FlagBankTRXPathValue = "/tmp/sample/bank"
+				cmd.FlagReportTRXPathValue = "/tmp/report"
+				cmd.FlagListBankValue = []string{"foo", "bar"}
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - invalid suggestion",
+			fields: fields{
+				c: func() *cobra.Command {
+					r := &cobra.Command{}
+					r.SetContext(ctx)
+					return r
+				}(),
+				appName: "",
+				wireApp: func(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz cconfig.TimeZone, errType []core.ErrorType, isShowLog clogger.IsShowLog, dBPath csqlite.DBPath) (*appcontext.AppContext, func(), error) {
+					app, cancel := appcontext.NewAppContext(
+						ctx,
+						nil,
+						nil,
+						nil,
+						&component.Components{
+							Logger: logger,
+							Config: &cconfig.Config{
+								Data: &config.Data{
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										Suggestion: reconciliation.Suggestion{
+											MaxCount: -1,
+										},
+									},
+								},
+							},
+							Profiler: cprofiler.NewProfiler(logger),
+						},
+						server.NewServer(
+							func() server.IServer {
+								m, _ := cli.NewCli(
+									&component.Components{
+										Logger: logger,
+										Config: &cconfig.Config{
+											Data: &config.Data{
+												Reconciliation: reconciliation.Reconciliation{
+													Action: "noop",
+												},
+											},
+										},
+									},
+									nil,
+									nil,
+									[]hcli.Handler{
+										noop.NewHandler(&bf),
+									},
+								)
+								return m
+							}(),
+						),
+					)
+
+					return app, cancel, nil
+				},
+				embedFS:      nil,
+				outPutWriter: nil,
+				errWriter:    nil,
+			},
+			args: args{},
+			trigger: func() {
+				cmd.FlagIsVerboseValue = true
+				cmd.FlagIsDebugValue = true
+				cmd.FlagIsProfilerActiveValue = true
+				cmd.FlagSystemTRXPathValue = "/tmp/sample/system"
+				cmd.FlagBankTRXPathValue = "/tmp/sample/bank"
+				cmd.FlagReportTRXPathValue = "/tmp/report"
+				cmd.FlagListBankValue = []string{"foo", "bar"}
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - invalid fx",
+			fields: fields{
+				c: func() *cobra.Command {
+					r := &cobra.Command{}
+					r.SetContext(ctx)
+					return r
+				}(),
+				appName: "",
+				wireApp: func(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz cconfig.TimeZone, errType []core.ErrorType, isShowLog clogger.IsShowLog, dBPath csqlite.DBPath) (*appcontext.AppContext, func(), error) {
+					app, cancel := appcontext.NewAppContext(
+						ctx,
+						nil,
+						nil,
+						nil,
+						&component.Components{
+							Logger: logger,
+							Config: &cconfig.Config{
+								Data: &config.Data{
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										FX: reconciliation.FX{
+											BaseCurrency:        "IDR",
+											TolerancePercentage: 101,
+										},
+									},
+								},
+							},
+							Profiler: cprofiler.NewProfiler(logger),
+						},
+						server.NewServer(
+							func() server.IServer {
+								m, _ := cli.NewCli(
+									&component.Components{
+										Logger: logger,
+										Config: &cconfig.Config{
+											Data: &config.Data{
+												Reconciliation: reconciliation.Reconciliation{
+													Action: "noop",
+												},
+											},
+										},
+									},
+									nil,
+									nil,
+									[]hcli.Handler{
+										noop.NewHandler(&bf),
+									},
+								)
+								return m
+							}(),
+						),
+					)
+
+					return app, cancel, nil
+				},
+				embedFS:      nil,
+				outPutWriter: nil,
+				errWriter:    nil,
+			},
+			args: args{},
+			trigger: func() {
+				cmd.FlagIsVerboseValue = true
+				cmd.FlagIsDebugValue = true
+				cmd.FlagIsProfilerActiveValue = true
+				cmd.FlagSystemTRXPathValue = "/tmp/sample/system"
+				cmd.FlagBankTRXPathValue = "/tmp/sample/bank"
+				cmd.FlagReportTRXPathValue = "/tmp/report"
+				cmd.FlagListBankValue = []string{"foo", "bar"}
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - invalid bank fee",
+			fields: fields{
+				c: func() *cobra.Command {
+					r := &cobra.Command{}
+					r.SetContext(ctx)
+					return r
+				}(),
+				appName: "",
+				wireApp: func(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz cconfig.TimeZone, errType []core.ErrorType, isShowLog clogger.IsShowLog, dBPath csqlite.DBPath) (*appcontext.AppContext, func(), error) {
+					app, cancel := appcontext.NewAppContext(
+						ctx,
+						nil,
+						nil,
+						nil,
+						&component.Components{
+							Logger: logger,
+							Config: &cconfig.Config{
+								Data: &config.Data{
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										FX: reconciliation.FX{
+											BaseCurrency: "IDR",
+										},
+										BankFee: map[string]reconciliation.BankFee{
+											"bca": {
+												Type: "fixed",
+											},
+										},
+									},
+								},
+							},
+							Profiler: cprofiler.NewProfiler(logger),
+						},
+						server.NewServer(
+							func() server.IServer {
+								m, _ := cli.NewCli(
+									&component.Components{
+										Logger: logger,
+										Config: &cconfig.Config{
+											Data: &config.Data{
+												Reconciliation: reconciliation.Reconciliation{
+													Action: "noop",
+												},
+											},
+										},
+									},
+									nil,
+									nil,
+									[]hcli.Handler{
+										noop.NewHandler(&bf),
+									},
+								)
+								return m
+							}(),
+						),
+					)
+
+					return app, cancel, nil
+				},
+				embedFS:      nil,
+				outPutWriter: nil,
+				errWriter:    nil,
+			},
+			args: args{},
+			trigger: func() {
+				cmd.FlagIsVerboseValue = true
+				cmd.FlagIsDebugValue = true
+				cmd.FlagIsProfilerActiveValue = true
+				cmd.FlagSystemTRXPathValue = "/tmp/sample/system"
+				cmd.FlagBankTRXPathValue = "/tmp/sample/bank"
+				cmd.FlagReportTRXPathValue = "/tmp/report"
+				cmd.FlagListBankValue = []string{"foo", "bar"}
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - invalid bank parser",
+			fields: fields{
+				c: func() *cobra.Command {
+					r := &cobra.Command{}
+					r.SetContext(ctx)
+					return r
+				}(),
+				appName: "",
+				wireApp: func(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz cconfig.TimeZone, errType []core.ErrorType, isShowLog clogger.IsShowLog, dBPath csqlite.DBPath) (*appcontext.AppContext, func(), error) {
+					app, cancel := appcontext.NewAppContext(
+						ctx,
+						nil,
+						nil,
+						nil,
+						&component.Components{
+							Logger: logger,
+							Config: &cconfig.Config{
+								Data: &config.Data{
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										FX: reconciliation.FX{
+											BaseCurrency: "IDR",
+										},
+										BankParser: map[string]string{
+											"mandiri": "mt940",
+										},
+									},
+								},
+							},
+							Profiler: cprofiler.NewProfiler(logger),
+						},
+						server.NewServer(
+							func() server.IServer {
+								m, _ := cli.NewCli(
+									&component.Components{
+										Logger: logger,
+										Config: &cconfig.Config{
+											Data: &config.Data{
+												Reconciliation: reconciliation.Reconciliation{
+													Action: "noop",
+												},
+											},
+										},
+									},
+									nil,
+									nil,
+									[]hcli.Handler{
+										noop.NewHandler(&bf),
+									},
+								)
+								return m
+							}(),
+						),
+					)
+
+					return app, cancel, nil
+				},
+				embedFS:      nil,
+				outPutWriter: nil,
+				errWriter:    nil,
+			},
+			args: args{},
+			trigger: func() {
+				cmd.FlagIsVerboseValue = true
+				cmd.FlagIsDebugValue = true
+				cmd.FlagIsProfilerActiveValue = true
+				cmd.FlagSystemTRXPathValue = "/tmp/sample/system"
+				cmd.FlagBankTRXPathValue = "/tmp/sample/bank"
+				cmd.FlagReportTRXPathValue = "/tmp/report"
+				cmd.FlagListBankValue = []string{"foo", "bar"}
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - invalid locale",
+			fields: fields{
+				c: func() *cobra.Command {
+					r := &cobra.Command{}
+					r.SetContext(ctx)
+					return r
+				}(),
+				appName: "",
+				wireApp: func(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz cconfig.TimeZone, errType []core.ErrorType, isShowLog clogger.IsShowLog, dBPath csqlite.DBPath) (*appcontext.AppContext, func(), error) {
+					app, cancel := appcontext.NewAppContext(
+						ctx,
+						nil,
+						nil,
+						nil,
+						&component.Components{
+							Logger: logger,
+							Config: &cconfig.Config{
+								Data: &config.Data{
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										FX: reconciliation.FX{
+											BaseCurrency: "IDR",
+										},
+										BankLocale: map[string]reconciliation.Locale{
+											"mandiri": {
+												DecimalSeparator:   ",",
+												ThousandsSeparator: ",",
+											},
+										},
+									},
+								},
+							},
+							Profiler: cprofiler.NewProfiler(logger),
+						},
+						server.NewServer(
+							func() server.IServer {
+								m, _ := cli.NewCli(
+									&component.Components{
+										Logger: logger,
+										Config: &cconfig.Config{
+											Data: &config.Data{
+												Reconciliation: reconciliation.Reconciliation{
+													Action: "noop",
+												},
+											},
+										},
+									},
+									nil,
+									nil,
+									[]hcli.Handler{
+										noop.NewHandler(&bf),
+									},
+								)
+								return m
+							}(),
+						),
+					)
+
+					return app, cancel, nil
+				},
+				embedFS:      nil,
+				outPutWriter: nil,
+				errWriter:    nil,
+			},
+			args: args{},
+			trigger: func() {
+				cmd.FlagIsVerboseValue = true
+				cmd.FlagIsDebugValue = true
+				cmd.FlagIsProfilerActiveValue = true
+				cmd.FlagSystemTRXPathValue = "/tmp/sample/system"
+				cmd.FlagBankTRXPathValue = "/tmp/sample/bank"
+				cmd.FlagReportTRXPathValue = "/tmp/report"
+				cmd.FlagListBankValue = []string{"foo", "bar"}
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - invalid bank format",
+			fields: fields{
+				c: func() *cobra.Command {
+					r := &cobra.Command{}
+					r.SetContext(ctx)
+					return r
+				}(),
+				appName: "",
+				wireApp: func(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz cconfig.TimeZone, errType []core.ErrorType, isShowLog clogger.IsShowLog, dBPath csqlite.DBPath) (*appcontext.AppContext, func(), error) {
+					app, cancel := appcontext.NewAppContext(
+						ctx,
+						nil,
+						nil,
+						nil,
+						&component.Components{
+							Logger: logger,
+							Config: &cconfig.Config{
+								Data: &config.Data{
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										FX: reconciliation.FX{
+											BaseCurrency: "IDR",
+										},
+										BankFormat: map[string]reconciliation.BankFormat{
+											"mandiri": {
+												Sign:      reconciliation.BankFormatSignNegativeDebit,
+												Delimiter: ",",
+											},
+										},
+									},
+								},
+							},
+							Profiler: cprofiler.NewProfiler(logger),
+						},
+						server.NewServer(
+							func() server.IServer {
+								m, _ := cli.NewCli(
+									&component.Components{
+										Logger: logger,
+										Config: &cconfig.Config{
+											Data: &config.Data{
+												Reconciliation: reconciliation.Reconciliation{
+													Action: "noop",
+												},
+											},
+										},
+									},
+									nil,
+									nil,
+									[]hcli.Handler{
+										noop.NewHandler(&bf),
+									},
+								)
+								return m
+							}(),
+						),
+					)
+
+					return app, cancel, nil
+				},
+				embedFS:      nil,
+				outPutWriter: nil,
+				errWriter:    nil,
+			},
+			args: args{},
+			trigger: func() {
+				cmd.FlagIsVerboseValue = true
+				cmd.FlagIsDebugValue = true
+				cmd.FlagIsProfilerActiveValue = true
+				cmd.FlagSystemTRXPathValue = "/tmp/sample/system"
+				cmd.FlagBankTRXPathValue = "/tmp/sample/bank"
+				cmd.FlagReportTRXPathValue = "/tmp/report"
+				cmd.FlagListBankValue = []string{"foo", "bar"}
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - invalid duplicate policy",
+			fields: fields{
+				c: func() *cobra.Command {
+					r := &cobra.Command{}
+					r.SetContext(ctx)
+					return r
+				}(),
+				appName: "",
+				wireApp: func(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz cconfig.TimeZone, errType []core.ErrorType, isShowLog clogger.IsShowLog, dBPath csqlite.DBPath) (*appcontext.AppContext, func(), error) {
+					app, cancel := appcontext.NewAppContext(
+						ctx,
+						nil,
+						nil,
+						nil,
+						&component.Components{
+							Logger: logger,
+							Config: &cconfig.Config{
+								Data: &config.Data{
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										FX: reconciliation.FX{
+											BaseCurrency: "IDR",
+										},
+										Duplicate: reconciliation.Duplicate{
+											Policy:        "drop",
+											ContentPolicy: reconciliation.DuplicatePolicyKeepAll,
+										},
+									},
+								},
+							},
+							Profiler: cprofiler.NewProfiler(logger),
+						},
+						server.NewServer(
+							func() server.IServer {
+								m, _ := cli.NewCli(
+									&component.Components{
+										Logger: logger,
+										Config: &cconfig.Config{
+											Data: &config.Data{
+												Reconciliation: reconciliation.Reconciliation{
+													Action: "noop",
+												},
+											},
+										},
+									},
+									nil,
+									nil,
+									[]hcli.Handler{
+										noop.NewHandler(&bf),
+									},
+								)
+								return m
+							}(),
+						),
+					)
+
+					return app, cancel, nil
+				},
+				embedFS:      nil,
+				outPutWriter: nil,
+				errWriter:    nil,
+			},
+			args: args{},
+			trigger: func() {
+				cmd.FlagIsVerboseValue = true
+				cmd.FlagIsDebugValue = true
+				cmd.FlagIsProfilerActiveValue = true
+				cmd.FlagSystemTRXPathValue = "/tmp/sample/system"
+				cmd.FlagBankTRXPathValue = "/tmp/sample/bank"
+				cmd.FlagReportTRXPathValue = "/tmp/report"
+				cmd.FlagListBankValue = []string{"foo", "bar"}
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - invalid reversal days",
+			fields: fields{
+				c: func() *cobra.Command {
+					r := &cobra.Command{}
+					r.SetContext(ctx)
+					return r
+				}(),
+				appName: "",
+				wireApp: func(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz cconfig.TimeZone, errType []core.ErrorType, isShowLog clogger.IsShowLog, dBPath csqlite.DBPath) (*appcontext.AppContext, func(), error) {
+					app, cancel := appcontext.NewAppContext(
+						ctx,
+						nil,
+						nil,
+						nil,
+						&component.Components{
+							Logger: logger,
+							Config: &cconfig.Config{
+								Data: &config.Data{
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										FX: reconciliation.FX{
+											BaseCurrency: "IDR",
+										},
+										Duplicate: reconciliation.Duplicate{
+											Policy:        reconciliation.DuplicatePolicyKeepFirst,
+											ContentPolicy: reconciliation.DuplicatePolicyKeepAll,
+										},
+										Reversal: reconciliation.Reversal{
+											IsEnabled: true,
+											Days:      -1,
+										},
+									},
+								},
+							},
+							Profiler: cprofiler.NewProfiler(logger),
+						},
+						server.NewServer(
+							func() server.IServer {
+								m, _ := cli.NewCli(
+									&component.Components{
+										Logger: logger,
+										Config: &cconfig.Config{
+											Data: &config.Data{
+												Reconciliation: reconciliation.Reconciliation{
+													Action: "noop",
+												},
+											},
+										},
+									},
+									nil,
+									nil,
+									[]hcli.Handler{
+										noop.NewHandler(&bf),
+									},
+								)
+								return m
+							}(),
+						),
+					)
+
+					return app, cancel, nil
+				},
+				embedFS:      nil,
+				outPutWriter: nil,
+				errWriter:    nil,
+			},
+			args: args{},
+			trigger: func() {
+				cmd.FlagIsVerboseValue = true
+				cmd.FlagIsDebugValue = true
+				cmd.FlagIsProfilerActiveValue = true
+				cmd.FlagSystemTRXPathValue = "/tmp/sample/system"
+				cmd.FlagBankTRXPathValue = "/tmp/sample/bank"
+				cmd.FlagReportTRXPathValue = "/tmp/report"
+				cmd.FlagListBankValue = []string{"foo", "bar"}
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - invalid transfer days",
+			fields: fields{
+				c: func() *cobra.Command {
+					r := &cobra.Command{}
+					r.SetContext(ctx)
+					return r
+				}(),
+				appName: "",
+				wireApp: func(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz cconfig.TimeZone, errType []core.ErrorType, isShowLog clogger.IsShowLog, dBPath csqlite.DBPath) (*appcontext.AppContext, func(), error) {
+					app, cancel := appcontext.NewAppContext(
+						ctx,
+						nil,
+						nil,
+						nil,
+						&component.Components{
+							Logger: logger,
+							Config: &cconfig.Config{
+								Data: &config.Data{
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										FX: reconciliation.FX{
+											BaseCurrency: "IDR",
+										},
+										Duplicate: reconciliation.Duplicate{
+											Policy:        reconciliation.DuplicatePolicyKeepFirst,
+											ContentPolicy: reconciliation.DuplicatePolicyKeepAll,
+										},
+										Transfer: reconciliation.Transfer{
+											IsEnabled: true,
+											Days:      -1,
+										},
+									},
+								},
+							},
+							Profiler: cprofiler.NewProfiler(logger),
+						},
+						server.NewServer(
+							func() server.IServer {
+								m, _ := cli.NewCli(
+									&component.Components{
+										Logger: logger,
+										Config: &cconfig.Config{
+											Data: &config.Data{
+												Reconciliation: reconciliation.Reconciliation{
+													Action: "noop",
+												},
+											},
+										},
+									},
+									nil,
+									nil,
+									[]hcli.Handler{
+										noop.NewHandler(&bf),
+									},
+								)
+								return m
+							}(),
+						),
+					)
+
+					return app, cancel, nil
+				},
+				embedFS:      nil,
+				outPutWriter: nil,
+				errWriter:    nil,
+			},
+			args: args{},
+			trigger: func() {
+				cmd.FlagIsVerboseValue = true
+				cmd.FlagIsDebugValue = true
+				cmd.FlagIsProfilerActiveValue = true
+				cmd.FlagSystemTRXPathValue = "/tmp/sample/system"
+				cmd.FlagBankTRXPathValue = "/tmp/sample/bank"
+				cmd.FlagReportTRXPathValue = "/tmp/report"
+				cmd.FlagListBankValue = []string{"foo", "bar"}
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - invalid gateway days",
+			fields: fields{
+				c: func() *cobra.Command {
+					r := &cobra.Command{}
+					r.SetContext(ctx)
+					return r
+				}(),
+				appName: "",
+				wireApp: func(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz cconfig.TimeZone, errType []core.ErrorType, isShowLog clogger.IsShowLog, dBPath csqlite.DBPath) (*appcontext.AppContext, func(), error) {
+					app, cancel := appcontext.NewAppContext(
+						ctx,
+						nil,
+						nil,
+						nil,
+						&component.Components{
+							Logger: logger,
+							Config: &cconfig.Config{
+								Data: &config.Data{
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										FX: reconciliation.FX{
+											BaseCurrency: "IDR",
+										},
+										Duplicate: reconciliation.Duplicate{
+											Policy:        reconciliation.DuplicatePolicyKeepFirst,
+											ContentPolicy: reconciliation.DuplicatePolicyKeepAll,
+										},
+										Gateway: reconciliation.Gateway{
+											Days: -1,
+										},
+									},
+								},
+							},
+							Profiler: cprofiler.NewProfiler(logger),
+						},
+						server.NewServer(
+							func() server.IServer {
+								m, _ := cli.NewCli(
+									&component.Components{
+										Logger: logger,
+										Config: &cconfig.Config{
+											Data: &config.Data{
+												Reconciliation: reconciliation.Reconciliation{
+													Action: "noop",
+												},
+											},
+										},
+									},
+									nil,
+									nil,
+									[]hcli.Handler{
+										noop.NewHandler(&bf),
+									},
+								)
+								return m
+							}(),
+						),
+					)
+
+					return app, cancel, nil
+				},
+				embedFS:      nil,
+				outPutWriter: nil,
+				errWriter:    nil,
+			},
+			args: args{},
+			trigger: func() {
+				cmd.FlagIsVerboseValue = true
+				cmd.FlagIsDebugValue = true
+				cmd.FlagIsProfilerActiveValue = true
+				cmd.FlagSystemTRXPathValue = "/tmp/sample/system"
+				cmd.FlagBankTRXPathValue = "/tmp/sample/bank"
+				cmd.FlagReportTRXPathValue = "/tmp/report"
+				cmd.FlagListBankValue = []string{"foo", "bar"}
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - invalid balance policy",
+			fields: fields{
+				c: func() *cobra.Command {
+					r := &cobra.Command{}
+					r.SetContext(ctx)
+					return r
+				}(),
+				appName: "",
+				wireApp: func(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz cconfig.TimeZone, errType []core.ErrorType, isShowLog clogger.IsShowLog, dBPath csqlite.DBPath) (*appcontext.AppContext, func(), error) {
+					app, cancel := appcontext.NewAppContext(
+						ctx,
+						nil,
+						nil,
+						nil,
+						&component.Components{
+							Logger: logger,
+							Config: &cconfig.Config{
+								Data: &config.Data{
+									App: core2.App{},
+									Reconciliation: reconciliation.Reconciliation{
+										FX: reconciliation.FX{
+											BaseCurrency: "IDR",
+										},
+										Duplicate: reconciliation.Duplicate{
+											Policy:        reconciliation.DuplicatePolicyKeepFirst,
+											ContentPolicy: reconciliation.DuplicatePolicyKeepAll,
+										},
+										Balance: reconciliation.Balance{
+											Policy: "strict",
+										},
+									},
+								},
+							},
+							Profiler: cprofiler.NewProfiler(logger),
+						},
+						server.NewServer(
+							func() server.IServer {
+								m, _ := cli.NewCli(
+									&component.Components{
+										Logger: logger,
+										Config: &cconfig.Config{
+											Data: &config.Data{
+												Reconciliation: reconciliation.Reconciliation{
+													Action: "noop",
+												},
+											},
+										},
+									},
+									nil,
+									nil,
+									[]hcli.Handler{
+										noop.NewHandler(&bf),
+									},
+								)
+								return m
+							}(),
+						),
+					)
+
+					return app, cancel, nil
+				},
+				embedFS:      nil,
+				outPutWriter: nil,
+				errWriter:    nil,
+			},
+			args: args{},
+			trigger: func() {
+				cmd.FlagIsVerboseValue = true
+				cmd.FlagIsDebugValue = true
+				cmd.FlagIsProfilerActiveValue = true
+				cmd.FlagSystemTRXPathValue = "/tmp/sample/system"
+				cmd.FlagBankTRXPathValue = "/tmp/sample/bank"
+				cmd.FlagReportTRXPathValue = "/tmp/report"
+				cmd.FlagListBankValue = []string{"foo", "bar"}
+				cmd.FlagFromDateValue = DateFrom
+				cmd.FlagToDateValue = DateFrom
+			},
+			wantErr: true,
+		},
 		{
 			name: "Error - dependency injection cause error",
 			fields: fields{
@@ -483,17 +1604,24 @@ func TestCmdProcessInitPersistentFlags(t *testing.T) {
 				outPutWriter: bf,
 				errWriter:    bf,
 			},
-			want: fmt.Sprintf(`  -b, --banktrxpath string     Path location of Bank Transaction directory (default "%s/sample/bank")
-  -g, --debug                  debug mode
-  -d, --deleteoldfile          delete old report files (default true)
-  -f, --from string            from date (YYYY-MM-DD) (default "%s")
-  -l, --listbank strings       List bank accepted (default [bca,bni,mandiri,bri,danamon])
-  -i, --profiler               pprof active mode
-  -r, --reportpath string      Path location of Archive directory (default "%s/report")
-  -o, --showlog                show logs
-  -s, --systemtrxpath string   Path location of System Transaction directory (default "%s/sample/system")
-  -z, --time_zone string       time zone settings (default "Asia/Jakarta")
-  -t, --to string              to date (YYYY-MM-DD) (default "%s")
+			want: fmt.Sprintf(`      --aggregatematch                    match trx group of same date and type to one bank trx
+      --amounttolerance float             maximum amount difference of matched trx
+      --amounttolerancepercentage float   maximum amount difference of matched trx in percent of system amount
+  -b, --banktrxpath string                Path location of Bank Transaction directory (default "%s/sample/bank")
+  -g, --debug                             debug mode
+  -d, --deleteoldfile                     delete old report files (default true)
+      --failoncoveragegap                 fail when a bank has no statement data on a day between from and to date
+  -f, --from string                       from date (YYYY-MM-DD) (default "%s")
+  -l, --listbank strings                  List bank accepted (default [bca,bni,mandiri,bri,danamon])
+  -i, --profiler                          pprof active mode
+  -r, --reportpath string                 Path location of Archive directory (default "%s/report")
+      --reviewconfidence float            matched trx with confidence below this value (0 - 1) go to review report instead, 0 to disable
+  -o, --showlog                           show logs
+      --splitmatchmaxparts int            maximum bank trx parts to match one trx, 0 to disable
+      --suggestioncount int               maximum near match bank trx suggested for each not matched trx, 0 to disable (default 3)
+  -s, --systemtrxpath string              Path location of System Transaction directory (default "%s/sample/system")
+  -z, --time_zone string                  time zone settings (default "Asia/Jakarta")
+  -t, --to string                         to date (YYYY-MM-DD) (default "%s")
 `,
 				wD,
 				dateNow,
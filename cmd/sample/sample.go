@@ -133,6 +133,10 @@ func (c *CmdSample) Runner(_ *cobra.Command, _ []string) (er error) {
 	conf.Reconciliation.TotalData = cmd.FlagTotalDataSampleToGenerateValue
 	conf.Reconciliation.PercentageMatch = cmd.FlagPercentageMatchSampleToGenerateValue
 
+	if e = conf.Reconciliation.ValidateCurrencyDecimalPlaces(); e != nil {
+		return e
+	}
+
 	return app.Start()
 }
 
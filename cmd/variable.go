@@ -17,6 +17,13 @@ var FlagIsVerboseValue bool
 var FlagIsDebugValue bool
 var FlagIsProfilerActiveValue bool
 var FlagReportTRXPathValue string
+var FlagAmountToleranceValue float64
+var FlagAmountTolerancePercentageValue float64
+var FlagIsAggregateMatchValue bool
+var FlagSplitMatchMaxPartsValue int
+var FlagReviewConfidenceThresholdValue float64
+var FlagSuggestionCountValue int
+var FlagIsFailOnCoverageGapValue bool
 
 const (
 	DateFormatString                         string = "2006-01-02"
@@ -62,4 +69,18 @@ const (
 	FlagIsProfilerActive                     string = "profiler"
 	FlagIsProfilerActiveShort                string = "i"
 	FlagIsProfilerActiveUsage                string = `pprof active mode`
+	FlagAmountTolerance                      string = "amounttolerance"
+	FlagAmountToleranceUsage                 string = `maximum amount difference of matched trx`
+	FlagAmountTolerancePercentage            string = "amounttolerancepercentage"
+	FlagAmountTolerancePercentageUsage       string = `maximum amount difference of matched trx in percent of system amount`
+	FlagIsAggregateMatch                     string = "aggregatematch"
+	FlagIsAggregateMatchUsage                string = `match trx group of same date and type to one bank trx`
+	FlagSplitMatchMaxParts                   string = "splitmatchmaxparts"
+	FlagSplitMatchMaxPartsUsage              string = `maximum bank trx parts to match one trx, 0 to disable`
+	FlagReviewConfidenceThreshold            string = "reviewconfidence"
+	FlagReviewConfidenceThresholdUsage       string = `matched trx with confidence below this value (0 - 1) go to review report instead, 0 to disable`
+	FlagSuggestionCount                      string = "suggestioncount"
+	FlagSuggestionCountUsage                 string = `maximum near match bank trx suggested for each not matched trx, 0 to disable`
+	FlagIsFailOnCoverageGap                  string = "failoncoveragegap"
+	FlagIsFailOnCoverageGapUsage             string = `fail when a bank has no statement data on a day between from and to date`
 )
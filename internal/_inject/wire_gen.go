@@ -28,6 +28,7 @@ import (
 	process2 "github.com/oprekable/bank-reconcile/internal/app/service/process"
 	sample2 "github.com/oprekable/bank-reconcile/internal/app/service/sample"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
 	"github.com/spf13/afero"
 	"io"
 	"os"
@@ -66,12 +67,14 @@ func WireApp(ctx context.Context, embedFS *embed.FS, appName cconfig.AppName, tz
 	}
 	repositories := repository.NewRepositories(db, processDB)
 	svc := sample2.ProviderSvc(components, repositories)
-	v := service.ProvideBankParserFactoryMap()
+	v := service.ProvideBankParserFactoryMap(components)
 	parserRegistry := banks.NewParserRegistry(v)
-	processSvc := process2.ProviderSvc(components, repositories, parserRegistry)
+	v2 := service.ProvideGatewayParserFactoryMap()
+	gatewaysParserRegistry := gateways.NewParserRegistry(v2)
+	processSvc := process2.ProviderSvc(components, repositories, parserRegistry, gatewaysParserRegistry)
 	services := service.NewServices(svc, processSvc)
-	v2 := hcli.ProviderHandlers()
-	cliCli, err := cli.NewCli(components, services, repositories, v2)
+	v3 := hcli.ProviderHandlers()
+	cliCli, err := cli.NewCli(components, services, repositories, v3)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
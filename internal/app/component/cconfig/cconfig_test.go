@@ -373,6 +373,34 @@ is_delete_current_sample_directory = true
 						ListBank: []string{
 							"bca", "danamon", "bri", "mandiri",
 						},
+						CurrencyDecimalPlaces: 2,
+						Suggestion: reconciliation.Suggestion{
+							MaxCount:         3,
+							Days:             3,
+							AmountPercentage: 5,
+						},
+						FX: reconciliation.FX{
+							BaseCurrency: "IDR",
+						},
+						Duplicate: reconciliation.Duplicate{
+							Policy:        reconciliation.DuplicatePolicyKeepFirst,
+							ContentPolicy: reconciliation.DuplicatePolicyKeepAll,
+						},
+						Reversal: reconciliation.Reversal{
+							IsEnabled: false,
+							Days:      1,
+						},
+						Transfer: reconciliation.Transfer{
+							IsEnabled: false,
+							Days:      1,
+						},
+						Gateway: reconciliation.Gateway{
+							Days:       1,
+							PayoutDays: 2,
+						},
+						Balance: reconciliation.Balance{
+							Policy: reconciliation.BalancePolicyWarn,
+						},
 					},
 				},
 				timeLocation: func() *time.Location {
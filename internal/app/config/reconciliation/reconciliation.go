@@ -1,19 +1,678 @@
 package reconciliation
 
-import "time"
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+)
+
+const (
+	MatchRuleTypeReference  = "reference"
+	MatchRuleTypeAmountDate = "amount_date"
+	MatchRuleTypeSplit      = "split"
+	MatchRuleTypeAggregate  = "aggregate"
+)
+
+const (
+	DuplicatePolicyKeepFirst = "keep_first"
+	DuplicatePolicyRejectAll = "reject_all"
+	DuplicatePolicyKeepAll   = "keep_all"
+)
+
+const (
+	BalancePolicyOff  = "off"
+	BalancePolicyWarn = "warn"
+	BalancePolicyFail = "fail"
+)
+
+const (
+	BankFormatSignNegativeDebit = "negative_debit"
+	BankFormatSignPositiveDebit = "positive_debit"
+)
+
+const (
+	BankFeeTypeFlat       = "flat"
+	BankFeeTypePercentage = "percentage"
+	BankFeeTypeTiered     = "tiered"
+)
+
+// DateWindow ..
+type DateWindow struct {
+	DaysBefore int `default:"0" mapstructure:"days_before"`
+	DaysAfter  int `default:"0" mapstructure:"days_after"`
+}
+
+// Suggestion limits the not matched bank trx suggested for a not matched system trx, up to MaxCount bank trx
+// of the same type at most Days away and AmountPercentage of the system amount off. MaxCount 0 disables it
+type Suggestion struct {
+	MaxCount         int     `default:"3" mapstructure:"max_count"`
+	Days             int     `default:"3" mapstructure:"days"`
+	AmountPercentage float64 `default:"5" mapstructure:"amount_percentage"`
+}
+
+// Validate checks the suggestion limits
+func (s Suggestion) Validate() error {
+	if s.MaxCount < 0 {
+		return fmt.Errorf("suggestion: max_count %d should not be negative", s.MaxCount)
+	}
+
+	if s.Days < 0 {
+		return fmt.Errorf("suggestion: days %d should not be negative", s.Days)
+	}
+
+	if s.AmountPercentage < 0 || s.AmountPercentage > 100 {
+		return fmt.Errorf("suggestion: amount_percentage %v should between 0 and 100", s.AmountPercentage)
+	}
+
+	return nil
+}
+
+// FX converts trx booked in another currency to BaseCurrency before matching. Rates come from the csv file at
+// RateFilePath, BankCurrency is the currency of the statements of a bank (BaseCurrency when not set). Cross currency
+// pairs may differ by TolerancePercentage of the system amount on top of the tolerance of the matching pass
+type FX struct {
+	BankCurrency        map[string]string `default:"-"   mapstructure:"bank_currency"`
+	BaseCurrency        string            `default:"IDR" mapstructure:"base_currency"`
+	RateFilePath        string            `default:"-"   mapstructure:"rate_file_path"`
+	TolerancePercentage float64           `default:"0"   mapstructure:"tolerance_percentage"`
+}
+
+// Validate checks the base currency and the tolerance
+func (f FX) Validate() error {
+	if strings.TrimSpace(f.BaseCurrency) == "" {
+		return errors.New("fx: base_currency should not be empty")
+	}
+
+	if f.TolerancePercentage < 0 || f.TolerancePercentage > 100 {
+		return fmt.Errorf("fx: tolerance_percentage %v should between 0 and 100", f.TolerancePercentage)
+	}
+
+	return nil
+}
+
+// GetBankCurrency returns the currency of the bank statements, falls back to BaseCurrency
+func (f FX) GetBankCurrency(bank string) string {
+	if currency, ok := f.BankCurrency[strings.ToLower(bank)]; ok && currency != "" {
+		return strings.ToUpper(currency)
+	}
+
+	return strings.ToUpper(f.BaseCurrency)
+}
+
+// Duplicate is the handling of trx repeated within the system or the bank source. Policy applies to trx sharing
+// their TrxID or UniqueIdentifier, ContentPolicy to trx of other IDs sharing date, type, currency and amount. A policy
+// keeps the first trx (keep_first), rejects every trx of the group (reject_all) or keeps them all (keep_all), every
+// duplicate is reported either way
+type Duplicate struct {
+	Policy        string `default:"keep_first" mapstructure:"policy"`
+	ContentPolicy string `default:"keep_all"   mapstructure:"content_policy"`
+}
+
+// Validate checks both policies
+func (d Duplicate) Validate() error {
+	if !isDuplicatePolicy(d.Policy) {
+		return fmt.Errorf("duplicate: unknown policy %q", d.Policy)
+	}
+
+	if !isDuplicatePolicy(d.ContentPolicy) {
+		return fmt.Errorf("duplicate: unknown content_policy %q", d.ContentPolicy)
+	}
+
+	return nil
+}
+
+func isDuplicatePolicy(policy string) bool {
+	switch policy {
+	case DuplicatePolicyKeepFirst, DuplicatePolicyRejectAll, DuplicatePolicyKeepAll:
+		return true
+	default:
+		return false
+	}
+}
+
+// Balance checks every bank statement file, per account, against the balances it states: the opening balance plus
+// its movements should be its closing balance and every running balance should follow the line before. Policy skips
+// the check (off), logs the statements not balancing (warn) or stops the process on the first of them (fail), the
+// check of every statement is reported unless off
+type Balance struct {
+	Policy string `default:"warn" mapstructure:"policy"`
+}
+
+// Validate checks the policy
+func (b Balance) Validate() error {
+	switch b.Policy {
+	case BalancePolicyOff, BalancePolicyWarn, BalancePolicyFail:
+		return nil
+	default:
+		return fmt.Errorf("balance: unknown policy %q", b.Policy)
+	}
+}
+
+// Reversal pairs a debit with a credit of the same currency and amount on the same side, at most Days apart, before
+// matching. Bank statements of a pair are of the same bank and share their reference when both have one. Both trx
+// of a pair cancel each other, they are left out of matching and of the not matched reports and listed in the
+// reversal report instead
+type Reversal struct {
+	IsEnabled bool `default:"false" mapstructure:"is_enabled"`
+	Days      int  `default:"1"     mapstructure:"days"`
+}
+
+// Validate checks the window
+func (r Reversal) Validate() error {
+	if r.Days < 0 {
+		return fmt.Errorf("reversal: days should not be negative, got %d", r.Days)
+	}
+
+	return nil
+}
+
+// Transfer pairs a debit and a credit of the same currency and amount left over by matching, at different banks or
+// accounts with the credit at most Days after the debit. Such pairs move money between our own accounts, they are left
+// out of the not matched reports and listed in the transfer report instead. With OwnAccounts, the counterparty account
+// a statement line states should be one of them, and at least one line of a pair should state it
+type Transfer struct {
+	OwnAccounts []string `default:"-"     mapstructure:"own_accounts"`
+	IsEnabled   bool     `default:"false" mapstructure:"is_enabled"`
+	Days        int      `default:"1"     mapstructure:"days"`
+}
+
+// Validate checks the window
+func (t Transfer) Validate() error {
+	if t.Days < 0 {
+		return fmt.Errorf("transfer: days should not be negative, got %d", t.Days)
+	}
+
+	return nil
+}
+
+// Gateway reconciles system trx paid through payment gateways in two legs, the system trx to the lines of the gateway
+// settlement files under TRXPath/<gateway>/*.csv, then the payout batches of those lines to bank statements. A line
+// matches the system trx of its Reference, or of its type and gross amount at most Days apart. A payout is stated as
+// its credit lines less its debit lines and fees, by the PayoutBank of the gateway (any bank when not set) at most
+// PayoutDays after its latest line
+type Gateway struct {
+	PayoutBank  map[string]string `default:"-" mapstructure:"payout_bank"`
+	TRXPath     string            `default:"-" mapstructure:"trx_path"`
+	ListGateway []string          `default:"-" mapstructure:"list_gateway"`
+	Days        int               `default:"1" mapstructure:"days"`
+	PayoutDays  int               `default:"2" mapstructure:"payout_days"`
+}
+
+// IsEnabled tells whether gateway settlement files are reconciled
+func (g Gateway) IsEnabled() bool {
+	return g.TRXPath != "" && len(g.ListGateway) > 0
+}
+
+// Validate checks both windows
+func (g Gateway) Validate() error {
+	if g.Days < 0 {
+		return fmt.Errorf("gateway: days should not be negative, got %d", g.Days)
+	}
+
+	if g.PayoutDays < 0 {
+		return fmt.Errorf("gateway: payout_days should not be negative, got %d", g.PayoutDays)
+	}
+
+	return nil
+}
+
+// GetPayoutBank returns the bank paying the payouts of the gateway, empty when any bank may
+func (g Gateway) GetPayoutBank(gateway string) string {
+	return strings.ToLower(g.PayoutBank[strings.ToLower(gateway)])
+}
+
+// Account tells the accounts of a bank apart. The account of a statement line is its Account column, else the first
+// group of PathPattern matched against the statement path relative to the bank trx path (slash separated, like
+// "bca/1234567890/march.csv" or "bca/statement_1234567890.csv"), else the folder of a <bank>/<account>/*.csv layout.
+// With IsMatchAccount a system trx stating the account it settles on only matches statements of that account
+type Account struct {
+	PathPattern    string `default:"-"     mapstructure:"path_pattern"`
+	IsMatchAccount bool   `default:"false" mapstructure:"is_match_account"`
+}
+
+// Validate checks the path pattern
+func (a Account) Validate() error {
+	if a.PathPattern == "" {
+		return nil
+	}
+
+	pattern, err := regexp.Compile(a.PathPattern)
+	if err != nil {
+		return fmt.Errorf("account: invalid path_pattern %q: %w", a.PathPattern, err)
+	}
+
+	if pattern.NumSubexp() == 0 {
+		return fmt.Errorf("account: path_pattern %q should have a group capturing the account", a.PathPattern)
+	}
+
+	return nil
+}
+
+// BankFeeTier applies to system amounts from MinAmount up to the MinAmount of the next tier, the fee is Flat plus
+// Percentage of the system amount. Amounts are in major units of the base currency
+type BankFeeTier struct {
+	MinAmount  float64 `default:"0" mapstructure:"min_amount"`
+	Flat       float64 `default:"0" mapstructure:"flat"`
+	Percentage float64 `default:"0" mapstructure:"percentage"`
+}
+
+// BankFee is the fee a bank deducts from the amount it states, Flat for flat type, Percentage of the system amount for
+// percentage type, or the fee of the tier the system amount falls in for tiered type
+type BankFee struct {
+	Type       string        `default:"-" mapstructure:"type"`
+	Tiers      []BankFeeTier `default:"-" mapstructure:"tiers"`
+	Flat       float64       `default:"0" mapstructure:"flat"`
+	Percentage float64       `default:"0" mapstructure:"percentage"`
+}
+
+// Validate checks the fee type and its amounts
+func (f BankFee) Validate(bank string) error {
+	switch f.Type {
+	case BankFeeTypeFlat, BankFeeTypePercentage:
+	case BankFeeTypeTiered:
+		if len(f.Tiers) == 0 {
+			return fmt.Errorf("bank fee %q: tiered type should have tiers", bank)
+		}
+	default:
+		return fmt.Errorf("bank fee %q: unknown type %q", bank, f.Type)
+	}
+
+	for _, tier := range append([]BankFeeTier{{Flat: f.Flat, Percentage: f.Percentage}}, f.Tiers...) {
+		if tier.MinAmount < 0 || tier.Flat < 0 {
+			return fmt.Errorf("bank fee %q: amounts should not be negative", bank)
+		}
+
+		if tier.Percentage < 0 || tier.Percentage > 100 {
+			return fmt.Errorf("bank fee %q: percentage %v should between 0 and 100", bank, tier.Percentage)
+		}
+	}
+
+	return nil
+}
+
+// GetTiers returns the fee schedule as tiers, flat and percentage types are one tier from 0
+func (f BankFee) GetTiers() []BankFeeTier {
+	switch f.Type {
+	case BankFeeTypeFlat:
+		return []BankFeeTier{{Flat: f.Flat}}
+	case BankFeeTypePercentage:
+		return []BankFeeTier{{Percentage: f.Percentage}}
+	case BankFeeTypeTiered:
+		return f.Tiers
+	default:
+		return nil
+	}
+}
+
+// BankFormatColumns are the columns of the statement lines, a column is its name in the header or its position from
+// 1. UniqueIdentifier, Date and Amount (or Debit and Credit for exports stating them apart) are required
+type BankFormatColumns struct {
+	UniqueIdentifier    string `default:"-" mapstructure:"unique_identifier"`
+	Date                string `default:"-" mapstructure:"date"`
+	Amount              string `default:"-" mapstructure:"amount"`
+	Debit               string `default:"-" mapstructure:"debit"`
+	Credit              string `default:"-" mapstructure:"credit"`
+	Reference           string `default:"-" mapstructure:"reference"`
+	Account             string `default:"-" mapstructure:"account"`
+	Balance             string `default:"-" mapstructure:"balance"`
+	CounterpartyAccount string `default:"-" mapstructure:"counterparty_account"`
+}
+
+// BankFormat declares the csv export of a bank, it replaces the built-in parser of the bank. SkipRows lines come
+// before the first statement line (preamble and header), columns given by name are looked up in the last of them.
+// DateLayout is a Go time layout, empty for YYYY-MM-DD with optional time. Sign tells whether a negative amount is a
+// debit (negative_debit, when empty) or a credit (positive_debit), Delimiter is a comma when empty
+type BankFormat struct {
+	Columns    BankFormatColumns `mapstructure:"columns"`
+	DateLayout string            `default:"-" mapstructure:"date_layout"`
+	Sign       string            `default:"-" mapstructure:"sign"`
+	Delimiter  string            `default:"-" mapstructure:"delimiter"`
+	SkipRows   int               `default:"0" mapstructure:"skip_rows"`
+}
+
+// Validate checks the columns, the sign and the delimiter
+func (f BankFormat) Validate(bank string) error {
+	if f.Columns.UniqueIdentifier == "" || f.Columns.Date == "" {
+		return fmt.Errorf("bank format %q: unique_identifier and date columns should be set", bank)
+	}
+
+	isHaveAmount, isHaveDebit, isHaveCredit := f.Columns.Amount != "", f.Columns.Debit != "", f.Columns.Credit != ""
+	if isHaveDebit != isHaveCredit || isHaveAmount == isHaveDebit {
+		return fmt.Errorf("bank format %q: either amount or both debit and credit columns should be set", bank)
+	}
+
+	switch f.Sign {
+	case "", BankFormatSignNegativeDebit, BankFormatSignPositiveDebit:
+	default:
+		return fmt.Errorf("bank format %q: unknown sign %q", bank, f.Sign)
+	}
+
+	if delimiter := []rune(f.Delimiter); len(delimiter) > 1 || (len(delimiter) == 1 && strings.ContainsRune("\"\r\n", delimiter[0])) {
+		return fmt.Errorf("bank format %q: delimiter %q should be one character other than quote or line break", bank, f.Delimiter)
+	}
+
+	if f.SkipRows < 0 {
+		return fmt.Errorf("bank format %q: skip_rows should not be negative, got %d", bank, f.SkipRows)
+	}
+
+	return nil
+}
+
+// Locale is how amounts and dates are written in the files of a bank or of the system. DecimalSeparator is a dot and
+// ThousandsSeparator is no grouping when empty. DateLayouts are Go time layouts tried in order, the default date
+// formats when empty. MonthNames is en (when empty) or id for Indonesian month names like "17 Agu 2025", Delimiter
+// is a comma when empty
+type Locale struct {
+	DecimalSeparator   string   `default:"-" mapstructure:"decimal_separator"`
+	ThousandsSeparator string   `default:"-" mapstructure:"thousands_separator"`
+	MonthNames         string   `default:"-" mapstructure:"month_names"`
+	Delimiter          string   `default:"-" mapstructure:"delimiter"`
+	DateLayouts        []string `default:"-" mapstructure:"date_layouts"`
+}
+
+// Validate checks the separators, the month names, the date layouts and the delimiter
+func (l Locale) Validate() error {
+	for _, separator := range []string{l.DecimalSeparator, l.ThousandsSeparator} {
+		if len([]rune(separator)) > 1 || strings.ContainsAny(separator, "0123456789+-") {
+			return fmt.Errorf("separator %q should be one character other than a digit or sign", separator)
+		}
+	}
+
+	if decimalSeparator := cmp.Or(l.DecimalSeparator, "."); l.ThousandsSeparator == decimalSeparator {
+		return fmt.Errorf("thousands_separator %q should differ from the decimal separator", l.ThousandsSeparator)
+	}
+
+	switch l.MonthNames {
+	case "", locale.MonthNamesEnglish, locale.MonthNamesIndonesian:
+	default:
+		return fmt.Errorf("unknown month_names %q", l.MonthNames)
+	}
+
+	for _, layout := range l.DateLayouts {
+		if strings.TrimSpace(layout) == "" {
+			return errors.New("date_layouts should not have an empty layout")
+		}
+	}
+
+	if delimiter := []rune(l.Delimiter); len(delimiter) > 1 || (len(delimiter) == 1 && strings.ContainsRune("\"\r\n", delimiter[0])) {
+		return fmt.Errorf("delimiter %q should be one character other than quote or line break", l.Delimiter)
+	}
+
+	return nil
+}
+
+// ToLocale returns the locale the parsers read the files with
+func (l Locale) ToLocale() (returnData locale.Locale) {
+	returnData = locale.Locale{
+		DecimalSeparator:   l.DecimalSeparator,
+		ThousandsSeparator: l.ThousandsSeparator,
+		MonthNames:         l.MonthNames,
+		DateLayouts:        l.DateLayouts,
+	}
+
+	if delimiter := []rune(l.Delimiter); len(delimiter) > 0 {
+		returnData.Delimiter = delimiter[0]
+	}
+
+	return
+}
+
+// MatchRule is one matching pass, passes run in order and each only takes trx left over by earlier passes.
+// SettlementWindow overrides the bank settlement windows when set, SplitMaxParts is only used by split rule
+type MatchRule struct {
+	SettlementWindow          *DateWindow `default:"-" mapstructure:"settlement_window"`
+	Name                      string      `default:"-" mapstructure:"name"`
+	Type                      string      `default:"-" mapstructure:"type"`
+	AmountTolerance           float64     `default:"0" mapstructure:"amount_tolerance"`
+	AmountTolerancePercentage float64     `default:"0" mapstructure:"amount_tolerance_percentage"`
+	SplitMaxParts             int         `default:"0" mapstructure:"split_max_parts"`
+}
+
+// Validate checks the rule name, type and its limits
+func (m MatchRule) Validate() error {
+	if strings.TrimSpace(m.Name) == "" {
+		return fmt.Errorf("match rule of type %q: name should not be empty", m.Type)
+	}
+
+	switch m.Type {
+	case MatchRuleTypeReference, MatchRuleTypeAmountDate, MatchRuleTypeAggregate:
+	case MatchRuleTypeSplit:
+		if m.SplitMaxParts < 2 {
+			return fmt.Errorf("match rule %q: split_max_parts %d should be at least 2", m.Name, m.SplitMaxParts)
+		}
+	default:
+		return fmt.Errorf("match rule %q: unknown type %q", m.Name, m.Type)
+	}
+
+	if m.AmountTolerance < 0 {
+		return fmt.Errorf("match rule %q: amount_tolerance %v should not be negative", m.Name, m.AmountTolerance)
+	}
+
+	if m.AmountTolerancePercentage < 0 || m.AmountTolerancePercentage > 100 {
+		return fmt.Errorf("match rule %q: amount_tolerance_percentage %v should between 0 and 100", m.Name, m.AmountTolerancePercentage)
+	}
+
+	if m.SettlementWindow != nil && (m.SettlementWindow.DaysBefore < 0 || m.SettlementWindow.DaysAfter < 0) {
+		return fmt.Errorf("match rule %q: settlement_window days should not be negative", m.Name)
+	}
+
+	return nil
+}
 
 // Reconciliation ..
 type Reconciliation struct {
-	FromDate                       time.Time `default:"-"    mapstructure:"from_date"`
-	ToDate                         time.Time `default:"-"    mapstructure:"to_date"`
-	Action                         string    `default:"-"    mapstructure:"action"`
-	SystemTRXPath                  string    `default:"-"    mapstructure:"system_trx_path"`
-	BankTRXPath                    string    `default:"-"    mapstructure:"bank_trx_path"`
-	ReportTRXPath                  string    `default:"-"    mapstructure:"report_trx_path"`
-	ListBank                       []string  `default:"-"    mapstructure:"list_bank"`
-	TotalData                      int64     `default:"-"    mapstructure:"total_data"`
-	PercentageMatch                int       `default:"100"  mapstructure:"percentage_match"`
-	NumberWorker                   int       `default:"10"   mapstructure:"number_worker"`
-	IsDeleteCurrentSampleDirectory bool      `default:"true" mapstructure:"is_delete_current_sample_directory"`
-	IsDeleteCurrentReportDirectory bool      `default:"true" mapstructure:"is_delete_current_report_directory"`
+	FromDate                       time.Time             `default:"-"    mapstructure:"from_date"`
+	ToDate                         time.Time             `default:"-"    mapstructure:"to_date"`
+	BankSettlementWindow           map[string]DateWindow `default:"-"    mapstructure:"bank_settlement_window"`
+	BankFee                        map[string]BankFee    `default:"-"    mapstructure:"bank_fee"`
+	BankFormat                     map[string]BankFormat `default:"-"    mapstructure:"bank_format"`
+	BankParser                     map[string]string     `default:"-"    mapstructure:"bank_parser"`
+	BankLocale                     map[string]Locale     `default:"-"    mapstructure:"bank_locale"`
+	Action                         string                `default:"-"    mapstructure:"action"`
+	SystemTRXPath                  string                `default:"-"    mapstructure:"system_trx_path"`
+	BankTRXPath                    string                `default:"-"    mapstructure:"bank_trx_path"`
+	ReportTRXPath                  string                `default:"-"    mapstructure:"report_trx_path"`
+	ListBank                       []string              `default:"-"    mapstructure:"list_bank"`
+	SettlementWindow               DateWindow            `mapstructure:"settlement_window"`
+	Suggestion                     Suggestion            `mapstructure:"suggestion"`
+	FX                             FX                    `mapstructure:"fx"`
+	Duplicate                      Duplicate             `mapstructure:"duplicate"`
+	Reversal                       Reversal              `mapstructure:"reversal"`
+	Transfer                       Transfer              `mapstructure:"transfer"`
+	Gateway                        Gateway               `mapstructure:"gateway"`
+	Account                        Account               `mapstructure:"account"`
+	Balance                        Balance               `mapstructure:"balance"`
+	SystemLocale                   Locale                `mapstructure:"system_locale"`
+	TotalData                      int64                 `default:"-"    mapstructure:"total_data"`
+	AmountTolerance                float64               `default:"0"    mapstructure:"amount_tolerance"`
+	AmountTolerancePercentage      float64               `default:"0"    mapstructure:"amount_tolerance_percentage"`
+	PercentageMatch                int                   `default:"100"  mapstructure:"percentage_match"`
+	NumberWorker                   int                   `default:"10"   mapstructure:"number_worker"`
+	IsDeleteCurrentSampleDirectory bool                  `default:"true" mapstructure:"is_delete_current_sample_directory"`
+	IsDeleteCurrentReportDirectory bool                  `default:"true" mapstructure:"is_delete_current_report_directory"`
+	SplitMatchMaxParts             int                   `default:"0"    mapstructure:"split_match_max_parts"`
+	IsAggregateMatch               bool                  `default:"false" mapstructure:"is_aggregate_match"`
+	IsFailOnCoverageGap            bool                  `default:"false" mapstructure:"is_fail_on_coverage_gap"`
+	ReviewConfidenceThreshold      float64               `default:"0"    mapstructure:"review_confidence_threshold"`
+	CurrencyDecimalPlaces          int                   `default:"2"    mapstructure:"currency_decimal_places"`
+	MatchRules                     []MatchRule           `default:"-"    mapstructure:"match_rules"`
+}
+
+// ValidateCurrencyDecimalPlaces checks the minor unit digits amounts are kept in
+func (r *Reconciliation) ValidateCurrencyDecimalPlaces() error {
+	if r.CurrencyDecimalPlaces < 0 || r.CurrencyDecimalPlaces > money.MaxDecimalPlaces {
+		return fmt.Errorf("currency_decimal_places %d should between 0 and %d", r.CurrencyDecimalPlaces, money.MaxDecimalPlaces)
+	}
+
+	return nil
+}
+
+// ValidateBankFee checks the fee schedule of every bank
+func (r *Reconciliation) ValidateBankFee() error {
+	banks := make([]string, 0, len(r.BankFee))
+	for bank := range r.BankFee {
+		banks = append(banks, bank)
+	}
+
+	sort.Strings(banks)
+	for _, bank := range banks {
+		if err := r.BankFee[bank].Validate(bank); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateBankFormat checks the declared format of every bank
+func (r *Reconciliation) ValidateBankFormat() error {
+	banks := make([]string, 0, len(r.BankFormat))
+	for bank := range r.BankFormat {
+		banks = append(banks, bank)
+	}
+
+	sort.Strings(banks)
+	for _, bank := range banks {
+		if err := r.BankFormat[bank].Validate(bank); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateBankParser checks the built-in parser picked for every bank
+func (r *Reconciliation) ValidateBankParser() error {
+	for bank, parser := range r.BankParser {
+		switch banks.BankParserType(strings.ToUpper(parser)) {
+		case banks.DefaultBankParser, banks.BCABankParser, banks.BNIBankParser, banks.DebitCreditBankParser:
+		default:
+			return fmt.Errorf("bank parser %q: unknown parser %q", bank, parser)
+		}
+	}
+
+	return nil
+}
+
+// ValidateLocale checks the locale of the system and of every bank
+func (r *Reconciliation) ValidateLocale() error {
+	if err := r.SystemLocale.Validate(); err != nil {
+		return fmt.Errorf("system locale: %w", err)
+	}
+
+	banks := make([]string, 0, len(r.BankLocale))
+	for bank := range r.BankLocale {
+		banks = append(banks, bank)
+	}
+
+	sort.Strings(banks)
+	for _, bank := range banks {
+		if err := r.BankLocale[bank].Validate(); err != nil {
+			return fmt.Errorf("bank locale %q: %w", bank, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateMatchRules checks every matching pass, the rule name labels the matches so it should be unique
+func (r *Reconciliation) ValidateMatchRules() error {
+	names := make(map[string]struct{})
+	for _, rule := range r.GetMatchRules() {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+
+		if _, ok := names[rule.Name]; ok {
+			return fmt.Errorf("match rule %q: name is used by more than one rule", rule.Name)
+		}
+
+		names[rule.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// GetBankFeeTiers returns the fee schedule of the bank as tiers, nil when the bank deducts no fee
+func (r *Reconciliation) GetBankFeeTiers(bank string) []BankFeeTier {
+	return r.BankFee[strings.ToLower(bank)].GetTiers()
+}
+
+// GetSettlementWindow returns settlement date window of the bank, falls back to SettlementWindow when the bank has no specific window
+func (r *Reconciliation) GetSettlementWindow(bank string) DateWindow {
+	if window, ok := r.BankSettlementWindow[strings.ToLower(bank)]; ok {
+		return window
+	}
+
+	return r.SettlementWindow
+}
+
+// GetMaxSettlementWindow returns the widest window of all banks in ListBank, all match rules and the gateway payouts,
+// used to widen the accepted bank statement date range
+func (r *Reconciliation) GetMaxSettlementWindow() (returnData DateWindow) {
+	// a payout can be paid up to PayoutDays after a line booked up to Days after the system trx
+	if r.Gateway.IsEnabled() {
+		returnData.DaysAfter = r.Gateway.Days + r.Gateway.PayoutDays
+	}
+
+	for _, bank := range r.ListBank {
+		window := r.GetSettlementWindow(bank)
+		returnData.DaysBefore = max(returnData.DaysBefore, window.DaysBefore)
+		returnData.DaysAfter = max(returnData.DaysAfter, window.DaysAfter)
+	}
+
+	for _, rule := range r.MatchRules {
+		if rule.SettlementWindow != nil {
+			returnData.DaysBefore = max(returnData.DaysBefore, rule.SettlementWindow.DaysBefore)
+			returnData.DaysAfter = max(returnData.DaysAfter, rule.SettlementWindow.DaysAfter)
+		}
+	}
+
+	return
+}
+
+// GetMatchRules returns the configured matching passes, without match_rules the passes are built from
+// the amount tolerance, split and aggregate settings: reference, amount_date, then split and aggregate when enabled
+func (r *Reconciliation) GetMatchRules() (returnData []MatchRule) {
+	if len(r.MatchRules) > 0 {
+		return r.MatchRules
+	}
+
+	returnData = []MatchRule{
+		{
+			Name: MatchRuleTypeReference,
+			Type: MatchRuleTypeReference,
+		},
+		{
+			Name:                      MatchRuleTypeAmountDate,
+			Type:                      MatchRuleTypeAmountDate,
+			AmountTolerance:           r.AmountTolerance,
+			AmountTolerancePercentage: r.AmountTolerancePercentage,
+		},
+	}
+
+	if r.SplitMatchMaxParts > 1 {
+		returnData = append(returnData, MatchRule{
+			Name:          MatchRuleTypeSplit,
+			Type:          MatchRuleTypeSplit,
+			SplitMaxParts: r.SplitMatchMaxParts,
+		})
+	}
+
+	if r.IsAggregateMatch {
+		returnData = append(returnData, MatchRule{
+			Name: MatchRuleTypeAggregate,
+			Type: MatchRuleTypeAggregate,
+		})
+	}
+
+	return
 }
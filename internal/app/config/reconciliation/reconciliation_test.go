@@ -0,0 +1,1074 @@
+package reconciliation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+)
+
+func TestReconciliationGetSettlementWindow(t *testing.T) {
+	type fields struct {
+		BankSettlementWindow map[string]DateWindow
+		SettlementWindow     DateWindow
+	}
+
+	type args struct {
+		bank string
+	}
+
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+		want   DateWindow
+	}{
+		{
+			name: "Ok - bank specific window",
+			fields: fields{
+				BankSettlementWindow: map[string]DateWindow{
+					"bca": {DaysBefore: 1, DaysAfter: 2},
+				},
+				SettlementWindow: DateWindow{DaysAfter: 1},
+			},
+			args: args{
+				bank: "BCA",
+			},
+			want: DateWindow{DaysBefore: 1, DaysAfter: 2},
+		},
+		{
+			name: "Ok - fallback to default window",
+			fields: fields{
+				BankSettlementWindow: map[string]DateWindow{
+					"bca": {DaysBefore: 1, DaysAfter: 2},
+				},
+				SettlementWindow: DateWindow{DaysAfter: 1},
+			},
+			args: args{
+				bank: "bni",
+			},
+			want: DateWindow{DaysAfter: 1},
+		},
+		{
+			name:   "Ok - no window",
+			fields: fields{},
+			args: args{
+				bank: "bni",
+			},
+			want: DateWindow{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciliation{
+				BankSettlementWindow: tt.fields.BankSettlementWindow,
+				SettlementWindow:     tt.fields.SettlementWindow,
+			}
+
+			if got := r.GetSettlementWindow(tt.args.bank); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetSettlementWindow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconciliationGetMaxSettlementWindow(t *testing.T) {
+	type fields struct {
+		BankSettlementWindow map[string]DateWindow
+		ListBank             []string
+		MatchRules           []MatchRule
+		Gateway              Gateway
+		SettlementWindow     DateWindow
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantReturnData DateWindow
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				BankSettlementWindow: map[string]DateWindow{
+					"bca":     {DaysBefore: 3, DaysAfter: 0},
+					"mandiri": {DaysBefore: 10, DaysAfter: 10},
+				},
+				ListBank:         []string{"bca", "bni"},
+				SettlementWindow: DateWindow{DaysAfter: 2},
+			},
+			wantReturnData: DateWindow{DaysBefore: 3, DaysAfter: 2},
+		},
+		{
+			name: "Ok - empty list bank",
+			fields: fields{
+				SettlementWindow: DateWindow{DaysAfter: 2},
+			},
+			wantReturnData: DateWindow{},
+		},
+		{
+			name: "Ok - match rule window",
+			fields: fields{
+				ListBank:         []string{"bca"},
+				SettlementWindow: DateWindow{DaysAfter: 2},
+				MatchRules: []MatchRule{
+					{Name: "exact", Type: MatchRuleTypeAmountDate},
+					{Name: "near", Type: MatchRuleTypeAmountDate, SettlementWindow: &DateWindow{DaysBefore: 1, DaysAfter: 1}},
+				},
+			},
+			wantReturnData: DateWindow{DaysBefore: 1, DaysAfter: 2},
+		},
+		{
+			name: "Ok - gateway payout window",
+			fields: fields{
+				ListBank:         []string{"bca"},
+				SettlementWindow: DateWindow{DaysAfter: 2},
+				Gateway:          Gateway{TRXPath: "/tmp/gateway", ListGateway: []string{"xendit"}, Days: 1, PayoutDays: 3},
+			},
+			wantReturnData: DateWindow{DaysAfter: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciliation{
+				BankSettlementWindow: tt.fields.BankSettlementWindow,
+				ListBank:             tt.fields.ListBank,
+				MatchRules:           tt.fields.MatchRules,
+				Gateway:              tt.fields.Gateway,
+				SettlementWindow:     tt.fields.SettlementWindow,
+			}
+
+			if gotReturnData := r.GetMaxSettlementWindow(); !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("GetMaxSettlementWindow() = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestReconciliationGetMatchRules(t *testing.T) {
+	type fields struct {
+		MatchRules                []MatchRule
+		AmountTolerance           float64
+		AmountTolerancePercentage float64
+		SplitMatchMaxParts        int
+		IsAggregateMatch          bool
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantReturnData []MatchRule
+	}{
+		{
+			name: "Ok - configured rules",
+			fields: fields{
+				MatchRules: []MatchRule{
+					{Name: "exact", Type: MatchRuleTypeAmountDate},
+				},
+				AmountTolerance:  50,
+				IsAggregateMatch: true,
+			},
+			wantReturnData: []MatchRule{
+				{Name: "exact", Type: MatchRuleTypeAmountDate},
+			},
+		},
+		{
+			name: "Ok - default rules",
+			fields: fields{
+				AmountTolerance:           50,
+				AmountTolerancePercentage: 0.5,
+			},
+			wantReturnData: []MatchRule{
+				{Name: MatchRuleTypeReference, Type: MatchRuleTypeReference},
+				{Name: MatchRuleTypeAmountDate, Type: MatchRuleTypeAmountDate, AmountTolerance: 50, AmountTolerancePercentage: 0.5},
+			},
+		},
+		{
+			name: "Ok - default rules with split and aggregate",
+			fields: fields{
+				SplitMatchMaxParts: 3,
+				IsAggregateMatch:   true,
+			},
+			wantReturnData: []MatchRule{
+				{Name: MatchRuleTypeReference, Type: MatchRuleTypeReference},
+				{Name: MatchRuleTypeAmountDate, Type: MatchRuleTypeAmountDate},
+				{Name: MatchRuleTypeSplit, Type: MatchRuleTypeSplit, SplitMaxParts: 3},
+				{Name: MatchRuleTypeAggregate, Type: MatchRuleTypeAggregate},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciliation{
+				MatchRules:                tt.fields.MatchRules,
+				AmountTolerance:           tt.fields.AmountTolerance,
+				AmountTolerancePercentage: tt.fields.AmountTolerancePercentage,
+				SplitMatchMaxParts:        tt.fields.SplitMatchMaxParts,
+				IsAggregateMatch:          tt.fields.IsAggregateMatch,
+			}
+
+			if gotReturnData := r.GetMatchRules(); !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("GetMatchRules() = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestMatchRuleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    MatchRule
+		wantErr bool
+	}{
+		{
+			name:    "Ok - reference",
+			rule:    MatchRule{Name: "reference", Type: MatchRuleTypeReference},
+			wantErr: false,
+		},
+		{
+			name:    "Ok - amount date",
+			rule:    MatchRule{Name: "near", Type: MatchRuleTypeAmountDate, AmountTolerancePercentage: 0.5, SettlementWindow: &DateWindow{DaysAfter: 1}},
+			wantErr: false,
+		},
+		{
+			name:    "Ok - split",
+			rule:    MatchRule{Name: "split", Type: MatchRuleTypeSplit, SplitMaxParts: 2},
+			wantErr: false,
+		},
+		{
+			name:    "Error - split parts",
+			rule:    MatchRule{Name: "split", Type: MatchRuleTypeSplit, SplitMaxParts: 1},
+			wantErr: true,
+		},
+		{
+			name:    "Error - unknown type",
+			rule:    MatchRule{Name: "foo", Type: "bar"},
+			wantErr: true,
+		},
+		{
+			name:    "Error - negative tolerance",
+			rule:    MatchRule{Name: "near", Type: MatchRuleTypeAmountDate, AmountTolerance: -1},
+			wantErr: true,
+		},
+		{
+			name:    "Error - tolerance percentage",
+			rule:    MatchRule{Name: "near", Type: MatchRuleTypeAmountDate, AmountTolerancePercentage: 101},
+			wantErr: true,
+		},
+		{
+			name:    "Error - negative window",
+			rule:    MatchRule{Name: "near", Type: MatchRuleTypeAggregate, SettlementWindow: &DateWindow{DaysBefore: -1}},
+			wantErr: true,
+		},
+		{
+			name:    "Error - empty name",
+			rule:    MatchRule{Type: MatchRuleTypeReference},
+			wantErr: true,
+		},
+		{
+			name:    "Error - blank name",
+			rule:    MatchRule{Name: " ", Type: MatchRuleTypeReference},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReconciliationValidateMatchRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		matchRules []MatchRule
+		wantErr    bool
+	}{
+		{
+			name:       "Ok - default rules",
+			matchRules: nil,
+			wantErr:    false,
+		},
+		{
+			name: "Ok - unique names",
+			matchRules: []MatchRule{
+				{Name: "reference", Type: MatchRuleTypeReference},
+				{Name: "exact", Type: MatchRuleTypeAmountDate},
+				{Name: "near", Type: MatchRuleTypeAmountDate, AmountTolerance: 100},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error - empty name",
+			matchRules: []MatchRule{
+				{Name: "reference", Type: MatchRuleTypeReference},
+				{Type: MatchRuleTypeAmountDate},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - duplicate name",
+			matchRules: []MatchRule{
+				{Name: "exact", Type: MatchRuleTypeAmountDate},
+				{Name: "exact", Type: MatchRuleTypeAmountDate, AmountTolerance: 100},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - invalid rule",
+			matchRules: []MatchRule{
+				{Name: "split", Type: MatchRuleTypeSplit, SplitMaxParts: 1},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciliation{
+				MatchRules: tt.matchRules,
+			}
+
+			if err := r.ValidateMatchRules(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMatchRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSuggestionValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		suggestion Suggestion
+		wantErr    bool
+	}{
+		{
+			name:       "Ok",
+			suggestion: Suggestion{MaxCount: 3, Days: 3, AmountPercentage: 5},
+			wantErr:    false,
+		},
+		{
+			name:       "Ok - disabled",
+			suggestion: Suggestion{},
+			wantErr:    false,
+		},
+		{
+			name:       "Error - negative max count",
+			suggestion: Suggestion{MaxCount: -1},
+			wantErr:    true,
+		},
+		{
+			name:       "Error - negative days",
+			suggestion: Suggestion{MaxCount: 3, Days: -1},
+			wantErr:    true,
+		},
+		{
+			name:       "Error - amount percentage",
+			suggestion: Suggestion{MaxCount: 3, AmountPercentage: 101},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.suggestion.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReconciliationValidateCurrencyDecimalPlaces(t *testing.T) {
+	tests := []struct {
+		name          string
+		decimalPlaces int
+		wantErr       bool
+	}{
+		{
+			name:          "Ok",
+			decimalPlaces: 2,
+		},
+		{
+			name:          "Ok - without decimals",
+			decimalPlaces: 0,
+		},
+		{
+			name:          "Error - negative",
+			decimalPlaces: -1,
+			wantErr:       true,
+		},
+		{
+			name:          "Error - too many",
+			decimalPlaces: 5,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciliation{
+				CurrencyDecimalPlaces: tt.decimalPlaces,
+			}
+
+			if err := r.ValidateCurrencyDecimalPlaces(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCurrencyDecimalPlaces() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFXValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		fx      FX
+		wantErr bool
+	}{
+		{
+			name:    "Ok",
+			fx:      FX{BaseCurrency: "IDR", TolerancePercentage: 1},
+			wantErr: false,
+		},
+		{
+			name:    "Error - empty base currency",
+			fx:      FX{BaseCurrency: " "},
+			wantErr: true,
+		},
+		{
+			name:    "Error - tolerance percentage",
+			fx:      FX{BaseCurrency: "IDR", TolerancePercentage: -1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fx.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDuplicateValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		duplicate Duplicate
+		wantErr   bool
+	}{
+		{
+			name:      "Ok",
+			duplicate: Duplicate{Policy: DuplicatePolicyRejectAll, ContentPolicy: DuplicatePolicyKeepAll},
+			wantErr:   false,
+		},
+		{
+			name:      "Error - unknown policy",
+			duplicate: Duplicate{Policy: "drop", ContentPolicy: DuplicatePolicyKeepAll},
+			wantErr:   true,
+		},
+		{
+			name:      "Error - unknown content policy",
+			duplicate: Duplicate{Policy: DuplicatePolicyKeepFirst, ContentPolicy: ""},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.duplicate.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBalanceValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		balance Balance
+		wantErr bool
+	}{
+		{
+			name:    "Ok",
+			balance: Balance{Policy: BalancePolicyFail},
+			wantErr: false,
+		},
+		{
+			name:    "Error - unknown policy",
+			balance: Balance{Policy: "strict"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.balance.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReversalValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		reversal Reversal
+		wantErr  bool
+	}{
+		{
+			name:     "Ok",
+			reversal: Reversal{IsEnabled: true, Days: 2},
+			wantErr:  false,
+		},
+		{
+			name:     "Ok - same day",
+			reversal: Reversal{IsEnabled: true, Days: 0},
+			wantErr:  false,
+		},
+		{
+			name:     "Error - negative days",
+			reversal: Reversal{IsEnabled: true, Days: -1},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.reversal.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransferValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		transfer Transfer
+		wantErr  bool
+	}{
+		{
+			name:     "Ok",
+			transfer: Transfer{IsEnabled: true, Days: 2, OwnAccounts: []string{"111", "222"}},
+			wantErr:  false,
+		},
+		{
+			name:     "Ok - same day",
+			transfer: Transfer{IsEnabled: true, Days: 0},
+			wantErr:  false,
+		},
+		{
+			name:     "Error - negative days",
+			transfer: Transfer{IsEnabled: true, Days: -1},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.transfer.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGatewayValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		gateway Gateway
+		wantErr bool
+	}{
+		{
+			name:    "Ok",
+			gateway: Gateway{Days: 1, PayoutDays: 2},
+			wantErr: false,
+		},
+		{
+			name:    "Error - negative days",
+			gateway: Gateway{Days: -1, PayoutDays: 2},
+			wantErr: true,
+		},
+		{
+			name:    "Error - negative payout days",
+			gateway: Gateway{Days: 1, PayoutDays: -1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.gateway.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGatewayGetPayoutBank(t *testing.T) {
+	gateway := Gateway{
+		PayoutBank: map[string]string{
+			"xendit": "BCA",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		gateway string
+		want    string
+	}{
+		{
+			name:    "Ok - payout bank",
+			gateway: "Xendit",
+			want:    "bca",
+		},
+		{
+			name:    "Ok - any bank",
+			gateway: "midtrans",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gateway.GetPayoutBank(tt.gateway); got != tt.want {
+				t.Errorf("GetPayoutBank() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFXGetBankCurrency(t *testing.T) {
+	fx := FX{
+		BaseCurrency: "idr",
+		BankCurrency: map[string]string{
+			"bca": "usd",
+		},
+	}
+
+	tests := []struct {
+		name string
+		bank string
+		want string
+	}{
+		{
+			name: "Ok - bank currency",
+			bank: "BCA",
+			want: "USD",
+		},
+		{
+			name: "Ok - base currency",
+			bank: "bni",
+			want: "IDR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fx.GetBankCurrency(tt.bank); got != tt.want {
+				t.Errorf("GetBankCurrency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconciliationValidateBankFee(t *testing.T) {
+	tests := []struct {
+		name    string
+		bankFee map[string]BankFee
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			bankFee: map[string]BankFee{
+				"bca": {Type: BankFeeTypeFlat, Flat: 2500},
+				"bni": {Type: BankFeeTypePercentage, Percentage: 0.7},
+				"bri": {Type: BankFeeTypeTiered, Tiers: []BankFeeTier{{Flat: 1000}, {MinAmount: 1000000, Percentage: 0.5}}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Ok - no fee",
+			bankFee: nil,
+			wantErr: false,
+		},
+		{
+			name: "Error - unknown type",
+			bankFee: map[string]BankFee{
+				"bca": {Type: "fixed", Flat: 2500},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - tiered without tiers",
+			bankFee: map[string]BankFee{
+				"bca": {Type: BankFeeTypeTiered},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - negative amount",
+			bankFee: map[string]BankFee{
+				"bca": {Type: BankFeeTypeTiered, Tiers: []BankFeeTier{{MinAmount: -1, Flat: 1000}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - percentage",
+			bankFee: map[string]BankFee{
+				"bca": {Type: BankFeeTypePercentage, Percentage: 101},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciliation{
+				BankFee: tt.bankFee,
+			}
+
+			if err := r.ValidateBankFee(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBankFee() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReconciliationValidateBankFormat(t *testing.T) {
+	newFormat := func(columns BankFormatColumns) BankFormat {
+		return BankFormat{Columns: columns, Sign: BankFormatSignNegativeDebit, Delimiter: ",", SkipRows: 1}
+	}
+
+	tests := []struct {
+		name       string
+		bankFormat map[string]BankFormat
+		wantErr    bool
+	}{
+		{
+			name: "Ok",
+			bankFormat: map[string]BankFormat{
+				"mandiri": newFormat(BankFormatColumns{UniqueIdentifier: "No Ref", Date: "Tanggal", Amount: "Mutasi"}),
+				"bri":     newFormat(BankFormatColumns{UniqueIdentifier: "1", Date: "2", Debit: "3", Credit: "4"}),
+				"danamon": {Columns: BankFormatColumns{UniqueIdentifier: "1", Date: "2", Amount: "3"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:       "Ok - no format",
+			bankFormat: nil,
+			wantErr:    false,
+		},
+		{
+			name: "Error - without unique identifier",
+			bankFormat: map[string]BankFormat{
+				"mandiri": newFormat(BankFormatColumns{Date: "Tanggal", Amount: "Mutasi"}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - without amount",
+			bankFormat: map[string]BankFormat{
+				"mandiri": newFormat(BankFormatColumns{UniqueIdentifier: "No Ref", Date: "Tanggal"}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - amount and debit credit",
+			bankFormat: map[string]BankFormat{
+				"mandiri": newFormat(BankFormatColumns{UniqueIdentifier: "No Ref", Date: "Tanggal", Amount: "Mutasi", Debit: "Debit", Credit: "Kredit"}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - debit without credit",
+			bankFormat: map[string]BankFormat{
+				"mandiri": newFormat(BankFormatColumns{UniqueIdentifier: "No Ref", Date: "Tanggal", Debit: "Debit"}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - unknown sign",
+			bankFormat: map[string]BankFormat{
+				"mandiri": {Columns: BankFormatColumns{UniqueIdentifier: "1", Date: "2", Amount: "3"}, Sign: "negative", Delimiter: ","},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - delimiter",
+			bankFormat: map[string]BankFormat{
+				"mandiri": {Columns: BankFormatColumns{UniqueIdentifier: "1", Date: "2", Amount: "3"}, Sign: BankFormatSignNegativeDebit, Delimiter: ";;"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - negative skip rows",
+			bankFormat: map[string]BankFormat{
+				"mandiri": {Columns: BankFormatColumns{UniqueIdentifier: "1", Date: "2", Amount: "3"}, Sign: BankFormatSignNegativeDebit, Delimiter: ";", SkipRows: -1},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciliation{
+				BankFormat: tt.bankFormat,
+			}
+
+			if err := r.ValidateBankFormat(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBankFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReconciliationValidateBankParser(t *testing.T) {
+	tests := []struct {
+		name       string
+		bankParser map[string]string
+		wantErr    bool
+	}{
+		{
+			name: "Ok",
+			bankParser: map[string]string{
+				"mandiri": "debit_credit",
+				"bri":     "DEBIT_CREDIT",
+				"danamon": "bca",
+			},
+			wantErr: false,
+		},
+		{
+			name:       "Ok - no parser",
+			bankParser: nil,
+			wantErr:    false,
+		},
+		{
+			name: "Error - unknown parser",
+			bankParser: map[string]string{
+				"mandiri": "mt940",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciliation{
+				BankParser: tt.bankParser,
+			}
+
+			if err := r.ValidateBankParser(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBankParser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReconciliationValidateLocale(t *testing.T) {
+	tests := []struct {
+		name         string
+		bankLocale   map[string]Locale
+		systemLocale Locale
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			bankLocale: map[string]Locale{
+				"mandiri": {
+					DecimalSeparator:   ",",
+					ThousandsSeparator: ".",
+					MonthNames:         locale.MonthNamesIndonesian,
+					DateLayouts:        []string{"02/01/2006", "2 Jan 2006"},
+					Delimiter:          ";",
+				},
+				"bri": {ThousandsSeparator: ","},
+			},
+			systemLocale: Locale{MonthNames: locale.MonthNamesEnglish},
+			wantErr:      false,
+		},
+		{
+			name:       "Ok - no locale",
+			bankLocale: nil,
+			wantErr:    false,
+		},
+		{
+			name:         "Error - system thousands separator same as default decimal separator",
+			systemLocale: Locale{ThousandsSeparator: "."},
+			wantErr:      true,
+		},
+		{
+			name: "Error - separator longer than one character",
+			bankLocale: map[string]Locale{
+				"mandiri": {DecimalSeparator: ",,"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - digit separator",
+			bankLocale: map[string]Locale{
+				"mandiri": {ThousandsSeparator: "0"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - unknown month names",
+			bankLocale: map[string]Locale{
+				"mandiri": {MonthNames: "fr"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - empty date layout",
+			bankLocale: map[string]Locale{
+				"mandiri": {DateLayouts: []string{"02/01/2006", " "}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - delimiter",
+			bankLocale: map[string]Locale{
+				"mandiri": {Delimiter: "\""},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciliation{
+				BankLocale:   tt.bankLocale,
+				SystemLocale: tt.systemLocale,
+			}
+
+			if err := r.ValidateLocale(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLocale() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLocaleToLocale(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale Locale
+		want   locale.Locale
+	}{
+		{
+			name: "Ok",
+			locale: Locale{
+				DecimalSeparator:   ",",
+				ThousandsSeparator: ".",
+				MonthNames:         locale.MonthNamesIndonesian,
+				DateLayouts:        []string{"2 Jan 2006"},
+				Delimiter:          ";",
+			},
+			want: locale.Locale{
+				DecimalSeparator:   ",",
+				ThousandsSeparator: ".",
+				MonthNames:         locale.MonthNamesIndonesian,
+				DateLayouts:        []string{"2 Jan 2006"},
+				Delimiter:          ';',
+			},
+		},
+		{
+			name:   "Ok - empty",
+			locale: Locale{},
+			want:   locale.Locale{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.locale.ToLocale(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToLocale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconciliationGetBankFeeTiers(t *testing.T) {
+	r := &Reconciliation{
+		BankFee: map[string]BankFee{
+			"bca": {Type: BankFeeTypeFlat, Flat: 2500, Percentage: 1},
+			"bni": {Type: BankFeeTypePercentage, Flat: 2500, Percentage: 0.7},
+			"bri": {Type: BankFeeTypeTiered, Tiers: []BankFeeTier{{Flat: 1000}, {MinAmount: 1000000, Percentage: 0.5}}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		bank string
+		want []BankFeeTier
+	}{
+		{
+			name: "Ok - flat",
+			bank: "BCA",
+			want: []BankFeeTier{{Flat: 2500}},
+		},
+		{
+			name: "Ok - percentage",
+			bank: "bni",
+			want: []BankFeeTier{{Percentage: 0.7}},
+		},
+		{
+			name: "Ok - tiered",
+			bank: "bri",
+			want: []BankFeeTier{{Flat: 1000}, {MinAmount: 1000000, Percentage: 0.5}},
+		},
+		{
+			name: "Ok - no fee",
+			bank: "mandiri",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.GetBankFeeTiers(tt.bank); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBankFeeTiers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		account Account
+		wantErr bool
+	}{
+		{
+			name:    "Ok - no pattern",
+			account: Account{IsMatchAccount: true},
+			wantErr: false,
+		},
+		{
+			name:    "Ok - pattern",
+			account: Account{PathPattern: `statement_(\d+)\.csv$`},
+			wantErr: false,
+		},
+		{
+			name:    "Error - invalid pattern",
+			account: Account{PathPattern: `statement_(\d+`},
+			wantErr: true,
+		},
+		{
+			name:    "Error - pattern without group",
+			account: Account{PathPattern: `statement_\d+\.csv$`},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.account.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,17 +4,24 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/aaronjan/hunch"
 	"github.com/dustin/go-humanize"
 	"github.com/oprekable/bank-reconcile/cmd"
 	"github.com/oprekable/bank-reconcile/internal/app/component"
+	"github.com/oprekable/bank-reconcile/internal/app/config/reconciliation"
 	"github.com/oprekable/bank-reconcile/internal/app/handler/hcli/helper"
 	"github.com/oprekable/bank-reconcile/internal/app/repository"
+	repositoryprocess "github.com/oprekable/bank-reconcile/internal/app/repository/process"
 	"github.com/oprekable/bank-reconcile/internal/app/service"
 	"github.com/oprekable/bank-reconcile/internal/app/service/process"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/utils/memstats"
 	"github.com/oprekable/bank-reconcile/internal/pkg/utils/tablewriterhelper"
+	"github.com/samber/lo"
 )
 
 const name = "process"
@@ -59,6 +66,7 @@ func (h *Handler) Exec() error {
 		// Display config information
 		func(c context.Context, _ interface{}) (interface{}, error) {
 			formatText := "-%s --%s"
+			numberFloatFormat := "#.###,##"
 			args := helper.InitCommonArgs(
 				h.comp.Config.Data,
 				[][]string{
@@ -66,6 +74,43 @@ func (h *Handler) Exec() error {
 						fmt.Sprintf(formatText, cmd.FlagReportTRXPathShort, cmd.FlagReportTRXPath),
 						h.comp.Config.Data.Reconciliation.ReportTRXPath,
 					},
+					{
+						fmt.Sprintf("--%s", cmd.FlagAmountTolerance),
+						humanize.FormatFloat(numberFloatFormat, h.comp.Config.Data.Reconciliation.AmountTolerance),
+					},
+					{
+						fmt.Sprintf("--%s", cmd.FlagAmountTolerancePercentage),
+						humanize.FormatFloat(numberFloatFormat, h.comp.Config.Data.Reconciliation.AmountTolerancePercentage),
+					},
+					{
+						fmt.Sprintf("--%s", cmd.FlagIsAggregateMatch),
+						strconv.FormatBool(h.comp.Config.Data.Reconciliation.IsAggregateMatch),
+					},
+					{
+						fmt.Sprintf("--%s", cmd.FlagSplitMatchMaxParts),
+						strconv.Itoa(h.comp.Config.Data.Reconciliation.SplitMatchMaxParts),
+					},
+					{
+						fmt.Sprintf("--%s", cmd.FlagReviewConfidenceThreshold),
+						strconv.FormatFloat(h.comp.Config.Data.Reconciliation.ReviewConfidenceThreshold, 'f', -1, 64),
+					},
+					{
+						fmt.Sprintf("--%s", cmd.FlagSuggestionCount),
+						strconv.Itoa(h.comp.Config.Data.Reconciliation.Suggestion.MaxCount),
+					},
+					{
+						fmt.Sprintf("--%s", cmd.FlagIsFailOnCoverageGap),
+						strconv.FormatBool(h.comp.Config.Data.Reconciliation.IsFailOnCoverageGap),
+					},
+					{
+						"match_rules",
+						strings.Join(
+							lo.Map(h.comp.Config.Data.Reconciliation.GetMatchRules(), func(item reconciliation.MatchRule, _ int) string {
+								return item.Name
+							}),
+							" > ",
+						),
+					},
 				},
 			)
 
@@ -84,14 +129,72 @@ func (h *Handler) Exec() error {
 		func(c context.Context, i interface{}) (interface{}, error) {
 			summary = i.(process.ReconciliationSummary)
 			numberIntegerFormat := "#.###,"
-			numberFloatFormat := "#.###,##"
+			// amounts are exact minor units, grouped the same way as numberIntegerFormat
+			formatAmount := func(a money.Amount) string {
+				return a.FormatGrouped(h.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces, ".", ",")
+			}
+
 			dataDesc := [][]string{
 				{"Total number of transactions processed", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalProcessedSystemTrx))},
-				{"Total number of matched transactions", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalMatchedSystemTrx))},
+				{"Total number of matched transactions (including needing review)", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalMatchedSystemTrx))},
+				{"Total number of matched transactions needing review (counted in matched)", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalReviewSystemTrx))},
+				{"Total number of matched transactions in settlement groups", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalAggregateMatchedSystemTrx))},
+				{"Total number of settlement group bank statements", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalAggregateMatchedBankTrx))},
+				{"Total number of matched transactions settled in parts", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalSplitMatchedSystemTrx))},
+				{"Total number of bank statements settling in parts", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalSplitMatchedBankTrx))},
+				{"Total number of matched transactions through gateways", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalGatewayMatchedSystemTrx))},
+				{"Total number of gateway payout bank statements", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalGatewayMatchedBankTrx))},
 				{"Total number of not matched transactions", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalNotMatchedSystemTrx))},
-				{"Sum amount all transactions", humanize.FormatFloat(numberFloatFormat, summary.SumAmountProcessedSystemTrx)},
-				{"Sum amount matched transactions", humanize.FormatFloat(numberFloatFormat, summary.SumAmountMatchedSystemTrx)},
-				{"Total discrepancies", humanize.FormatFloat(numberFloatFormat, summary.SumAmountDiscrepanciesSystemTrx)},
+			}
+
+			for _, reason := range repositoryprocess.UnmatchedReasons {
+				dataDesc = append(
+					dataDesc,
+					[]string{fmt.Sprintf("Total number of not matched transactions - %s", reason), humanize.FormatInteger(numberIntegerFormat, summary.TotalNotMatchedSystemTrxByReason[reason])},
+				)
+			}
+
+			dataDesc = append(
+				dataDesc,
+				[]string{fmt.Sprintf("Total number of not matched transactions - %s", repositoryprocess.UnmatchedReasonPayoutNotMatched), humanize.FormatInteger(numberIntegerFormat, summary.TotalNotMatchedSystemTrxByReason[repositoryprocess.UnmatchedReasonPayoutNotMatched])},
+			)
+
+			for _, reason := range repositoryprocess.UnmatchedReasons {
+				dataDesc = append(
+					dataDesc,
+					[]string{fmt.Sprintf("Total number of not matched bank statements - %s", reason), humanize.FormatInteger(numberIntegerFormat, summary.TotalNotMatchedBankTrxByReason[reason])},
+				)
+			}
+
+			dataDesc = append(
+				dataDesc,
+				[][]string{
+					{"Total number of duplicate transactions", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalDuplicateSystemTrx))},
+					{"Total number of duplicate bank statements", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalDuplicateBankTrx))},
+					{"Total number of rejected duplicates", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalRejectedDuplicateTrx))},
+					{"Total number of reversed transactions", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalReversalSystemTrx))},
+					{"Total number of reversed bank statements", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalReversalBankTrx))},
+					{"Total number of internal transfer bank statements", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalTransferBankTrx))},
+					{"Total number of not matched gateway transactions", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalNotMatchedGatewayTrx))},
+					{"Total number of not matched gateway payouts", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalNotMatchedGatewayPayout))},
+					{"Balance check - balanced bank statements", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalBalancedStatement))},
+					{"Balance check - not balanced bank statements", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalNotBalancedStatement))},
+					{"Balance check - bank statements without balance", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalNoBalanceStatement))},
+					{"Total number of bank days without statement data", humanize.FormatInteger(numberIntegerFormat, int(summary.TotalCoverageGap))},
+					{"Sum amount all transactions", formatAmount(summary.SumAmountProcessedSystemTrx)},
+					{"Sum amount matched transactions (including needing review)", formatAmount(summary.SumAmountMatchedSystemTrx)},
+					{"Sum amount not matched transactions", formatAmount(summary.SumAmountNotMatchedSystemTrx)},
+					{"Total discrepancies", formatAmount(summary.SumAmountDiscrepanciesSystemTrx)},
+				}...,
+			)
+
+			feeBanks := lo.Keys(summary.SumFeeByBank)
+			slices.Sort(feeBanks)
+			for _, bank := range feeBanks {
+				dataDesc = append(
+					dataDesc,
+					[]string{fmt.Sprintf("Total bank fees absorbed - %s", bank), formatAmount(summary.SumFeeByBank[bank])},
+				)
 			}
 
 			_, _ = fmt.Fprintln(h.writer, "")
@@ -100,6 +203,24 @@ func (h *Handler) Exec() error {
 			_ = tableDesc.Bulk(dataDesc)
 			_ = tableDesc.Render()
 
+			if len(summary.CoverageGapByBank) == 0 {
+				return fmt.Fprintln(h.writer, "")
+			}
+
+			// a missing statement file makes every system trx of its days not matched, show it apart
+			gapBanks := lo.Keys(summary.CoverageGapByBank)
+			slices.Sort(gapBanks)
+			dataGap := lo.Map(gapBanks, func(bank string, _ int) []string {
+				return []string{bank, strings.Join(summary.CoverageGapByBank[bank], ", ")}
+			})
+
+			_, _ = fmt.Fprintln(h.writer, "")
+			_, _ = fmt.Fprintln(h.writer, "WARNING: no bank statement data on these days, check the statement files were downloaded")
+			tableGap := tablewriterhelper.InitTableWriter(h.writer)
+			tableGap.Header([]string{"Bank", "Days Without Statement Data"})
+			_ = tableGap.Bulk(dataGap)
+			_ = tableGap.Render()
+
 			return fmt.Fprintln(h.writer, "")
 		},
 		// Display reconcile output files information
@@ -108,6 +229,13 @@ func (h *Handler) Exec() error {
 				{"Matched system transaction data", summary.FileMatchedSystemTrx},
 			}
 
+			if summary.FileReviewSystemTrx != "" {
+				dataFilePath = append(
+					dataFilePath,
+					[]string{"Matched system transaction data needing review", summary.FileReviewSystemTrx},
+				)
+			}
+
 			if summary.FileMissingSystemTrx != "" {
 				dataFilePath = append(
 					dataFilePath,
@@ -115,6 +243,62 @@ func (h *Handler) Exec() error {
 				)
 			}
 
+			if summary.FileSuggestionSystemTrx != "" {
+				dataFilePath = append(
+					dataFilePath,
+					[]string{"Near match suggestions of missing system transaction data", summary.FileSuggestionSystemTrx},
+				)
+			}
+
+			if summary.FileDuplicateTrx != "" {
+				dataFilePath = append(
+					dataFilePath,
+					[]string{"Duplicate transaction and bank statement data", summary.FileDuplicateTrx},
+				)
+			}
+
+			if summary.FileReversalTrx != "" {
+				dataFilePath = append(
+					dataFilePath,
+					[]string{"Reversal transaction and bank statement data", summary.FileReversalTrx},
+				)
+			}
+
+			if summary.FileTransferTrx != "" {
+				dataFilePath = append(
+					dataFilePath,
+					[]string{"Internal transfer bank statement data", summary.FileTransferTrx},
+				)
+			}
+
+			if summary.FileMissingGatewayTrx != "" {
+				dataFilePath = append(
+					dataFilePath,
+					[]string{"Missing gateway transaction data", summary.FileMissingGatewayTrx},
+				)
+			}
+
+			if summary.FileMissingGatewayPayout != "" {
+				dataFilePath = append(
+					dataFilePath,
+					[]string{"Missing gateway payout data", summary.FileMissingGatewayPayout},
+				)
+			}
+
+			if summary.FileCoverage != "" {
+				dataFilePath = append(
+					dataFilePath,
+					[]string{"Bank statement coverage per day", summary.FileCoverage},
+				)
+			}
+
+			if summary.FileBalanceCheck != "" {
+				dataFilePath = append(
+					dataFilePath,
+					[]string{"Balance check of bank statement data", summary.FileBalanceCheck},
+				)
+			}
+
 			for bank, value := range summary.FileMissingBankTrx {
 				dataFilePath = append(
 					dataFilePath,
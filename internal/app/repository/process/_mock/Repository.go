@@ -7,8 +7,12 @@ import (
 
 	banks "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
 
+	gateways "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
+
 	mock "github.com/stretchr/testify/mock"
 
+	money "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+
 	process "github.com/oprekable/bank-reconcile/internal/app/repository/process"
 
 	systems "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems"
@@ -39,17 +43,89 @@ func (_m *Repository) Close() error {
 	return r0
 }
 
-// GenerateReconciliationMap provides a mock function with given fields: ctx, minAmount, maxAmount
-func (_m *Repository) GenerateReconciliationMap(ctx context.Context, minAmount float64, maxAmount float64) error {
-	ret := _m.Called(ctx, minAmount, maxAmount)
+// GenerateGatewayMap provides a mock function with given fields: ctx, days
+func (_m *Repository) GenerateGatewayMap(ctx context.Context, days int) error {
+	ret := _m.Called(ctx, days)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateGatewayMap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, days)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GenerateReconciliationAggregateMap provides a mock function with given fields: ctx, rule
+func (_m *Repository) GenerateReconciliationAggregateMap(ctx context.Context, rule process.MatchRule) error {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateReconciliationAggregateMap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, process.MatchRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GenerateReconciliationReferenceMap provides a mock function with given fields: ctx, rule
+func (_m *Repository) GenerateReconciliationReferenceMap(ctx context.Context, rule process.MatchRule) error {
+	ret := _m.Called(ctx, rule)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GenerateReconciliationMap")
+		panic("no return value specified for GenerateReconciliationReferenceMap")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, float64, float64) error); ok {
-		r0 = rf(ctx, minAmount, maxAmount)
+	if rf, ok := ret.Get(0).(func(context.Context, process.MatchRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GenerateReversalMap provides a mock function with given fields: ctx, days
+func (_m *Repository) GenerateReversalMap(ctx context.Context, days int) error {
+	ret := _m.Called(ctx, days)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateReversalMap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, days)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GenerateTransferMap provides a mock function with given fields: ctx, days, ownAccounts
+func (_m *Repository) GenerateTransferMap(ctx context.Context, days int, ownAccounts []string) error {
+	ret := _m.Called(ctx, days, ownAccounts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateTransferMap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) error); ok {
+		r0 = rf(ctx, days, ownAccounts)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -117,6 +193,66 @@ func (_m *Repository) GetNotMatchedBankTrx(ctx context.Context) ([]process.NotMa
 	return r0, r1
 }
 
+// GetNotMatchedGatewayPayout provides a mock function with given fields: ctx
+func (_m *Repository) GetNotMatchedGatewayPayout(ctx context.Context) ([]process.NotMatchedGatewayPayout, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotMatchedGatewayPayout")
+	}
+
+	var r0 []process.NotMatchedGatewayPayout
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]process.NotMatchedGatewayPayout, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []process.NotMatchedGatewayPayout); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]process.NotMatchedGatewayPayout)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNotMatchedGatewayTrx provides a mock function with given fields: ctx
+func (_m *Repository) GetNotMatchedGatewayTrx(ctx context.Context) ([]process.NotMatchedGatewayTrx, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotMatchedGatewayTrx")
+	}
+
+	var r0 []process.NotMatchedGatewayTrx
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]process.NotMatchedGatewayTrx, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []process.NotMatchedGatewayTrx); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]process.NotMatchedGatewayTrx)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNotMatchedSystemTrx provides a mock function with given fields: ctx
 func (_m *Repository) GetNotMatchedSystemTrx(ctx context.Context) ([]process.NotMatchedSystemTrx, error) {
 	ret := _m.Called(ctx)
@@ -147,6 +283,66 @@ func (_m *Repository) GetNotMatchedSystemTrx(ctx context.Context) ([]process.Not
 	return r0, r1
 }
 
+// GetNotMatchedSystemTrxSuggestion provides a mock function with given fields: ctx, maxCount, days, amountPercentage
+func (_m *Repository) GetNotMatchedSystemTrxSuggestion(ctx context.Context, maxCount int, days int, amountPercentage float64) ([]process.NotMatchedSystemTrxSuggestion, error) {
+	ret := _m.Called(ctx, maxCount, days, amountPercentage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotMatchedSystemTrxSuggestion")
+	}
+
+	var r0 []process.NotMatchedSystemTrxSuggestion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, float64) ([]process.NotMatchedSystemTrxSuggestion, error)); ok {
+		return rf(ctx, maxCount, days, amountPercentage)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, float64) []process.NotMatchedSystemTrxSuggestion); ok {
+		r0 = rf(ctx, maxCount, days, amountPercentage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]process.NotMatchedSystemTrxSuggestion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, float64) error); ok {
+		r1 = rf(ctx, maxCount, days, amountPercentage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReconciliationMapCandidate provides a mock function with given fields: ctx, minAmount, maxAmount, rule
+func (_m *Repository) GetReconciliationMapCandidate(ctx context.Context, minAmount money.Amount, maxAmount money.Amount, rule process.MatchRule) ([]process.ReconciliationMapCandidate, error) {
+	ret := _m.Called(ctx, minAmount, maxAmount, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReconciliationMapCandidate")
+	}
+
+	var r0 []process.ReconciliationMapCandidate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, money.Amount, money.Amount, process.MatchRule) ([]process.ReconciliationMapCandidate, error)); ok {
+		return rf(ctx, minAmount, maxAmount, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, money.Amount, money.Amount, process.MatchRule) []process.ReconciliationMapCandidate); ok {
+		r0 = rf(ctx, minAmount, maxAmount, rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]process.ReconciliationMapCandidate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, money.Amount, money.Amount, process.MatchRule) error); ok {
+		r1 = rf(ctx, minAmount, maxAmount, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetReconciliationSummary provides a mock function with given fields: ctx
 func (_m *Repository) GetReconciliationSummary(ctx context.Context) (process.ReconciliationSummary, error) {
 	ret := _m.Called(ctx)
@@ -175,6 +371,96 @@ func (_m *Repository) GetReconciliationSummary(ctx context.Context) (process.Rec
 	return r0, r1
 }
 
+// GetReversalTrx provides a mock function with given fields: ctx
+func (_m *Repository) GetReversalTrx(ctx context.Context) ([]process.ReversalTrx, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReversalTrx")
+	}
+
+	var r0 []process.ReversalTrx
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]process.ReversalTrx, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []process.ReversalTrx); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]process.ReversalTrx)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSplitMatchCandidate provides a mock function with given fields: ctx, maxParts, rule
+func (_m *Repository) GetSplitMatchCandidate(ctx context.Context, maxParts int, rule process.MatchRule) ([]process.SplitMatchCandidate, error) {
+	ret := _m.Called(ctx, maxParts, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSplitMatchCandidate")
+	}
+
+	var r0 []process.SplitMatchCandidate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, process.MatchRule) ([]process.SplitMatchCandidate, error)); ok {
+		return rf(ctx, maxParts, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, process.MatchRule) []process.SplitMatchCandidate); ok {
+		r0 = rf(ctx, maxParts, rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]process.SplitMatchCandidate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, process.MatchRule) error); ok {
+		r1 = rf(ctx, maxParts, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransferTrx provides a mock function with given fields: ctx
+func (_m *Repository) GetTransferTrx(ctx context.Context) ([]process.TransferTrx, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransferTrx")
+	}
+
+	var r0 []process.TransferTrx
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]process.TransferTrx, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []process.TransferTrx); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]process.TransferTrx)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ImportBankTrx provides a mock function with given fields: ctx, data, from, to
 func (_m *Repository) ImportBankTrx(ctx context.Context, data []*banks.BankTrxData, from int, to int) error {
 	ret := _m.Called(ctx, data, from, to)
@@ -193,6 +479,60 @@ func (_m *Repository) ImportBankTrx(ctx context.Context, data []*banks.BankTrxDa
 	return r0
 }
 
+// ImportGatewayTrx provides a mock function with given fields: ctx, data, from, to
+func (_m *Repository) ImportGatewayTrx(ctx context.Context, data []*gateways.GatewayTrxData, from int, to int) error {
+	ret := _m.Called(ctx, data, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportGatewayTrx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*gateways.GatewayTrxData, int, int) error); ok {
+		r0 = rf(ctx, data, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportReconciliationMap provides a mock function with given fields: ctx, data
+func (_m *Repository) ImportReconciliationMap(ctx context.Context, data []process.ReconciliationMatch) error {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportReconciliationMap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []process.ReconciliationMatch) error); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportReconciliationSplitMap provides a mock function with given fields: ctx, data
+func (_m *Repository) ImportReconciliationSplitMap(ctx context.Context, data []process.SplitMatch) error {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportReconciliationSplitMap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []process.SplitMatch) error); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ImportSystemTrx provides a mock function with given fields: ctx, data, from, to
 func (_m *Repository) ImportSystemTrx(ctx context.Context, data []*systems.SystemTrxData, from int, to int) error {
 	ret := _m.Called(ctx, data, from, to)
@@ -229,17 +569,17 @@ func (_m *Repository) Post(ctx context.Context) error {
 	return r0
 }
 
-// Pre provides a mock function with given fields: ctx, listBank, startDate, toDate
-func (_m *Repository) Pre(ctx context.Context, listBank []string, startDate time.Time, toDate time.Time) error {
-	ret := _m.Called(ctx, listBank, startDate, toDate)
+// Pre provides a mock function with given fields: ctx, listBank, listGateway, startDate, toDate
+func (_m *Repository) Pre(ctx context.Context, listBank []process.Bank, listGateway []process.Gateway, startDate time.Time, toDate time.Time) error {
+	ret := _m.Called(ctx, listBank, listGateway, startDate, toDate)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Pre")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time, time.Time) error); ok {
-		r0 = rf(ctx, listBank, startDate, toDate)
+	if rf, ok := ret.Get(0).(func(context.Context, []process.Bank, []process.Gateway, time.Time, time.Time) error); ok {
+		r0 = rf(ctx, listBank, listGateway, startDate, toDate)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -10,7 +10,9 @@ import (
 	"github.com/aaronjan/hunch"
 	"github.com/goccy/go-json"
 	"github.com/oprekable/bank-reconcile/internal/app/repository/helper"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems"
 	"github.com/oprekable/bank-reconcile/internal/pkg/utils/log"
 )
@@ -40,6 +42,14 @@ func (d *DB) dropTableWith(ctx context.Context, methodName string, extraExec hun
 		func(c context.Context, i interface{}) (r interface{}, e error) {
 			tx := i.(*sql.Tx)
 			stmtData := []helper.StmtData{
+				{
+					Name:  "QueryDropViewReconciliationMatch",
+					Query: QueryDropViewReconciliationMatch,
+				},
+				{
+					Name:  "QueryDropViewGatewayPayout",
+					Query: QueryDropViewGatewayPayout,
+				},
 				{
 					Name:  "QueryDropTableArguments",
 					Query: QueryDropTableArguments,
@@ -48,6 +58,10 @@ func (d *DB) dropTableWith(ctx context.Context, methodName string, extraExec hun
 					Name:  "QueryDropTableBanks",
 					Query: QueryDropTableBanks,
 				},
+				{
+					Name:  "QueryDropTableBankFee",
+					Query: QueryDropTableBankFee,
+				},
 				{
 					Name:  "QueryDropTableSystemTrx",
 					Query: QueryDropTableSystemTrx,
@@ -60,6 +74,38 @@ func (d *DB) dropTableWith(ctx context.Context, methodName string, extraExec hun
 					Name:  "QueryDropTableReconciliationMap",
 					Query: QueryDropTableReconciliationMap,
 				},
+				{
+					Name:  "QueryDropTableReconciliationAggregateMap",
+					Query: QueryDropTableReconciliationAggregateMap,
+				},
+				{
+					Name:  "QueryDropTableReconciliationSplitMap",
+					Query: QueryDropTableReconciliationSplitMap,
+				},
+				{
+					Name:  "QueryDropTableReversalMap",
+					Query: QueryDropTableReversalMap,
+				},
+				{
+					Name:  "QueryDropTableTransferMap",
+					Query: QueryDropTableTransferMap,
+				},
+				{
+					Name:  "QueryDropTableGateways",
+					Query: QueryDropTableGateways,
+				},
+				{
+					Name:  "QueryDropTableGatewayTrx",
+					Query: QueryDropTableGatewayTrx,
+				},
+				{
+					Name:  "QueryDropTableGatewayMap",
+					Query: QueryDropTableGatewayMap,
+				},
+				{
+					Name:  "QueryDropTableGatewayPayoutMap",
+					Query: QueryDropTableGatewayPayoutMap,
+				},
 			}
 
 			return tx, helper.ExecTxQueries(ctx, tx, d.stmtMap, stmtData)
@@ -76,7 +122,15 @@ func (d *DB) dropTableWith(ctx context.Context, methodName string, extraExec hun
 	)
 }
 
-func (d *DB) createTables(ctx context.Context, tx *sql.Tx, listBank []string, startDate time.Time, toDate time.Time) (err error) {
+func (d *DB) createTables(ctx context.Context, tx *sql.Tx, listBank []Bank, listGateway []Gateway, startDate time.Time, toDate time.Time) (err error) {
+	b := new(strings.Builder)
+	_ = json.NewEncoder(b).Encode(listBank)
+	listBankJSON := strings.TrimRight(b.String(), "\n")
+
+	g := new(strings.Builder)
+	_ = json.NewEncoder(g).Encode(listGateway)
+	listGatewayJSON := strings.TrimRight(g.String(), "\n")
+
 	return helper.ExecTxQueries(
 		ctx,
 		tx,
@@ -96,14 +150,23 @@ func (d *DB) createTables(ctx context.Context, tx *sql.Tx, listBank []string, st
 			{
 				Name:  "QueryCreateTableBanks",
 				Query: QueryCreateTableBanks,
-				Args: func() []any {
-					b := new(strings.Builder)
-					_ = json.NewEncoder(b).Encode(listBank)
-
-					return []any{
-						strings.TrimRight(b.String(), "\n"),
-					}
-				}(),
+				Args: []any{
+					listBankJSON,
+				},
+			},
+			{
+				Name:  "QueryCreateTableBankFee",
+				Query: QueryCreateTableBankFee,
+				Args: []any{
+					listBankJSON,
+				},
+			},
+			{
+				Name:  "QueryCreateTableGateways",
+				Query: QueryCreateTableGateways,
+				Args: []any{
+					listGatewayJSON,
+				},
 			},
 			{
 				Name:  "QueryCreateTableSystemTrx",
@@ -117,13 +180,49 @@ func (d *DB) createTables(ctx context.Context, tx *sql.Tx, listBank []string, st
 				Name:  "QueryCreateTableReconciliationMap",
 				Query: QueryCreateTableReconciliationMap,
 			},
+			{
+				Name:  "QueryCreateTableReconciliationAggregateMap",
+				Query: QueryCreateTableReconciliationAggregateMap,
+			},
+			{
+				Name:  "QueryCreateTableReconciliationSplitMap",
+				Query: QueryCreateTableReconciliationSplitMap,
+			},
+			{
+				Name:  "QueryCreateTableReversalMap",
+				Query: QueryCreateTableReversalMap,
+			},
+			{
+				Name:  "QueryCreateTableTransferMap",
+				Query: QueryCreateTableTransferMap,
+			},
+			{
+				Name:  "QueryCreateTableGatewayTrx",
+				Query: QueryCreateTableGatewayTrx,
+			},
+			{
+				Name:  "QueryCreateTableGatewayMap",
+				Query: QueryCreateTableGatewayMap,
+			},
+			{
+				Name:  "QueryCreateTableGatewayPayoutMap",
+				Query: QueryCreateTableGatewayPayoutMap,
+			},
+			{
+				Name:  "QueryCreateViewGatewayPayout",
+				Query: QueryCreateViewGatewayPayout,
+			},
+			{
+				Name:  "QueryCreateViewReconciliationMatch",
+				Query: QueryCreateViewReconciliationMatch,
+			},
 		},
 	)
 }
 
-func (d *DB) Pre(ctx context.Context, listBank []string, startDate time.Time, toDate time.Time) (err error) {
+func (d *DB) Pre(ctx context.Context, listBank []Bank, listGateway []Gateway, startDate time.Time, toDate time.Time) (err error) {
 	extraExec := func(c context.Context, i interface{}) (interface{}, error) {
-		return nil, d.createTables(c, i.(*sql.Tx), listBank, startDate, toDate)
+		return nil, d.createTables(c, i.(*sql.Tx), listBank, listGateway, startDate, toDate)
 	}
 
 	return d.dropTableWith(
@@ -171,20 +270,187 @@ func (d *DB) ImportBankTrx(ctx context.Context, data []*banks.BankTrxData, from,
 	return d.importInterface(ctx, fmt.Sprintf("ImportBankTrx : range data (%d - %d)", from, to), QueryInsertTableBankTrx, data)
 }
 
-func (d *DB) GenerateReconciliationMap(ctx context.Context, minAmount float64, maxAmount float64) (err error) {
+func (d *DB) ImportGatewayTrx(ctx context.Context, data []*gateways.GatewayTrxData, from, to int) (err error) {
+	return d.importInterface(ctx, fmt.Sprintf("ImportGatewayTrx : range data (%d - %d)", from, to), QueryInsertTableGatewayTrx, data)
+}
+
+// GenerateGatewayMap links system trx to gateway lines, by reference first then by amount at most days apart, and
+// gateway payouts to the bank trx paying them
+func (d *DB) GenerateGatewayMap(ctx context.Context, days int) (err error) {
 	execFn := []hunch.ExecutableInSequence{
 		func(c context.Context, i interface{}) (r interface{}, e error) {
 			tx := i.(*sql.Tx)
 			stmtData := []helper.StmtData{
 				{
-					Name:  "QueryInsertTableReconciliationMap",
-					Query: QueryInsertTableReconciliationMap,
-					Args: func() []any {
-						return []any{
-							minAmount,
-							maxAmount,
-						}
-					}(),
+					Name:  "QueryInsertTableGatewayMapReference",
+					Query: QueryInsertTableGatewayMapReference,
+				},
+				{
+					Name:  "QueryInsertTableGatewayMapAmountDate",
+					Query: QueryInsertTableGatewayMapAmountDate,
+					Args: []any{
+						days,
+					},
+				},
+				{
+					Name:  "QueryInsertTableGatewayPayoutMap",
+					Query: QueryInsertTableGatewayPayoutMap,
+				},
+			}
+
+			return tx, helper.ExecTxQueries(ctx, tx, d.stmtMap, stmtData)
+		},
+	}
+
+	return helper.TxWith(
+		ctx,
+		logFlag,
+		"GenerateGatewayMap",
+		d.db,
+		execFn...,
+	)
+}
+
+// GenerateReversalMap pairs system trx then bank trx cancelling each other, matching and the not matched reports
+// leave the paired trx out
+func (d *DB) GenerateReversalMap(ctx context.Context, days int) (err error) {
+	execFn := []hunch.ExecutableInSequence{
+		func(c context.Context, i interface{}) (r interface{}, e error) {
+			tx := i.(*sql.Tx)
+			stmtData := []helper.StmtData{
+				{
+					Name:  "QueryInsertTableReversalMapSystemTrx",
+					Query: QueryInsertTableReversalMapSystemTrx,
+					Args: []any{
+						days,
+					},
+				},
+				{
+					Name:  "QueryInsertTableReversalMapBankTrx",
+					Query: QueryInsertTableReversalMapBankTrx,
+					Args: []any{
+						days,
+					},
+				},
+			}
+
+			return tx, helper.ExecTxQueries(ctx, tx, d.stmtMap, stmtData)
+		},
+	}
+
+	return helper.TxWith(
+		ctx,
+		logFlag,
+		"GenerateReversalMap",
+		d.db,
+		execFn...,
+	)
+}
+
+// GenerateTransferMap pairs bank trx left over by matching moving money between our own accounts, ownAccounts
+// restricts the counterparty accounts when not empty
+func (d *DB) GenerateTransferMap(ctx context.Context, days int, ownAccounts []string) (err error) {
+	execFn := []hunch.ExecutableInSequence{
+		func(c context.Context, i interface{}) (r interface{}, e error) {
+			// a json null would be one own account
+			if ownAccounts == nil {
+				ownAccounts = []string{}
+			}
+
+			ownAccountsJSON, _ := json.Marshal(ownAccounts)
+			tx := i.(*sql.Tx)
+			stmtData := []helper.StmtData{
+				{
+					Name:  "QueryInsertTableTransferMap",
+					Query: QueryInsertTableTransferMap,
+					Args: []any{
+						days,
+						string(ownAccountsJSON),
+					},
+				},
+			}
+
+			return tx, helper.ExecTxQueries(ctx, tx, d.stmtMap, stmtData)
+		},
+	}
+
+	return helper.TxWith(
+		ctx,
+		logFlag,
+		"GenerateTransferMap",
+		d.db,
+		execFn...,
+	)
+}
+
+func (d *DB) GenerateReconciliationReferenceMap(ctx context.Context, rule MatchRule) (err error) {
+	execFn := []hunch.ExecutableInSequence{
+		func(c context.Context, i interface{}) (r interface{}, e error) {
+			tx := i.(*sql.Tx)
+			stmtData := []helper.StmtData{
+				{
+					Name:  "QueryInsertTableReconciliationReferenceMap",
+					Query: QueryInsertTableReconciliationReferenceMap,
+					Args: []any{
+						rule.Name,
+					},
+				},
+			}
+
+			return tx, helper.ExecTxQueries(ctx, tx, d.stmtMap, stmtData)
+		},
+	}
+
+	return helper.TxWith(
+		ctx,
+		logFlag,
+		fmt.Sprintf("GenerateReconciliationReferenceMap : rule %s", rule.Name),
+		d.db,
+		execFn...,
+	)
+}
+
+func (d *DB) GetReconciliationMapCandidate(ctx context.Context, minAmount money.Amount, maxAmount money.Amount, rule MatchRule) (returnData []ReconciliationMapCandidate, err error) {
+	defer func() {
+		log.Err(ctx, fmt.Sprintf("[process.NewDB] Exec GetReconciliationMapCandidate method from db : rule %s range amount (%d - %d)", rule.Name, minAmount, maxAmount), err)
+	}()
+
+	returnData, err = helper.QueryContext[[]ReconciliationMapCandidate](
+		ctx,
+		d.db,
+		d.stmtMap,
+		helper.StmtData{
+			Name:  "QueryGetReconciliationMapCandidate",
+			Query: QueryGetReconciliationMapCandidate,
+			Args: append(
+				[]any{
+					minAmount,
+					maxAmount,
+					rule.Tolerance.Absolute,
+					rule.Tolerance.Percentage,
+					rule.Tolerance.FXPercentage,
+				},
+				rule.windowArgs()...,
+			),
+		},
+	)
+
+	return
+}
+
+func (d *DB) ImportReconciliationMap(ctx context.Context, data []ReconciliationMatch) (err error) {
+	return d.importInterface(ctx, "ImportReconciliationMap", QueryInsertTableReconciliationMap, data)
+}
+
+func (d *DB) GenerateReconciliationAggregateMap(ctx context.Context, rule MatchRule) (err error) {
+	execFn := []hunch.ExecutableInSequence{
+		func(c context.Context, i interface{}) (r interface{}, e error) {
+			tx := i.(*sql.Tx)
+			stmtData := []helper.StmtData{
+				{
+					Name:  "QueryInsertTableReconciliationAggregateMap",
+					Query: QueryInsertTableReconciliationAggregateMap,
+					Args:  append(rule.windowArgs(), rule.Name),
 				},
 			}
 
@@ -195,12 +461,35 @@ func (d *DB) GenerateReconciliationMap(ctx context.Context, minAmount float64, m
 	return helper.TxWith(
 		ctx,
 		logFlag,
-		fmt.Sprintf("GenerateReconciliationMap : range amount (%.0f - %.0f)", minAmount, maxAmount),
+		fmt.Sprintf("GenerateReconciliationAggregateMap : rule %s", rule.Name),
 		d.db,
 		execFn...,
 	)
 }
 
+func (d *DB) GetSplitMatchCandidate(ctx context.Context, maxParts int, rule MatchRule) (returnData []SplitMatchCandidate, err error) {
+	defer func() {
+		log.Err(ctx, "[process.NewDB] Exec GetSplitMatchCandidate method from db", err)
+	}()
+
+	returnData, err = helper.QueryContext[[]SplitMatchCandidate](
+		ctx,
+		d.db,
+		d.stmtMap,
+		helper.StmtData{
+			Name:  "QueryGetSplitMatchCandidate",
+			Query: QueryGetSplitMatchCandidate,
+			Args:  append(rule.windowArgs(), maxParts),
+		},
+	)
+
+	return
+}
+
+func (d *DB) ImportReconciliationSplitMap(ctx context.Context, data []SplitMatch) (err error) {
+	return d.importInterface(ctx, "ImportReconciliationSplitMap", QueryInsertTableReconciliationSplitMap, data)
+}
+
 func (d *DB) GetReconciliationSummary(ctx context.Context) (returnData ReconciliationSummary, err error) {
 	defer func() {
 		log.Err(ctx, "[process.NewDB] Exec GetReconciliationSummary method from db", err)
@@ -292,3 +581,98 @@ func (d *DB) GetNotMatchedBankTrx(ctx context.Context) (returnData []NotMatchedB
 
 	return
 }
+
+func (d *DB) GetReversalTrx(ctx context.Context) (returnData []ReversalTrx, err error) {
+	defer func() {
+		log.Err(ctx, "[process.NewDB] Exec GetReversalTrx method from db", err)
+	}()
+
+	returnData, err = helper.QueryContext[[]ReversalTrx](
+		ctx,
+		d.db,
+		d.stmtMap,
+		helper.StmtData{
+			Name:  "QueryGetReversalTrx",
+			Query: QueryGetReversalTrx,
+			Args:  nil,
+		},
+	)
+
+	return
+}
+
+func (d *DB) GetTransferTrx(ctx context.Context) (returnData []TransferTrx, err error) {
+	defer func() {
+		log.Err(ctx, "[process.NewDB] Exec GetTransferTrx method from db", err)
+	}()
+
+	returnData, err = helper.QueryContext[[]TransferTrx](
+		ctx,
+		d.db,
+		d.stmtMap,
+		helper.StmtData{
+			Name:  "QueryGetTransferTrx",
+			Query: QueryGetTransferTrx,
+			Args:  nil,
+		},
+	)
+
+	return
+}
+
+func (d *DB) GetNotMatchedSystemTrxSuggestion(ctx context.Context, maxCount int, days int, amountPercentage float64) (returnData []NotMatchedSystemTrxSuggestion, err error) {
+	defer func() {
+		log.Err(ctx, "[process.NewDB] Exec GetNotMatchedSystemTrxSuggestion method from db", err)
+	}()
+
+	returnData, err = helper.QueryContext[[]NotMatchedSystemTrxSuggestion](
+		ctx,
+		d.db,
+		d.stmtMap,
+		helper.StmtData{
+			Name:  "QueryGetNotMatchedSystemTrxSuggestion",
+			Query: QueryGetNotMatchedSystemTrxSuggestion,
+			Args:  []any{maxCount, days, amountPercentage},
+		},
+	)
+
+	return
+}
+
+func (d *DB) GetNotMatchedGatewayTrx(ctx context.Context) (returnData []NotMatchedGatewayTrx, err error) {
+	defer func() {
+		log.Err(ctx, "[process.NewDB] Exec GetNotMatchedGatewayTrx method from db", err)
+	}()
+
+	returnData, err = helper.QueryContext[[]NotMatchedGatewayTrx](
+		ctx,
+		d.db,
+		d.stmtMap,
+		helper.StmtData{
+			Name:  "QueryGetNotMatchedGatewayTrx",
+			Query: QueryGetNotMatchedGatewayTrx,
+			Args:  nil,
+		},
+	)
+
+	return
+}
+
+func (d *DB) GetNotMatchedGatewayPayout(ctx context.Context) (returnData []NotMatchedGatewayPayout, err error) {
+	defer func() {
+		log.Err(ctx, "[process.NewDB] Exec GetNotMatchedGatewayPayout method from db", err)
+	}()
+
+	returnData, err = helper.QueryContext[[]NotMatchedGatewayPayout](
+		ctx,
+		d.db,
+		d.stmtMap,
+		helper.StmtData{
+			Name:  "QueryGetNotMatchedGatewayPayout",
+			Query: QueryGetNotMatchedGatewayPayout,
+			Args:  nil,
+		},
+	)
+
+	return
+}
@@ -3,16 +3,22 @@ package process
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/aaronjan/hunch"
 	"github.com/goccy/go-json"
+
+	// Initialize DB driver to sqlite
+	_ "modernc.org/sqlite"
 )
 
 const (
@@ -83,15 +89,14 @@ func TestDBClose(t *testing.T) {
 	}
 }
 
-func TestDBGenerateReconciliationMap(t *testing.T) {
+func TestDBGenerateReversalMap(t *testing.T) {
 	type fields struct {
 		db      *sql.DB
 		stmtMap map[string]*sql.Stmt
 	}
 
 	type args struct {
-		minAmount float64
-		maxAmount float64
+		days int
 	}
 
 	tests := []struct {
@@ -107,11 +112,14 @@ func TestDBGenerateReconciliationMap(t *testing.T) {
 					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 					s.ExpectBegin()
 
-					s.ExpectPrepare(QueryInsertTableReconciliationMap).
+					s.ExpectPrepare(QueryInsertTableReversalMapSystemTrx).
 						ExpectExec().
-						WithArgs(float64(0),
-							float64(1000),
-						).
+						WithArgs(2).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryInsertTableReversalMapBankTrx).
+						ExpectExec().
+						WithArgs(2).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					s.ExpectCommit()
 
@@ -120,11 +128,31 @@ func TestDBGenerateReconciliationMap(t *testing.T) {
 				stmtMap: make(map[string]*sql.Stmt),
 			},
 			args: args{
-				minAmount: 0,
-				maxAmount: 1000,
+				days: 2,
 			},
 			wantErr: false,
 		},
+		{
+			name: "Error",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableReversalMapSystemTrx).
+						ExpectExec().
+						WillReturnError(sql.ErrConnDone)
+					s.ExpectRollback()
+
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				days: 2,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -134,63 +162,85 @@ func TestDBGenerateReconciliationMap(t *testing.T) {
 				stmtMap: tt.fields.stmtMap,
 			}
 
-			if err := d.GenerateReconciliationMap(context.Background(), tt.args.minAmount, tt.args.maxAmount); (err != nil) != tt.wantErr {
-				t.Errorf("GenerateReconciliationMap() error = %v, wantErr %v", err, tt.wantErr)
+			if err := d.GenerateReversalMap(context.Background(), tt.args.days); (err != nil) != tt.wantErr {
+				t.Errorf("GenerateReversalMap() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func TestDBGetMatchedTrx(t *testing.T) {
+func TestDBGenerateGatewayMap(t *testing.T) {
 	type fields struct {
 		db      *sql.DB
 		stmtMap map[string]*sql.Stmt
 	}
 
+	type args struct {
+		days int
+	}
+
 	tests := []struct {
-		name           string
-		fields         fields
-		wantReturnData []MatchedTrx
-		wantErr        bool
+		fields  fields
+		name    string
+		args    args
+		wantErr bool
 	}{
 		{
 			name: "Ok",
 			fields: fields{
 				db: func() *sql.DB {
 					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
-					s.ExpectPrepare(QueryGetMatchedTrx).ExpectQuery().
-						WillReturnRows(
-							sqlmock.NewRows([]string{"SystemTrxTrxID", "BankTrxUniqueIdentifier", "SystemTrxTransactionTime", "BankTrxDate", "SystemTrxType", "Bank", "SystemTrxAmount", "BankTrxAmount"}).
-								AddRow("0012d068c53eb0971fc8563343c5d81f", "foo-0012d068c53eb0971fc8563343c5d81f", TrxDateTimeOne, TrxDateOne, "DEBIT", "foo", 20500, 20500).
-								AddRow("005dcbc9e27365a072be5393ea8d0f37", "foo-005dcbc9e27365a072be5393ea8d0f37", TrxDateTimeTwo, TrxDateTwo, "CREDIT", "foo", 42100, -42100))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableGatewayMapReference).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryInsertTableGatewayMapAmountDate).
+						ExpectExec().
+						WithArgs(1).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryInsertTableGatewayPayoutMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					s.ExpectCommit()
+
 					return db
 				}(),
 				stmtMap: make(map[string]*sql.Stmt),
 			},
-			wantReturnData: []MatchedTrx{
-				{
-					SystemTrxTrxID:           "0012d068c53eb0971fc8563343c5d81f",
-					BankTrxUniqueIdentifier:  "foo-0012d068c53eb0971fc8563343c5d81f",
-					SystemTrxTransactionTime: TrxDateTimeOne,
-					BankTrxDate:              TrxDateOne,
-					SystemTrxType:            "DEBIT",
-					Bank:                     "foo",
-					SystemTrxAmount:          20500,
-					BankTrxAmount:            20500,
-				},
-				{
-					SystemTrxTrxID:           "005dcbc9e27365a072be5393ea8d0f37",
-					BankTrxUniqueIdentifier:  "foo-005dcbc9e27365a072be5393ea8d0f37",
-					SystemTrxTransactionTime: TrxDateTimeTwo,
-					BankTrxDate:              TrxDateTwo,
-					SystemTrxType:            "CREDIT",
-					Bank:                     "foo",
-					SystemTrxAmount:          42100,
-					BankTrxAmount:            -42100,
-				},
+			args: args{
+				days: 1,
 			},
 			wantErr: false,
 		},
+		{
+			name: "Error",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableGatewayMapReference).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryInsertTableGatewayMapAmountDate).
+						ExpectExec().
+						WithArgs(1).
+						WillReturnError(sql.ErrConnDone)
+					s.ExpectRollback()
+
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				days: 1,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -200,61 +250,97 @@ func TestDBGetMatchedTrx(t *testing.T) {
 				stmtMap: tt.fields.stmtMap,
 			}
 
-			gotReturnData, err := d.GetMatchedTrx(context.Background())
-			if (err != nil) != tt.wantErr {
-				t.Errorf("GetMatchedTrx() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-
-			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
-				t.Errorf("GetMatchedTrx() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			if err := d.GenerateGatewayMap(context.Background(), tt.args.days); (err != nil) != tt.wantErr {
+				t.Errorf("GenerateGatewayMap() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func TestDBGetNotMatchedBankTrx(t *testing.T) {
+func TestDBGenerateTransferMap(t *testing.T) {
 	type fields struct {
 		db      *sql.DB
 		stmtMap map[string]*sql.Stmt
 	}
 
+	type args struct {
+		ownAccounts []string
+		days        int
+	}
+
 	tests := []struct {
-		name           string
-		fields         fields
-		wantReturnData []NotMatchedBankTrx
-		wantErr        bool
+		fields  fields
+		name    string
+		args    args
+		wantErr bool
 	}{
 		{
 			name: "Ok",
 			fields: fields{
 				db: func() *sql.DB {
 					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
-					s.ExpectPrepare(QueryGetNotMatchedBankTrx).ExpectQuery().
-						WillReturnRows(
-							sqlmock.NewRows([]string{"UniqueIdentifier", "Date", "Bank", "Amount"}).
-								AddRow("0012d068c53eb0971fc8563343c5d81f", TrxDateOne, "foo", 20500).
-								AddRow("005dcbc9e27365a072be5393ea8d0f37", TrxDateTwo, "foo", 42100))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableTransferMap).
+						ExpectExec().
+						WithArgs(2, `["111","222"]`).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					s.ExpectCommit()
+
 					return db
 				}(),
 				stmtMap: make(map[string]*sql.Stmt),
 			},
-			wantReturnData: []NotMatchedBankTrx{
-				{
-					UniqueIdentifier: "0012d068c53eb0971fc8563343c5d81f",
-					Date:             TrxDateOne,
-					Bank:             "foo",
-					Amount:           20500,
-				},
-				{
-					UniqueIdentifier: "005dcbc9e27365a072be5393ea8d0f37",
-					Date:             TrxDateTwo,
-					Bank:             "foo",
-					Amount:           42100,
-				},
+			args: args{
+				days:        2,
+				ownAccounts: []string{"111", "222"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - without own accounts",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableTransferMap).
+						ExpectExec().
+						WithArgs(1, `[]`).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					s.ExpectCommit()
+
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				days: 1,
 			},
 			wantErr: false,
 		},
+		{
+			name: "Error",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableTransferMap).
+						ExpectExec().
+						WithArgs(1, `[]`).
+						WillReturnError(sql.ErrConnDone)
+					s.ExpectRollback()
+
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				days: 1,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -264,61 +350,76 @@ func TestDBGetNotMatchedBankTrx(t *testing.T) {
 				stmtMap: tt.fields.stmtMap,
 			}
 
-			gotReturnData, err := d.GetNotMatchedBankTrx(context.Background())
-			if (err != nil) != tt.wantErr {
-				t.Errorf("GetNotMatchedBankTrx() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-
-			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
-				t.Errorf("GetNotMatchedBankTrx() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			if err := d.GenerateTransferMap(context.Background(), tt.args.days, tt.args.ownAccounts); (err != nil) != tt.wantErr {
+				t.Errorf("GenerateTransferMap() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func TestDBGetNotMatchedSystemTrx(t *testing.T) {
+func TestDBGenerateReconciliationReferenceMap(t *testing.T) {
 	type fields struct {
 		db      *sql.DB
 		stmtMap map[string]*sql.Stmt
 	}
 
+	type args struct {
+		rule MatchRule
+	}
+
 	tests := []struct {
-		name           string
-		fields         fields
-		wantReturnData []NotMatchedSystemTrx
-		wantErr        bool
+		fields  fields
+		name    string
+		args    args
+		wantErr bool
 	}{
 		{
 			name: "Ok",
 			fields: fields{
 				db: func() *sql.DB {
 					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
-					s.ExpectPrepare(QueryGetNotMatchedSystemTrx).ExpectQuery().
-						WillReturnRows(
-							sqlmock.NewRows([]string{"TrxID", "TransactionTime", "Type", "Amount"}).
-								AddRow("0012d068c53eb0971fc8563343c5d81f", TrxDateTimeOne, "CREDIT", 20500).
-								AddRow("005dcbc9e27365a072be5393ea8d0f37", TrxDateTimeTwo, "CREDIT", 42100))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableReconciliationReferenceMap).
+						ExpectExec().
+						WithArgs("reference").
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					s.ExpectCommit()
+
 					return db
 				}(),
 				stmtMap: make(map[string]*sql.Stmt),
 			},
-			wantReturnData: []NotMatchedSystemTrx{
-				{
-					TrxID:           "0012d068c53eb0971fc8563343c5d81f",
-					TransactionTime: TrxDateTimeOne,
-					Type:            "CREDIT",
-					Amount:          20500,
-				},
-				{
-					TrxID:           "005dcbc9e27365a072be5393ea8d0f37",
-					TransactionTime: TrxDateTimeTwo,
-					Type:            "CREDIT",
-					Amount:          42100,
+			args: args{
+				rule: MatchRule{
+					Name: "reference",
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "Error",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableReconciliationReferenceMap).
+						ExpectExec().
+						WillReturnError(sql.ErrConnDone)
+					s.ExpectRollback()
+
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				rule: MatchRule{
+					Name: "reference",
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -328,61 +429,1310 @@ func TestDBGetNotMatchedSystemTrx(t *testing.T) {
 				stmtMap: tt.fields.stmtMap,
 			}
 
-			gotReturnData, err := d.GetNotMatchedSystemTrx(context.Background())
-			if (err != nil) != tt.wantErr {
-				t.Errorf("GetNotMatchedSystemTrx() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-
-			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
-				t.Errorf("GetNotMatchedSystemTrx() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			if err := d.GenerateReconciliationReferenceMap(context.Background(), tt.args.rule); (err != nil) != tt.wantErr {
+				t.Errorf("GenerateReconciliationReferenceMap() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func TestDBGetReconciliationSummary(t *testing.T) {
-	type fields struct {
-		db      *sql.DB
-		stmtMap map[string]*sql.Stmt
-	}
+func TestDBGetReconciliationMapCandidate(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	type args struct {
+		rule      MatchRule
+		minAmount money.Amount
+		maxAmount money.Amount
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantReturnData []ReconciliationMapCandidate
+		args           args
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetReconciliationMapCandidate).ExpectQuery().
+						WithArgs(int64(0),
+							int64(100000),
+							int64(0),
+							float64(0),
+							float64(0),
+							nil,
+							nil,
+						).
+						WillReturnRows(
+							sqlmock.NewRows([]string{"TrxID", "UniqueIdentifier", "AmountDifference", "Fee", "DateDifference", "TimeDistance", "TrxTime", "BankTime", "Confidence"}).
+								AddRow("0012d068c53eb0971fc8563343c5d81f", "foo-1", 0, 0, 0, 3600, 1742036400, 1742040000, 0.5),
+						)
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				minAmount: 0,
+				maxAmount: 100000,
+				rule: MatchRule{
+					Name: "amount_date",
+				},
+			},
+			wantReturnData: []ReconciliationMapCandidate{
+				{
+					TrxID:            "0012d068c53eb0971fc8563343c5d81f",
+					UniqueIdentifier: "foo-1",
+					TimeDistance:     3600,
+					TrxTime:          1742036400,
+					BankTime:         1742040000,
+					Confidence:       0.5,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - with tolerance and window",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetReconciliationMapCandidate).ExpectQuery().
+						WithArgs(int64(0),
+							int64(100000),
+							int64(5000),
+							float64(0.5),
+							float64(1),
+							1,
+							2,
+						).
+						WillReturnRows(
+							sqlmock.NewRows([]string{"TrxID", "UniqueIdentifier", "AmountDifference", "Fee", "DateDifference", "TimeDistance", "TrxTime", "BankTime", "Confidence"}),
+						)
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				minAmount: 0,
+				maxAmount: 100000,
+				rule: MatchRule{
+					Name: "near",
+					Tolerance: AmountTolerance{
+						Absolute:     5000,
+						Percentage:   0.5,
+						FXPercentage: 1,
+					},
+					Window: &SettlementWindow{
+						DaysBefore: 1,
+						DaysAfter:  2,
+					},
+				},
+			},
+			wantReturnData: nil,
+			wantErr:        false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetReconciliationMapCandidate).ExpectQuery().
+						WillReturnError(sql.ErrConnDone)
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				minAmount: 0,
+				maxAmount: 100000,
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			gotReturnData, err := d.GetReconciliationMapCandidate(context.Background(), tt.args.minAmount, tt.args.maxAmount, tt.args.rule)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetReconciliationMapCandidate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("GetReconciliationMapCandidate() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestDBImportReconciliationMap(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	type args struct {
+		data []ReconciliationMatch
+	}
+
+	tests := []struct {
+		fields  fields
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableReconciliationMap).
+						ExpectExec().
+						WithArgs(`[{"TrxID":"foo","UniqueIdentifier":"foo-1","AmountDifference":-100,"Fee":2500,"DateDifference":1,"MatchRule":"amount_date","Confidence":0.75}]`).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					s.ExpectCommit()
+
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				data: []ReconciliationMatch{
+					{
+						TrxID:            "foo",
+						UniqueIdentifier: "foo-1",
+						AmountDifference: -100,
+						Fee:              2500,
+						DateDifference:   1,
+						MatchRule:        "amount_date",
+						Confidence:       0.75,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableReconciliationMap).
+						ExpectExec().
+						WillReturnError(sql.ErrConnDone)
+					s.ExpectRollback()
+
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				data: []ReconciliationMatch{},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			if err := d.ImportReconciliationMap(context.Background(), tt.args.data); (err != nil) != tt.wantErr {
+				t.Errorf("ImportReconciliationMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDBGenerateReconciliationAggregateMap(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	type args struct {
+		rule MatchRule
+	}
+
+	tests := []struct {
+		fields  fields
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableReconciliationAggregateMap).
+						ExpectExec().
+						WithArgs(0, 1, "aggregate").
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					s.ExpectCommit()
+
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				rule: MatchRule{
+					Name: "aggregate",
+					Window: &SettlementWindow{
+						DaysAfter: 1,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableReconciliationAggregateMap).
+						ExpectExec().
+						WillReturnError(sql.ErrConnDone)
+					s.ExpectRollback()
+
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				rule: MatchRule{
+					Name: "aggregate",
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			if err := d.GenerateReconciliationAggregateMap(context.Background(), tt.args.rule); (err != nil) != tt.wantErr {
+				t.Errorf("GenerateReconciliationAggregateMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDBGetSplitMatchCandidate(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	type args struct {
+		maxParts int
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantReturnData []SplitMatchCandidate
+		args           args
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetSplitMatchCandidate).ExpectQuery().
+						WithArgs(nil, nil, 3).
+						WillReturnRows(
+							sqlmock.NewRows([]string{"TrxID", "UniqueIdentifiers", "Parts", "DateDistance", "Confidence"}).
+								AddRow("0012d068c53eb0971fc8563343c5d81f", `["foo-1","foo-2"]`, 2, 1, 0.75),
+						)
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				maxParts: 3,
+			},
+			wantReturnData: []SplitMatchCandidate{
+				{
+					TrxID:             "0012d068c53eb0971fc8563343c5d81f",
+					UniqueIdentifiers: `["foo-1","foo-2"]`,
+					Parts:             2,
+					DateDistance:      1,
+					Confidence:        0.75,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetSplitMatchCandidate).ExpectQuery().
+						WithArgs(nil, nil, 3).
+						WillReturnError(sql.ErrConnDone)
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				maxParts: 3,
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			gotReturnData, err := d.GetSplitMatchCandidate(context.Background(), tt.args.maxParts, MatchRule{Name: "split"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetSplitMatchCandidate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("GetSplitMatchCandidate() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestDBGetSplitMatchCandidateManySameDayTrx(t *testing.T) {
+	// one system trx of a large amount must not make every set of a busy day of the bank a candidate
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+
+	db.SetMaxOpenConns(1)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	d, _ := NewDB(db)
+	date, _ := time.Parse(DateFormat, TrxDateOne)
+	if err = d.Pre(ctx, []Bank{{Name: "bca"}}, nil, date, date); err != nil {
+		t.Fatalf("Pre() error = %v", err)
+	}
+
+	systemTrx := []*systems.SystemTrxData{
+		{TrxID: "big", TransactionTime: date, Type: "CREDIT", Amount: 1000000},
+		{TrxID: "small", TransactionTime: date, Type: "CREDIT", Amount: 2001},
+	}
+
+	if err = d.ImportSystemTrx(ctx, systemTrx, 0, len(systemTrx)); err != nil {
+		t.Fatalf("ImportSystemTrx() error = %v", err)
+	}
+
+	var bankTrx []*banks.BankTrxData
+	for i := range 40 {
+		bankTrx = append(bankTrx, &banks.BankTrxData{
+			UniqueIdentifier: fmt.Sprintf("bca-%02d", i),
+			Date:             date,
+			Type:             banks.CREDIT,
+			Bank:             "bca",
+			Amount:           money.Amount(1000 + i),
+		})
+	}
+
+	if err = d.ImportBankTrx(ctx, bankTrx, 0, len(bankTrx)); err != nil {
+		t.Fatalf("ImportBankTrx() error = %v", err)
+	}
+
+	gotReturnData, err := d.GetSplitMatchCandidate(ctx, 6, MatchRule{Name: "split"})
+	if err != nil {
+		t.Fatalf("GetSplitMatchCandidate() error = %v", err)
+	}
+
+	wantReturnData := []SplitMatchCandidate{
+		{
+			TrxID:             "small",
+			UniqueIdentifiers: `["bca-00","bca-01"]`,
+			Parts:             2,
+			DateDistance:      0,
+			Confidence:        1,
+		},
+	}
+
+	if !reflect.DeepEqual(gotReturnData, wantReturnData) {
+		t.Errorf("GetSplitMatchCandidate() gotReturnData = %v, want %v", gotReturnData, wantReturnData)
+	}
+}
+
+func TestDBImportReconciliationSplitMap(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	type args struct {
+		data []SplitMatch
+	}
+
+	tests := []struct {
+		fields  fields
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableReconciliationSplitMap).
+						ExpectExec().
+						WithArgs(`[{"TrxID":"foo","UniqueIdentifier":"foo-1","MatchRule":"split","Confidence":0.5},{"TrxID":"foo","UniqueIdentifier":"foo-2","MatchRule":"split","Confidence":0.5}]`).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					s.ExpectCommit()
+
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				data: []SplitMatch{
+					{
+						TrxID:            "foo",
+						UniqueIdentifier: "foo-1",
+						MatchRule:        "split",
+						Confidence:       0.5,
+					},
+					{
+						TrxID:            "foo",
+						UniqueIdentifier: "foo-2",
+						MatchRule:        "split",
+						Confidence:       0.5,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectBegin()
+
+					s.ExpectPrepare(QueryInsertTableReconciliationSplitMap).
+						ExpectExec().
+						WillReturnError(sql.ErrConnDone)
+					s.ExpectRollback()
+
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			args: args{
+				data: []SplitMatch{},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			if err := d.ImportReconciliationSplitMap(context.Background(), tt.args.data); (err != nil) != tt.wantErr {
+				t.Errorf("ImportReconciliationSplitMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDBGetMatchedTrx(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantReturnData []MatchedTrx
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetMatchedTrx).ExpectQuery().
+						WillReturnRows(
+							sqlmock.NewRows([]string{"SystemTrxTrxID", "BankTrxUniqueIdentifier", "SystemTrxTransactionTime", "BankTrxDate", "SystemTrxType", "Bank", "SystemTrxAmount", "BankTrxAmount", "AmountDifference", "Fee", "DateDifference", "MatchType", "GroupSize", "MatchRule", "Confidence", "SystemTrxCurrency", "SystemTrxOriginalAmount", "BankTrxCurrency", "BankTrxOriginalAmount"}).
+								AddRow("0012d068c53eb0971fc8563343c5d81f", "foo-0012d068c53eb0971fc8563343c5d81f", TrxDateTimeOne, TrxDateOne, "DEBIT", "foo", 20500, -20750, 0, 250, 0, "ONE_TO_ONE", 1, "reference", 1, "IDR", 20500, "IDR", -20750).
+								AddRow("005dcbc9e27365a072be5393ea8d0f37", "foo-005dcbc9e27365a072be5393ea8d0f37", TrxDateTimeTwo, TrxDateTwo, "CREDIT", "foo", 42100, -42050, -50, 0, 1, "MANY_TO_ONE", 2, "aggregate", 0.375, "IDR", 42100, "USD", -290))
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			wantReturnData: []MatchedTrx{
+				{
+					SystemTrxTrxID:           "0012d068c53eb0971fc8563343c5d81f",
+					BankTrxUniqueIdentifier:  "foo-0012d068c53eb0971fc8563343c5d81f",
+					SystemTrxTransactionTime: TrxDateTimeOne,
+					BankTrxDate:              TrxDateOne,
+					SystemTrxType:            "DEBIT",
+					Bank:                     "foo",
+					SystemTrxAmount:          20500,
+					BankTrxAmount:            -20750,
+					Fee:                      250,
+					MatchType:                "ONE_TO_ONE",
+					GroupSize:                1,
+					MatchRule:                "reference",
+					Confidence:               1,
+					SystemTrxCurrency:        "IDR",
+					SystemTrxOriginalAmount:  20500,
+					BankTrxCurrency:          "IDR",
+					BankTrxOriginalAmount:    -20750,
+				},
+				{
+					SystemTrxTrxID:           "005dcbc9e27365a072be5393ea8d0f37",
+					BankTrxUniqueIdentifier:  "foo-005dcbc9e27365a072be5393ea8d0f37",
+					SystemTrxTransactionTime: TrxDateTimeTwo,
+					BankTrxDate:              TrxDateTwo,
+					SystemTrxType:            "CREDIT",
+					Bank:                     "foo",
+					SystemTrxAmount:          42100,
+					BankTrxAmount:            -42050,
+					AmountDifference:         -50,
+					DateDifference:           1,
+					MatchType:                "MANY_TO_ONE",
+					GroupSize:                2,
+					MatchRule:                "aggregate",
+					Confidence:               0.375,
+					SystemTrxCurrency:        "IDR",
+					SystemTrxOriginalAmount:  42100,
+					BankTrxCurrency:          "USD",
+					BankTrxOriginalAmount:    -290,
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			gotReturnData, err := d.GetMatchedTrx(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetMatchedTrx() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("GetMatchedTrx() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestDBGetNotMatchedBankTrx(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantReturnData []NotMatchedBankTrx
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetNotMatchedBankTrx).ExpectQuery().
+						WillReturnRows(
+							sqlmock.NewRows([]string{"UniqueIdentifier", "Date", "Bank", "Account", "Amount", "Reason"}).
+								AddRow("0012d068c53eb0971fc8563343c5d81f", TrxDateOne, "foo", "1234567890", 20500, UnmatchedReasonAmountMismatch).
+								AddRow("005dcbc9e27365a072be5393ea8d0f37", TrxDateTwo, "foo", "", 42100, UnmatchedReasonNoTrxOnDate))
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			wantReturnData: []NotMatchedBankTrx{
+				{
+					UniqueIdentifier: "0012d068c53eb0971fc8563343c5d81f",
+					Date:             TrxDateOne,
+					Bank:             "foo",
+					Account:          "1234567890",
+					Amount:           20500,
+					Reason:           UnmatchedReasonAmountMismatch,
+				},
+				{
+					UniqueIdentifier: "005dcbc9e27365a072be5393ea8d0f37",
+					Date:             TrxDateTwo,
+					Bank:             "foo",
+					Amount:           42100,
+					Reason:           UnmatchedReasonNoTrxOnDate,
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			gotReturnData, err := d.GetNotMatchedBankTrx(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetNotMatchedBankTrx() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("GetNotMatchedBankTrx() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestDBGetNotMatchedGatewayTrx(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantReturnData []NotMatchedGatewayTrx
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetNotMatchedGatewayTrx).ExpectQuery().
+						WillReturnRows(
+							sqlmock.NewRows([]string{"UniqueIdentifier", "Gateway", "Date", "Amount", "Fee", "Reference", "PayoutID"}).
+								AddRow("gw-1", "paypal", TrxDateTimeOne, 20500, 500, "0012d068c53eb0971fc8563343c5d81f", "PO-1").
+								AddRow("gw-2", "paypal", TrxDateTimeTwo, -42100, 0, "", ""))
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			wantReturnData: []NotMatchedGatewayTrx{
+				{
+					UniqueIdentifier: "gw-1",
+					Gateway:          "paypal",
+					Date:             TrxDateTimeOne,
+					Amount:           20500,
+					Fee:              500,
+					Reference:        "0012d068c53eb0971fc8563343c5d81f",
+					PayoutID:         "PO-1",
+				},
+				{
+					UniqueIdentifier: "gw-2",
+					Gateway:          "paypal",
+					Date:             TrxDateTimeTwo,
+					Amount:           -42100,
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			gotReturnData, err := d.GetNotMatchedGatewayTrx(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetNotMatchedGatewayTrx() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("GetNotMatchedGatewayTrx() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestDBGetNotMatchedGatewayPayout(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantReturnData []NotMatchedGatewayPayout
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetNotMatchedGatewayPayout).ExpectQuery().
+						WillReturnRows(
+							sqlmock.NewRows([]string{"Gateway", "PayoutID", "PayoutDate", "TotalTrx", "GrossAmount", "Fee", "Amount"}).
+								AddRow("paypal", "PO-1", TrxDateOne, 2, 62600, 1000, 61600))
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			wantReturnData: []NotMatchedGatewayPayout{
+				{
+					Gateway:     "paypal",
+					PayoutID:    "PO-1",
+					PayoutDate:  TrxDateOne,
+					TotalTrx:    2,
+					GrossAmount: 62600,
+					Fee:         1000,
+					Amount:      61600,
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			gotReturnData, err := d.GetNotMatchedGatewayPayout(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetNotMatchedGatewayPayout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("GetNotMatchedGatewayPayout() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestDBGetNotMatchedSystemTrx(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantReturnData []NotMatchedSystemTrx
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetNotMatchedSystemTrx).ExpectQuery().
+						WillReturnRows(
+							sqlmock.NewRows([]string{"TrxID", "TransactionTime", "Type", "Amount", "Reason"}).
+								AddRow("0012d068c53eb0971fc8563343c5d81f", TrxDateTimeOne, "CREDIT", 20500, UnmatchedReasonCandidateConsumed).
+								AddRow("005dcbc9e27365a072be5393ea8d0f37", TrxDateTimeTwo, "CREDIT", 42100, UnmatchedReasonTypeMismatch))
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			wantReturnData: []NotMatchedSystemTrx{
+				{
+					TrxID:           "0012d068c53eb0971fc8563343c5d81f",
+					TransactionTime: TrxDateTimeOne,
+					Type:            "CREDIT",
+					Amount:          20500,
+					Reason:          UnmatchedReasonCandidateConsumed,
+				},
+				{
+					TrxID:           "005dcbc9e27365a072be5393ea8d0f37",
+					TransactionTime: TrxDateTimeTwo,
+					Type:            "CREDIT",
+					Amount:          42100,
+					Reason:          UnmatchedReasonTypeMismatch,
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			gotReturnData, err := d.GetNotMatchedSystemTrx(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetNotMatchedSystemTrx() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("GetNotMatchedSystemTrx() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestDBGetReversalTrx(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantReturnData []ReversalTrx
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetReversalTrx).ExpectQuery().
+						WillReturnRows(
+							sqlmock.NewRows([]string{"Source", "Bank", "DebitID", "DebitDate", "CreditID", "CreditDate", "Reference", "Amount", "Currency", "OriginalAmount", "DateDifference"}).
+								AddRow("SYSTEM", "", "0012d068c53eb0971fc8563343c5d81f", "2025-03-01", "005dcbc9e27365a072be5393ea8d0f37", "2025-03-02", "", 20500, "IDR", 20500, 1).
+								AddRow("BANK", "bca", "bca-1", "2025-03-01", "bca-2", "2025-03-01", "ref-1", 42100, "IDR", 42100, 0))
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			wantReturnData: []ReversalTrx{
+				{
+					Source:         "SYSTEM",
+					DebitID:        "0012d068c53eb0971fc8563343c5d81f",
+					DebitDate:      "2025-03-01",
+					CreditID:       "005dcbc9e27365a072be5393ea8d0f37",
+					CreditDate:     "2025-03-02",
+					Amount:         20500,
+					Currency:       "IDR",
+					OriginalAmount: 20500,
+					DateDifference: 1,
+				},
+				{
+					Source:         "BANK",
+					Bank:           "bca",
+					DebitID:        "bca-1",
+					DebitDate:      "2025-03-01",
+					CreditID:       "bca-2",
+					CreditDate:     "2025-03-01",
+					Reference:      "ref-1",
+					Amount:         42100,
+					Currency:       "IDR",
+					OriginalAmount: 42100,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetReversalTrx).ExpectQuery().
+						WillReturnError(sql.ErrConnDone)
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			gotReturnData, err := d.GetReversalTrx(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetReversalTrx() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("GetReversalTrx() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestDBGetTransferTrx(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantReturnData []TransferTrx
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetTransferTrx).ExpectQuery().
+						WillReturnRows(
+							sqlmock.NewRows([]string{"DebitID", "DebitBank", "DebitAccount", "DebitDate", "CreditID", "CreditBank", "CreditAccount", "CreditDate", "Amount", "Currency", "OriginalAmount", "DateDifference"}).
+								AddRow("bca-1", "bca", "111", "2025-03-01", "bni-1", "bni", "222", "2025-03-02", 500000, "IDR", 500000, 1))
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			wantReturnData: []TransferTrx{
+				{
+					DebitID:        "bca-1",
+					DebitBank:      "bca",
+					DebitAccount:   "111",
+					DebitDate:      "2025-03-01",
+					CreditID:       "bni-1",
+					CreditBank:     "bni",
+					CreditAccount:  "222",
+					CreditDate:     "2025-03-02",
+					Amount:         500000,
+					Currency:       "IDR",
+					OriginalAmount: 500000,
+					DateDifference: 1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetTransferTrx).ExpectQuery().
+						WillReturnError(sql.ErrConnDone)
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			gotReturnData, err := d.GetTransferTrx(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTransferTrx() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("GetTransferTrx() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestDBGetNotMatchedSystemTrxSuggestion(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantReturnData []NotMatchedSystemTrxSuggestion
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetNotMatchedSystemTrxSuggestion).ExpectQuery().
+						WithArgs(3, 3, 5.0).
+						WillReturnRows(
+							sqlmock.NewRows([]string{"TrxID", "Rank", "BankTrxUniqueIdentifier", "Bank", "BankTrxDate", "BankTrxAmount", "AmountDifference", "AmountDifferencePercentage", "DateDifference", "Distance"}).
+								AddRow("0012d068c53eb0971fc8563343c5d81f", 1, "foo-1", "foo", TrxDateOne, 20500, 0, 0, 1, 0.3333).
+								AddRow("0012d068c53eb0971fc8563343c5d81f", 2, "foo-2", "foo", TrxDateOne, 20600, 100, 0.4878, 0, 0.0976))
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			wantReturnData: []NotMatchedSystemTrxSuggestion{
+				{
+					TrxID:                   "0012d068c53eb0971fc8563343c5d81f",
+					Rank:                    1,
+					BankTrxUniqueIdentifier: "foo-1",
+					Bank:                    "foo",
+					BankTrxDate:             TrxDateOne,
+					BankTrxAmount:           20500,
+					DateDifference:          1,
+					Distance:                0.3333,
+				},
+				{
+					TrxID:                      "0012d068c53eb0971fc8563343c5d81f",
+					Rank:                       2,
+					BankTrxUniqueIdentifier:    "foo-2",
+					Bank:                       "foo",
+					BankTrxDate:                TrxDateOne,
+					BankTrxAmount:              20600,
+					AmountDifference:           100,
+					AmountDifferencePercentage: 0.4878,
+					Distance:                   0.0976,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetNotMatchedSystemTrxSuggestion).ExpectQuery().
+						WithArgs(3, 3, 5.0).
+						WillReturnError(sql.ErrConnDone)
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			gotReturnData, err := d.GetNotMatchedSystemTrxSuggestion(context.Background(), 3, 3, 5)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetNotMatchedSystemTrxSuggestion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("GetNotMatchedSystemTrxSuggestion() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestDBGetReconciliationSummary(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	tests := []struct {
+		fields         fields
+		name           string
+		wantReturnData ReconciliationSummary
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectPrepare(QueryGetReconciliationSummary).ExpectQuery().
+						WillReturnRows(
+							sqlmock.NewRows(
+								[]string{
+									"total_system_trx",
+									"total_matched_trx",
+									"total_not_matched_trx",
+									"sum_system_trx",
+									"sum_matched_trx",
+									"sum_not_matched_trx",
+									"sum_discrepancies_trx",
+									"total_aggregate_matched_trx",
+									"total_aggregate_matched_bank_trx",
+									"total_split_matched_trx",
+									"total_split_matched_bank_trx",
+									"total_gateway_matched_trx",
+									"total_gateway_matched_bank_trx",
+								},
+							).
+								AddRow(2, 1, 1, 200, 100, 100, 5, 1, 1, 1, 2, 2, 1),
+						)
+					return db
+				}(),
+				stmtMap: make(map[string]*sql.Stmt),
+			},
+			wantReturnData: ReconciliationSummary{
+				TotalSystemTrx:               2,
+				TotalMatchedTrx:              1,
+				TotalNotMatchedTrx:           1,
+				SumSystemTrx:                 200,
+				SumMatchedTrx:                100,
+				SumNotMatchedTrx:             100,
+				SumDiscrepanciesTrx:          5,
+				TotalAggregateMatchedTrx:     1,
+				TotalAggregateMatchedBankTrx: 1,
+				TotalSplitMatchedTrx:         1,
+				TotalSplitMatchedBankTrx:     2,
+				TotalGatewayMatchedTrx:       2,
+				TotalGatewayMatchedBankTrx:   1,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DB{
+				db:      tt.fields.db,
+				stmtMap: tt.fields.stmtMap,
+			}
+
+			gotReturnData, err := d.GetReconciliationSummary(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetReconciliationSummary() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("GetReconciliationSummary() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestDBImportBankTrx(t *testing.T) {
+	type fields struct {
+		db      *sql.DB
+		stmtMap map[string]*sql.Stmt
+	}
+
+	type args struct {
+		data []*banks.BankTrxData
+		min  int
+		max  int
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				db: func() *sql.DB {
+					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					s.ExpectBegin()
+					s.ExpectPrepare(QueryInsertTableBankTrx).
+						ExpectExec().
+						WithArgs(
+							func() string {
+								marshal, _ := json.Marshal(
+									[]*banks.BankTrxData{
+										{
+											UniqueIdentifier: UniqueUUID,
+											Date:             time.Time{},
+											Type:             "DEBIT",
+											FilePath:         FilePath,
+											Amount:           1000,
+										},
+									},
+								)
+
+								return string(marshal)
+							}(),
+						).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					s.ExpectCommit()
 
-	tests := []struct {
-		fields         fields
-		name           string
-		wantReturnData ReconciliationSummary
-		wantErr        bool
-	}{
-		{
-			name: "Ok",
-			fields: fields{
-				db: func() *sql.DB {
-					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
-					s.ExpectPrepare(QueryGetReconciliationSummary).ExpectQuery().
-						WillReturnRows(
-							sqlmock.NewRows(
-								[]string{
-									"total_system_trx",
-									"total_matched_trx",
-									"total_not_matched_trx",
-									"sum_system_trx",
-									"sum_matched_trx",
-									"sum_discrepancies_trx",
-								},
-							).
-								AddRow(1, 1, 0, 1, 1, 0),
-						)
 					return db
 				}(),
 				stmtMap: make(map[string]*sql.Stmt),
 			},
-			wantReturnData: ReconciliationSummary{
-				TotalSystemTrx:      1,
-				TotalMatchedTrx:     1,
-				TotalNotMatchedTrx:  0,
-				SumSystemTrx:        1,
-				SumMatchedTrx:       1,
-				SumDiscrepanciesTrx: 0,
+			args: args{
+				data: []*banks.BankTrxData{
+					{
+						UniqueIdentifier: UniqueUUID,
+						Date:             time.Time{},
+						Type:             "DEBIT",
+						FilePath:         FilePath,
+						Amount:           1000,
+					},
+				},
+				min: 0,
+				max: 10,
 			},
 			wantErr: false,
 		},
@@ -395,27 +1745,21 @@ func TestDBGetReconciliationSummary(t *testing.T) {
 				stmtMap: tt.fields.stmtMap,
 			}
 
-			gotReturnData, err := d.GetReconciliationSummary(context.Background())
-			if (err != nil) != tt.wantErr {
-				t.Errorf("GetReconciliationSummary() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-
-			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
-				t.Errorf("GetReconciliationSummary() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			if err := d.ImportBankTrx(context.Background(), tt.args.data, tt.args.min, tt.args.max); (err != nil) != tt.wantErr {
+				t.Errorf("ImportBankTrx() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func TestDBImportBankTrx(t *testing.T) {
+func TestDBImportGatewayTrx(t *testing.T) {
 	type fields struct {
 		db      *sql.DB
 		stmtMap map[string]*sql.Stmt
 	}
 
 	type args struct {
-		data []*banks.BankTrxData
+		data []*gateways.GatewayTrxData
 		min  int
 		max  int
 	}
@@ -432,18 +1776,20 @@ func TestDBImportBankTrx(t *testing.T) {
 				db: func() *sql.DB {
 					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 					s.ExpectBegin()
-					s.ExpectPrepare(QueryInsertTableBankTrx).
+					s.ExpectPrepare(QueryInsertTableGatewayTrx).
 						ExpectExec().
 						WithArgs(
 							func() string {
 								marshal, _ := json.Marshal(
-									[]*banks.BankTrxData{
+									[]*gateways.GatewayTrxData{
 										{
 											UniqueIdentifier: UniqueUUID,
-											Date:             time.Time{},
-											Type:             "DEBIT",
+											PayoutID:         "PO-1",
+											Type:             gateways.CREDIT,
+											Gateway:          "paypal",
 											FilePath:         FilePath,
 											Amount:           1000,
+											Fee:              30,
 										},
 									},
 								)
@@ -459,13 +1805,15 @@ func TestDBImportBankTrx(t *testing.T) {
 				stmtMap: make(map[string]*sql.Stmt),
 			},
 			args: args{
-				data: []*banks.BankTrxData{
+				data: []*gateways.GatewayTrxData{
 					{
 						UniqueIdentifier: UniqueUUID,
-						Date:             time.Time{},
-						Type:             "DEBIT",
+						PayoutID:         "PO-1",
+						Type:             gateways.CREDIT,
+						Gateway:          "paypal",
 						FilePath:         FilePath,
 						Amount:           1000,
+						Fee:              30,
 					},
 				},
 				min: 0,
@@ -482,8 +1830,8 @@ func TestDBImportBankTrx(t *testing.T) {
 				stmtMap: tt.fields.stmtMap,
 			}
 
-			if err := d.ImportBankTrx(context.Background(), tt.args.data, tt.args.min, tt.args.max); (err != nil) != tt.wantErr {
-				t.Errorf("ImportBankTrx() error = %v, wantErr %v", err, tt.wantErr)
+			if err := d.ImportGatewayTrx(context.Background(), tt.args.data, tt.args.min, tt.args.max); (err != nil) != tt.wantErr {
+				t.Errorf("ImportGatewayTrx() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -588,6 +1936,14 @@ func TestDBPost(t *testing.T) {
 					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 					s.ExpectBegin()
 
+					s.ExpectPrepare(QueryDropViewReconciliationMatch).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropViewGatewayPayout).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
 					s.ExpectPrepare(QueryDropTableArguments).
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
@@ -596,6 +1952,10 @@ func TestDBPost(t *testing.T) {
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
 
+					s.ExpectPrepare(QueryDropTableBankFee).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
 					s.ExpectPrepare(QueryDropTableSystemTrx).
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
@@ -608,6 +1968,38 @@ func TestDBPost(t *testing.T) {
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
 
+					s.ExpectPrepare(QueryDropTableReconciliationAggregateMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableReconciliationSplitMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableReversalMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableTransferMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableGateways).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableGatewayTrx).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableGatewayMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableGatewayPayoutMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
 					s.ExpectCommit()
 
 					return db
@@ -639,9 +2031,10 @@ func TestDBPre(t *testing.T) {
 	}
 
 	type args struct {
-		startDate time.Time
-		toDate    time.Time
-		listBank  []string
+		startDate   time.Time
+		toDate      time.Time
+		listBank    []Bank
+		listGateway []Gateway
 	}
 
 	tests := []struct {
@@ -657,6 +2050,14 @@ func TestDBPre(t *testing.T) {
 					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 					s.ExpectBegin()
 
+					s.ExpectPrepare(QueryDropViewReconciliationMatch).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropViewGatewayPayout).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
 					s.ExpectPrepare(QueryDropTableArguments).
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
@@ -665,6 +2066,10 @@ func TestDBPre(t *testing.T) {
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
 
+					s.ExpectPrepare(QueryDropTableBankFee).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
 					s.ExpectPrepare(QueryDropTableSystemTrx).
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
@@ -677,6 +2082,38 @@ func TestDBPre(t *testing.T) {
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
 
+					s.ExpectPrepare(QueryDropTableReconciliationAggregateMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableReconciliationSplitMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableReversalMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableTransferMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableGateways).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableGatewayTrx).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableGatewayMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableGatewayPayoutMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
 					s.ExpectPrepare(QueryCreateTableArguments).
 						ExpectExec().
 						WithArgs(
@@ -688,7 +2125,21 @@ func TestDBPre(t *testing.T) {
 					s.ExpectPrepare(QueryCreateTableBanks).
 						ExpectExec().
 						WithArgs(
-							`["foo","bar"]`,
+							`[{"Name":"foo","FeeTiers":null,"SettlementDaysBefore":0,"SettlementDaysAfter":0},{"Name":"bar","FeeTiers":[{"MinAmount":0,"Flat":250000,"Percentage":0},{"MinAmount":100000000,"Flat":0,"Percentage":0.5}],"SettlementDaysBefore":1,"SettlementDaysAfter":2}]`,
+						).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableBankFee).
+						ExpectExec().
+						WithArgs(
+							`[{"Name":"foo","FeeTiers":null,"SettlementDaysBefore":0,"SettlementDaysAfter":0},{"Name":"bar","FeeTiers":[{"MinAmount":0,"Flat":250000,"Percentage":0},{"MinAmount":100000000,"Flat":0,"Percentage":0.5}],"SettlementDaysBefore":1,"SettlementDaysAfter":2}]`,
+						).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableGateways).
+						ExpectExec().
+						WithArgs(
+							`[{"Name":"paypal","PayoutBank":"bca","PayoutDays":2}]`,
 						).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -704,6 +2155,42 @@ func TestDBPre(t *testing.T) {
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
 
+					s.ExpectPrepare(QueryCreateTableReconciliationAggregateMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableReconciliationSplitMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableReversalMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableTransferMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableGatewayTrx).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableGatewayMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableGatewayPayoutMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateViewGatewayPayout).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateViewReconciliationMatch).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
 					s.ExpectCommit()
 
 					return db
@@ -711,9 +2198,26 @@ func TestDBPre(t *testing.T) {
 				stmtMap: make(map[string]*sql.Stmt),
 			},
 			args: args{
-				listBank: []string{
-					"foo",
-					"bar",
+				listBank: []Bank{
+					{
+						Name: "foo",
+					},
+					{
+						Name: "bar",
+						FeeTiers: []FeeTier{
+							{Flat: 250000},
+							{MinAmount: 100000000, Percentage: 0.5},
+						},
+						SettlementDaysBefore: 1,
+						SettlementDaysAfter:  2,
+					},
+				},
+				listGateway: []Gateway{
+					{
+						Name:       "paypal",
+						PayoutBank: "bca",
+						PayoutDays: 2,
+					},
 				},
 				startDate: func() time.Time {
 					r, _ := time.Parse(DateFormat, StartDateString)
@@ -735,7 +2239,7 @@ func TestDBPre(t *testing.T) {
 				stmtMap: tt.fields.stmtMap,
 			}
 
-			if err := d.Pre(context.Background(), tt.args.listBank, tt.args.startDate, tt.args.toDate); (err != nil) != tt.wantErr {
+			if err := d.Pre(context.Background(), tt.args.listBank, tt.args.listGateway, tt.args.startDate, tt.args.toDate); (err != nil) != tt.wantErr {
 				t.Errorf("Pre() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -749,9 +2253,10 @@ func TestDBCreateTables(t *testing.T) {
 	}
 
 	type args struct {
-		startDate time.Time
-		toDate    time.Time
-		listBank  []string
+		startDate   time.Time
+		toDate      time.Time
+		listBank    []Bank
+		listGateway []Gateway
 	}
 
 	tests := []struct {
@@ -778,7 +2283,21 @@ func TestDBCreateTables(t *testing.T) {
 					s.ExpectPrepare(QueryCreateTableBanks).
 						ExpectExec().
 						WithArgs(
-							`["foo","bar"]`,
+							`[{"Name":"foo","FeeTiers":null,"SettlementDaysBefore":0,"SettlementDaysAfter":0},{"Name":"bar","FeeTiers":[{"MinAmount":0,"Flat":250000,"Percentage":0},{"MinAmount":100000000,"Flat":0,"Percentage":0.5}],"SettlementDaysBefore":1,"SettlementDaysAfter":2}]`,
+						).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableBankFee).
+						ExpectExec().
+						WithArgs(
+							`[{"Name":"foo","FeeTiers":null,"SettlementDaysBefore":0,"SettlementDaysAfter":0},{"Name":"bar","FeeTiers":[{"MinAmount":0,"Flat":250000,"Percentage":0},{"MinAmount":100000000,"Flat":0,"Percentage":0.5}],"SettlementDaysBefore":1,"SettlementDaysAfter":2}]`,
+						).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableGateways).
+						ExpectExec().
+						WithArgs(
+							`[{"Name":"paypal","PayoutBank":"bca","PayoutDays":2}]`,
 						).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -794,6 +2313,42 @@ func TestDBCreateTables(t *testing.T) {
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
 
+					s.ExpectPrepare(QueryCreateTableReconciliationAggregateMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableReconciliationSplitMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableReversalMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableTransferMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableGatewayTrx).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableGatewayMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateTableGatewayPayoutMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateViewGatewayPayout).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryCreateViewReconciliationMatch).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
 					s.ExpectCommit()
 
 					return db
@@ -801,9 +2356,26 @@ func TestDBCreateTables(t *testing.T) {
 				stmtMap: make(map[string]*sql.Stmt),
 			},
 			args: args{
-				listBank: []string{
-					"foo",
-					"bar",
+				listBank: []Bank{
+					{
+						Name: "foo",
+					},
+					{
+						Name: "bar",
+						FeeTiers: []FeeTier{
+							{Flat: 250000},
+							{MinAmount: 100000000, Percentage: 0.5},
+						},
+						SettlementDaysBefore: 1,
+						SettlementDaysAfter:  2,
+					},
+				},
+				listGateway: []Gateway{
+					{
+						Name:       "paypal",
+						PayoutBank: "bca",
+						PayoutDays: 2,
+					},
 				},
 				startDate: func() time.Time {
 					r, _ := time.Parse(DateFormat, StartDateString)
@@ -826,7 +2398,7 @@ func TestDBCreateTables(t *testing.T) {
 			}
 
 			tx, _ := tt.fields.db.BeginTx(context.Background(), nil)
-			if err := d.createTables(context.Background(), tx, tt.args.listBank, tt.args.startDate, tt.args.toDate); (err != nil) != tt.wantErr {
+			if err := d.createTables(context.Background(), tx, tt.args.listBank, tt.args.listGateway, tt.args.startDate, tt.args.toDate); (err != nil) != tt.wantErr {
 				t.Errorf("createTables() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -857,6 +2429,14 @@ func TestDBDropTableWith(t *testing.T) {
 					db, s, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 					s.ExpectBegin()
 
+					s.ExpectPrepare(QueryDropViewReconciliationMatch).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropViewGatewayPayout).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
 					s.ExpectPrepare(QueryDropTableArguments).
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
@@ -865,6 +2445,10 @@ func TestDBDropTableWith(t *testing.T) {
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
 
+					s.ExpectPrepare(QueryDropTableBankFee).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
 					s.ExpectPrepare(QueryDropTableSystemTrx).
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
@@ -877,6 +2461,38 @@ func TestDBDropTableWith(t *testing.T) {
 						ExpectExec().
 						WillReturnResult(sqlmock.NewResult(1, 1))
 
+					s.ExpectPrepare(QueryDropTableReconciliationAggregateMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableReconciliationSplitMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableReversalMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableTransferMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableGateways).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableGatewayTrx).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableGatewayMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
+					s.ExpectPrepare(QueryDropTableGatewayPayoutMap).
+						ExpectExec().
+						WillReturnResult(sqlmock.NewResult(1, 1))
+
 					s.ExpectCommit()
 
 					return db
@@ -1,35 +1,306 @@
 package process
 
+import (
+	"github.com/goccy/go-json"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+)
+
+// AmountTolerance is the maximum gap between system and bank amount for a pair to still match,
+// the bigger allowance of both wins. FXPercentage of the system amount is added on top for pairs of different currency
+type AmountTolerance struct {
+	Absolute     money.Amount
+	Percentage   float64
+	FXPercentage float64
+}
+
+// SettlementWindow is the accepted bank statement date range around the system transaction date
+type SettlementWindow struct {
+	DaysBefore int
+	DaysAfter  int
+}
+
+// MatchRule is the matching pass generating the links, Name is recorded on every link it makes.
+// Window overrides the bank settlement windows when not nil
+type MatchRule struct {
+	Window    *SettlementWindow
+	Name      string
+	Tolerance AmountTolerance
+}
+
+// windowArgs returns the window as query arguments, NULL falls back to the bank settlement windows
+func (m MatchRule) windowArgs() []any {
+	if m.Window == nil {
+		return []any{nil, nil}
+	}
+
+	return []any{m.Window.DaysBefore, m.Window.DaysAfter}
+}
+
+// FeeTier is the fee of system amounts from MinAmount up to the MinAmount of the next tier, Flat plus Percentage
+// of the system amount
+type FeeTier struct {
+	MinAmount  money.Amount
+	Flat       money.Amount
+	Percentage float64
+}
+
+// Bank is the accepted bank with its settlement date window, bank statement date should be between
+// system transaction date - SettlementDaysBefore and system transaction date + SettlementDaysAfter.
+// The bank states the system amount less the fee of FeeTiers, no tiers means no fee
+type Bank struct {
+	Name                 string
+	FeeTiers             []FeeTier
+	SettlementDaysBefore int
+	SettlementDaysAfter  int
+}
+
+// Gateway is the accepted payment gateway, its payouts are stated by PayoutBank, any bank when empty, at most
+// PayoutDays after the date of the latest line of the payout
+type Gateway struct {
+	Name       string
+	PayoutBank string
+	PayoutDays int
+}
+
 type ReconciliationSummary struct {
-	TotalSystemTrx      int64   `db:"total_system_trx"`
-	TotalMatchedTrx     int64   `db:"total_matched_trx"`
-	TotalNotMatchedTrx  int64   `db:"total_not_matched_trx"`
-	SumSystemTrx        float64 `db:"sum_system_trx"`
-	SumMatchedTrx       float64 `db:"sum_matched_trx"`
-	SumDiscrepanciesTrx float64 `db:"sum_discrepancies_trx"`
+	TotalSystemTrx      int64        `db:"total_system_trx"`
+	TotalMatchedTrx     int64        `db:"total_matched_trx"`
+	TotalNotMatchedTrx  int64        `db:"total_not_matched_trx"`
+	SumSystemTrx        money.Amount `db:"sum_system_trx"`
+	SumMatchedTrx       money.Amount `db:"sum_matched_trx"`
+	SumNotMatchedTrx    money.Amount `db:"sum_not_matched_trx"`
+	SumDiscrepanciesTrx money.Amount `db:"sum_discrepancies_trx"`
+	// TotalAggregateMatchedTrx is the number of system trx matched as part of a settlement group,
+	// TotalAggregateMatchedBankTrx is the number of bank trx settling those groups
+	TotalAggregateMatchedTrx     int64 `db:"total_aggregate_matched_trx"`
+	TotalAggregateMatchedBankTrx int64 `db:"total_aggregate_matched_bank_trx"`
+	// TotalSplitMatchedTrx is the number of system trx settled in parts,
+	// TotalSplitMatchedBankTrx is the number of bank trx being those parts
+	TotalSplitMatchedTrx     int64 `db:"total_split_matched_trx"`
+	TotalSplitMatchedBankTrx int64 `db:"total_split_matched_bank_trx"`
+	// TotalGatewayMatchedTrx is the number of system trx settled through a gateway payout,
+	// TotalGatewayMatchedBankTrx is the number of bank trx paying those payouts
+	TotalGatewayMatchedTrx     int64 `db:"total_gateway_matched_trx"`
+	TotalGatewayMatchedBankTrx int64 `db:"total_gateway_matched_bank_trx"`
 }
 
 type MatchedTrx struct {
-	SystemTrxTrxID           string  `db:"SystemTrxTrxID"`
-	BankTrxUniqueIdentifier  string  `db:"BankTrxUniqueIdentifier"`
-	SystemTrxTransactionTime string  `db:"SystemTrxTransactionTime"`
-	BankTrxDate              string  `db:"BankTrxDate"`
-	SystemTrxType            string  `db:"SystemTrxType"`
-	Bank                     string  `db:"Bank"`
-	SystemTrxAmount          float64 `db:"SystemTrxAmount"`
-	BankTrxAmount            float64 `db:"BankTrxAmount"`
+	SystemTrxTrxID           string       `db:"SystemTrxTrxID"`
+	BankTrxUniqueIdentifier  string       `db:"BankTrxUniqueIdentifier"`
+	SystemTrxTransactionTime string       `db:"SystemTrxTransactionTime"`
+	BankTrxDate              string       `db:"BankTrxDate"`
+	SystemTrxType            string       `db:"SystemTrxType"`
+	Bank                     string       `db:"Bank"`
+	Account                  string       `db:"Account"`
+	SystemTrxAmount          money.Amount `db:"SystemTrxAmount"`
+	BankTrxAmount            money.Amount `db:"BankTrxAmount"`
+	AmountDifference         money.Amount `db:"AmountDifference"`
+	// Fee is the bank fee the match absorbed, AmountDifference is the gap left on top of it
+	Fee            money.Amount `db:"Fee"`
+	DateDifference int64        `db:"DateDifference"`
+	// MatchType is ONE_TO_ONE, MANY_TO_ONE, ONE_TO_MANY or GATEWAY, GroupSize is the number of system trx sharing the bank trx
+	// or the number of bank trx settling the system trx. BankTrxUniqueIdentifier of ONE_TO_MANY lists all bank trx
+	// separated by ';'
+	MatchType string `db:"MatchType"`
+	GroupSize int64  `db:"GroupSize"`
+	// MatchRule is the name of the matching pass linking the pair
+	MatchRule string `db:"MatchRule"`
+	// Confidence is between 0 and 1, lowered by competing candidates and by the date and amount gaps
+	Confidence float64 `db:"Confidence"`
+	// amounts above are in the base currency, these are as booked in the system and as stated by the bank
+	SystemTrxCurrency       string       `db:"SystemTrxCurrency"`
+	SystemTrxOriginalAmount money.Amount `db:"SystemTrxOriginalAmount"`
+	BankTrxCurrency         string       `db:"BankTrxCurrency"`
+	BankTrxOriginalAmount   money.Amount `db:"BankTrxOriginalAmount"`
+}
+
+// MatchTypeGateway is the MatchType of system trx settled through a gateway payout, Fee of the match is the fee of
+// the gateway rather than of the bank
+const MatchTypeGateway = "GATEWAY"
+
+// ReconciliationMapCandidate is a bank trx within the amount tolerance and settlement window of a system trx.
+// TimeDistance is the gap in seconds to the time of the statement when the bank states one, to its date otherwise,
+// TrxTime and BankTime are the unix times it is taken from
+type ReconciliationMapCandidate struct {
+	TrxID            string       `db:"TrxID"`
+	UniqueIdentifier string       `db:"UniqueIdentifier"`
+	AmountDifference money.Amount `db:"AmountDifference"`
+	Fee              money.Amount `db:"Fee"`
+	DateDifference   int64        `db:"DateDifference"`
+	TimeDistance     int64        `db:"TimeDistance"`
+	TrxTime          int64        `db:"TrxTime"`
+	BankTime         int64        `db:"BankTime"`
+	// Confidence accounts the other candidates of both trx and the date and amount gaps
+	Confidence float64 `db:"Confidence"`
+}
+
+// ReconciliationMatch links a system trx to the bank trx settling it one to one
+type ReconciliationMatch struct {
+	TrxID            string
+	UniqueIdentifier string
+	AmountDifference money.Amount
+	Fee              money.Amount
+	DateDifference   int64
+	MatchRule        string
+	Confidence       float64
 }
 
+// SplitMatchCandidate is a set of bank trx whose sum equals the amount of a system trx,
+// UniqueIdentifiers is a JSON array of the bank trx UniqueIdentifier, DateDistance is the furthest part in days
+type SplitMatchCandidate struct {
+	TrxID             string `db:"TrxID"`
+	UniqueIdentifiers string `db:"UniqueIdentifiers"`
+	Parts             int64  `db:"Parts"`
+	DateDistance      int64  `db:"DateDistance"`
+	// Confidence only accounts other sets of the same system trx, sets sharing a bank trx lower it when picked
+	Confidence float64 `db:"Confidence"`
+}
+
+func (s SplitMatchCandidate) ListUniqueIdentifier() (returnData []string, err error) {
+	err = json.Unmarshal([]byte(s.UniqueIdentifiers), &returnData)
+	return
+}
+
+// SplitMatch links a bank trx to the system trx it partially settles
+type SplitMatch struct {
+	TrxID            string
+	UniqueIdentifier string
+	MatchRule        string
+	Confidence       float64
+}
+
+// Reason of not matched system trx and bank trx, in the order they are checked
+const (
+	// UnmatchedReasonMissingFXRate no fx rate converts the trx to the base currency, it is left out of matching
+	UnmatchedReasonMissingFXRate = "MISSING_FX_RATE"
+	// UnmatchedReasonCandidateConsumed an exact counterpart within the settlement window is matched to another trx
+	UnmatchedReasonCandidateConsumed = "CANDIDATE_CONSUMED"
+	// UnmatchedReasonTypeMismatch a counterpart of the same amount within the settlement window has the other type
+	UnmatchedReasonTypeMismatch = "TYPE_MISMATCH"
+	// UnmatchedReasonOutsideSettlementWindow a counterpart of the same type and amount is outside the settlement window
+	UnmatchedReasonOutsideSettlementWindow = "OUTSIDE_SETTLEMENT_WINDOW"
+	// UnmatchedReasonAmountMismatch a counterpart of the same type within the settlement window has another amount
+	UnmatchedReasonAmountMismatch = "AMOUNT_MISMATCH"
+	// UnmatchedReasonNoTrxOnDate no counterpart of the same type within the settlement window
+	UnmatchedReasonNoTrxOnDate = "NO_TRX_ON_DATE"
+)
+
+// UnmatchedReasonPayoutNotMatched a system trx is paid through a gateway line whose payout no bank trx pays, only
+// given to NotMatchedSystemTrx
+const UnmatchedReasonPayoutNotMatched = "PAYOUT_NOT_MATCHED"
+
+// UnmatchedReasons lists all Reason values of NotMatchedSystemTrx and NotMatchedBankTrx
+var UnmatchedReasons = []string{
+	UnmatchedReasonMissingFXRate,
+	UnmatchedReasonCandidateConsumed,
+	UnmatchedReasonTypeMismatch,
+	UnmatchedReasonOutsideSettlementWindow,
+	UnmatchedReasonAmountMismatch,
+	UnmatchedReasonNoTrxOnDate,
+}
+
+// NotMatchedSystemTrx Amount is in the base currency, OriginalAmount is booked in Currency. Account is the account the
+// trx should settle on, only kept when matching by account
 type NotMatchedSystemTrx struct {
-	TrxID           string  `db:"TrxID"`
-	TransactionTime string  `db:"TransactionTime"`
-	Type            string  `db:"Type"`
-	Amount          float64 `db:"Amount"`
+	TrxID           string       `db:"TrxID"`
+	TransactionTime string       `db:"TransactionTime"`
+	Type            string       `db:"Type"`
+	Amount          money.Amount `db:"Amount"`
+	Currency        string       `db:"Currency"`
+	OriginalAmount  money.Amount `db:"OriginalAmount"`
+	Account         string       `db:"Account"`
+	Reason          string       `db:"Reason"`
 }
 
+// NotMatchedBankTrx Amount is in the base currency, OriginalAmount is the statement amount in Currency
 type NotMatchedBankTrx struct {
-	UniqueIdentifier string  `db:"UniqueIdentifier"`
-	Bank             string  `db:"Bank"`
-	Date             string  `db:"Date"`
-	Amount           float64 `db:"Amount"`
+	UniqueIdentifier string       `db:"UniqueIdentifier"`
+	Bank             string       `db:"Bank"`
+	Account          string       `db:"Account"`
+	Date             string       `db:"Date"`
+	Amount           money.Amount `db:"Amount"`
+	Currency         string       `db:"Currency"`
+	OriginalAmount   money.Amount `db:"OriginalAmount"`
+	Reason           string       `db:"Reason"`
+}
+
+// NotMatchedGatewayTrx is a gateway line not linked to a system trx, Amount is negative for a refund
+type NotMatchedGatewayTrx struct {
+	UniqueIdentifier string       `db:"UniqueIdentifier"`
+	Gateway          string       `db:"Gateway"`
+	Date             string       `db:"Date"`
+	Amount           money.Amount `db:"Amount"`
+	Fee              money.Amount `db:"Fee"`
+	Reference        string       `db:"Reference"`
+	PayoutID         string       `db:"PayoutID"`
+}
+
+// NotMatchedGatewayPayout is a gateway payout no bank trx pays, Amount is GrossAmount less Fee, the amount expected on
+// the bank statement
+type NotMatchedGatewayPayout struct {
+	Gateway     string       `db:"Gateway"`
+	PayoutID    string       `db:"PayoutID"`
+	PayoutDate  string       `db:"PayoutDate"`
+	TotalTrx    int64        `db:"TotalTrx"`
+	GrossAmount money.Amount `db:"GrossAmount"`
+	Fee         money.Amount `db:"Fee"`
+	Amount      money.Amount `db:"Amount"`
+}
+
+// Source of a ReversalTrx
+const (
+	ReversalSourceSystem = "SYSTEM"
+	ReversalSourceBank   = "BANK"
+)
+
+// ReversalTrx is a debit and a credit of the same Source, SYSTEM or BANK, cancelling each other. Amount is the debit
+// amount in the base currency, OriginalAmount in Currency, DateDifference is the credit date minus the debit date
+type ReversalTrx struct {
+	Source         string       `db:"Source"`
+	Bank           string       `db:"Bank"`
+	DebitID        string       `db:"DebitID"`
+	DebitDate      string       `db:"DebitDate"`
+	CreditID       string       `db:"CreditID"`
+	CreditDate     string       `db:"CreditDate"`
+	Reference      string       `db:"Reference"`
+	Amount         money.Amount `db:"Amount"`
+	Currency       string       `db:"Currency"`
+	OriginalAmount money.Amount `db:"OriginalAmount"`
+	DateDifference int64        `db:"DateDifference"`
+}
+
+// TransferTrx is a bank debit and a bank credit of another bank or account moving money between our own accounts.
+// Amount is the debit amount in the base currency, OriginalAmount in Currency, DateDifference is the credit date minus
+// the debit date
+type TransferTrx struct {
+	DebitID        string       `db:"DebitID"`
+	DebitBank      string       `db:"DebitBank"`
+	DebitAccount   string       `db:"DebitAccount"`
+	DebitDate      string       `db:"DebitDate"`
+	CreditID       string       `db:"CreditID"`
+	CreditBank     string       `db:"CreditBank"`
+	CreditAccount  string       `db:"CreditAccount"`
+	CreditDate     string       `db:"CreditDate"`
+	Amount         money.Amount `db:"Amount"`
+	Currency       string       `db:"Currency"`
+	OriginalAmount money.Amount `db:"OriginalAmount"`
+	DateDifference int64        `db:"DateDifference"`
+}
+
+// NotMatchedSystemTrxSuggestion is a not matched bank trx near a not matched system trx, Rank 1 is the closest.
+// AmountDifferencePercentage is relative to the system amount, Distance sums the date and amount gaps each relative
+// to its search limit
+type NotMatchedSystemTrxSuggestion struct {
+	TrxID                      string       `db:"TrxID"`
+	BankTrxUniqueIdentifier    string       `db:"BankTrxUniqueIdentifier"`
+	Bank                       string       `db:"Bank"`
+	BankTrxDate                string       `db:"BankTrxDate"`
+	Rank                       int64        `db:"Rank"`
+	BankTrxAmount              money.Amount `db:"BankTrxAmount"`
+	BankTrxCurrency            string       `db:"BankTrxCurrency"`
+	BankTrxOriginalAmount      money.Amount `db:"BankTrxOriginalAmount"`
+	AmountDifference           money.Amount `db:"AmountDifference"`
+	AmountDifferencePercentage float64      `db:"AmountDifferencePercentage"`
+	DateDifference             int64        `db:"DateDifference"`
+	Distance                   float64      `db:"Distance"`
 }
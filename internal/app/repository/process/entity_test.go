@@ -0,0 +1,94 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitMatchCandidateListUniqueIdentifier(t *testing.T) {
+	type fields struct {
+		UniqueIdentifiers string
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantReturnData []string
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				UniqueIdentifiers: `["foo","bar"]`,
+			},
+			wantReturnData: []string{"foo", "bar"},
+			wantErr:        false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				UniqueIdentifiers: `foo`,
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := SplitMatchCandidate{
+				UniqueIdentifiers: tt.fields.UniqueIdentifiers,
+			}
+
+			gotReturnData, err := s.ListUniqueIdentifier()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListUniqueIdentifier() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("ListUniqueIdentifier() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestMatchRuleWindowArgs(t *testing.T) {
+	type fields struct {
+		Window *SettlementWindow
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   []any
+	}{
+		{
+			name: "Ok - rule window",
+			fields: fields{
+				Window: &SettlementWindow{
+					DaysBefore: 1,
+					DaysAfter:  2,
+				},
+			},
+			want: []any{1, 2},
+		},
+		{
+			name:   "Ok - bank settlement window",
+			fields: fields{},
+			want:   []any{nil, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := MatchRule{
+				Window: tt.fields.Window,
+			}
+
+			if got := m.windowArgs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("windowArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems"
 )
 
@@ -12,18 +14,33 @@ import (
 type Repository interface {
 	Pre(
 		ctx context.Context,
-		listBank []string,
+		listBank []Bank,
+		listGateway []Gateway,
 		startDate time.Time,
 		toDate time.Time,
 	) (err error)
 
 	ImportSystemTrx(ctx context.Context, data []*systems.SystemTrxData, from, to int) (err error)
 	ImportBankTrx(ctx context.Context, data []*banks.BankTrxData, from, to int) (err error)
-	GenerateReconciliationMap(ctx context.Context, minAmount float64, maxAmount float64) (err error)
+	ImportGatewayTrx(ctx context.Context, data []*gateways.GatewayTrxData, from, to int) (err error)
+	GenerateReversalMap(ctx context.Context, days int) (err error)
+	GenerateGatewayMap(ctx context.Context, days int) (err error)
+	GenerateTransferMap(ctx context.Context, days int, ownAccounts []string) (err error)
+	GenerateReconciliationReferenceMap(ctx context.Context, rule MatchRule) (err error)
+	GetReconciliationMapCandidate(ctx context.Context, minAmount money.Amount, maxAmount money.Amount, rule MatchRule) (returnData []ReconciliationMapCandidate, err error)
+	ImportReconciliationMap(ctx context.Context, data []ReconciliationMatch) (err error)
+	GenerateReconciliationAggregateMap(ctx context.Context, rule MatchRule) (err error)
+	GetSplitMatchCandidate(ctx context.Context, maxParts int, rule MatchRule) (returnData []SplitMatchCandidate, err error)
+	ImportReconciliationSplitMap(ctx context.Context, data []SplitMatch) (err error)
 	GetReconciliationSummary(ctx context.Context) (returnData ReconciliationSummary, err error)
 	Post(ctx context.Context) (err error)
 	Close() (err error)
 	GetMatchedTrx(ctx context.Context) (returnData []MatchedTrx, err error)
 	GetNotMatchedSystemTrx(ctx context.Context) (returnData []NotMatchedSystemTrx, err error)
 	GetNotMatchedBankTrx(ctx context.Context) (returnData []NotMatchedBankTrx, err error)
+	GetNotMatchedGatewayTrx(ctx context.Context) (returnData []NotMatchedGatewayTrx, err error)
+	GetNotMatchedGatewayPayout(ctx context.Context) (returnData []NotMatchedGatewayPayout, err error)
+	GetReversalTrx(ctx context.Context) (returnData []ReversalTrx, err error)
+	GetTransferTrx(ctx context.Context) (returnData []TransferTrx, err error)
+	GetNotMatchedSystemTrxSuggestion(ctx context.Context, maxCount int, days int, amountPercentage float64) (returnData []NotMatchedSystemTrxSuggestion, err error)
 }
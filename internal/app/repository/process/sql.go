@@ -9,6 +9,10 @@ DROP TABLE IF EXISTS arguments;
 	QueryDropTableBanks = `
 -- QuerystmtDropTableBanks
 DROP TABLE IF EXISTS banks;
+`
+	QueryDropTableBankFee = `
+-- QueryDropTableBankFee
+DROP TABLE IF EXISTS bank_fee;
 `
 	QueryDropTableSystemTrx = `
 -- QueryDropTableSystemTrx
@@ -21,6 +25,46 @@ DROP TABLE IF EXISTS bank_trx;
 	QueryDropTableReconciliationMap = `
 -- QueryDropTableReconciliationMap
 DROP TABLE IF EXISTS reconciliation_map;
+`
+	QueryDropTableReconciliationAggregateMap = `
+-- QueryDropTableReconciliationAggregateMap
+DROP TABLE IF EXISTS reconciliation_aggregate_map;
+`
+	QueryDropTableReconciliationSplitMap = `
+-- QueryDropTableReconciliationSplitMap
+DROP TABLE IF EXISTS reconciliation_split_map;
+`
+	QueryDropTableReversalMap = `
+-- QueryDropTableReversalMap
+DROP TABLE IF EXISTS reversal_map;
+`
+	QueryDropTableTransferMap = `
+-- QueryDropTableTransferMap
+DROP TABLE IF EXISTS transfer_map;
+`
+	QueryDropTableGateways = `
+-- QueryDropTableGateways
+DROP TABLE IF EXISTS gateways;
+`
+	QueryDropTableGatewayTrx = `
+-- QueryDropTableGatewayTrx
+DROP TABLE IF EXISTS gateway_trx;
+`
+	QueryDropTableGatewayMap = `
+-- QueryDropTableGatewayMap
+DROP TABLE IF EXISTS gateway_map;
+`
+	QueryDropTableGatewayPayoutMap = `
+-- QueryDropTableGatewayPayoutMap
+DROP TABLE IF EXISTS gateway_payout_map;
+`
+	QueryDropViewGatewayPayout = `
+-- QueryDropViewGatewayPayout
+DROP VIEW IF EXISTS gateway_payout;
+`
+	QueryDropViewReconciliationMatch = `
+-- QueryDropViewReconciliationMatch
+DROP VIEW IF EXISTS reconciliation_match;
 `
 	QueryCreateTableArguments = `
 -- QueryCreateTableArguments
@@ -35,7 +79,66 @@ SELECT
 CREATE TABLE IF NOT EXISTS banks AS
 SELECT
     key AS id
-    , LOWER(value) AS bank_name
+    , LOWER(json_extract(value, '$.Name')) AS bank_name
+    , COALESCE(json_extract(value, '$.SettlementDaysBefore'), 0) AS settlement_days_before
+    , COALESCE(json_extract(value, '$.SettlementDaysAfter'), 0) AS settlement_days_after
+FROM json_each(
+    ?
+)
+;
+`
+
+	QueryCreateTableBankFee = `
+-- QueryCreateTableBankFee
+-- fee tiers of the banks, a tier applies to system amounts from min_amount up to max_amount (min_amount of the next
+-- tier), the fee of an amount is ROUND(flat + amount * percentage / 100). Amounts below the first tier and banks
+-- without fee get a zero fee tier so every bank covers all amounts
+CREATE TABLE IF NOT EXISTS bank_fee AS
+SELECT
+    bank_name
+    , min_amount
+    , COALESCE(LEAD(min_amount) OVER (PARTITION BY bank_name ORDER BY min_amount), 9223372036854775807) AS max_amount
+    , flat
+    , percentage
+FROM (
+    SELECT
+        LOWER(json_extract(b.value, '$.Name')) AS bank_name
+        , COALESCE(json_extract(t.value, '$.MinAmount'), 0) AS min_amount
+        , COALESCE(json_extract(t.value, '$.Flat'), 0) AS flat
+        , COALESCE(json_extract(t.value, '$.Percentage'), 0) AS percentage
+    FROM json_each(
+        ?1
+    ) AS b
+    INNER JOIN json_each(b.value, '$.FeeTiers') AS t
+    WHERE t.type = 'object'
+    UNION ALL
+    SELECT
+        LOWER(json_extract(b.value, '$.Name')) AS bank_name
+        , 0 AS min_amount
+        , 0 AS flat
+        , 0 AS percentage
+    FROM json_each(
+        ?1
+    ) AS b
+    WHERE NOT EXISTS (
+        SELECT 1
+        FROM json_each(b.value, '$.FeeTiers') AS t
+        WHERE t.type = 'object'
+            AND COALESCE(json_extract(t.value, '$.MinAmount'), 0) <= 0
+    )
+)
+;
+`
+
+	QueryCreateTableGateways = `
+-- QueryCreateTableGateways
+-- payouts of a gateway are paid by payout_bank, any bank when empty, at most payout_days after their latest line
+CREATE TABLE IF NOT EXISTS gateways AS
+SELECT
+    key AS id
+    , LOWER(json_extract(value, '$.Name')) AS gateway_name
+    , LOWER(COALESCE(json_extract(value, '$.PayoutBank'), '')) AS payout_bank
+    , COALESCE(json_extract(value, '$.PayoutDays'), 0) AS payout_days
 FROM json_each(
     ?
 )
@@ -44,47 +147,277 @@ FROM json_each(
 
 	QueryCreateTableSystemTrx = `
 -- QueryCreateTableSystemTrx
+-- Amount is in the base currency, OriginalAmount is booked in Currency. Amount is left unconverted when
+-- IsMissingFXRate, such trx are left out of matching. Reference links a reversal to the trx it cancels, NULL when none
 CREATE TABLE IF NOT EXISTS system_trx (
 	TrxID TEXT PRIMARY KEY,
-	Amount FLOAT,
+	Reference TEXT,
+	Amount INTEGER,
 	Type TEXT,
 	TransactionTime DATETIME,
-	FilePath TEXT
+	FilePath TEXT,
+	Currency TEXT,
+	OriginalAmount INTEGER,
+	Account TEXT,
+	IsMissingFXRate INTEGER
 );
 
 CREATE INDEX IF NOT EXISTS system_trx_Amount_index ON system_trx (Amount);
+CREATE INDEX IF NOT EXISTS system_trx_Type_TransactionTime_index ON system_trx (Type, TransactionTime);
+CREATE INDEX IF NOT EXISTS system_trx_Type_Amount_TransactionTime_index ON system_trx (Type, Amount, TransactionTime);
+CREATE INDEX IF NOT EXISTS system_trx_Type_Currency_OriginalAmount_index ON system_trx (Type, Currency, OriginalAmount);
+CREATE INDEX IF NOT EXISTS system_trx_Reference_index ON system_trx (Reference);
 `
 	QueryCreateTableBankTrx = `
 -- QueryCreateTableBankTrx
+-- Amount is in the base currency, OriginalAmount is the statement amount in Currency. Amount is left unconverted when
+-- IsMissingFXRate, such trx are left out of matching. Date keeps the time of the statement when IsHaveTime, midnight
+-- otherwise. Account is NULL when the statement does not tell the account
 CREATE TABLE IF NOT EXISTS bank_trx (
 	UniqueIdentifier TEXT PRIMARY KEY,
-	Amount FLOAT,
+	Amount INTEGER,
 	Type TEXT,
 	Bank TEXT,
 	Date DATE,
-	FilePath TEXT
+	FilePath TEXT,
+	Reference TEXT,
+	Currency TEXT,
+	OriginalAmount INTEGER,
+	IsHaveTime INTEGER,
+	Account TEXT,
+	CounterpartyAccount TEXT,
+	IsMissingFXRate INTEGER
 );
 
 CREATE INDEX IF NOT EXISTS bank_trx_Date_Type_Amount_UniqueIdentifier_index ON bank_trx (Date, Type, Amount, UniqueIdentifier);
+CREATE INDEX IF NOT EXISTS bank_trx_Type_Amount_Date_index ON bank_trx (Type, Amount, Date);
+CREATE INDEX IF NOT EXISTS bank_trx_Reference_index ON bank_trx (Reference);
+CREATE INDEX IF NOT EXISTS bank_trx_Bank_Type_Amount_index ON bank_trx (LOWER(Bank), Type, Amount);
+CREATE INDEX IF NOT EXISTS bank_trx_Bank_Type_Currency_OriginalAmount_index ON bank_trx (LOWER(Bank), Type, Currency, OriginalAmount);
+`
+	QueryCreateTableGatewayTrx = `
+-- QueryCreateTableGatewayTrx
+-- lines of the gateway settlement reports, Amount is the gross amount in the base currency and Fee what the gateway
+-- keeps of it, Reference is the system TrxID given to the gateway
+CREATE TABLE IF NOT EXISTS gateway_trx (
+	UniqueIdentifier TEXT PRIMARY KEY,
+	Gateway TEXT,
+	Reference TEXT,
+	PayoutID TEXT,
+	Date DATETIME,
+	Type TEXT,
+	Amount INTEGER,
+	Fee INTEGER,
+	FilePath TEXT
+);
+
+CREATE INDEX IF NOT EXISTS gateway_trx_Reference_index ON gateway_trx (Reference);
+CREATE INDEX IF NOT EXISTS gateway_trx_Type_Amount_Date_index ON gateway_trx (Type, Amount, Date);
+CREATE INDEX IF NOT EXISTS gateway_trx_Gateway_PayoutID_index ON gateway_trx (Gateway, PayoutID);
+`
+	QueryCreateTableGatewayMap = `
+-- QueryCreateTableGatewayMap
+-- first leg, a system trx paid through a gateway line
+CREATE TABLE IF NOT EXISTS gateway_map (
+	TrxID TEXT PRIMARY KEY,
+	GatewayUniqueIdentifier TEXT,
+	AmountDifference INTEGER,
+	DateDifference INTEGER,
+	MatchRule TEXT,
+	Confidence FLOAT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS gateway_map_GatewayUniqueIdentifier_index ON gateway_map (GatewayUniqueIdentifier);
+`
+	QueryCreateTableGatewayPayoutMap = `
+-- QueryCreateTableGatewayPayoutMap
+-- second leg, a gateway payout paid by a bank trx
+CREATE TABLE IF NOT EXISTS gateway_payout_map (
+	Gateway TEXT,
+	PayoutID TEXT,
+	UniqueIdentifier TEXT,
+	DateDifference INTEGER,
+	PRIMARY KEY (Gateway, PayoutID)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS gateway_payout_map_UniqueIdentifier_index ON gateway_payout_map (UniqueIdentifier);
+`
+	QueryCreateViewGatewayPayout = `
+-- QueryCreateViewGatewayPayout
+-- every payout batch of the gateway lines, Amount is what the bank should state: the credit lines less the debit
+-- lines and all fees. PayoutDate is the date of the latest line
+CREATE VIEW IF NOT EXISTS gateway_payout AS
+SELECT
+    Gateway
+    , PayoutID
+    , MAX(DATE(Date)) AS PayoutDate
+    , COUNT(*) AS TotalTrx
+    , SUM(
+        CASE
+            WHEN Type = 'DEBIT' THEN Amount * (-1)
+            ELSE Amount
+        END
+    ) AS GrossAmount
+    , SUM(Fee) AS Fee
+    , SUM(
+        CASE
+            WHEN Type = 'DEBIT' THEN Amount * (-1)
+            ELSE Amount
+        END
+    ) - SUM(Fee) AS Amount
+FROM gateway_trx
+WHERE PayoutID IS NOT NULL
+GROUP BY Gateway, PayoutID
+;
 `
 	QueryCreateTableReconciliationMap = `
 -- QueryCreateTableReconciliationMap
 CREATE TABLE IF NOT EXISTS reconciliation_map (
 	TrxID TEXT PRIMARY KEY,
-	UniqueIdentifier TEXT
+	UniqueIdentifier TEXT,
+	AmountDifference INTEGER,
+	Fee INTEGER,
+	DateDifference INTEGER,
+	MatchRule TEXT,
+	Confidence FLOAT
 );
 
 CREATE UNIQUE INDEX IF NOT EXISTS reconciliation_map_UniqueIdentifier_index ON reconciliation_map (UniqueIdentifier);
+`
+	QueryCreateTableReconciliationAggregateMap = `
+-- QueryCreateTableReconciliationAggregateMap
+-- many system trx settled by one bank trx
+CREATE TABLE IF NOT EXISTS reconciliation_aggregate_map (
+	TrxID TEXT PRIMARY KEY,
+	UniqueIdentifier TEXT,
+	DateDifference INTEGER,
+	MatchRule TEXT,
+	Confidence FLOAT
+);
+
+CREATE INDEX IF NOT EXISTS reconciliation_aggregate_map_UniqueIdentifier_index ON reconciliation_aggregate_map (UniqueIdentifier);
+`
+	QueryCreateTableReconciliationSplitMap = `
+-- QueryCreateTableReconciliationSplitMap
+-- one system trx settled by many bank trx
+CREATE TABLE IF NOT EXISTS reconciliation_split_map (
+	UniqueIdentifier TEXT PRIMARY KEY,
+	TrxID TEXT,
+	DateDifference INTEGER,
+	MatchRule TEXT,
+	Confidence FLOAT
+);
+
+CREATE INDEX IF NOT EXISTS reconciliation_split_map_TrxID_index ON reconciliation_split_map (TrxID);
+`
+	QueryCreateTableReversalMap = `
+-- QueryCreateTableReversalMap
+-- a debit and a credit of the same side cancelling each other, Source is SYSTEM or BANK. The trx of a pair stay in
+-- system_trx or bank_trx, matching and the not matched reports leave them out
+CREATE TABLE IF NOT EXISTS reversal_map (
+	Source TEXT,
+	Bank TEXT,
+	DebitID TEXT,
+	DebitDate TEXT,
+	CreditID TEXT,
+	CreditDate TEXT,
+	Reference TEXT,
+	Amount INTEGER,
+	Currency TEXT,
+	OriginalAmount INTEGER,
+	DateDifference INTEGER,
+	PRIMARY KEY (Source, DebitID),
+	UNIQUE (Source, CreditID)
+);
+`
+	QueryCreateTableTransferMap = `
+-- QueryCreateTableTransferMap
+-- a bank debit and a bank credit of another bank or account moving money between our own accounts, they stay in
+-- bank_trx and the not matched bank report leaves them out
+CREATE TABLE IF NOT EXISTS transfer_map (
+	DebitID TEXT PRIMARY KEY,
+	DebitBank TEXT,
+	DebitAccount TEXT,
+	DebitDate TEXT,
+	CreditID TEXT UNIQUE,
+	CreditBank TEXT,
+	CreditAccount TEXT,
+	CreditDate TEXT,
+	Amount INTEGER,
+	Currency TEXT,
+	OriginalAmount INTEGER,
+	DateDifference INTEGER
+);
+`
+	QueryCreateViewReconciliationMatch = `
+-- QueryCreateViewReconciliationMatch
+-- all matched system trx regardless of how they are matched, correlated lookups should check the tables
+-- instead as the view can not use their indexes
+CREATE VIEW IF NOT EXISTS reconciliation_match AS
+SELECT
+    TrxID
+    , UniqueIdentifier
+    , AmountDifference
+    , Fee
+    , DateDifference
+    , 'ONE_TO_ONE' AS MatchType
+    , MatchRule
+    , Confidence
+FROM reconciliation_map
+UNION ALL
+SELECT
+    TrxID
+    , UniqueIdentifier
+    , 0 AS AmountDifference
+    , 0 AS Fee
+    , DateDifference
+    , 'MANY_TO_ONE' AS MatchType
+    , MatchRule
+    , Confidence
+FROM reconciliation_aggregate_map
+UNION ALL
+SELECT
+    TrxID
+    , UniqueIdentifier
+    , 0 AS AmountDifference
+    , 0 AS Fee
+    , DateDifference
+    , 'ONE_TO_MANY' AS MatchType
+    , MatchRule
+    , Confidence
+FROM reconciliation_split_map
+UNION ALL
+-- system trx paid through a gateway line whose payout is paid by the bank trx, Fee is the fee of the gateway
+SELECT
+    gm.TrxID
+    , gpm.UniqueIdentifier
+    , gm.AmountDifference
+    , gt.Fee
+    , CAST(JULIANDAY(DATE(bt.Date)) - JULIANDAY(DATE(st.TransactionTime)) AS INTEGER) AS DateDifference
+    , 'GATEWAY' AS MatchType
+    , gm.MatchRule
+    , gm.Confidence
+FROM gateway_map gm
+INNER JOIN system_trx st ON st.TrxID = gm.TrxID
+INNER JOIN gateway_trx gt ON gt.UniqueIdentifier = gm.GatewayUniqueIdentifier
+INNER JOIN gateway_payout_map gpm ON gpm.Gateway = gt.Gateway AND gpm.PayoutID = gt.PayoutID
+INNER JOIN bank_trx bt ON bt.UniqueIdentifier = gpm.UniqueIdentifier
+;
 `
 	QueryInsertTableSystemTrx = `
 -- QueryInsertTableSystemTrx
-INSERT INTO system_trx (TrxID, Amount, Type, TransactionTime, FilePath)
+INSERT INTO system_trx (TrxID, Reference, Amount, Type, TransactionTime, FilePath, Currency, OriginalAmount, Account, IsMissingFXRate)
 	SELECT
 	json_extract(j.value, '$.TrxID') AS TrxID
+	 , NULLIF(json_extract(j.value, '$.Reference'), '') AS Reference
 	 , json_extract(j.value, '$.Amount') AS Amount
 	 , json_extract(j.value, '$.Type') AS Type
 	 , json_extract(j.value, '$.TransactionTime') AS TransactionTime
 	 , json_extract(j.value, '$.FilePath') AS FilePath
+	 , json_extract(j.value, '$.Currency') AS Currency
+	 , json_extract(j.value, '$.OriginalAmount') AS OriginalAmount
+	 , NULLIF(json_extract(j.value, '$.Account'), '') AS Account
+	 , COALESCE(json_extract(j.value, '$.IsMissingFXRate'), 0) AS IsMissingFXRate
 	FROM json_each(
 	 ?
 	) AS j
@@ -93,7 +426,7 @@ INSERT INTO system_trx (TrxID, Amount, Type, TransactionTime, FilePath)
 
 	QueryInsertTableBankTrx = `
 -- QueryInsertTableBankTrx
-INSERT INTO bank_trx (UniqueIdentifier, Date, Type, FilePath, Bank, Amount)
+INSERT INTO bank_trx (UniqueIdentifier, Date, Type, FilePath, Bank, Amount, Reference, Currency, OriginalAmount, IsHaveTime, Account, CounterpartyAccount, IsMissingFXRate)
 	SELECT
 	json_extract(j.value, '$.UniqueIdentifier') AS UniqueIdentifier
 	 , json_extract(j.value, '$.Date') AS Date
@@ -101,51 +434,805 @@ INSERT INTO bank_trx (UniqueIdentifier, Date, Type, FilePath, Bank, Amount)
 	 , json_extract(j.value, '$.FilePath') AS FilePath
 	 , json_extract(j.value, '$.Bank') AS Bank
 	 , json_extract(j.value, '$.Amount') AS Amount
+	 , NULLIF(json_extract(j.value, '$.Reference'), '') AS Reference
+	 , json_extract(j.value, '$.Currency') AS Currency
+	 , json_extract(j.value, '$.OriginalAmount') AS OriginalAmount
+	 , json_extract(j.value, '$.IsHaveTime') AS IsHaveTime
+	 , NULLIF(json_extract(j.value, '$.Account'), '') AS Account
+	 , NULLIF(json_extract(j.value, '$.CounterpartyAccount'), '') AS CounterpartyAccount
+	 , COALESCE(json_extract(j.value, '$.IsMissingFXRate'), 0) AS IsMissingFXRate
 	FROM json_each(
 	 ?
 	) AS j
 ;
 `
 
-	QueryInsertTableReconciliationMap = `
--- QueryInsertTableReconciliationMap
+	QueryInsertTableGatewayTrx = `
+-- QueryInsertTableGatewayTrx
+-- a line repeated in the gateway files keeps its first occurrence
+INSERT OR IGNORE INTO gateway_trx (UniqueIdentifier, Gateway, Reference, PayoutID, Date, Type, Amount, Fee, FilePath)
+	SELECT
+	json_extract(j.value, '$.UniqueIdentifier') AS UniqueIdentifier
+	 , LOWER(json_extract(j.value, '$.Gateway')) AS Gateway
+	 , NULLIF(json_extract(j.value, '$.Reference'), '') AS Reference
+	 , NULLIF(json_extract(j.value, '$.PayoutID'), '') AS PayoutID
+	 , json_extract(j.value, '$.Date') AS Date
+	 , json_extract(j.value, '$.Type') AS Type
+	 , json_extract(j.value, '$.Amount') AS Amount
+	 , json_extract(j.value, '$.Fee') AS Fee
+	 , json_extract(j.value, '$.FilePath') AS FilePath
+	FROM json_each(
+	 ?
+	) AS j
+;
+`
+	QueryInsertTableGatewayMapReference = `
+-- QueryInsertTableGatewayMapReference
+-- gateway lines carrying our TrxID as reference are linked to it regardless of amount and date, a TrxID referenced by
+-- more than one line goes to the closest date
+INSERT OR IGNORE INTO gateway_map(
+    TrxID,
+    GatewayUniqueIdentifier,
+    AmountDifference,
+    DateDifference,
+    MatchRule,
+    Confidence
+)
+SELECT
+    st.TrxID
+    , gt.UniqueIdentifier AS GatewayUniqueIdentifier
+    , gt.Amount - st.Amount AS AmountDifference
+    , CAST(JULIANDAY(DATE(gt.Date)) - JULIANDAY(DATE(st.TransactionTime)) AS INTEGER) AS DateDifference
+    , 'gateway_reference' AS MatchRule
+    , ROUND(1.0 / COUNT(*) OVER (PARTITION BY st.TrxID), 4) AS Confidence
+FROM gateway_trx gt
+INNER JOIN system_trx st ON st.TrxID = gt.Reference AND st.Type = gt.Type
+WHERE NOT st.IsMissingFXRate
+    AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'SYSTEM' AND (rvm.DebitID = st.TrxID OR rvm.CreditID = st.TrxID))
+ORDER BY ABS(DateDifference), ABS(AmountDifference), st.TrxID, gt.UniqueIdentifier
+;
+`
+	QueryInsertTableGatewayMapAmountDate = `
+-- QueryInsertTableGatewayMapAmountDate
+-- open gateway lines of the type and gross amount of an open system trx at most Days apart, the closest time first and
+-- every line and system trx in one link only
 WITH main_data AS (
     SELECT
-        CAST(? AS FLOAT) AS MinAmount
-        , CAST(? AS FLOAT) AS MaxAmount
+        CAST(? AS INTEGER) AS Days
 )
-INSERT INTO reconciliation_map(
+INSERT OR IGNORE INTO gateway_map(
     TrxID,
-    UniqueIdentifier
+    GatewayUniqueIdentifier,
+    AmountDifference,
+    DateDifference,
+    MatchRule,
+    Confidence
+)
+SELECT
+    TrxID
+    , GatewayUniqueIdentifier
+    , 0 AS AmountDifference
+    , DateDifference
+    , 'gateway_amount_date' AS MatchRule
+    , Confidence
+FROM (
+    SELECT
+        st.TrxID
+        , gt.UniqueIdentifier AS GatewayUniqueIdentifier
+        , CAST(JULIANDAY(DATE(gt.Date)) - JULIANDAY(DATE(st.TransactionTime)) AS INTEGER) AS DateDifference
+        , ABS(JULIANDAY(gt.Date) - JULIANDAY(st.TransactionTime)) AS TimeDistance
+        -- competing candidates of either side divide the confidence
+        , ROUND(
+            1.0 / MAX(
+                COUNT(*) OVER (PARTITION BY st.TrxID)
+                , COUNT(*) OVER (PARTITION BY gt.UniqueIdentifier)
+            )
+            , 4
+        ) AS Confidence
+    FROM main_data md
+    INNER JOIN system_trx st
+    INNER JOIN gateway_trx gt ON
+        gt.Type = st.Type
+        AND gt.Amount = st.Amount
+        AND gt.Date >= STRFTIME('%FT%TZ', DATE(st.TransactionTime, '-' || md.Days || ' days'))
+        AND gt.Date < STRFTIME('%FT%TZ', DATE(st.TransactionTime, '+' || (md.Days + 1) || ' days'))
+    WHERE NOT EXISTS (SELECT 1 FROM gateway_map gm WHERE gm.TrxID = st.TrxID)
+        AND NOT EXISTS (SELECT 1 FROM gateway_map gm WHERE gm.GatewayUniqueIdentifier = gt.UniqueIdentifier)
+        AND NOT st.IsMissingFXRate
+        AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'SYSTEM' AND (rvm.DebitID = st.TrxID OR rvm.CreditID = st.TrxID))
+)
+ORDER BY TimeDistance, TrxID, GatewayUniqueIdentifier
+;
+`
+	QueryInsertTableGatewayPayoutMap = `
+-- QueryInsertTableGatewayPayoutMap
+-- a payout is paid by one bank trx of its amount from the payout bank of the gateway (any bank when not set), dated on
+-- the payout date up to payout_days later. A bank trx referencing the PayoutID goes first, then the closest date
+INSERT OR IGNORE INTO gateway_payout_map(
+    Gateway,
+    PayoutID,
+    UniqueIdentifier,
+    DateDifference
+)
+SELECT
+    gp.Gateway
+    , gp.PayoutID
+    , bt.UniqueIdentifier
+    , CAST(JULIANDAY(DATE(bt.Date)) - JULIANDAY(gp.PayoutDate) AS INTEGER) AS DateDifference
+FROM gateway_payout gp
+INNER JOIN gateways g ON g.gateway_name = gp.Gateway
+INNER JOIN bank_trx bt ON
+    bt.Type = CASE
+        WHEN gp.Amount < 0 THEN 'DEBIT'
+        ELSE 'CREDIT'
+    END
+    AND bt.Amount = ABS(gp.Amount)
+    AND bt.Date >= STRFTIME('%FT%TZ', gp.PayoutDate)
+    AND bt.Date < STRFTIME('%FT%TZ', DATE(gp.PayoutDate, '+' || (g.payout_days + 1) || ' days'))
+WHERE (g.payout_bank = '' OR LOWER(bt.Bank) = g.payout_bank)
+    AND NOT bt.IsMissingFXRate
+    AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'BANK' AND (rvm.DebitID = bt.UniqueIdentifier OR rvm.CreditID = bt.UniqueIdentifier))
+ORDER BY COALESCE(bt.Reference, '') <> gp.PayoutID, DateDifference, gp.Gateway, gp.PayoutID, bt.UniqueIdentifier
+;
+`
+
+	QueryInsertTableReversalMapSystemTrx = `
+-- QueryInsertTableReversalMapSystemTrx
+-- a system debit and a credit of the same currency and amount at most Days apart cancel each other when one references
+-- the TrxID of the other or both share a reference, the closest pair first and every trx in one pair only
+WITH main_data AS (
+    SELECT
+        CAST(? AS INTEGER) AS Days
+)
+INSERT OR IGNORE INTO reversal_map(
+    Source,
+    Bank,
+    DebitID,
+    DebitDate,
+    CreditID,
+    CreditDate,
+    Reference,
+    Amount,
+    Currency,
+    OriginalAmount,
+    DateDifference
+)
+SELECT
+    'SYSTEM' AS Source
+    , '' AS Bank
+    , d.TrxID AS DebitID
+    , STRFTIME('%F', d.TransactionTime) AS DebitDate
+    , c.TrxID AS CreditID
+    , STRFTIME('%F', c.TransactionTime) AS CreditDate
+    , COALESCE(c.Reference, d.Reference, '') AS Reference
+    , d.Amount
+    , d.Currency
+    , d.OriginalAmount
+    , CAST(JULIANDAY(DATE(c.TransactionTime)) - JULIANDAY(DATE(d.TransactionTime)) AS INTEGER) AS DateDifference
+FROM main_data md
+INNER JOIN system_trx d ON d.Type = 'DEBIT'
+INNER JOIN system_trx c ON
+    c.Type = 'CREDIT'
+    AND c.Currency = d.Currency
+    AND c.OriginalAmount = d.OriginalAmount
+    AND DATE(c.TransactionTime) BETWEEN DATE(d.TransactionTime, '-' || md.Days || ' days') AND DATE(d.TransactionTime, '+' || md.Days || ' days')
+    AND (c.Reference = d.TrxID OR d.Reference = c.TrxID OR c.Reference = d.Reference)
+ORDER BY ABS(DateDifference), ABS(JULIANDAY(c.TransactionTime) - JULIANDAY(d.TransactionTime)), DebitID, CreditID
+;
+`
+	QueryInsertTableReversalMapBankTrx = `
+-- QueryInsertTableReversalMapBankTrx
+-- a debit and a credit statement of the same bank, currency and amount at most Days apart cancel each other. When
+-- both have a reference it should be the same, pairs sharing one go first then the closest
+WITH main_data AS (
+    SELECT
+        CAST(? AS INTEGER) AS Days
+)
+INSERT OR IGNORE INTO reversal_map(
+    Source,
+    Bank,
+    DebitID,
+    DebitDate,
+    CreditID,
+    CreditDate,
+    Reference,
+    Amount,
+    Currency,
+    OriginalAmount,
+    DateDifference
+)
+SELECT
+    'BANK' AS Source
+    , LOWER(d.Bank) AS Bank
+    , d.UniqueIdentifier AS DebitID
+    , STRFTIME('%F', d.Date) AS DebitDate
+    , c.UniqueIdentifier AS CreditID
+    , STRFTIME('%F', c.Date) AS CreditDate
+    , COALESCE(d.Reference, c.Reference, '') AS Reference
+    , d.Amount
+    , d.Currency
+    , d.OriginalAmount
+    , CAST(JULIANDAY(DATE(c.Date)) - JULIANDAY(DATE(d.Date)) AS INTEGER) AS DateDifference
+FROM main_data md
+INNER JOIN bank_trx d ON d.Type = 'DEBIT'
+INNER JOIN bank_trx c ON
+    LOWER(c.Bank) = LOWER(d.Bank)
+    AND c.Type = 'CREDIT'
+    AND c.Currency = d.Currency
+    AND c.OriginalAmount = d.OriginalAmount
+    AND DATE(c.Date) BETWEEN DATE(d.Date, '-' || md.Days || ' days') AND DATE(d.Date, '+' || md.Days || ' days')
+    AND (d.Reference IS NULL OR c.Reference IS NULL OR d.Reference = c.Reference)
+ORDER BY d.Reference IS NULL OR c.Reference IS NULL, ABS(DateDifference), DebitID, CreditID
+;
+`
+	QueryInsertTableTransferMap = `
+-- QueryInsertTableTransferMap
+-- a debit and a credit not matched to any system trx nor gateway payout, of the same currency and amount at different
+-- banks or accounts, the credit at most Days after the debit. With own accounts (a json array, empty to skip), the
+-- counterparty account a leg states should be one of them and the account of the other leg when known, and at least
+-- one leg should state it. The closest pair goes first and every trx is in one pair only
+WITH main_data AS (
+    SELECT
+        CAST(? AS INTEGER) AS Days
+        , CAST(? AS TEXT) AS OwnAccounts
+)
+, own_account AS (
+    SELECT value AS Account FROM main_data md, json_each(md.OwnAccounts)
+)
+, open_bank_trx AS (
+    SELECT *
+    FROM bank_trx bt
+    WHERE NOT EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT EXISTS (SELECT 1 FROM gateway_payout_map gpm WHERE gpm.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'BANK' AND (rvm.DebitID = bt.UniqueIdentifier OR rvm.CreditID = bt.UniqueIdentifier))
+)
+INSERT OR IGNORE INTO transfer_map(
+    DebitID,
+    DebitBank,
+    DebitAccount,
+    DebitDate,
+    CreditID,
+    CreditBank,
+    CreditAccount,
+    CreditDate,
+    Amount,
+    Currency,
+    OriginalAmount,
+    DateDifference
+)
+SELECT
+    d.UniqueIdentifier AS DebitID
+    , LOWER(d.Bank) AS DebitBank
+    , COALESCE(d.Account, '') AS DebitAccount
+    , STRFTIME('%F', d.Date) AS DebitDate
+    , c.UniqueIdentifier AS CreditID
+    , LOWER(c.Bank) AS CreditBank
+    , COALESCE(c.Account, '') AS CreditAccount
+    , STRFTIME('%F', c.Date) AS CreditDate
+    , d.Amount
+    , d.Currency
+    , d.OriginalAmount
+    , CAST(JULIANDAY(DATE(c.Date)) - JULIANDAY(DATE(d.Date)) AS INTEGER) AS DateDifference
+FROM main_data md
+INNER JOIN open_bank_trx d ON d.Type = 'DEBIT'
+INNER JOIN open_bank_trx c ON
+    c.Type = 'CREDIT'
+    AND c.Currency = d.Currency
+    AND c.OriginalAmount = d.OriginalAmount
+    AND DATE(c.Date) BETWEEN DATE(d.Date) AND DATE(d.Date, '+' || md.Days || ' days')
+    AND (LOWER(c.Bank) <> LOWER(d.Bank) OR c.Account <> d.Account)
+WHERE NOT EXISTS (SELECT 1 FROM own_account)
+    OR (
+        (d.CounterpartyAccount IS NOT NULL OR c.CounterpartyAccount IS NOT NULL)
+        AND (d.CounterpartyAccount IS NULL OR d.CounterpartyAccount IN (SELECT Account FROM own_account))
+        AND (c.CounterpartyAccount IS NULL OR c.CounterpartyAccount IN (SELECT Account FROM own_account))
+        AND (d.CounterpartyAccount IS NULL OR c.Account IS NULL OR d.CounterpartyAccount = c.Account)
+        AND (c.CounterpartyAccount IS NULL OR d.Account IS NULL OR c.CounterpartyAccount = d.Account)
+    )
+ORDER BY DateDifference, DebitID, CreditID
+;
+`
+	QueryInsertTableReconciliationReferenceMap = `
+-- QueryInsertTableReconciliationReferenceMap
+-- bank trx carrying our TrxID as reference are linked to it regardless of amount and date, the gap is recorded
+-- on top of the fee of the bank. A system trx stating its account only takes bank trx of that account
+WITH main_data AS (
+    SELECT
+        CAST(? AS TEXT) AS MatchRule
+)
+INSERT OR IGNORE INTO reconciliation_map(
+    TrxID,
+    UniqueIdentifier,
+    AmountDifference,
+    Fee,
+    DateDifference,
+    MatchRule,
+    Confidence
 )
 SELECT
     TrxID
      , UniqueIdentifier
+     -- a debit takes the fee on top of the system amount, a credit arrives less the fee
+     , Amount - CASE
+         WHEN Type = 'DEBIT' THEN SystemAmount + Fee
+         ELSE SystemAmount - Fee
+     END AS AmountDifference
+     , Fee
+     , DateDifference
+     , MatchRule
+     , Confidence
 FROM (
          SELECT
-             TRUE
-              , ROW_NUMBER() OVER (PARTITION BY st.TrxID ORDER BY bt.UniqueIdentifier) AS r_system
-              , ROW_NUMBER() OVER (PARTITION BY bt.UniqueIdentifier ORDER BY st.TrxID) AS r_bank
-              , st.TrxID
+             st.TrxID
               , bt.UniqueIdentifier
+              , bt.Type
+              , bt.Amount
+              , st.Amount AS SystemAmount
+              , COALESCE(CAST(ROUND(bf.flat + st.Amount * bf.percentage / 100) AS INTEGER), 0) AS Fee
+              , CAST(JULIANDAY(DATE(bt.Date)) - JULIANDAY(DATE(st.TransactionTime)) AS INTEGER) AS DateDifference
+              , md.MatchRule
+              -- amount and date are not part of the rule, only other bank trx referencing the same TrxID compete
+              , ROUND(1.0 / COUNT(*) OVER (PARTITION BY st.TrxID), 4) AS Confidence
          FROM main_data md
-        INNER JOIN system_trx st ON st.Amount >= md.MinAmount AND st.Amount < md.MaxAmount
-        INNER JOIN bank_trx bt ON
-            bt.Date = STRFTIME('%FT%TZ', DATE(st.TransactionTime))
-            AND bt.Type = st.Type
-            AND bt.Amount = st.Amount
+        INNER JOIN bank_trx bt ON bt.Reference IS NOT NULL
+        INNER JOIN banks b ON LOWER(bt.Bank) = b.bank_name
+        INNER JOIN system_trx st ON
+            st.TrxID = bt.Reference
+            AND st.Type = bt.Type
+            AND (st.Account IS NULL OR st.Account = bt.Account)
+        LEFT JOIN bank_fee bf ON
+            bf.bank_name = b.bank_name
+            AND bf.min_amount <= st.Amount
+            AND bf.max_amount > st.Amount
+        WHERE NOT EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.TrxID = st.TrxID)
+            AND NOT EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.TrxID = st.TrxID)
+            AND NOT EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.TrxID = st.TrxID)
+            AND NOT EXISTS (SELECT 1 FROM gateway_map gm WHERE gm.TrxID = st.TrxID)
+            AND NOT st.IsMissingFXRate
+            AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'SYSTEM' AND (rvm.DebitID = st.TrxID OR rvm.CreditID = st.TrxID))
+            AND NOT EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.UniqueIdentifier = bt.UniqueIdentifier)
+            AND NOT EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.UniqueIdentifier = bt.UniqueIdentifier)
+            AND NOT EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.UniqueIdentifier = bt.UniqueIdentifier)
+            AND NOT EXISTS (SELECT 1 FROM gateway_payout_map gpm WHERE gpm.UniqueIdentifier = bt.UniqueIdentifier)
+            AND NOT bt.IsMissingFXRate
+            AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'BANK' AND (rvm.DebitID = bt.UniqueIdentifier OR rvm.CreditID = bt.UniqueIdentifier))
      )
-WHERE r_system = r_bank;
+-- a TrxID referenced by more than one bank trx goes to the closest date, the rest are left for the other rules
+ORDER BY ABS(DateDifference), ABS(AmountDifference), TrxID, UniqueIdentifier;
+`
+	QueryGetReconciliationMapCandidate = `
+-- QueryGetReconciliationMapCandidate
+-- every open bank trx within the amount tolerance and settlement window of an open system trx, TimeDistance is the gap
+-- in seconds to the time of the statement when the bank states one, to its date otherwise, TrxTime and BankTime are the
+-- unix times it is taken from. A system trx stating its account only takes bank trx of that account
+WITH main_data AS (
+    SELECT
+        CAST(? AS INTEGER) AS MinAmount
+        , CAST(? AS INTEGER) AS MaxAmount
+        , CAST(? AS INTEGER) AS ToleranceAbsolute
+        , CAST(? AS FLOAT) AS TolerancePercentage
+        , CAST(? AS FLOAT) AS FXTolerancePercentage
+        , CAST(? AS INTEGER) AS DaysBefore
+        , CAST(? AS INTEGER) AS DaysAfter
+)
+SELECT
+    TrxID
+     , UniqueIdentifier
+     , AmountDifference
+     , Fee
+     , DateDifference
+     , TimeDistance
+     , TrxTime
+     , BankTime
+     -- competing candidates of either side divide the confidence, date and amount gaps take up to a quarter each
+     -- relative to the allowed window and tolerance
+     , ROUND(
+         1.0 / MAX(TrxCandidates, BankCandidates)
+         * (
+             1
+             - 0.25 * MIN(
+                 1.0
+                 , ABS(DateDifference) * 1.0 / MAX(
+                     CASE
+                         WHEN DateDifference < 0 THEN DaysBefore
+                         ELSE DaysAfter
+                     END
+                     , 1
+                 )
+             )
+             - 0.25 * CASE
+                 WHEN AmountAllowance > 0 THEN MIN(1.0, ABS(AmountDifference) * 1.0 / AmountAllowance)
+                 ELSE 0
+             END
+         )
+         , 4
+     ) AS Confidence
+FROM (
+         SELECT
+             c.*
+              , COUNT(*) OVER (PARTITION BY c.TrxID) AS TrxCandidates
+              , COUNT(*) OVER (PARTITION BY c.UniqueIdentifier) AS BankCandidates
+         FROM (
+                  SELECT
+                      ost.TrxID
+                       , bt.UniqueIdentifier
+                       , bt.Amount - ost.ExpectedAmount AS AmountDifference
+                       , ost.Fee
+                       , CAST(JULIANDAY(DATE(bt.Date)) - JULIANDAY(DATE(ost.TransactionTime)) AS INTEGER) AS DateDifference
+                       , CASE
+                           WHEN bt.IsHaveTime THEN CAST(ROUND(ABS(JULIANDAY(bt.Date) - JULIANDAY(ost.TransactionTime)) * 86400) AS INTEGER)
+                           ELSE ABS(CAST(JULIANDAY(DATE(bt.Date)) - JULIANDAY(DATE(ost.TransactionTime)) AS INTEGER)) * 86400
+                       END AS TimeDistance
+                       , CAST(STRFTIME('%s', ost.TransactionTime) AS INTEGER) AS TrxTime
+                       , CAST(
+                           STRFTIME(
+                               '%s'
+                               , CASE
+                                   WHEN bt.IsHaveTime THEN bt.Date
+                                   ELSE DATE(bt.Date)
+                               END
+                           ) AS INTEGER
+                       ) AS BankTime
+                       , ost.Tolerance
+                           + CASE
+                               WHEN bt.Currency <> ost.Currency THEN ost.FXTolerance
+                               ELSE 0
+                           END AS AmountAllowance
+                       , ost.DaysBefore
+                       , ost.DaysAfter
+                  -- every open system trx once per bank, the bank is expected to state the system amount less its fee,
+                  -- a debit takes the fee on top
+                  FROM (
+                           SELECT
+                               st.TrxID
+                                , st.Type
+                                , st.TransactionTime
+                                , st.Currency
+                                , st.Account
+                                , b.bank_name
+                                , COALESCE(CAST(ROUND(bf.flat + st.Amount * bf.percentage / 100) AS INTEGER), 0) AS Fee
+                                , st.Amount
+                                  + CASE
+                                      WHEN st.Type = 'DEBIT' THEN 1
+                                      ELSE -1
+                                  END * COALESCE(CAST(ROUND(bf.flat + st.Amount * bf.percentage / 100) AS INTEGER), 0) AS ExpectedAmount
+                                , MAX(md.ToleranceAbsolute, st.Amount * md.TolerancePercentage / 100) AS Tolerance
+                                , st.Amount * md.FXTolerancePercentage / 100 AS FXTolerance
+                                , COALESCE(md.DaysBefore, b.settlement_days_before) AS DaysBefore
+                                , COALESCE(md.DaysAfter, b.settlement_days_after) AS DaysAfter
+                           FROM main_data md
+                           CROSS JOIN system_trx st ON st.Amount >= md.MinAmount AND st.Amount < md.MaxAmount
+                           CROSS JOIN banks b
+                           LEFT JOIN bank_fee bf ON
+                               bf.bank_name = b.bank_name
+                               AND bf.min_amount <= st.Amount
+                               AND bf.max_amount > st.Amount
+                           WHERE NOT EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.TrxID = st.TrxID)
+                               AND NOT EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.TrxID = st.TrxID)
+                               AND NOT EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.TrxID = st.TrxID)
+                               AND NOT EXISTS (SELECT 1 FROM gateway_map gm WHERE gm.TrxID = st.TrxID)
+                               AND NOT st.IsMissingFXRate
+                               AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'SYSTEM' AND (rvm.DebitID = st.TrxID OR rvm.CreditID = st.TrxID))
+                       ) ost
+                  -- CROSS JOIN keeps the system trx the outer loop, the amount range includes the fx tolerance so it can
+                  -- use the index and is narrowed to the currency of the pair below
+                  CROSS JOIN bank_trx bt ON
+                      LOWER(bt.Bank) = ost.bank_name
+                      AND bt.Type = ost.Type
+                      AND (ost.Account IS NULL OR ost.Account = bt.Account)
+                      AND bt.Amount >= ost.ExpectedAmount - ost.Tolerance - ost.FXTolerance
+                      AND bt.Amount <= ost.ExpectedAmount + ost.Tolerance + ost.FXTolerance
+                      AND bt.Date >= STRFTIME('%FT%TZ', DATE(ost.TransactionTime, '-' || ost.DaysBefore || ' days'))
+                      AND bt.Date < STRFTIME('%FT%TZ', DATE(ost.TransactionTime, '+' || (ost.DaysAfter + 1) || ' days'))
+                  WHERE NOT EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.UniqueIdentifier = bt.UniqueIdentifier)
+                      AND NOT EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.UniqueIdentifier = bt.UniqueIdentifier)
+                      AND NOT EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.UniqueIdentifier = bt.UniqueIdentifier)
+                      AND NOT EXISTS (SELECT 1 FROM gateway_payout_map gpm WHERE gpm.UniqueIdentifier = bt.UniqueIdentifier)
+                      AND NOT bt.IsMissingFXRate
+                      AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'BANK' AND (rvm.DebitID = bt.UniqueIdentifier OR rvm.CreditID = bt.UniqueIdentifier))
+              ) c
+         WHERE ABS(c.AmountDifference) <= c.AmountAllowance
+     )
+ORDER BY TrxID, UniqueIdentifier;
+`
+	QueryInsertTableReconciliationMap = `
+-- QueryInsertTableReconciliationMap
+INSERT OR IGNORE INTO reconciliation_map (TrxID, UniqueIdentifier, AmountDifference, Fee, DateDifference, MatchRule, Confidence)
+	SELECT
+	json_extract(j.value, '$.TrxID') AS TrxID
+	 , json_extract(j.value, '$.UniqueIdentifier') AS UniqueIdentifier
+	 , json_extract(j.value, '$.AmountDifference') AS AmountDifference
+	 , json_extract(j.value, '$.Fee') AS Fee
+	 , json_extract(j.value, '$.DateDifference') AS DateDifference
+	 , json_extract(j.value, '$.MatchRule') AS MatchRule
+	 , json_extract(j.value, '$.Confidence') AS Confidence
+	FROM json_each(
+	 ?
+	) AS j
+;
+`
+
+	QueryInsertTableReconciliationAggregateMap = `
+-- QueryInsertTableReconciliationAggregateMap
+WITH main_data AS (
+    SELECT
+        CAST(? AS INTEGER) AS DaysBefore
+        , CAST(? AS INTEGER) AS DaysAfter
+        , CAST(? AS TEXT) AS MatchRule
+), system_group AS (
+    -- all not matched system trx of a day, type and account settled at once
+    SELECT
+        DATE(st.TransactionTime) AS GroupDate
+        , st.Type
+        , st.Account
+        , SUM(st.Amount) AS GroupAmount
+    FROM system_trx st
+    WHERE NOT EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.TrxID = st.TrxID)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.TrxID = st.TrxID)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.TrxID = st.TrxID)
+        AND NOT EXISTS (SELECT 1 FROM gateway_map gm WHERE gm.TrxID = st.TrxID)
+        AND NOT st.IsMissingFXRate
+        AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'SYSTEM' AND (rvm.DebitID = st.TrxID OR rvm.CreditID = st.TrxID))
+    GROUP BY DATE(st.TransactionTime), st.Type, st.Account
+    HAVING COUNT(*) > 1
+), candidate AS (
+    SELECT
+        sg.GroupDate
+        , sg.Type
+        , sg.Account
+        , bt.UniqueIdentifier
+        , CAST(JULIANDAY(DATE(bt.Date)) - JULIANDAY(sg.GroupDate) AS INTEGER) AS DateDifference
+        , md.MatchRule
+        , COALESCE(md.DaysBefore, b.settlement_days_before) AS DaysBefore
+        , COALESCE(md.DaysAfter, b.settlement_days_after) AS DaysAfter
+    FROM main_data md
+    INNER JOIN system_group sg
+    INNER JOIN banks b
+    INNER JOIN bank_trx bt ON
+        LOWER(bt.Bank) = b.bank_name
+        AND bt.Type = sg.Type
+        AND bt.Amount = sg.GroupAmount
+        AND (sg.Account IS NULL OR sg.Account = bt.Account)
+        AND bt.Date >= STRFTIME('%FT%TZ', DATE(sg.GroupDate, '-' || COALESCE(md.DaysBefore, b.settlement_days_before) || ' days'))
+        AND bt.Date < STRFTIME('%FT%TZ', DATE(sg.GroupDate, '+' || (COALESCE(md.DaysAfter, b.settlement_days_after) + 1) || ' days'))
+    WHERE NOT EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT EXISTS (SELECT 1 FROM gateway_payout_map gpm WHERE gpm.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT bt.IsMissingFXRate
+        AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'BANK' AND (rvm.DebitID = bt.UniqueIdentifier OR rvm.CreditID = bt.UniqueIdentifier))
+), ranked_candidate AS (
+    -- closest date first, a group and a bank trx are only paired when both are the first choice of each other
+    SELECT
+        c.*
+        , ROW_NUMBER() OVER (PARTITION BY c.GroupDate, c.Type, c.Account ORDER BY ABS(c.DateDifference), c.UniqueIdentifier) AS r_group
+        , ROW_NUMBER() OVER (PARTITION BY c.UniqueIdentifier ORDER BY ABS(c.DateDifference), c.GroupDate, c.Type, c.Account) AS r_bank
+        -- amount is exact, competing candidates of either side divide the confidence and the date gap takes up to a quarter
+        , ROUND(
+            1.0 / MAX(
+                COUNT(*) OVER (PARTITION BY c.GroupDate, c.Type, c.Account)
+                , COUNT(*) OVER (PARTITION BY c.UniqueIdentifier)
+            )
+            * (
+                1
+                - 0.25 * MIN(
+                    1.0
+                    , ABS(c.DateDifference) * 1.0 / MAX(
+                        CASE
+                            WHEN c.DateDifference < 0 THEN c.DaysBefore
+                            ELSE c.DaysAfter
+                        END
+                        , 1
+                    )
+                )
+            )
+            , 4
+        ) AS Confidence
+    FROM candidate c
+)
+INSERT OR IGNORE INTO reconciliation_aggregate_map(
+    TrxID,
+    UniqueIdentifier,
+    DateDifference,
+    MatchRule,
+    Confidence
+)
+SELECT
+    st.TrxID
+    , rc.UniqueIdentifier
+    , rc.DateDifference
+    , rc.MatchRule
+    , rc.Confidence
+FROM ranked_candidate rc
+INNER JOIN system_trx st ON
+    DATE(st.TransactionTime) = rc.GroupDate
+    AND st.Type = rc.Type
+    AND st.Account IS rc.Account
+WHERE rc.r_group = 1
+    AND rc.r_bank = 1
+    AND NOT EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.TrxID = st.TrxID)
+    AND NOT EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.TrxID = st.TrxID)
+    AND NOT EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.TrxID = st.TrxID)
+    AND NOT EXISTS (SELECT 1 FROM gateway_map gm WHERE gm.TrxID = st.TrxID)
+    AND NOT st.IsMissingFXRate
+    AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'SYSTEM' AND (rvm.DebitID = st.TrxID OR rvm.CreditID = st.TrxID))
+;
+`
+	QueryGetSplitMatchCandidate = `
+-- QueryGetSplitMatchCandidate
+WITH RECURSIVE main_data AS (
+    SELECT
+        CAST(? AS INTEGER) AS DaysBefore
+        , CAST(? AS INTEGER) AS DaysAfter
+        , CAST(? AS INTEGER) AS MaxParts
+), open_system_trx AS MATERIALIZED (
+    SELECT
+        st.TrxID
+        , st.Type
+        , st.Amount
+        , st.Account
+        , DATE(st.TransactionTime) AS Date
+    FROM system_trx st
+    WHERE NOT EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.TrxID = st.TrxID)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.TrxID = st.TrxID)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.TrxID = st.TrxID)
+        AND NOT EXISTS (SELECT 1 FROM gateway_map gm WHERE gm.TrxID = st.TrxID)
+        AND NOT st.IsMissingFXRate
+        AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'SYSTEM' AND (rvm.DebitID = st.TrxID OR rvm.CreditID = st.TrxID))
+), open_bank_trx AS MATERIALIZED (
+    -- MaxAmount is the largest amount of the bank and type, it tells when the parts left can no longer reach a sum
+    SELECT
+        bt.UniqueIdentifier
+        , bt.Type
+        , bt.Amount
+        , DATE(bt.Date) AS Date
+        , b.bank_name AS Bank
+        , bt.Account
+        , COALESCE(md.DaysBefore, b.settlement_days_before) AS DaysBefore
+        , COALESCE(md.DaysAfter, b.settlement_days_after) AS DaysAfter
+        , MAX(bt.Amount) OVER (PARTITION BY b.bank_name, bt.Type) AS MaxAmount
+    FROM main_data md
+    INNER JOIN bank_trx bt
+    INNER JOIN banks b ON LOWER(bt.Bank) = b.bank_name
+    WHERE bt.Amount > 0
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT EXISTS (SELECT 1 FROM gateway_payout_map gpm WHERE gpm.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT bt.IsMissingFXRate
+        AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'BANK' AND (rvm.DebitID = bt.UniqueIdentifier OR rvm.CreditID = bt.UniqueIdentifier))
+), combination AS (
+    -- every set of not matched bank trx of one bank and type in the settlement window of a system trx, built in amount
+    -- then UniqueIdentifier order. A branch stops once its sum is over the system amount or its parts left can no longer
+    -- reach it, a system trx stating its account only takes bank trx of that account
+    SELECT
+        ost.TrxID
+        , ost.Amount AS TargetAmount
+        , ost.Date AS SystemDate
+        , ost.Account AS SystemAccount
+        , obt.Bank
+        , obt.Type
+        , obt.DaysBefore
+        , obt.DaysAfter
+        , obt.MaxAmount
+        , obt.Amount AS LastAmount
+        , obt.UniqueIdentifier AS LastUniqueIdentifier
+        , json_array(obt.UniqueIdentifier) AS UniqueIdentifiers
+        , obt.Amount AS SumAmount
+        , obt.Date AS MinDate
+        , obt.Date AS MaxDate
+        , 1 AS Parts
+    FROM main_data md
+    INNER JOIN open_system_trx ost
+    INNER JOIN open_bank_trx obt ON
+        obt.Type = ost.Type
+        AND (ost.Account IS NULL OR ost.Account = obt.Account)
+        AND obt.Date >= DATE(ost.Date, '-' || obt.DaysBefore || ' days')
+        AND obt.Date <= DATE(ost.Date, '+' || obt.DaysAfter || ' days')
+    WHERE obt.Amount < ost.Amount
+        AND obt.Amount + (md.MaxParts - 1) * obt.MaxAmount >= ost.Amount
+    UNION ALL
+    SELECT
+        c.TrxID
+        , c.TargetAmount
+        , c.SystemDate
+        , c.SystemAccount
+        , c.Bank
+        , c.Type
+        , c.DaysBefore
+        , c.DaysAfter
+        , c.MaxAmount
+        , obt.Amount
+        , obt.UniqueIdentifier
+        , json_insert(c.UniqueIdentifiers, '$[#]', obt.UniqueIdentifier)
+        , c.SumAmount + obt.Amount
+        , MIN(c.MinDate, obt.Date)
+        , MAX(c.MaxDate, obt.Date)
+        , c.Parts + 1
+    FROM main_data md
+    INNER JOIN combination c
+    INNER JOIN open_bank_trx obt ON
+        obt.Bank = c.Bank
+        AND obt.Type = c.Type
+        AND (c.SystemAccount IS NULL OR c.SystemAccount = obt.Account)
+        AND obt.Date >= DATE(c.SystemDate, '-' || c.DaysBefore || ' days')
+        AND obt.Date <= DATE(c.SystemDate, '+' || c.DaysAfter || ' days')
+        AND (obt.Amount, obt.UniqueIdentifier) > (c.LastAmount, c.LastUniqueIdentifier)
+    WHERE c.Parts < md.MaxParts
+        AND c.SumAmount + obt.Amount <= c.TargetAmount
+        AND c.SumAmount + obt.Amount + (md.MaxParts - c.Parts - 1) * c.MaxAmount >= c.TargetAmount
+), candidate AS (
+    SELECT
+        TrxID
+        , UniqueIdentifiers
+        , Parts
+        , MAX(JULIANDAY(SystemDate) - JULIANDAY(MinDate), 0) AS DaysEarly
+        , MAX(JULIANDAY(MaxDate) - JULIANDAY(SystemDate), 0) AS DaysLate
+        , DaysBefore
+        , DaysAfter
+        , COUNT(*) OVER (PARTITION BY TrxID) AS TrxCandidates
+    FROM combination
+    WHERE Parts > 1
+        AND SumAmount = TargetAmount
+)
+SELECT
+    TrxID AS TrxID
+    , UniqueIdentifiers AS UniqueIdentifiers
+    , Parts AS Parts
+    , CAST(MAX(DaysEarly, DaysLate) AS INTEGER) AS DateDistance
+    -- other sets of the same system trx divide the confidence and the date gap takes up to a quarter,
+    -- sets competing for the same bank trx are accounted when the candidates are picked
+    , ROUND(
+        1.0 / TrxCandidates
+        * (
+            1
+            - 0.25 * MIN(
+                1.0
+                , MAX(DaysEarly / MAX(DaysBefore, 1), DaysLate / MAX(DaysAfter, 1))
+            )
+        )
+        , 4
+    ) AS Confidence
+FROM candidate
+-- fewest parts then closest date first
+ORDER BY Parts, DateDistance, TrxID, UniqueIdentifiers
+;
+`
+	QueryInsertTableReconciliationSplitMap = `
+-- QueryInsertTableReconciliationSplitMap
+INSERT OR IGNORE INTO reconciliation_split_map (UniqueIdentifier, TrxID, DateDifference, MatchRule, Confidence)
+	SELECT
+	bt.UniqueIdentifier AS UniqueIdentifier
+	 , st.TrxID AS TrxID
+	 , CAST(JULIANDAY(DATE(bt.Date)) - JULIANDAY(DATE(st.TransactionTime)) AS INTEGER) AS DateDifference
+	 , json_extract(j.value, '$.MatchRule') AS MatchRule
+	 , json_extract(j.value, '$.Confidence') AS Confidence
+	FROM json_each(
+	 ?
+	) AS j
+	INNER JOIN system_trx st ON st.TrxID = json_extract(j.value, '$.TrxID')
+	INNER JOIN bank_trx bt ON bt.UniqueIdentifier = json_extract(j.value, '$.UniqueIdentifier')
+;
 `
 	QueryGetReconciliationSummary = `
 -- QueryGetReconciliationSummary
+-- reversed trx are processed but neither matched nor not matched
 SELECT
     COALESCE(main_data.total_system_trx, 0) AS total_system_trx
     , COALESCE(main_data.total_matched_trx, 0) AS total_matched_trx
-    , COALESCE((main_data.total_system_trx - main_data.total_matched_trx), 0) AS total_not_matched_trx
+    , COALESCE((main_data.total_system_trx - main_data.total_matched_trx - main_data.total_reversal_trx), 0) AS total_not_matched_trx
     , COALESCE(main_data.sum_system_trx, 0) AS sum_system_trx
     , COALESCE(main_data.sum_matched_trx, 0) AS sum_matched_trx
-    , COALESCE((main_data.sum_system_trx - main_data.sum_matched_trx), 0) AS sum_discrepancies_trx
+    , COALESCE((main_data.sum_system_trx - main_data.sum_matched_trx - main_data.sum_reversal_trx), 0) AS sum_not_matched_trx
+    , COALESCE(main_data.sum_discrepancies_trx, 0) AS sum_discrepancies_trx
+    , COALESCE(main_data.total_aggregate_matched_trx, 0) AS total_aggregate_matched_trx
+    , COALESCE(main_data.total_aggregate_matched_bank_trx, 0) AS total_aggregate_matched_bank_trx
+    , COALESCE(main_data.total_split_matched_trx, 0) AS total_split_matched_trx
+    , COALESCE(main_data.total_split_matched_bank_trx, 0) AS total_split_matched_bank_trx
+    , COALESCE(main_data.total_gateway_matched_trx, 0) AS total_gateway_matched_trx
+    , COALESCE(main_data.total_gateway_matched_bank_trx, 0) AS total_gateway_matched_bank_trx
 FROM (
     SELECT
         COUNT(*) AS total_system_trx
@@ -162,56 +1249,491 @@ FROM (
             ELSE 0
         END
         ) AS sum_matched_trx
+        , SUM(ABS(COALESCE(rm.AmountDifference, 0))) AS sum_discrepancies_trx
+        , SUM(
+            CASE
+                WHEN rvm.TrxID IS NOT NULL then 1
+                ELSE 0
+            END
+        ) AS total_reversal_trx
+        , SUM(
+            CASE
+                WHEN rvm.TrxID IS NOT NULL then st.Amount
+                ELSE 0
+            END
+        ) AS sum_reversal_trx
+        , SUM(
+            CASE
+                WHEN rm.MatchType = 'MANY_TO_ONE' then 1
+                ELSE 0
+            END
+        ) AS total_aggregate_matched_trx
+        , COUNT(
+            DISTINCT CASE
+                WHEN rm.MatchType = 'MANY_TO_ONE' then rm.UniqueIdentifier
+            END
+        ) AS total_aggregate_matched_bank_trx
+        , SUM(
+            CASE
+                WHEN rm.MatchType = 'ONE_TO_MANY' then 1
+                ELSE 0
+            END
+        ) AS total_split_matched_trx
+        , SUM(
+            CASE
+                WHEN rm.MatchType = 'ONE_TO_MANY' then rm.Parts
+                ELSE 0
+            END
+        ) AS total_split_matched_bank_trx
+        , SUM(
+            CASE
+                WHEN rm.MatchType = 'GATEWAY' then 1
+                ELSE 0
+            END
+        ) AS total_gateway_matched_trx
+        , COUNT(
+            DISTINCT CASE
+                WHEN rm.MatchType = 'GATEWAY' then rm.UniqueIdentifier
+            END
+        ) AS total_gateway_matched_bank_trx
     FROM system_trx st
-    LEFT JOIN reconciliation_map rm ON rm.TrxID = st.TrxID
+    LEFT JOIN (
+        -- one row per system trx, a split match has many bank trx
+        SELECT
+            TrxID
+            , MatchType
+            , MIN(UniqueIdentifier) AS UniqueIdentifier
+            , SUM(AmountDifference) AS AmountDifference
+            , COUNT(*) AS Parts
+        FROM reconciliation_match
+        GROUP BY TrxID
+    ) rm ON rm.TrxID = st.TrxID
+    LEFT JOIN (
+        SELECT DebitID AS TrxID FROM reversal_map WHERE Source = 'SYSTEM'
+        UNION ALL
+        SELECT CreditID AS TrxID FROM reversal_map WHERE Source = 'SYSTEM'
+    ) rvm ON rvm.TrxID = st.TrxID
 ) main_data
 ;
 `
 
 	QueryGetMatchedTrx = `
 -- QueryGetMatchedTrx
+-- one row per system trx, bank trx of a split match are joined into one logical bank trx settled at the latest date
 SELECT
     st.TrxID AS SystemTrxTrxID,
-    bt.UniqueIdentifier AS BankTrxUniqueIdentifier,
+    GROUP_CONCAT(bt.UniqueIdentifier, ';' ORDER BY bt.UniqueIdentifier) AS BankTrxUniqueIdentifier,
     STRFTIME('%F %T', st.TransactionTime) AS SystemTrxTransactionTime,
-    DATE(bt.Date) AS BankTrxDate,
+    DATE(MAX(bt.Date)) AS BankTrxDate,
     st.Type AS SystemTrxType,
     st.Amount AS SystemTrxAmount,
-    CASE
-        WHEN bt.Type == 'DEBIT' THEN bt.Amount * (-1)
-        ELSE bt.Amount
-    END AS BankTrxAmount,
-    bt.Bank
-FROM reconciliation_map rm
+    SUM(
+        CASE
+            WHEN bt.Type == 'DEBIT' THEN bt.Amount * (-1)
+            ELSE bt.Amount
+        END
+    ) AS BankTrxAmount,
+    SUM(rm.AmountDifference) AS AmountDifference,
+    SUM(rm.Fee) AS Fee,
+    MAX(rm.DateDifference) AS DateDifference,
+    rm.MatchType AS MatchType,
+    MAX(rm.GroupSize) AS GroupSize,
+    rm.MatchRule AS MatchRule,
+    MIN(rm.Confidence) AS Confidence,
+    bt.Bank,
+    COALESCE(bt.Account, '') AS Account,
+    st.Currency AS SystemTrxCurrency,
+    st.OriginalAmount AS SystemTrxOriginalAmount,
+    bt.Currency AS BankTrxCurrency,
+    SUM(
+        CASE
+            WHEN bt.Type == 'DEBIT' THEN bt.OriginalAmount * (-1)
+            ELSE bt.OriginalAmount
+        END
+    ) AS BankTrxOriginalAmount
+FROM (
+    SELECT
+        TrxID
+        , UniqueIdentifier
+        , AmountDifference
+        , Fee
+        , DateDifference
+        , MatchType
+        , MatchRule
+        , Confidence
+        , MAX(COUNT(*) OVER (PARTITION BY TrxID), COUNT(*) OVER (PARTITION BY UniqueIdentifier)) AS GroupSize
+    FROM reconciliation_match
+) rm
 INNER JOIN system_trx st on rm.TrxID = st.TrxID
 INNER JOIN bank_trx bt on rm.UniqueIdentifier = bt.UniqueIdentifier
+GROUP BY st.TrxID
 ;
 `
 
 	QueryGetNotMatchedSystemTrx = `
 -- QueryGetNotMatchedSystemTrx
+-- Reason is the first of: an exact bank trx within the settlement window was matched to another trx, one within the
+-- window has the other type, one of the same type is outside the window, one within the window of the same type has
+-- another amount, or there is no bank trx of the same type within the window at all. An exact bank trx states the system
+-- amount less the fee of its bank, a debit takes the fee on top. A system trx paid through a gateway line whose payout
+-- the bank never stated is PAYOUT_NOT_MATCHED before all of these, and one without fx rate is MISSING_FX_RATE before
+-- that. A system trx stating its account only looks at bank trx of that account. Reversed trx are left out of both sides
 SELECT st.TrxID                              AS TrxID,
        STRFTIME('%F %T', st.TransactionTime) AS TransactionTime,
        st.Type                               AS Type,
-       st.Amount                             AS Amount
+       st.Amount                             AS Amount,
+       st.Currency                           AS Currency,
+       st.OriginalAmount                     AS OriginalAmount,
+       COALESCE(st.Account, '')              AS Account,
+       CASE
+           WHEN st.IsMissingFXRate THEN 'MISSING_FX_RATE'
+           WHEN EXISTS (SELECT 1 FROM gateway_map gm WHERE gm.TrxID = st.TrxID) THEN 'PAYOUT_NOT_MATCHED'
+           WHEN EXISTS (
+               SELECT 1
+               FROM banks b
+               LEFT JOIN bank_fee bf ON
+                   bf.bank_name = b.bank_name
+                   AND bf.min_amount <= st.Amount
+                   AND bf.max_amount > st.Amount
+               CROSS JOIN bank_trx bt ON
+                   LOWER(bt.Bank) = b.bank_name
+                   AND (st.Account IS NULL OR st.Account = bt.Account)
+                   AND bt.Type = st.Type
+                   AND bt.Amount = st.Amount + CASE WHEN st.Type = 'DEBIT' THEN 1 ELSE -1 END * COALESCE(CAST(ROUND(bf.flat + st.Amount * bf.percentage / 100) AS INTEGER), 0)
+                   AND bt.Date >= STRFTIME('%FT%TZ', DATE(st.TransactionTime, '-' || b.settlement_days_before || ' days'))
+                   AND bt.Date < STRFTIME('%FT%TZ', DATE(st.TransactionTime, '+' || (b.settlement_days_after + 1) || ' days'))
+               WHERE EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.UniqueIdentifier = bt.UniqueIdentifier)
+                   OR EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.UniqueIdentifier = bt.UniqueIdentifier)
+                   OR EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.UniqueIdentifier = bt.UniqueIdentifier)
+                   OR EXISTS (SELECT 1 FROM gateway_payout_map gpm WHERE gpm.UniqueIdentifier = bt.UniqueIdentifier)
+           ) THEN 'CANDIDATE_CONSUMED'
+           WHEN EXISTS (
+               SELECT 1
+               FROM banks b
+               INNER JOIN bank_trx bt ON
+                   LOWER(bt.Bank) = b.bank_name
+                   AND (st.Account IS NULL OR st.Account = bt.Account)
+                   AND bt.Type = CASE st.Type WHEN 'DEBIT' THEN 'CREDIT' ELSE 'DEBIT' END
+                   AND bt.Amount = st.Amount
+                   AND bt.Date >= STRFTIME('%FT%TZ', DATE(st.TransactionTime, '-' || b.settlement_days_before || ' days'))
+                   AND bt.Date < STRFTIME('%FT%TZ', DATE(st.TransactionTime, '+' || (b.settlement_days_after + 1) || ' days'))
+               WHERE NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'BANK' AND (rvm.DebitID = bt.UniqueIdentifier OR rvm.CreditID = bt.UniqueIdentifier))
+           ) THEN 'TYPE_MISMATCH'
+           WHEN EXISTS (
+               SELECT 1
+               FROM banks b
+               LEFT JOIN bank_fee bf ON
+                   bf.bank_name = b.bank_name
+                   AND bf.min_amount <= st.Amount
+                   AND bf.max_amount > st.Amount
+               CROSS JOIN bank_trx bt ON
+                   LOWER(bt.Bank) = b.bank_name
+                   AND (st.Account IS NULL OR st.Account = bt.Account)
+                   AND bt.Type = st.Type
+                   AND bt.Amount = st.Amount + CASE WHEN st.Type = 'DEBIT' THEN 1 ELSE -1 END * COALESCE(CAST(ROUND(bf.flat + st.Amount * bf.percentage / 100) AS INTEGER), 0)
+               WHERE NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'BANK' AND (rvm.DebitID = bt.UniqueIdentifier OR rvm.CreditID = bt.UniqueIdentifier))
+           ) THEN 'OUTSIDE_SETTLEMENT_WINDOW'
+           WHEN EXISTS (
+               SELECT 1
+               FROM banks b
+               INNER JOIN bank_trx bt ON
+                   LOWER(bt.Bank) = b.bank_name
+                   AND (st.Account IS NULL OR st.Account = bt.Account)
+                   AND bt.Type = st.Type
+                   AND bt.Date >= STRFTIME('%FT%TZ', DATE(st.TransactionTime, '-' || b.settlement_days_before || ' days'))
+                   AND bt.Date < STRFTIME('%FT%TZ', DATE(st.TransactionTime, '+' || (b.settlement_days_after + 1) || ' days'))
+               WHERE NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'BANK' AND (rvm.DebitID = bt.UniqueIdentifier OR rvm.CreditID = bt.UniqueIdentifier))
+           ) THEN 'AMOUNT_MISMATCH'
+           ELSE 'NO_TRX_ON_DATE'
+       END                                   AS Reason
 FROM system_trx st
-LEFT JOIN reconciliation_map rm on rm.TrxID = st.TrxID
+LEFT JOIN reconciliation_match rm on rm.TrxID = st.TrxID
 WHERE rm.TrxID IS NULL
+    AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'SYSTEM' AND (rvm.DebitID = st.TrxID OR rvm.CreditID = st.TrxID))
 ;
 `
 	QueryGetNotMatchedBankTrx = `
 -- QueryGetNotMatchedBankTrx
+-- Reason is the same as QueryGetNotMatchedSystemTrx looking for system trx, the window is the bank settlement window
+-- seen from the bank side, the system amounts a fee tier could bring to the bank amount are looked up per tier first.
+-- A bank trx without fx rate is MISSING_FX_RATE before all of these
 SELECT
     bt.UniqueIdentifier AS UniqueIdentifier,
     bt.Bank AS Bank,
+    COALESCE(bt.Account, '') AS Account,
     STRFTIME('%F', bt.Date) AS Date,
     CASE
         WHEN bt.Type == 'DEBIT' THEN bt.Amount * (-1)
         ELSE bt.Amount
-    END AS Amount
+    END AS Amount,
+    bt.Currency AS Currency,
+    CASE
+        WHEN bt.Type == 'DEBIT' THEN bt.OriginalAmount * (-1)
+        ELSE bt.OriginalAmount
+    END AS OriginalAmount,
+    CASE
+        WHEN bt.IsMissingFXRate THEN 'MISSING_FX_RATE'
+        WHEN EXISTS (
+            SELECT 1
+            FROM bank_fee bf
+            CROSS JOIN system_trx st ON
+                st.Type = bt.Type
+                AND (st.Account IS NULL OR st.Account = bt.Account)
+                AND st.Amount >= CASE
+                    WHEN bt.Type = 'DEBIT' THEN (bt.Amount - bf.flat - 0.5) * 100.0 / (100 + bf.percentage)
+                    ELSE (bt.Amount + bf.flat - 0.5) * 100.0 / (100 - bf.percentage)
+                END
+                AND st.Amount <= CASE
+                    WHEN bt.Type = 'DEBIT' THEN (bt.Amount - bf.flat + 0.5) * 100.0 / (100 + bf.percentage)
+                    ELSE (bt.Amount + bf.flat + 0.5) * 100.0 / (100 - bf.percentage)
+                END
+            WHERE bf.bank_name = b.bank_name
+                -- unary + keeps the tier bounds out of the index lookup, the range above is much narrower
+                AND +st.Amount >= bf.min_amount
+                AND +st.Amount < bf.max_amount
+                AND st.Amount + CASE WHEN st.Type = 'DEBIT' THEN 1 ELSE -1 END * CAST(ROUND(bf.flat + st.Amount * bf.percentage / 100) AS INTEGER) = bt.Amount
+                AND st.TransactionTime >= STRFTIME('%FT%TZ', DATE(bt.Date, '-' || COALESCE(b.settlement_days_after, 0) || ' days'))
+                AND st.TransactionTime < STRFTIME('%FT%TZ', DATE(bt.Date, '+' || (COALESCE(b.settlement_days_before, 0) + 1) || ' days'))
+                AND (
+                    EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.TrxID = st.TrxID)
+                    OR EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.TrxID = st.TrxID)
+                    OR EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.TrxID = st.TrxID)
+                    OR EXISTS (SELECT 1 FROM gateway_map gm WHERE gm.TrxID = st.TrxID)
+                )
+        ) THEN 'CANDIDATE_CONSUMED'
+        WHEN EXISTS (
+            SELECT 1
+            FROM system_trx st
+            WHERE st.Type = CASE bt.Type WHEN 'DEBIT' THEN 'CREDIT' ELSE 'DEBIT' END
+                AND (st.Account IS NULL OR st.Account = bt.Account)
+                AND st.Amount = bt.Amount
+                AND st.TransactionTime >= STRFTIME('%FT%TZ', DATE(bt.Date, '-' || COALESCE(b.settlement_days_after, 0) || ' days'))
+                AND st.TransactionTime < STRFTIME('%FT%TZ', DATE(bt.Date, '+' || (COALESCE(b.settlement_days_before, 0) + 1) || ' days'))
+                AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'SYSTEM' AND (rvm.DebitID = st.TrxID OR rvm.CreditID = st.TrxID))
+        ) THEN 'TYPE_MISMATCH'
+        WHEN EXISTS (
+            SELECT 1
+            FROM bank_fee bf
+            CROSS JOIN system_trx st ON
+                st.Type = bt.Type
+                AND (st.Account IS NULL OR st.Account = bt.Account)
+                AND st.Amount >= CASE
+                    WHEN bt.Type = 'DEBIT' THEN (bt.Amount - bf.flat - 0.5) * 100.0 / (100 + bf.percentage)
+                    ELSE (bt.Amount + bf.flat - 0.5) * 100.0 / (100 - bf.percentage)
+                END
+                AND st.Amount <= CASE
+                    WHEN bt.Type = 'DEBIT' THEN (bt.Amount - bf.flat + 0.5) * 100.0 / (100 + bf.percentage)
+                    ELSE (bt.Amount + bf.flat + 0.5) * 100.0 / (100 - bf.percentage)
+                END
+            WHERE bf.bank_name = b.bank_name
+                AND +st.Amount >= bf.min_amount
+                AND +st.Amount < bf.max_amount
+                AND st.Amount + CASE WHEN st.Type = 'DEBIT' THEN 1 ELSE -1 END * CAST(ROUND(bf.flat + st.Amount * bf.percentage / 100) AS INTEGER) = bt.Amount
+                AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'SYSTEM' AND (rvm.DebitID = st.TrxID OR rvm.CreditID = st.TrxID))
+        ) THEN 'OUTSIDE_SETTLEMENT_WINDOW'
+        WHEN EXISTS (
+            SELECT 1
+            FROM system_trx st
+            WHERE st.Type = bt.Type
+                AND (st.Account IS NULL OR st.Account = bt.Account)
+                AND st.TransactionTime >= STRFTIME('%FT%TZ', DATE(bt.Date, '-' || COALESCE(b.settlement_days_after, 0) || ' days'))
+                AND st.TransactionTime < STRFTIME('%FT%TZ', DATE(bt.Date, '+' || (COALESCE(b.settlement_days_before, 0) + 1) || ' days'))
+                AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'SYSTEM' AND (rvm.DebitID = st.TrxID OR rvm.CreditID = st.TrxID))
+        ) THEN 'AMOUNT_MISMATCH'
+        ELSE 'NO_TRX_ON_DATE'
+    END AS Reason
 FROM bank_trx bt
-LEFT JOIN reconciliation_map rm on rm.UniqueIdentifier = bt.UniqueIdentifier
+INNER JOIN arguments a
+LEFT JOIN banks b ON LOWER(bt.Bank) = b.bank_name
+LEFT JOIN reconciliation_match rm on rm.UniqueIdentifier = bt.UniqueIdentifier
 WHERE rm.UniqueIdentifier IS NULL
+    -- a paid gateway payout is a break of the gateway leg when none of its lines matched
+    AND NOT EXISTS (SELECT 1 FROM gateway_payout_map gpm WHERE gpm.UniqueIdentifier = bt.UniqueIdentifier)
+    AND NOT EXISTS (SELECT 1 FROM transfer_map tm WHERE tm.DebitID = bt.UniqueIdentifier OR tm.CreditID = bt.UniqueIdentifier)
+    AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'BANK' AND (rvm.DebitID = bt.UniqueIdentifier OR rvm.CreditID = bt.UniqueIdentifier))
+    -- statement lines outside the period only load to settle trx inside the period
+    AND DATE(bt.Date) BETWEEN DATE(a.start) AND DATE(a.end)
+;
+`
+	QueryGetNotMatchedGatewayTrx = `
+-- QueryGetNotMatchedGatewayTrx
+-- gateway lines within the period not linked to any system trx, the break of the first leg
+SELECT
+    gt.UniqueIdentifier AS UniqueIdentifier,
+    gt.Gateway AS Gateway,
+    STRFTIME('%F %T', gt.Date) AS Date,
+    CASE
+        WHEN gt.Type == 'DEBIT' THEN gt.Amount * (-1)
+        ELSE gt.Amount
+    END AS Amount,
+    gt.Fee AS Fee,
+    COALESCE(gt.Reference, '') AS Reference,
+    COALESCE(gt.PayoutID, '') AS PayoutID
+FROM gateway_trx gt
+INNER JOIN arguments a
+WHERE NOT EXISTS (SELECT 1 FROM gateway_map gm WHERE gm.GatewayUniqueIdentifier = gt.UniqueIdentifier)
+    AND DATE(gt.Date) BETWEEN DATE(a.start) AND DATE(a.end)
+;
+`
+	QueryGetNotMatchedGatewayPayout = `
+-- QueryGetNotMatchedGatewayPayout
+-- gateway payouts within the period no bank trx pays, the break of the second leg
+SELECT
+    gp.Gateway AS Gateway,
+    gp.PayoutID AS PayoutID,
+    gp.PayoutDate AS PayoutDate,
+    gp.TotalTrx AS TotalTrx,
+    gp.GrossAmount AS GrossAmount,
+    gp.Fee AS Fee,
+    gp.Amount AS Amount
+FROM gateway_payout gp
+INNER JOIN arguments a
+WHERE NOT EXISTS (
+        SELECT 1
+        FROM gateway_payout_map gpm
+        WHERE gpm.Gateway = gp.Gateway
+            AND gpm.PayoutID = gp.PayoutID
+    )
+    AND gp.PayoutDate BETWEEN DATE(a.start) AND DATE(a.end)
+;
+`
+	QueryGetNotMatchedSystemTrxSuggestion = `
+-- QueryGetNotMatchedSystemTrxSuggestion
+-- not matched bank trx of the same type near a not matched system trx, Distance adds up the date and amount gaps each
+-- relative to its search limit so 0 is an exact match and 2 is at both limits, the closest ones first
+WITH main_data AS (
+    SELECT
+        CAST(? AS INTEGER) AS MaxCount
+        , CAST(? AS INTEGER) AS Days
+        , CAST(? AS FLOAT) AS AmountPercentage
+), open_system_trx AS MATERIALIZED (
+    SELECT
+        st.TrxID
+        , st.Type
+        , st.Amount
+        , DATE(st.TransactionTime) AS Date
+        , st.Amount - st.Amount * md.AmountPercentage / 100 AS MinAmount
+        , st.Amount + st.Amount * md.AmountPercentage / 100 AS MaxAmount
+        , DATE(st.TransactionTime, '-' || md.Days || ' days') AS MinDate
+        , DATE(st.TransactionTime, '+' || md.Days || ' days') AS MaxDate
+    FROM main_data md
+    INNER JOIN system_trx st
+    WHERE md.MaxCount > 0
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.TrxID = st.TrxID)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.TrxID = st.TrxID)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.TrxID = st.TrxID)
+        AND NOT EXISTS (SELECT 1 FROM gateway_map gm WHERE gm.TrxID = st.TrxID)
+        AND NOT st.IsMissingFXRate
+        AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'SYSTEM' AND (rvm.DebitID = st.TrxID OR rvm.CreditID = st.TrxID))
+), open_bank_trx AS MATERIALIZED (
+    SELECT
+        bt.UniqueIdentifier
+        , bt.Type
+        , bt.Amount
+        , DATE(bt.Date) AS Date
+    FROM main_data md
+    INNER JOIN bank_trx bt
+    WHERE md.MaxCount > 0
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_map rm WHERE rm.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_aggregate_map ram WHERE ram.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT EXISTS (SELECT 1 FROM reconciliation_split_map rsm WHERE rsm.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT EXISTS (SELECT 1 FROM gateway_payout_map gpm WHERE gpm.UniqueIdentifier = bt.UniqueIdentifier)
+        AND NOT bt.IsMissingFXRate
+        AND NOT EXISTS (SELECT 1 FROM reversal_map rvm WHERE rvm.Source = 'BANK' AND (rvm.DebitID = bt.UniqueIdentifier OR rvm.CreditID = bt.UniqueIdentifier))
+), candidate AS (
+    SELECT
+        ost.TrxID
+        , obt.UniqueIdentifier
+        , ost.Amount AS SystemAmount
+        , obt.Amount - ost.Amount AS AmountDifference
+        , CAST(JULIANDAY(obt.Date) - JULIANDAY(ost.Date) AS INTEGER) AS DateDifference
+        , md.MaxCount
+        , md.Days
+        , md.AmountPercentage
+    FROM main_data md
+    INNER JOIN open_system_trx ost
+    INNER JOIN open_bank_trx obt ON
+        obt.Type = ost.Type
+        AND obt.Amount BETWEEN ost.MinAmount AND ost.MaxAmount
+        AND obt.Date BETWEEN ost.MinDate AND ost.MaxDate
+), ranked_candidate AS (
+    SELECT
+        c.*
+        , ROUND(
+            ABS(c.DateDifference) * 1.0 / MAX(c.Days, 1)
+            + CASE
+                WHEN c.AmountPercentage > 0 THEN ABS(c.AmountDifference) * 100.0 / c.SystemAmount / c.AmountPercentage
+                ELSE 0
+            END
+            , 4
+        ) AS Distance
+    FROM candidate c
+), numbered_candidate AS (
+    SELECT
+        rc.*
+        , ROW_NUMBER() OVER (PARTITION BY rc.TrxID ORDER BY rc.Distance, rc.UniqueIdentifier) AS Rank
+    FROM ranked_candidate rc
+)
+SELECT
+    nc.TrxID AS TrxID
+    , nc.Rank AS Rank
+    , bt.UniqueIdentifier AS BankTrxUniqueIdentifier
+    , bt.Bank AS Bank
+    , STRFTIME('%F', bt.Date) AS BankTrxDate
+    , CASE
+        WHEN bt.Type == 'DEBIT' THEN bt.Amount * (-1)
+        ELSE bt.Amount
+    END AS BankTrxAmount
+    , bt.Currency AS BankTrxCurrency
+    , CASE
+        WHEN bt.Type == 'DEBIT' THEN bt.OriginalAmount * (-1)
+        ELSE bt.OriginalAmount
+    END AS BankTrxOriginalAmount
+    , nc.AmountDifference AS AmountDifference
+    , ROUND(nc.AmountDifference * 100.0 / nc.SystemAmount, 4) AS AmountDifferencePercentage
+    , nc.DateDifference AS DateDifference
+    , nc.Distance AS Distance
+FROM numbered_candidate nc
+INNER JOIN bank_trx bt ON bt.UniqueIdentifier = nc.UniqueIdentifier
+WHERE nc.Rank <= nc.MaxCount
+ORDER BY nc.TrxID, nc.Rank
+;
+`
+	QueryGetReversalTrx = `
+-- QueryGetReversalTrx
+SELECT
+    Source
+    , Bank
+    , DebitID
+    , DebitDate
+    , CreditID
+    , CreditDate
+    , Reference
+    , Amount
+    , Currency
+    , OriginalAmount
+    , DateDifference
+FROM reversal_map
+ORDER BY Source DESC, Bank, DebitDate, DebitID
+;
+`
+	QueryGetTransferTrx = `
+-- QueryGetTransferTrx
+SELECT
+    DebitID
+    , DebitBank
+    , DebitAccount
+    , DebitDate
+    , CreditID
+    , CreditBank
+    , CreditAccount
+    , CreditDate
+    , Amount
+    , Currency
+    , OriginalAmount
+    , DateDifference
+FROM transfer_map
+ORDER BY DebitDate, DebitBank, DebitID
 ;
 `
 )
@@ -1,18 +1,108 @@
 package process
 
+import "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+
+// FilePathBankTrx Account is the account of the statement taken from its path, statement lines stating their own
+// account keep it
 type FilePathBankTrx struct {
 	Bank     string
+	Account  string
+	FilePath string
+}
+
+type FilePathGatewayTrx struct {
+	Gateway  string
 	FilePath string
 }
 
 type ReconciliationSummary struct {
-	FileMissingBankTrx              map[string]string `deepcopier:"skip"`
-	FileMissingSystemTrx            string            `deepcopier:"skip"`
-	FileMatchedSystemTrx            string            `deepcopier:"skip"`
-	TotalProcessedSystemTrx         int64             `deepcopier:"field:TotalSystemTrx"`
-	TotalMatchedSystemTrx           int64             `deepcopier:"field:TotalMatchedTrx"`
-	TotalNotMatchedSystemTrx        int64             `deepcopier:"field:TotalNotMatchedTrx"`
-	SumAmountProcessedSystemTrx     float64           `deepcopier:"field:SumSystemTrx"`
-	SumAmountMatchedSystemTrx       float64           `deepcopier:"field:SumMatchedTrx"`
-	SumAmountDiscrepanciesSystemTrx float64           `deepcopier:"field:SumDiscrepanciesTrx"`
+	FileMissingBankTrx               map[string]string       `deepcopier:"skip"`
+	CoverageGapByBank                map[string][]string     `deepcopier:"skip"`
+	SumFeeByBank                     map[string]money.Amount `deepcopier:"skip"`
+	TotalNotMatchedSystemTrxByReason map[string]int          `deepcopier:"skip"`
+	TotalNotMatchedBankTrxByReason   map[string]int          `deepcopier:"skip"`
+	FileMissingSystemTrx             string                  `deepcopier:"skip"`
+	FileMatchedSystemTrx             string                  `deepcopier:"skip"`
+	FileReviewSystemTrx              string                  `deepcopier:"skip"`
+	FileSuggestionSystemTrx          string                  `deepcopier:"skip"`
+	FileDuplicateTrx                 string                  `deepcopier:"skip"`
+	FileReversalTrx                  string                  `deepcopier:"skip"`
+	FileTransferTrx                  string                  `deepcopier:"skip"`
+	FileMissingGatewayTrx            string                  `deepcopier:"skip"`
+	FileMissingGatewayPayout         string                  `deepcopier:"skip"`
+	FileBalanceCheck                 string                  `deepcopier:"skip"`
+	FileCoverage                     string                  `deepcopier:"skip"`
+	TotalProcessedSystemTrx          int64                   `deepcopier:"field:TotalSystemTrx"`
+	TotalMatchedSystemTrx            int64                   `deepcopier:"field:TotalMatchedTrx"`
+	TotalNotMatchedSystemTrx         int64                   `deepcopier:"field:TotalNotMatchedTrx"`
+	SumAmountProcessedSystemTrx      money.Amount            `deepcopier:"field:SumSystemTrx"`
+	SumAmountMatchedSystemTrx        money.Amount            `deepcopier:"field:SumMatchedTrx"`
+	SumAmountNotMatchedSystemTrx     money.Amount            `deepcopier:"field:SumNotMatchedTrx"`
+	SumAmountDiscrepanciesSystemTrx  money.Amount            `deepcopier:"field:SumDiscrepanciesTrx"`
+	TotalAggregateMatchedSystemTrx   int64                   `deepcopier:"field:TotalAggregateMatchedTrx"`
+	TotalAggregateMatchedBankTrx     int64                   `deepcopier:"field:TotalAggregateMatchedBankTrx"`
+	TotalSplitMatchedSystemTrx       int64                   `deepcopier:"field:TotalSplitMatchedTrx"`
+	TotalSplitMatchedBankTrx         int64                   `deepcopier:"field:TotalSplitMatchedBankTrx"`
+	TotalGatewayMatchedSystemTrx     int64                   `deepcopier:"field:TotalGatewayMatchedTrx"`
+	TotalGatewayMatchedBankTrx       int64                   `deepcopier:"field:TotalGatewayMatchedBankTrx"`
+	TotalReviewSystemTrx             int64                   `deepcopier:"skip"`
+	TotalDuplicateSystemTrx          int64                   `deepcopier:"skip"`
+	TotalDuplicateBankTrx            int64                   `deepcopier:"skip"`
+	TotalRejectedDuplicateTrx        int64                   `deepcopier:"skip"`
+	// TotalReversalSystemTrx and TotalReversalBankTrx count both trx of every reversal pair
+	TotalReversalSystemTrx int64 `deepcopier:"skip"`
+	TotalReversalBankTrx   int64 `deepcopier:"skip"`
+	// TotalTransferBankTrx counts both bank trx of every internal transfer pair
+	TotalTransferBankTrx int64 `deepcopier:"skip"`
+	// TotalNotMatchedGatewayTrx is the break of the system to gateway leg,
+	// TotalNotMatchedGatewayPayout the break of the gateway payout to bank leg
+	TotalNotMatchedGatewayTrx    int64 `deepcopier:"skip"`
+	TotalNotMatchedGatewayPayout int64 `deepcopier:"skip"`
+	// TotalBalancedStatement, TotalNotBalancedStatement and TotalNoBalanceStatement count the bank statement files
+	// per account by their balance check status
+	TotalBalancedStatement    int64 `deepcopier:"skip"`
+	TotalNotBalancedStatement int64 `deepcopier:"skip"`
+	TotalNoBalanceStatement   int64 `deepcopier:"skip"`
+	// TotalCoverageGap counts the days of the date range a bank of the list states no trx on, CoverageGapByBank lists
+	// them per bank
+	TotalCoverageGap int64 `deepcopier:"skip"`
+}
+
+// DuplicateTrx is a system or bank trx of a duplicate group, Kind is EXACT_KEY (same ID) or CONTENT (same date, type,
+// currency and amount under another ID, same bank and account too for bank trx) and DuplicateOf is the ID of the first
+// trx of the group, empty for the first trx itself. Action is what the duplicate policy did with the trx, ID is the ID
+// it is loaded with
+type DuplicateTrx struct {
+	Source         string
+	Bank           string
+	Account        string
+	ID             string
+	DuplicateOf    string
+	Kind           string
+	Action         string
+	Date           string
+	Type           string
+	Amount         money.Amount
+	Currency       string
+	OriginalAmount money.Amount
+	FilePath       string
 }
+
+const (
+	DuplicateSourceSystem = "SYSTEM"
+	DuplicateSourceBank   = "BANK"
+)
+
+// BankCoverage is the number of trx a bank of the list states on a day of the date range, a day without any is a GAP,
+// most likely a statement file never downloaded
+type BankCoverage struct {
+	Bank         string
+	Date         string
+	Status       string
+	TotalBankTrx int
+}
+
+const (
+	CoverageStatusCovered = "COVERED"
+	CoverageStatusGap     = "GAP"
+)
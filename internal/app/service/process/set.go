@@ -5,14 +5,16 @@ import (
 	"github.com/oprekable/bank-reconcile/internal/app/component"
 	"github.com/oprekable/bank-reconcile/internal/app/repository"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
 )
 
 func ProviderSvc(
 	comp *component.Components,
 	repo *repository.Repositories,
 	parserRegistry *banks.ParserRegistry, // <-- Dependensi baru ditambahkan
+	gatewayParserRegistry *gateways.ParserRegistry,
 ) *Svc {
-	return NewSvc(comp, repo, parserRegistry) // <-- Diteruskan ke konstruktor
+	return NewSvc(comp, repo, parserRegistry, gatewayParserRegistry) // <-- Diteruskan ke konstruktor
 }
 
 var Set = wire.NewSet(
@@ -16,14 +16,16 @@ import (
 	mockprocess "github.com/oprekable/bank-reconcile/internal/app/repository/process/_mock"
 	mocksample "github.com/oprekable/bank-reconcile/internal/app/repository/sample/_mock"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
 )
 
 func TestProviderSvc(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
-		comp           *component.Components
-		repo           *repository.Repositories
-		parserRegistry *banks.ParserRegistry
+		comp                  *component.Components
+		repo                  *repository.Repositories
+		parserRegistry        *banks.ParserRegistry
+		gatewayParserRegistry *gateways.ParserRegistry
 	}
 
 	tests := []struct {
@@ -47,7 +49,8 @@ func TestProviderSvc(t *testing.T) {
 					mocksample.NewRepository(t),
 					mockprocess.NewRepository(t),
 				),
-				parserRegistry: nil, // Provide nil for the test
+				parserRegistry:        nil, // Provide nil for the test
+				gatewayParserRegistry: nil,
 			},
 			want: ProviderSvc(
 				component.NewComponents(
@@ -63,13 +66,14 @@ func TestProviderSvc(t *testing.T) {
 					mockprocess.NewRepository(t),
 				),
 				nil, // Provide nil for the test
+				nil,
 			),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := ProviderSvc(tt.args.comp, tt.args.repo, tt.args.parserRegistry); !reflect.DeepEqual(got, tt.want) {
+			if got := ProviderSvc(tt.args.comp, tt.args.repo, tt.args.parserRegistry, tt.args.gatewayParserRegistry); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("ProviderSvc() = %v, want %v", got, tt.want)
 			}
 		})
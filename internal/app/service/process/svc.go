@@ -1,10 +1,13 @@
 package process
 
 import (
+	"cmp"
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io/fs"
+	"math"
 	"path/filepath"
 	"regexp"
 	"slices"
@@ -15,10 +18,17 @@ import (
 
 	"github.com/aaronjan/hunch"
 	"github.com/oprekable/bank-reconcile/internal/app/component"
+	"github.com/oprekable/bank-reconcile/internal/app/config/reconciliation"
 	"github.com/oprekable/bank-reconcile/internal/app/repository"
 	"github.com/oprekable/bank-reconcile/internal/app/repository/process"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/assignment"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/balance"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/duplicate"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/fx"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems/default_system"
 	"github.com/oprekable/bank-reconcile/internal/pkg/utils/csvhelper"
@@ -33,10 +43,12 @@ import (
 )
 
 type Svc struct {
-	comp                 *component.Components
-	repo                 *repository.Repositories
-	parserRegistry       *banks.ParserRegistry
-	regexCompileBankName *regexp.Regexp
+	comp                        *component.Components
+	repo                        *repository.Repositories
+	parserRegistry              *banks.ParserRegistry
+	gatewayParserRegistry       *gateways.ParserRegistry
+	regexCompileBankName        *regexp.Regexp
+	regexCompileBankAccountName *regexp.Regexp
 }
 
 var _ ServiceGenerator = (*Svc)(nil)
@@ -45,12 +57,15 @@ func NewSvc(
 	comp *component.Components,
 	repo *repository.Repositories,
 	parserRegistry *banks.ParserRegistry,
+	gatewayParserRegistry *gateways.ParserRegistry,
 ) *Svc {
 	return &Svc{
-		comp:                 comp,
-		repo:                 repo,
-		parserRegistry:       parserRegistry,
-		regexCompileBankName: regexp.MustCompile(`.*[\\/]+([^\\/]+)[\\/][^\\/]+\.csv$`),
+		comp:                        comp,
+		repo:                        repo,
+		parserRegistry:              parserRegistry,
+		gatewayParserRegistry:       gatewayParserRegistry,
+		regexCompileBankName:        regexp.MustCompile(`.*[\\/]+([^\\/]+)[\\/][^\\/]+\.(?:csv|sta|mt940|xml)$`),
+		regexCompileBankAccountName: regexp.MustCompile(`.*[\\/]+([^\\/]+)[\\/]+([^\\/]+)[\\/][^\\/]+\.(?:csv|sta|mt940|xml)$`),
 	}
 }
 
@@ -75,6 +90,8 @@ func (s *Svc) parseSystemTrxFile(ctx context.Context, afs afero.Fs, filePath str
 		&default_system.CSVSystemTrxData{},
 		csv.NewReader(f),
 		true,
+		s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces,
+		s.comp.Config.Data.Reconciliation.SystemLocale.ToLocale(),
 	); err == nil {
 		returnData, err = systemParser.ToSystemTrxData(ctx, filePath)
 	}
@@ -147,30 +164,156 @@ func (s *Svc) importReconcileSystemDataToDB(ctx context.Context, data []*systems
 	return
 }
 
-func (s *Svc) importReconcileMapToDB(ctx context.Context, min float64, max float64) (err error) {
-	max = max + 1
-	numberWorker := float64(s.comp.Config.Data.Reconciliation.NumberWorker * 2)
-	defSize := max / numberWorker
-	size := defSize + 1
+func (s *Svc) importReconcileMapToDB(ctx context.Context, rule reconciliation.MatchRule, min money.Amount, max money.Amount) (err error) {
+	matchRule := process.MatchRule{
+		Name: rule.Name,
+		Tolerance: process.AmountTolerance{
+			Absolute:     money.FromMajor(rule.AmountTolerance, s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces),
+			Percentage:   rule.AmountTolerancePercentage,
+			FXPercentage: s.comp.Config.Data.Reconciliation.FX.TolerancePercentage,
+		},
+	}
 
-	for i, idx := 0.0, min; i < numberWorker; i++ {
-		err = s.repo.RepoProcess.GenerateReconciliationMap(
-			ctx,
-			idx,
-			idx+size,
-		)
+	if rule.SettlementWindow != nil {
+		matchRule.Window = &process.SettlementWindow{
+			DaysBefore: rule.SettlementWindow.DaysBefore,
+			DaysAfter:  rule.SettlementWindow.DaysAfter,
+		}
+	}
 
-		if err != nil {
+	switch rule.Type {
+	case reconciliation.MatchRuleTypeReference:
+		return s.repo.RepoProcess.GenerateReconciliationReferenceMap(ctx, matchRule)
+	case reconciliation.MatchRuleTypeSplit:
+		return s.importReconcileSplitMapToDB(ctx, rule.SplitMaxParts, matchRule)
+	case reconciliation.MatchRuleTypeAggregate:
+		return s.repo.RepoProcess.GenerateReconciliationAggregateMap(ctx, matchRule)
+	case reconciliation.MatchRuleTypeAmountDate:
+		return s.importReconcileAmountDateMapToDB(ctx, matchRule, min, max)
+	default:
+		return fmt.Errorf("match rule %q: unknown type %q", rule.Name, rule.Type)
+	}
+}
+
+// importReconcileAmountDateMapToDB reads the candidates per system amount range, a bank trx within the tolerance of
+// system trx of two ranges is a candidate of both, so all the candidates are assigned at once
+func (s *Svc) importReconcileAmountDateMapToDB(ctx context.Context, rule process.MatchRule, min money.Amount, max money.Amount) (err error) {
+	numberWorker := money.Amount(s.comp.Config.Data.Reconciliation.NumberWorker * 2)
+	size := (max+1-min)/numberWorker + 1
+
+	var candidates []process.ReconciliationMapCandidate
+	for i, idx := money.Amount(0), min; i < numberWorker; i++ {
+		var chunk []process.ReconciliationMapCandidate
+		if chunk, err = s.repo.RepoProcess.GetReconciliationMapCandidate(ctx, idx, idx+size, rule); err != nil {
 			return
 		}
 
+		candidates = append(candidates, chunk...)
 		idx += size
 	}
 
+	if data := assignReconciliationMap(candidates, rule.Name); len(data) > 0 {
+		err = s.repo.RepoProcess.ImportReconciliationMap(ctx, data)
+	}
+
 	return
 }
 
-func (s *Svc) parseBankTrxFile(ctx context.Context, afs afero.Fs, item FilePathBankTrx) (returnData []*banks.BankTrxData, err error) {
+// assignReconciliationMap picks the one to one links matching the most system trx, among those the closest amounts
+// then the closest times
+func assignReconciliationMap(candidates []process.ReconciliationMapCandidate, matchRule string) (returnData []process.ReconciliationMatch) {
+	edges := lo.Map(candidates, func(item process.ReconciliationMapCandidate, _ int) assignment.Edge {
+		return assignment.Edge{
+			Left:      item.TrxID,
+			Right:     item.UniqueIdentifier,
+			LeftTime:  item.TrxTime,
+			RightTime: item.BankTime,
+			Cost: assignment.Cost{
+				Primary:   int64(item.AmountDifference.Abs()),
+				Secondary: item.TimeDistance,
+			},
+		}
+	})
+
+	for _, i := range assignment.Solve(edges) {
+		returnData = append(
+			returnData,
+			process.ReconciliationMatch{
+				TrxID:            candidates[i].TrxID,
+				UniqueIdentifier: candidates[i].UniqueIdentifier,
+				AmountDifference: candidates[i].AmountDifference,
+				Fee:              candidates[i].Fee,
+				DateDifference:   candidates[i].DateDifference,
+				MatchRule:        matchRule,
+				Confidence:       candidates[i].Confidence,
+			},
+		)
+	}
+
+	return
+}
+
+func (s *Svc) importReconcileSplitMapToDB(ctx context.Context, maxParts int, rule process.MatchRule) (err error) {
+	var candidates []process.SplitMatchCandidate
+	candidates, err = s.repo.RepoProcess.GetSplitMatchCandidate(ctx, maxParts, rule)
+	if err != nil || len(candidates) == 0 {
+		return
+	}
+
+	// sets sharing a bank trx compete for it, the most contested part divides the confidence
+	candidateUniqueIdentifiers := make([][]string, len(candidates))
+	uniqueIdentifierCandidates := make(map[string]int)
+	for i, candidate := range candidates {
+		if candidateUniqueIdentifiers[i], err = candidate.ListUniqueIdentifier(); err != nil {
+			return
+		}
+
+		for _, uniqueIdentifier := range candidateUniqueIdentifiers[i] {
+			uniqueIdentifierCandidates[uniqueIdentifier]++
+		}
+	}
+
+	// candidates come best first, take each one unless its system trx or one of its bank trx is already taken
+	var data []process.SplitMatch
+	takenTrxID := make(map[string]struct{})
+	takenUniqueIdentifier := make(map[string]struct{})
+	for i, candidate := range candidates {
+		if _, ok := takenTrxID[candidate.TrxID]; ok {
+			continue
+		}
+
+		uniqueIdentifiers := candidateUniqueIdentifiers[i]
+		if lo.SomeBy(uniqueIdentifiers, func(item string) bool {
+			_, ok := takenUniqueIdentifier[item]
+			return ok
+		}) {
+			continue
+		}
+
+		takenTrxID[candidate.TrxID] = struct{}{}
+		confidence := candidate.Confidence / float64(lo.Max(lo.Map(uniqueIdentifiers, func(item string, _ int) int {
+			return uniqueIdentifierCandidates[item]
+		})))
+
+		for _, uniqueIdentifier := range uniqueIdentifiers {
+			takenUniqueIdentifier[uniqueIdentifier] = struct{}{}
+			data = append(
+				data,
+				process.SplitMatch{
+					TrxID:            candidate.TrxID,
+					UniqueIdentifier: uniqueIdentifier,
+					MatchRule:        rule.Name,
+					Confidence:       math.Round(confidence*10000) / 10000,
+				},
+			)
+		}
+	}
+
+	return s.repo.RepoProcess.ImportReconciliationSplitMap(ctx, data)
+}
+
+// parseBankTrxFile reads the lines of a statement file, and the balances it states when its format states them
+func (s *Svc) parseBankTrxFile(ctx context.Context, afs afero.Fs, item FilePathBankTrx) (returnData []*banks.BankTrxData, statementBalances []banks.StatementBalance, err error) {
 	var bankParser banks.ReconcileBankData
 	var f afero.File
 	bank := strings.ToUpper(item.Bank)
@@ -189,7 +332,7 @@ func (s *Svc) parseBankTrxFile(ctx context.Context, afs afero.Fs, item FilePathB
 		},
 		func(c context.Context, _ interface{}) (r interface{}, e error) {
 			// Use the injected registry to get the correct parser
-			bankParser, e = s.parserRegistry.GetParser(bank, f, true)
+			bankParser, e = s.parserRegistry.GetParser(bank, f, true, s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces)
 			return
 		},
 	)
@@ -203,28 +346,178 @@ func (s *Svc) parseBankTrxFile(ctx context.Context, afs afero.Fs, item FilePathB
 	returnData, err = bankParser.ToBankTrxData(ctx, item.FilePath)
 	log.Err(ctx, "[process.NewSvc] parseBankTrxFile parse.ToBankTrxData ("+bank+") executed", err)
 
+	for _, data := range returnData {
+		data.Account = lo.CoalesceOrEmpty(data.Account, item.Account)
+	}
+
+	if statementBalanceData, ok := bankParser.(banks.StatementBalanceData); ok {
+		statementBalances = statementBalanceData.GetStatementBalances()
+		for index := range statementBalances {
+			statementBalances[index].Account = lo.CoalesceOrEmpty(statementBalances[index].Account, item.Account)
+		}
+	}
+
 	return
 }
 
-func (s *Svc) parseBankTrxFiles(ctx context.Context, afs afero.Fs) (returnData []*banks.BankTrxData, err error) {
+// bankTrxFilePath tells the bank and the account of a statement file, the bank is the folder of the file or, in a
+// <bank>/<account>/<file> layout, the folder above it. The account pattern takes precedence over the folder
+func (s *Svc) bankTrxFilePath(cleanPath string, path string, accountPattern *regexp.Regexp) (returnData FilePathBankTrx, ok bool) {
+	listBank := s.comp.Config.Data.Reconciliation.ListBank
+	if match := s.regexCompileBankName.FindStringSubmatch(path); len(match) > 1 && slices.Contains(listBank, match[1]) {
+		returnData = FilePathBankTrx{Bank: match[1], FilePath: path}
+	} else if match = s.regexCompileBankAccountName.FindStringSubmatch(path); len(match) > 2 && slices.Contains(listBank, match[1]) {
+		returnData = FilePathBankTrx{Bank: match[1], Account: match[2], FilePath: path}
+	} else {
+		return returnData, false
+	}
+
+	if accountPattern != nil {
+		relativePath, _ := filepath.Rel(cleanPath, path)
+		if match := accountPattern.FindStringSubmatch(filepath.ToSlash(relativePath)); len(match) > 1 && match[1] != "" {
+			returnData.Account = match[1]
+		}
+	}
+
+	return returnData, true
+}
+
+func (s *Svc) parseBankTrxFiles(ctx context.Context, afs afero.Fs) (returnData []*banks.BankTrxData, statementBalances []banks.StatementBalance, err error) {
 	var filePathBankTrx []FilePathBankTrx
+	var accountPattern *regexp.Regexp
 	cleanPath := filepath.Clean(s.comp.Config.Data.Reconciliation.BankTRXPath)
 
 	_, err = hunch.Waterfall(
 		ctx,
 		func(c context.Context, _ interface{}) (r interface{}, e error) {
-			// scan only csv file with first folder as bank name, bank should in the list of accepted bank name
+			if pathPattern := s.comp.Config.Data.Reconciliation.Account.PathPattern; pathPattern != "" {
+				accountPattern, e = regexp.Compile(pathPattern)
+			}
+
+			return
+		},
+		func(c context.Context, _ interface{}) (r interface{}, e error) {
+			// scan only csv, MT940 (.sta, .mt940) and camt.053 (.xml) files with first folder (or the folder above an account folder) as bank name, bank should in the list
+			// of accepted bank name
+			er := afero.Walk(afs, cleanPath, func(path string, info fs.FileInfo, err error) (e error) {
+				if item, ok := s.bankTrxFilePath(cleanPath, path, accountPattern); ok {
+					filePathBankTrx = append(filePathBankTrx, item)
+				}
+
+				return nil
+			})
+
+			return nil, er
+		},
+		func(c context.Context, _ interface{}) (interface{}, error) {
+			sliceMutex := sync.Mutex{}
+			wg := sync.WaitGroup{}
+
+			parallel.ForEach(filePathBankTrx, func(item FilePathBankTrx, _ int) {
+				wg.Add(1)
+				defer wg.Done()
+				data, balances, _ := s.parseBankTrxFile(c, afs, item)
+				sliceMutex.Lock()
+				returnData = append(returnData, data...)
+				statementBalances = append(statementBalances, balances...)
+				sliceMutex.Unlock()
+			})
+
+			wg.Wait()
+			return nil, nil
+		},
+	)
+
+	log.Err(ctx, "[process.NewSvc] parseBankTrxFiles executed", err)
+
+	return
+}
+
+func (s *Svc) importReconcileBankDataToDB(ctx context.Context, data []*banks.BankTrxData) (err error) {
+	numberWorker := s.comp.Config.Data.Reconciliation.NumberWorker * 2
+	defSize := len(data) / numberWorker
+	numBigger := len(data) - defSize*numberWorker
+	size := defSize + 1
+
+	for i, idx := 0, 0; i < numberWorker; i++ {
+		if i == numBigger {
+			size--
+			if size == 0 {
+				break
+			}
+		}
+
+		err = s.repo.RepoProcess.ImportBankTrx(
+			ctx,
+			data[idx:idx+size],
+			idx,
+			idx+size,
+		)
+
+		if err != nil {
+			return
+		}
+
+		idx += size
+	}
+
+	return
+}
+
+func (s *Svc) parseGatewayTrxFile(ctx context.Context, afs afero.Fs, item FilePathGatewayTrx) (returnData []*gateways.GatewayTrxData, err error) {
+	var gatewayParser gateways.ReconcileGatewayData
+	var f afero.File
+	gateway := strings.ToUpper(item.Gateway)
+
+	defer func() {
+		if f != nil {
+			_ = f.Close()
+		}
+	}()
+
+	_, err = hunch.Waterfall(
+		ctx,
+		func(c context.Context, _ interface{}) (r interface{}, e error) {
+			f, e = afs.Open(item.FilePath)
+			return
+		},
+		func(c context.Context, _ interface{}) (r interface{}, e error) {
+			gatewayParser, e = s.gatewayParserRegistry.GetParser(gateway, f, true, s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces)
+			return
+		},
+	)
+
+	log.Err(ctx, "[process.NewSvc] parseGatewayTrxFile parse ("+gateway+") - '"+item.Gateway+"' executed", err)
+
+	if err != nil {
+		return
+	}
+
+	returnData, err = gatewayParser.ToGatewayTrxData(ctx, item.FilePath)
+	log.Err(ctx, "[process.NewSvc] parseGatewayTrxFile parse.ToGatewayTrxData ("+gateway+") executed", err)
+
+	return
+}
+
+func (s *Svc) parseGatewayTrxFiles(ctx context.Context, afs afero.Fs) (returnData []*gateways.GatewayTrxData, err error) {
+	var filePathGatewayTrx []FilePathGatewayTrx
+	cleanPath := filepath.Clean(s.comp.Config.Data.Reconciliation.Gateway.TRXPath)
+
+	_, err = hunch.Waterfall(
+		ctx,
+		func(c context.Context, _ interface{}) (r interface{}, e error) {
+			// scan only csv file with first folder as gateway name, gateway should in the list of accepted gateway name
 			er := afero.Walk(afs, cleanPath, func(path string, info fs.FileInfo, err error) (e error) {
 				match := s.regexCompileBankName.FindStringSubmatch(path)
 				if len(match) <= 1 {
 					return
 				}
 
-				if slices.Contains(s.comp.Config.Data.Reconciliation.ListBank, match[1]) {
-					filePathBankTrx = append(
-						filePathBankTrx,
-						FilePathBankTrx{
-							Bank:     match[1],
+				if slices.Contains(s.comp.Config.Data.Reconciliation.Gateway.ListGateway, match[1]) {
+					filePathGatewayTrx = append(
+						filePathGatewayTrx,
+						FilePathGatewayTrx{
+							Gateway:  match[1],
 							FilePath: path,
 						},
 					)
@@ -239,10 +532,10 @@ func (s *Svc) parseBankTrxFiles(ctx context.Context, afs afero.Fs) (returnData [
 			sliceMutex := sync.Mutex{}
 			wg := sync.WaitGroup{}
 
-			parallel.ForEach(filePathBankTrx, func(item FilePathBankTrx, _ int) {
+			parallel.ForEach(filePathGatewayTrx, func(item FilePathGatewayTrx, _ int) {
 				wg.Add(1)
 				defer wg.Done()
-				data, _ := s.parseBankTrxFile(c, afs, item)
+				data, _ := s.parseGatewayTrxFile(c, afs, item)
 				sliceMutex.Lock()
 				returnData = append(returnData, data...)
 				sliceMutex.Unlock()
@@ -253,12 +546,12 @@ func (s *Svc) parseBankTrxFiles(ctx context.Context, afs afero.Fs) (returnData [
 		},
 	)
 
-	log.Err(ctx, "[process.NewSvc] parseBankTrxFiles executed", err)
+	log.Err(ctx, "[process.NewSvc] parseGatewayTrxFiles executed", err)
 
 	return
 }
 
-func (s *Svc) importReconcileBankDataToDB(ctx context.Context, data []*banks.BankTrxData) (err error) {
+func (s *Svc) importReconcileGatewayDataToDB(ctx context.Context, data []*gateways.GatewayTrxData) (err error) {
 	numberWorker := s.comp.Config.Data.Reconciliation.NumberWorker * 2
 	defSize := len(data) / numberWorker
 	numBigger := len(data) - defSize*numberWorker
@@ -272,7 +565,7 @@ func (s *Svc) importReconcileBankDataToDB(ctx context.Context, data []*banks.Ban
 			}
 		}
 
-		err = s.repo.RepoProcess.ImportBankTrx(
+		err = s.repo.RepoProcess.ImportGatewayTrx(
 			ctx,
 			data[idx:idx+size],
 			idx,
@@ -289,6 +582,329 @@ func (s *Svc) importReconcileBankDataToDB(ctx context.Context, data []*banks.Ban
 	return
 }
 
+func (s *Svc) readFXRateTable(ctx context.Context, afs afero.Fs) (returnData *fx.RateTable, err error) {
+	filePath := s.comp.Config.Data.Reconciliation.FX.RateFilePath
+	if filePath == "" {
+		return fx.NewRateTable(nil)
+	}
+
+	defer func() {
+		log.Err(ctx, "[process.NewSvc] readFXRateTable - '"+filePath+"' executed", err)
+	}()
+
+	var f afero.File
+	if f, err = afs.Open(filePath); err != nil {
+		return
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return fx.ReadRateTable(f)
+}
+
+// toBaseCurrency converts the system and bank trx amounts to the base currency with the rate of their date,
+// keeping the booked amounts in OriginalAmount. A trx without rate is logged and left unconverted with
+// IsMissingFXRate, it is reported as not matched rather than matched on a wrong amount
+func (s *Svc) toBaseCurrency(ctx context.Context, rates *fx.RateTable, trxData *parser.TrxData) {
+	baseCurrency := strings.ToUpper(s.comp.Config.Data.Reconciliation.FX.BaseCurrency)
+
+	for _, item := range trxData.SystemTrx {
+		item.Currency = lo.CoalesceOrEmpty(item.Currency, baseCurrency)
+		item.OriginalAmount = item.Amount
+
+		amount, _, err := rates.Convert(item.OriginalAmount, item.TransactionTime, item.Currency, baseCurrency)
+		if err != nil {
+			log.AddErr(ctx, fmt.Errorf("system trx %s: %w", item.TrxID, err))
+			item.IsMissingFXRate = true
+			continue
+		}
+
+		item.Amount = amount
+	}
+
+	for _, item := range trxData.BankTrx {
+		item.Currency = lo.CoalesceOrEmpty(item.Currency, s.comp.Config.Data.Reconciliation.FX.GetBankCurrency(item.Bank))
+		item.OriginalAmount = item.Amount
+
+		amount, _, err := rates.Convert(item.OriginalAmount, item.Date, item.Currency, baseCurrency)
+		if err != nil {
+			log.AddErr(ctx, fmt.Errorf("bank trx %s: %w", item.UniqueIdentifier, err))
+			item.IsMissingFXRate = true
+			continue
+		}
+
+		item.Amount = amount
+	}
+}
+
+// removeDuplicates resolves trx repeated within the system source and within the bank source with the duplicate
+// policies, the first trx of a group is the first by file path then by line. Rejected trx are removed from trxData,
+// kept exact key duplicates get their new ID, and every trx of a duplicate group is returned for the report
+func (s *Svc) removeDuplicates(trxData *parser.TrxData) (returnData []DuplicateTrx) {
+	policy := s.comp.Config.Data.Reconciliation.Duplicate
+
+	// files are parsed in parallel, their order is only fixed by the path
+	slices.SortStableFunc(trxData.SystemTrx, func(a, b *systems.SystemTrxData) int {
+		return strings.Compare(a.FilePath, b.FilePath)
+	})
+
+	slices.SortStableFunc(trxData.BankTrx, func(a, b *banks.BankTrxData) int {
+		return strings.Compare(a.FilePath, b.FilePath)
+	})
+
+	systemResult := duplicate.Resolve(
+		lo.Map(trxData.SystemTrx, func(item *systems.SystemTrxData, _ int) duplicate.Record {
+			return duplicate.Record{
+				Key:     item.TrxID,
+				Content: fmt.Sprintf("%s|%s|%s|%d", item.TransactionTime.Format(time.RFC3339), item.Type, item.Currency, item.OriginalAmount),
+			}
+		}),
+		policy.Policy,
+		policy.ContentPolicy,
+	)
+
+	for _, entry := range systemResult.Entries {
+		item := trxData.SystemTrx[entry.Index]
+		returnData = append(returnData, DuplicateTrx{
+			Source:         DuplicateSourceSystem,
+			ID:             systemResult.Keys[entry.Index],
+			DuplicateOf:    lo.Ternary(entry.Index == entry.FirstIndex, "", systemResult.Keys[entry.FirstIndex]),
+			Kind:           entry.Kind,
+			Action:         entry.Action,
+			Date:           item.TransactionTime.Format(time.DateTime),
+			Type:           string(item.Type),
+			Amount:         item.Amount,
+			Currency:       item.Currency,
+			OriginalAmount: item.OriginalAmount,
+			FilePath:       item.FilePath,
+		})
+	}
+
+	// statements stating a time compare it too
+	bankDate := func(item *banks.BankTrxData) string {
+		return item.Date.Format(lo.Ternary(item.IsHaveTime, time.DateTime, time.DateOnly))
+	}
+
+	// a statement can state the same amount twice a day, the n-th line of a content is only the same trx as the n-th
+	// line of that content in another file
+	bankContentCount := make(map[string]int)
+	bankResult := duplicate.Resolve(
+		lo.Map(trxData.BankTrx, func(item *banks.BankTrxData, _ int) duplicate.Record {
+			content := fmt.Sprintf("%s|%s|%s|%s|%s|%d", strings.ToLower(item.Bank), item.Account, bankDate(item), item.Type, item.Currency, item.OriginalAmount)
+			bankContentCount[item.FilePath+"|"+content]++
+
+			return duplicate.Record{
+				Key:     item.UniqueIdentifier,
+				Content: fmt.Sprintf("%s|%d", content, bankContentCount[item.FilePath+"|"+content]),
+			}
+		}),
+		policy.Policy,
+		policy.ContentPolicy,
+	)
+
+	for _, entry := range bankResult.Entries {
+		item := trxData.BankTrx[entry.Index]
+		returnData = append(returnData, DuplicateTrx{
+			Source:         DuplicateSourceBank,
+			Bank:           strings.ToLower(item.Bank),
+			Account:        item.Account,
+			ID:             bankResult.Keys[entry.Index],
+			DuplicateOf:    lo.Ternary(entry.Index == entry.FirstIndex, "", bankResult.Keys[entry.FirstIndex]),
+			Kind:           entry.Kind,
+			Action:         entry.Action,
+			Date:           bankDate(item),
+			Type:           string(item.Type),
+			Amount:         item.Amount,
+			Currency:       item.Currency,
+			OriginalAmount: item.OriginalAmount,
+			FilePath:       item.FilePath,
+		})
+	}
+
+	trxData.SystemTrx = lo.Map(systemResult.Keep, func(index int, _ int) *systems.SystemTrxData {
+		item := trxData.SystemTrx[index]
+		item.TrxID = systemResult.Keys[index]
+		return item
+	})
+
+	trxData.BankTrx = lo.Map(bankResult.Keep, func(index int, _ int) *banks.BankTrxData {
+		item := trxData.BankTrx[index]
+		item.UniqueIdentifier = bankResult.Keys[index]
+		return item
+	})
+
+	return
+}
+
+// checkBalances checks every bank statement file per account against the balances it states, lines of a file are in
+// their order in it. The opening balance of a file stating the balances of its statements is the one of its first
+// statement of the account, the closing balance the one of its last. A statement not balancing is logged, or fails the
+// check under the fail policy
+func (s *Svc) checkBalances(ctx context.Context, data []*banks.BankTrxData, statementBalances []banks.StatementBalance) (returnData []balance.Result, err error) {
+	policy := s.comp.Config.Data.Reconciliation.Balance.Policy
+	if policy == reconciliation.BalancePolicyOff {
+		return
+	}
+
+	decimalPlaces := s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces
+
+	var statements []*balance.Statement
+	statementByKey := make(map[string]*balance.Statement)
+	getStatement := func(bank string, account string, filePath string) *balance.Statement {
+		key := filePath + "|" + account
+		statement, ok := statementByKey[key]
+		if !ok {
+			statement = &balance.Statement{
+				Bank:     strings.ToLower(bank),
+				Account:  account,
+				FilePath: filePath,
+			}
+
+			statementByKey[key] = statement
+			statements = append(statements, statement)
+		}
+
+		return statement
+	}
+
+	for _, item := range data {
+		statement := getStatement(item.Bank, item.Account, item.FilePath)
+		statement.Lines = append(statement.Lines, balance.Line{
+			ID:            item.UniqueIdentifier,
+			Movement:      lo.Ternary(item.Type == banks.DEBIT, -item.Amount, item.Amount),
+			Balance:       item.Balance,
+			IsHaveBalance: item.IsHaveBalance,
+		})
+	}
+
+	// a statement stating balances without lines is checked too, its lines may all have failed to parse
+	for _, item := range statementBalances {
+		statement := getStatement(item.Bank, item.Account, item.FilePath)
+		if item.IsHaveOpeningBalance && !statement.IsHaveOpeningBalance {
+			statement.OpeningBalance = item.OpeningBalance
+			statement.IsHaveOpeningBalance = true
+		}
+
+		if item.IsHaveClosingBalance {
+			statement.ClosingBalance = item.ClosingBalance
+			statement.IsHaveClosingBalance = true
+		}
+	}
+
+	// files are parsed in parallel, their order is only fixed by the path
+	slices.SortStableFunc(statements, func(a, b *balance.Statement) int {
+		return cmp.Or(strings.Compare(a.FilePath, b.FilePath), strings.Compare(a.Account, b.Account))
+	})
+
+	for _, statement := range statements {
+		result := balance.Check(*statement)
+		returnData = append(returnData, result)
+
+		if result.Status != balance.StatusNotBalanced {
+			continue
+		}
+
+		msg := fmt.Sprintf(
+			"balance: statement %s account %q does not balance, opening %s movement %s closing %s difference %s, %d break(s) first at %q",
+			result.FilePath,
+			result.Account,
+			result.OpeningBalance.Format(decimalPlaces),
+			result.Movement.Format(decimalPlaces),
+			result.ClosingBalance.Format(decimalPlaces),
+			result.Difference.Format(decimalPlaces),
+			result.TotalBreak,
+			result.FirstBreakID,
+		)
+
+		if policy == reconciliation.BalancePolicyFail {
+			return nil, errors.New(msg)
+		}
+
+		log.Msg(ctx, msg)
+	}
+
+	return
+}
+
+// checkCoverage counts the bank trx of every bank of the list on every day of the date range. Days without any fail
+// the check when asked to
+func (s *Svc) checkCoverage(data []*banks.BankTrxData) (returnData []BankCoverage, err error) {
+	totalByBankDate := lo.CountValuesBy(data, func(item *banks.BankTrxData) string {
+		return strings.ToLower(item.Bank) + "|" + item.Date.Format(time.DateOnly)
+	})
+
+	var gaps []string
+	for _, bank := range s.comp.Config.Data.Reconciliation.ListBank {
+		bank = strings.ToLower(bank)
+		for day := s.comp.Config.Data.Reconciliation.FromDate; !day.After(s.comp.Config.Data.Reconciliation.ToDate); day = day.AddDate(0, 0, 1) {
+			date := day.Format(time.DateOnly)
+			total := totalByBankDate[bank+"|"+date]
+			returnData = append(returnData, BankCoverage{
+				Bank:         bank,
+				Date:         date,
+				Status:       lo.Ternary(total > 0, CoverageStatusCovered, CoverageStatusGap),
+				TotalBankTrx: total,
+			})
+
+			if total == 0 {
+				gaps = append(gaps, bank+" "+date)
+			}
+		}
+	}
+
+	if len(gaps) > 0 && s.comp.Config.Data.Reconciliation.IsFailOnCoverageGap {
+		return nil, fmt.Errorf("coverage: no bank statement data for %s", strings.Join(gaps, ", "))
+	}
+
+	return
+}
+
+func (s *Svc) listBank() (returnData []process.Bank) {
+	decimalPlaces := s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces
+	for _, bank := range s.comp.Config.Data.Reconciliation.ListBank {
+		window := s.comp.Config.Data.Reconciliation.GetSettlementWindow(bank)
+		returnData = append(
+			returnData,
+			process.Bank{
+				Name: bank,
+				FeeTiers: lo.Map(s.comp.Config.Data.Reconciliation.GetBankFeeTiers(bank), func(item reconciliation.BankFeeTier, _ int) process.FeeTier {
+					return process.FeeTier{
+						MinAmount:  money.FromMajor(item.MinAmount, decimalPlaces),
+						Flat:       money.FromMajor(item.Flat, decimalPlaces),
+						Percentage: item.Percentage,
+					}
+				}),
+				SettlementDaysBefore: window.DaysBefore,
+				SettlementDaysAfter:  window.DaysAfter,
+			},
+		)
+	}
+
+	return
+}
+
+func (s *Svc) listGateway() (returnData []process.Gateway) {
+	gateway := s.comp.Config.Data.Reconciliation.Gateway
+	if !gateway.IsEnabled() {
+		return
+	}
+
+	for _, name := range gateway.ListGateway {
+		returnData = append(
+			returnData,
+			process.Gateway{
+				Name:       name,
+				PayoutBank: gateway.GetPayoutBank(name),
+				PayoutDays: gateway.PayoutDays,
+			},
+		)
+	}
+
+	return
+}
+
 func (s *Svc) parse(ctx context.Context, afs afero.Fs) (trxData parser.TrxData, err error) {
 	isOK := func(t, minDate, maxDate time.Time) bool {
 		return (t.Equal(minDate) || t.After(minDate)) && t.Before(maxDate)
@@ -302,14 +918,23 @@ func (s *Svc) parse(ctx context.Context, afs afero.Fs) (trxData parser.TrxData,
 		)
 	}
 
-	setMaxAmount := func(currentAmount float64) {
-		if trxData.MaxSystemAmount < currentAmount {
-			trxData.MaxSystemAmount = currentAmount
-		}
+	// bank statement can settle system transactions before/after the date range, keep the statement lines
+	// within the widest settlement window so they are still considered in matching
+	maxWindow := s.comp.Config.Data.Reconciliation.GetMaxSettlementWindow()
+	isOKBankCheck := func(timeToCheck time.Time) bool {
+		return isOK(
+			timeToCheck,
+			s.comp.Config.Data.Reconciliation.FromDate.AddDate(0, 0, -maxWindow.DaysBefore),
+			s.comp.Config.Data.Reconciliation.ToDate.AddDate(0, 0, 1+maxWindow.DaysAfter),
+		)
 	}
 
-	_, err = hunch.All(
-		ctx,
+	var rates *fx.RateTable
+	if rates, err = s.readFXRateTable(ctx, afs); err != nil {
+		return
+	}
+
+	parseFn := []hunch.Executable{
 		func(ct context.Context) (d interface{}, e error) {
 			defer func() {
 				log.Err(ct, "[process.NewSvc] GenerateReconciliation parseSystemTrxFiles executed", e)
@@ -318,13 +943,7 @@ func (s *Svc) parse(ctx context.Context, afs afero.Fs) (trxData parser.TrxData,
 			var data []*systems.SystemTrxData
 			if data, e = s.parseSystemTrxFiles(ct, afs); e == nil {
 				trxData.SystemTrx = lo.Filter(data, func(item *systems.SystemTrxData, index int) bool {
-					if !isOKCheck(item.TransactionTime) {
-						return false
-					}
-
-					setMaxAmount(item.Amount)
-
-					return true
+					return isOKCheck(item.TransactionTime)
 				})
 			}
 
@@ -336,16 +955,70 @@ func (s *Svc) parse(ctx context.Context, afs afero.Fs) (trxData parser.TrxData,
 			}()
 
 			var data []*banks.BankTrxData
-			if data, e = s.parseBankTrxFiles(ct, afs); e == nil {
+			var statementBalances []banks.StatementBalance
+			if data, statementBalances, e = s.parseBankTrxFiles(ct, afs); e == nil {
+				// a statement only balances with all its lines, the ones out of the date range too
+				if trxData.BankStatementBalances, e = s.checkBalances(ct, data, statementBalances); e != nil {
+					return
+				}
+
 				trxData.BankTrx = lo.Filter(data, func(item *banks.BankTrxData, index int) bool {
-					return isOKCheck(item.Date)
+					return isOKBankCheck(item.Date)
 				})
 			}
 
 			return
 		},
+	}
+
+	// gateway lines are all kept whatever their date, a payout is only complete with all its lines
+	if s.comp.Config.Data.Reconciliation.Gateway.IsEnabled() {
+		parseFn = append(parseFn, func(ct context.Context) (d interface{}, e error) {
+			defer func() {
+				log.Err(ct, "[process.NewSvc] GenerateReconciliation parseGatewayTrxFiles executed", e)
+			}()
+
+			if trxData.GatewayTrx, e = s.parseGatewayTrxFiles(ct, afs); e == nil {
+				// files are parsed in parallel, a line repeated across files keeps the one of the first path
+				slices.SortStableFunc(trxData.GatewayTrx, func(a, b *gateways.GatewayTrxData) int {
+					return strings.Compare(a.FilePath, b.FilePath)
+				})
+			}
+
+			return
+		})
+	}
+
+	_, err = hunch.All(
+		ctx,
+		parseFn...,
 	)
 
+	if err != nil {
+		return parser.TrxData{}, err
+	}
+
+	s.toBaseCurrency(ctx, rates, &trxData)
+
+	// amount ranges of the matching passes are in the base currency, trx left unconverted are not matched. The
+	// account a system trx settles on only restricts matching when asked to
+	isMatchAccount := s.comp.Config.Data.Reconciliation.Account.IsMatchAccount
+	isFirstAmount := true
+	for _, item := range trxData.SystemTrx {
+		if !item.IsMissingFXRate {
+			if isFirstAmount || item.Amount < trxData.MinSystemAmount {
+				trxData.MinSystemAmount = item.Amount
+			}
+
+			trxData.MaxSystemAmount = max(trxData.MaxSystemAmount, item.Amount)
+			isFirstAmount = false
+		}
+
+		if !isMatchAccount {
+			item.Account = ""
+		}
+	}
+
 	return
 }
 
@@ -378,6 +1051,7 @@ func (s *Svc) generateReconciliationFiles(ctx context.Context, reconciliationSum
 
 	fileNameSuffix := strconv.FormatInt(clock.Get(ctx).Now().Unix(), 10)
 	logTemplate := "[process.NewSvc] save csv file %s executed"
+	amountMarshalers := money.CSVMarshalers(s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces)
 
 	_, err = hunch.Waterfall(
 		ctx,
@@ -388,17 +1062,54 @@ func (s *Svc) generateReconciliationFiles(ctx context.Context, reconciliationSum
 			}()
 
 			var d []process.MatchedTrx
-			if d, e = s.repo.RepoProcess.GetMatchedTrx(ctx); e == nil && len(d) > 0 {
-				return fileName, csvhelper.StructToCSVFile(
+			if d, e = s.repo.RepoProcess.GetMatchedTrx(ctx); e != nil || len(d) == 0 {
+				return "", e
+			}
+
+			reconciliationSummary.SumFeeByBank = make(map[string]money.Amount)
+			lo.ForEach(d, func(item process.MatchedTrx, _ int) {
+				if item.Fee != 0 && item.MatchType != process.MatchTypeGateway {
+					reconciliationSummary.SumFeeByBank[strings.ToLower(item.Bank)] += item.Fee
+				}
+			})
+
+			// matches below the confidence threshold are not accepted, they go to the review report instead
+			accepted, review := lo.FilterReject(d, func(item process.MatchedTrx, _ int) bool {
+				return item.Confidence >= s.comp.Config.Data.Reconciliation.ReviewConfidenceThreshold
+			})
+
+			if len(review) > 0 {
+				reviewFileName := fmt.Sprintf("%s/%s/%s/review_%s.csv", s.comp.Config.Data.Reconciliation.ReportTRXPath, "system", "review", fileNameSuffix)
+				e = csvhelper.StructToCSVFile(
 					c,
 					fs,
-					fileName,
-					d,
+					reviewFileName,
+					review,
 					isDeleteDirectory,
+					amountMarshalers,
 				)
+
+				log.Err(c, fmt.Sprintf(logTemplate, reviewFileName), e)
+				if e != nil {
+					return "", e
+				}
+
+				reconciliationSummary.FileReviewSystemTrx = reviewFileName
+				reconciliationSummary.TotalReviewSystemTrx = int64(len(review))
 			}
 
-			return "", e
+			if len(accepted) == 0 {
+				return "", nil
+			}
+
+			return fileName, csvhelper.StructToCSVFile(
+				c,
+				fs,
+				fileName,
+				accepted,
+				isDeleteDirectory,
+				amountMarshalers,
+			)
 		},
 		func(c context.Context, i interface{}) (r interface{}, e error) {
 			reconciliationSummary.FileMatchedSystemTrx = i.(string)
@@ -409,28 +1120,69 @@ func (s *Svc) generateReconciliationFiles(ctx context.Context, reconciliationSum
 
 			var d []process.NotMatchedSystemTrx
 			if d, e = s.repo.RepoProcess.GetNotMatchedSystemTrx(ctx); e == nil && len(d) > 0 {
+				reconciliationSummary.TotalNotMatchedSystemTrxByReason = lo.CountValuesBy(d, func(item process.NotMatchedSystemTrx) string {
+					return item.Reason
+				})
+
 				return fileName, csvhelper.StructToCSVFile(
 					c,
 					fs,
 					fileName,
 					d,
 					isDeleteDirectory,
+					amountMarshalers,
 				)
 			}
 
 			return "", e
 		},
-		func(c context.Context, i interface{}) (_ interface{}, e error) {
+		func(c context.Context, i interface{}) (r interface{}, e error) {
 			reconciliationSummary.FileMissingSystemTrx = i.(string)
+			suggestion := s.comp.Config.Data.Reconciliation.Suggestion
+			if reconciliationSummary.FileMissingSystemTrx == "" || suggestion.MaxCount == 0 {
+				return "", nil
+			}
+
+			fileName := fmt.Sprintf("%s/%s/%s/suggestion_%s.csv", s.comp.Config.Data.Reconciliation.ReportTRXPath, "system", "suggestion", fileNameSuffix)
+			defer func() {
+				log.Err(c, fmt.Sprintf(logTemplate, r), e)
+			}()
+
+			var d []process.NotMatchedSystemTrxSuggestion
+			if d, e = s.repo.RepoProcess.GetNotMatchedSystemTrxSuggestion(ctx, suggestion.MaxCount, suggestion.Days, suggestion.AmountPercentage); e == nil && len(d) > 0 {
+				return fileName, csvhelper.StructToCSVFile(
+					c,
+					fs,
+					fileName,
+					d,
+					isDeleteDirectory,
+					amountMarshalers,
+				)
+			}
+
+			return "", e
+		},
+		func(c context.Context, i interface{}) (_ interface{}, e error) {
+			reconciliationSummary.FileSuggestionSystemTrx = i.(string)
 			var d []process.NotMatchedBankTrx
 			if d, e = s.repo.RepoProcess.GetNotMatchedBankTrx(ctx); e != nil || len(d) == 0 {
 				return nil, e
 			}
 
+			reconciliationSummary.TotalNotMatchedBankTrxByReason = lo.CountValuesBy(d, func(item process.NotMatchedBankTrx) string {
+				return item.Reason
+			})
+
+			// one report per bank, or per bank account for statements telling their account
 			bankTrxData := make(map[string][]process.NotMatchedBankTrx)
 			lo.ForEach(d, func(data process.NotMatchedBankTrx, _ int) {
 				data.Bank = strings.ToLower(data.Bank)
-				bankTrxData[data.Bank] = append(bankTrxData[data.Bank], data)
+				key := data.Bank
+				if data.Account != "" {
+					key = data.Bank + "_" + data.Account
+				}
+
+				bankTrxData[key] = append(bankTrxData[key], data)
 			})
 
 			reconciliationSummary.FileMissingBankTrx = make(map[string]string)
@@ -457,6 +1209,7 @@ func (s *Svc) generateReconciliationFiles(ctx context.Context, reconciliationSum
 						fileReportBankTrx,
 						bankTrxData[item],
 						false,
+						amountMarshalers,
 					),
 				)
 			})
@@ -468,6 +1221,249 @@ func (s *Svc) generateReconciliationFiles(ctx context.Context, reconciliationSum
 	return
 }
 
+// generateDuplicateFile counts the duplicates in the summary and writes every trx of a duplicate group to the
+// duplicate report
+func (s *Svc) generateDuplicateFile(ctx context.Context, reconciliationSummary *ReconciliationSummary, duplicates []DuplicateTrx, fs afero.Fs, isDeleteDirectory bool) (err error) {
+	if reconciliationSummary == nil || len(duplicates) == 0 {
+		return
+	}
+
+	for _, item := range duplicates {
+		if item.Action == duplicate.ActionRejected {
+			reconciliationSummary.TotalRejectedDuplicateTrx++
+		}
+
+		// the first trx of a group is not a duplicate itself
+		if item.DuplicateOf == "" {
+			continue
+		}
+
+		if item.Source == DuplicateSourceSystem {
+			reconciliationSummary.TotalDuplicateSystemTrx++
+		} else {
+			reconciliationSummary.TotalDuplicateBankTrx++
+		}
+	}
+
+	fileName := fmt.Sprintf("%s/%s/duplicate_%s.csv", s.comp.Config.Data.Reconciliation.ReportTRXPath, "duplicate", strconv.FormatInt(clock.Get(ctx).Now().Unix(), 10))
+	err = csvhelper.StructToCSVFile(
+		ctx,
+		fs,
+		fileName,
+		duplicates,
+		isDeleteDirectory,
+		money.CSVMarshalers(s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces),
+	)
+
+	log.Err(ctx, fmt.Sprintf("[process.NewSvc] save csv file %s executed", fileName), err)
+	if err == nil {
+		reconciliationSummary.FileDuplicateTrx = fileName
+	}
+
+	return
+}
+
+// generateBalanceFile counts the statements of every balance check status in the summary and writes the check of every
+// statement to the balance report
+func (s *Svc) generateBalanceFile(ctx context.Context, reconciliationSummary *ReconciliationSummary, balances []balance.Result, fs afero.Fs, isDeleteDirectory bool) (err error) {
+	if reconciliationSummary == nil || len(balances) == 0 {
+		return
+	}
+
+	for _, item := range balances {
+		switch item.Status {
+		case balance.StatusBalanced:
+			reconciliationSummary.TotalBalancedStatement++
+		case balance.StatusNotBalanced:
+			reconciliationSummary.TotalNotBalancedStatement++
+		default:
+			reconciliationSummary.TotalNoBalanceStatement++
+		}
+	}
+
+	fileName := fmt.Sprintf("%s/%s/balance_%s.csv", s.comp.Config.Data.Reconciliation.ReportTRXPath, "balance", strconv.FormatInt(clock.Get(ctx).Now().Unix(), 10))
+	err = csvhelper.StructToCSVFile(
+		ctx,
+		fs,
+		fileName,
+		balances,
+		isDeleteDirectory,
+		money.CSVMarshalers(s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces),
+	)
+
+	log.Err(ctx, fmt.Sprintf("[process.NewSvc] save csv file %s executed", fileName), err)
+	if err == nil {
+		reconciliationSummary.FileBalanceCheck = fileName
+	}
+
+	return
+}
+
+// generateCoverageFile lists the coverage gaps per bank in the summary and writes the coverage of every bank and day to
+// the coverage report
+func (s *Svc) generateCoverageFile(ctx context.Context, reconciliationSummary *ReconciliationSummary, coverage []BankCoverage, fs afero.Fs, isDeleteDirectory bool) (err error) {
+	if reconciliationSummary == nil || len(coverage) == 0 {
+		return
+	}
+
+	for _, item := range coverage {
+		if item.Status != CoverageStatusGap {
+			continue
+		}
+
+		if reconciliationSummary.CoverageGapByBank == nil {
+			reconciliationSummary.CoverageGapByBank = make(map[string][]string)
+		}
+
+		reconciliationSummary.TotalCoverageGap++
+		reconciliationSummary.CoverageGapByBank[item.Bank] = append(reconciliationSummary.CoverageGapByBank[item.Bank], item.Date)
+	}
+
+	fileName := fmt.Sprintf("%s/%s/coverage_%s.csv", s.comp.Config.Data.Reconciliation.ReportTRXPath, "coverage", strconv.FormatInt(clock.Get(ctx).Now().Unix(), 10))
+	err = csvhelper.StructToCSVFile(
+		ctx,
+		fs,
+		fileName,
+		coverage,
+		isDeleteDirectory,
+	)
+
+	log.Err(ctx, fmt.Sprintf("[process.NewSvc] save csv file %s executed", fileName), err)
+	if err == nil {
+		reconciliationSummary.FileCoverage = fileName
+	}
+
+	return
+}
+
+// generateReversalFile counts the trx of the reversal pairs in the summary and writes the pairs to the reversal report
+func (s *Svc) generateReversalFile(ctx context.Context, reconciliationSummary *ReconciliationSummary, fs afero.Fs, isDeleteDirectory bool) (err error) {
+	if reconciliationSummary == nil || !s.comp.Config.Data.Reconciliation.Reversal.IsEnabled {
+		return
+	}
+
+	var d []process.ReversalTrx
+	if d, err = s.repo.RepoProcess.GetReversalTrx(ctx); err != nil || len(d) == 0 {
+		return
+	}
+
+	for _, item := range d {
+		if item.Source == process.ReversalSourceSystem {
+			reconciliationSummary.TotalReversalSystemTrx += 2
+		} else {
+			reconciliationSummary.TotalReversalBankTrx += 2
+		}
+	}
+
+	fileName := fmt.Sprintf("%s/%s/reversal_%s.csv", s.comp.Config.Data.Reconciliation.ReportTRXPath, "reversal", strconv.FormatInt(clock.Get(ctx).Now().Unix(), 10))
+	err = csvhelper.StructToCSVFile(
+		ctx,
+		fs,
+		fileName,
+		d,
+		isDeleteDirectory,
+		money.CSVMarshalers(s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces),
+	)
+
+	log.Err(ctx, fmt.Sprintf("[process.NewSvc] save csv file %s executed", fileName), err)
+	if err == nil {
+		reconciliationSummary.FileReversalTrx = fileName
+	}
+
+	return
+}
+
+// generateTransferFile counts the bank trx of the internal transfer pairs in the summary and writes the pairs to the
+// transfer report
+func (s *Svc) generateTransferFile(ctx context.Context, reconciliationSummary *ReconciliationSummary, fs afero.Fs, isDeleteDirectory bool) (err error) {
+	if reconciliationSummary == nil || !s.comp.Config.Data.Reconciliation.Transfer.IsEnabled {
+		return
+	}
+
+	var d []process.TransferTrx
+	if d, err = s.repo.RepoProcess.GetTransferTrx(ctx); err != nil || len(d) == 0 {
+		return
+	}
+
+	reconciliationSummary.TotalTransferBankTrx = int64(len(d)) * 2
+	fileName := fmt.Sprintf("%s/%s/transfer_%s.csv", s.comp.Config.Data.Reconciliation.ReportTRXPath, "transfer", strconv.FormatInt(clock.Get(ctx).Now().Unix(), 10))
+	err = csvhelper.StructToCSVFile(
+		ctx,
+		fs,
+		fileName,
+		d,
+		isDeleteDirectory,
+		money.CSVMarshalers(s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces),
+	)
+
+	log.Err(ctx, fmt.Sprintf("[process.NewSvc] save csv file %s executed", fileName), err)
+	if err == nil {
+		reconciliationSummary.FileTransferTrx = fileName
+	}
+
+	return
+}
+
+// generateGatewayFiles counts the breaks of both gateway legs in the summary and writes the gateway lines not linked
+// to a system trx and the payouts no bank trx pays to their reports
+func (s *Svc) generateGatewayFiles(ctx context.Context, reconciliationSummary *ReconciliationSummary, fs afero.Fs, isDeleteDirectory bool) (err error) {
+	if reconciliationSummary == nil || !s.comp.Config.Data.Reconciliation.Gateway.IsEnabled() {
+		return
+	}
+
+	fileNameSuffix := strconv.FormatInt(clock.Get(ctx).Now().Unix(), 10)
+	logTemplate := "[process.NewSvc] save csv file %s executed"
+	amountMarshalers := money.CSVMarshalers(s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces)
+
+	var trx []process.NotMatchedGatewayTrx
+	if trx, err = s.repo.RepoProcess.GetNotMatchedGatewayTrx(ctx); err != nil {
+		return
+	}
+
+	if len(trx) > 0 {
+		fileName := fmt.Sprintf("%s/%s/%s/not_matched_%s.csv", s.comp.Config.Data.Reconciliation.ReportTRXPath, "gateway", "not_matched", fileNameSuffix)
+		err = csvhelper.StructToCSVFile(
+			ctx,
+			fs,
+			fileName,
+			trx,
+			isDeleteDirectory,
+			amountMarshalers,
+		)
+
+		log.Err(ctx, fmt.Sprintf(logTemplate, fileName), err)
+		if err != nil {
+			return
+		}
+
+		reconciliationSummary.TotalNotMatchedGatewayTrx = int64(len(trx))
+		reconciliationSummary.FileMissingGatewayTrx = fileName
+	}
+
+	var payout []process.NotMatchedGatewayPayout
+	if payout, err = s.repo.RepoProcess.GetNotMatchedGatewayPayout(ctx); err != nil || len(payout) == 0 {
+		return
+	}
+
+	fileName := fmt.Sprintf("%s/%s/%s/not_matched_payout_%s.csv", s.comp.Config.Data.Reconciliation.ReportTRXPath, "gateway", "payout", fileNameSuffix)
+	err = csvhelper.StructToCSVFile(
+		ctx,
+		fs,
+		fileName,
+		payout,
+		isDeleteDirectory,
+		amountMarshalers,
+	)
+
+	log.Err(ctx, fmt.Sprintf(logTemplate, fileName), err)
+	if err == nil {
+		reconciliationSummary.TotalNotMatchedGatewayPayout = int64(len(payout))
+		reconciliationSummary.FileMissingGatewayPayout = fileName
+	}
+
+	return
+}
+
 func (s *Svc) GenerateReconciliation(ctx context.Context, afs afero.Fs, bar *progressbar.ProgressBar) (returnData ReconciliationSummary, err error) {
 	ctx = s.comp.Logger.GetLogger().With().Str("component", "Process ServiceGenerator").Ctx(ctx).Logger().WithContext(s.comp.Logger.GetCtx())
 
@@ -477,6 +1473,8 @@ func (s *Svc) GenerateReconciliation(ctx context.Context, afs afero.Fs, bar *pro
 	}()
 
 	var trxData parser.TrxData
+	var duplicates []DuplicateTrx
+	var coverage []BankCoverage
 
 	_, err = hunch.Waterfall(
 		ctx,
@@ -485,7 +1483,8 @@ func (s *Svc) GenerateReconciliation(ctx context.Context, afs afero.Fs, bar *pro
 
 			e = s.repo.RepoProcess.Pre(
 				c,
-				s.comp.Config.Data.Reconciliation.ListBank,
+				s.listBank(),
+				s.listGateway(),
 				s.comp.Config.Data.Reconciliation.FromDate,
 				s.comp.Config.Data.Reconciliation.ToDate,
 			)
@@ -493,9 +1492,23 @@ func (s *Svc) GenerateReconciliation(ctx context.Context, afs afero.Fs, bar *pro
 			log.Err(c, "[process.NewSvc] GenerateReconciliation RepoProcess.Pre executed", e)
 			return
 		},
-		func(c context.Context, _ interface{}) (interface{}, error) {
+		func(c context.Context, _ interface{}) (d interface{}, e error) {
 			progressbarhelper.BarDescribe(bar, "[cyan][2/7] Parse System/Bank Trx Files...")
-			return s.parse(c, afs)
+
+			var data parser.TrxData
+			if data, e = s.parse(c, afs); e != nil {
+				return
+			}
+
+			// a bank day without any trx leaves every system trx of the day not matched, better known first
+			if coverage, e = s.checkCoverage(data.BankTrx); e != nil {
+				log.Err(c, "[process.NewSvc] GenerateReconciliation checkCoverage executed", e)
+				return
+			}
+
+			// a repeated TrxID or UniqueIdentifier would fail the whole import chunk
+			duplicates = s.removeDuplicates(&data)
+			return data, nil
 		},
 		func(c context.Context, i interface{}) (d interface{}, e error) {
 			progressbarhelper.BarDescribe(bar, "[cyan][3/7] Import System Trx to DB...")
@@ -518,16 +1531,57 @@ func (s *Svc) GenerateReconciliation(ctx context.Context, afs afero.Fs, bar *pro
 
 			log.Err(c, "[process.NewSvc] GenerateReconciliation importReconcileBankDataToDB executed", e)
 
+			if e == nil && len(trxData.GatewayTrx) > 0 {
+				e = s.importReconcileGatewayDataToDB(c, trxData.GatewayTrx)
+				log.Err(c, "[process.NewSvc] GenerateReconciliation importReconcileGatewayDataToDB executed", e)
+			}
+
 			return
 		},
 		func(c context.Context, i interface{}) (d interface{}, e error) {
 			progressbarhelper.BarDescribe(bar, "[cyan][5/7] Mapping Reconciliation Data...")
 
+			// reversal pairs cancel each other, they are taken out before any matching pass
+			if reversal := s.comp.Config.Data.Reconciliation.Reversal; reversal.IsEnabled {
+				progressbarhelper.BarDescribe(bar, "[cyan][5/7] Mapping Reconciliation Data (reversal)...")
+				e = s.repo.RepoProcess.GenerateReversalMap(c, reversal.Days)
+				log.Err(c, "[process.NewSvc] GenerateReconciliation RepoProcess.GenerateReversalMap executed", e)
+
+				if e != nil {
+					return
+				}
+			}
+
+			// system trx paid through a gateway are settled by its payouts, the bank passes leave them out
+			if gateway := s.comp.Config.Data.Reconciliation.Gateway; gateway.IsEnabled() {
+				progressbarhelper.BarDescribe(bar, "[cyan][5/7] Mapping Reconciliation Data (gateway)...")
+				e = s.repo.RepoProcess.GenerateGatewayMap(c, gateway.Days)
+				log.Err(c, "[process.NewSvc] GenerateReconciliation RepoProcess.GenerateGatewayMap executed", e)
+
+				if e != nil {
+					return
+				}
+			}
+
+			// every pass only takes system and bank trx left over by the passes before it
 			if len(trxData.SystemTrx) > 0 {
-				e = s.importReconcileMapToDB(c, trxData.MinSystemAmount, trxData.MaxSystemAmount)
+				for _, rule := range s.comp.Config.Data.Reconciliation.GetMatchRules() {
+					progressbarhelper.BarDescribe(bar, fmt.Sprintf("[cyan][5/7] Mapping Reconciliation Data (%s)...", rule.Name))
+					e = s.importReconcileMapToDB(c, rule, trxData.MinSystemAmount, trxData.MaxSystemAmount)
+					log.Err(c, fmt.Sprintf("[process.NewSvc] GenerateReconciliation importReconcileMapToDB rule %s executed", rule.Name), e)
+
+					if e != nil {
+						return
+					}
+				}
 			}
 
-			log.Err(c, "[process.NewSvc] GenerateReconciliation importReconcileMapToDB executed", e)
+			// internal transfers only take bank trx no system trx nor gateway payout settles
+			if transfer := s.comp.Config.Data.Reconciliation.Transfer; transfer.IsEnabled {
+				progressbarhelper.BarDescribe(bar, "[cyan][5/7] Mapping Reconciliation Data (transfer)...")
+				e = s.repo.RepoProcess.GenerateTransferMap(c, transfer.Days, transfer.OwnAccounts)
+				log.Err(c, "[process.NewSvc] GenerateReconciliation RepoProcess.GenerateTransferMap executed", e)
+			}
 
 			return
 		},
@@ -537,7 +1591,31 @@ func (s *Svc) GenerateReconciliation(ctx context.Context, afs afero.Fs, bar *pro
 				log.Err(c, "[process.NewSvc] GenerateReconciliation generateReconciliationSummaryAndFiles executed", e)
 			}()
 
-			returnData, e = s.generateReconciliationSummaryAndFiles(c, afs, s.comp.Config.IsDeleteCurrentReportDirectory)
+			if returnData, e = s.generateReconciliationSummaryAndFiles(c, afs, s.comp.Config.IsDeleteCurrentReportDirectory); e != nil {
+				return
+			}
+
+			if e = s.generateDuplicateFile(c, &returnData, duplicates, afs, s.comp.Config.IsDeleteCurrentReportDirectory); e != nil {
+				return
+			}
+
+			if e = s.generateBalanceFile(c, &returnData, trxData.BankStatementBalances, afs, s.comp.Config.IsDeleteCurrentReportDirectory); e != nil {
+				return
+			}
+
+			if e = s.generateCoverageFile(c, &returnData, coverage, afs, s.comp.Config.IsDeleteCurrentReportDirectory); e != nil {
+				return
+			}
+
+			if e = s.generateReversalFile(c, &returnData, afs, s.comp.Config.IsDeleteCurrentReportDirectory); e != nil {
+				return
+			}
+
+			if e = s.generateTransferFile(c, &returnData, afs, s.comp.Config.IsDeleteCurrentReportDirectory); e != nil {
+				return
+			}
+
+			e = s.generateGatewayFiles(c, &returnData, afs, s.comp.Config.IsDeleteCurrentReportDirectory)
 			return
 		},
 		func(c context.Context, i interface{}) (r interface{}, e error) {
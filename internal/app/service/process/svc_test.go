@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/csv"
 	"errors"
+	"io"
 	"io/fs"
 	"os"
 	"reflect"
@@ -25,12 +26,21 @@ import (
 	"github.com/oprekable/bank-reconcile/internal/app/repository/process"
 	mockprocess "github.com/oprekable/bank-reconcile/internal/app/repository/process/_mock"
 	mocksample "github.com/oprekable/bank-reconcile/internal/app/repository/sample/_mock"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/balance"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/duplicate"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/bca"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/bni"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/camt053"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/default_bank"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/mt940"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways/default_gateway"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems"
+	"github.com/samber/lo"
 	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/mock"
@@ -64,18 +74,33 @@ const (
 // newTestParserRegistry is a helper function to create a parser registry for testing purposes.
 func newTestParserRegistry() *banks.ParserRegistry {
 	factories := make(map[string]banks.BankParserFactory)
-	factories[string(banks.BCABankParser)] = func(bankName string, reader *csv.Reader, hasHeader bool) (banks.ReconcileBankData, error) {
-		return bca.NewBankParser(bankName, reader, hasHeader)
+	factories[string(banks.BCABankParser)] = func(bankName string, reader io.Reader, hasHeader bool, decimalPlaces int) (banks.ReconcileBankData, error) {
+		return bca.NewBankParser(bankName, csv.NewReader(reader), hasHeader, decimalPlaces, locale.Locale{})
 	}
-	factories[string(banks.BNIBankParser)] = func(bankName string, reader *csv.Reader, hasHeader bool) (banks.ReconcileBankData, error) {
-		return bni.NewBankParser(bankName, reader, hasHeader)
+	factories[string(banks.BNIBankParser)] = func(bankName string, reader io.Reader, hasHeader bool, decimalPlaces int) (banks.ReconcileBankData, error) {
+		return bni.NewBankParser(bankName, csv.NewReader(reader), hasHeader, decimalPlaces, locale.Locale{})
 	}
-	factories[string(banks.DefaultBankParser)] = func(bankName string, reader *csv.Reader, hasHeader bool) (banks.ReconcileBankData, error) {
-		return default_bank.NewBankParser(bankName, reader, hasHeader)
+	factories[string(banks.DefaultBankParser)] = func(bankName string, reader io.Reader, hasHeader bool, decimalPlaces int) (banks.ReconcileBankData, error) {
+		return default_bank.NewBankParser(bankName, csv.NewReader(reader), hasHeader, decimalPlaces, locale.Locale{})
+	}
+	factories[string(banks.MT940BankParser)] = func(bankName string, reader io.Reader, _ bool, decimalPlaces int) (banks.ReconcileBankData, error) {
+		return mt940.NewBankParser(bankName, reader, decimalPlaces)
+	}
+	factories[string(banks.Camt053BankParser)] = func(bankName string, reader io.Reader, _ bool, decimalPlaces int) (banks.ReconcileBankData, error) {
+		return camt053.NewBankParser(bankName, reader, decimalPlaces)
 	}
 	return banks.NewParserRegistry(factories)
 }
 
+// newTestGatewayParserRegistry is a helper function to create a gateway parser registry for testing purposes.
+func newTestGatewayParserRegistry() *gateways.ParserRegistry {
+	factories := make(map[string]gateways.GatewayParserFactory)
+	factories[string(gateways.DefaultGatewayParser)] = func(gatewayName string, reader *csv.Reader, hasHeader bool, decimalPlaces int) (gateways.ReconcileGatewayData, error) {
+		return default_gateway.NewGatewayParser(gatewayName, reader, hasHeader, decimalPlaces)
+	}
+	return gateways.NewParserRegistry(factories)
+}
+
 type MockOpenPermissionDeniedFs struct {
 	afero.MemMapFs
 }
@@ -95,10 +120,12 @@ func (r *MockRemoveAllPermissionDeniedFs) RemoveAll(_ string) error {
 func TestNewSvc(t *testing.T) {
 	ctx := context.Background()
 	testRegistry := newTestParserRegistry()
+	testGatewayRegistry := newTestGatewayParserRegistry()
 	type args struct {
-		comp           *component.Components
-		repo           *repository.Repositories
-		parserRegistry *banks.ParserRegistry
+		comp                  *component.Components
+		repo                  *repository.Repositories
+		parserRegistry        *banks.ParserRegistry
+		gatewayParserRegistry *gateways.ParserRegistry
 	}
 
 	tests := []struct {
@@ -111,7 +138,7 @@ func TestNewSvc(t *testing.T) {
 			args: args{
 				comp: component.NewComponents(
 					ctx,
-					&cconfig.Config{},
+					&cconfig.Config{Data: &config.Data{}},
 					&clogger.Logger{},
 					&cerror.Error{},
 					&csqlite.DBSqlite{},
@@ -122,12 +149,13 @@ func TestNewSvc(t *testing.T) {
 					mocksample.NewRepository(t),
 					mockprocess.NewRepository(t),
 				),
-				parserRegistry: testRegistry,
+				parserRegistry:        testRegistry,
+				gatewayParserRegistry: testGatewayRegistry,
 			},
 			want: NewSvc(
 				component.NewComponents(
 					ctx,
-					&cconfig.Config{},
+					&cconfig.Config{Data: &config.Data{}},
 					&clogger.Logger{},
 					&cerror.Error{},
 					&csqlite.DBSqlite{},
@@ -139,19 +167,125 @@ func TestNewSvc(t *testing.T) {
 					mockprocess.NewRepository(t),
 				),
 				testRegistry,
+				testGatewayRegistry,
 			),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewSvc(tt.args.comp, tt.args.repo, tt.args.parserRegistry); !reflect.DeepEqual(got, tt.want) {
+			if got := NewSvc(tt.args.comp, tt.args.repo, tt.args.parserRegistry, tt.args.gatewayParserRegistry); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewSvc() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestSvcCheckCoverage(t *testing.T) {
+	newComp := func(isFailOnCoverageGap bool) *component.Components {
+		return component.NewComponents(
+			context.Background(),
+			&cconfig.Config{
+				Data: &config.Data{
+					Reconciliation: reconciliation.Reconciliation{
+						FromDate: func() time.Time {
+							t, _ := time.Parse(DateFormat, DateFrom)
+							return t
+						}(),
+						ToDate: func() time.Time {
+							t, _ := time.Parse(DateFormat, "2025-03-07")
+							return t
+						}(),
+						ListBank:            []string{"BCA", "bni"},
+						IsFailOnCoverageGap: isFailOnCoverageGap,
+					},
+				},
+			},
+			&clogger.Logger{},
+			&cerror.Error{},
+			&csqlite.DBSqlite{},
+			&cfs.Fs{},
+			&cprofiler.Profiler{},
+		)
+	}
+
+	bankTrx := func(bank string, date string) *banks.BankTrxData {
+		t, _ := time.Parse(DateFormat, date)
+		return &banks.BankTrxData{Bank: bank, Date: t}
+	}
+
+	tests := []struct {
+		name           string
+		comp           *component.Components
+		data           []*banks.BankTrxData
+		wantReturnData []BankCoverage
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			comp: newComp(true),
+			data: []*banks.BankTrxData{
+				bankTrx("bca", "2025-03-06"),
+				bankTrx("bca", "2025-03-06"),
+				bankTrx("bca", "2025-03-07"),
+				bankTrx("BNI", "2025-03-06"),
+				bankTrx("bni", "2025-03-07"),
+				bankTrx("bni", "2025-03-08"),
+			},
+			wantReturnData: []BankCoverage{
+				{Bank: "bca", Date: "2025-03-06", Status: CoverageStatusCovered, TotalBankTrx: 2},
+				{Bank: "bca", Date: "2025-03-07", Status: CoverageStatusCovered, TotalBankTrx: 1},
+				{Bank: "bni", Date: "2025-03-06", Status: CoverageStatusCovered, TotalBankTrx: 1},
+				{Bank: "bni", Date: "2025-03-07", Status: CoverageStatusCovered, TotalBankTrx: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - gap",
+			comp: newComp(false),
+			data: []*banks.BankTrxData{
+				bankTrx("bca", "2025-03-07"),
+			},
+			wantReturnData: []BankCoverage{
+				{Bank: "bca", Date: "2025-03-06", Status: CoverageStatusGap, TotalBankTrx: 0},
+				{Bank: "bca", Date: "2025-03-07", Status: CoverageStatusCovered, TotalBankTrx: 1},
+				{Bank: "bni", Date: "2025-03-06", Status: CoverageStatusGap, TotalBankTrx: 0},
+				{Bank: "bni", Date: "2025-03-07", Status: CoverageStatusGap, TotalBankTrx: 0},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error - gap",
+			comp: newComp(true),
+			data: []*banks.BankTrxData{
+				bankTrx("bca", "2025-03-06"),
+				bankTrx("bca", "2025-03-07"),
+				bankTrx("bni", "2025-03-06"),
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp: tt.comp,
+			}
+
+			gotReturnData, err := s.checkCoverage(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkCoverage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("checkCoverage() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
 func TestSvcGenerateReconciliation(t *testing.T) {
 	ctx := context.Background()
 	var bf bytes.Buffer
@@ -224,6 +358,7 @@ func TestSvcGenerateReconciliation(t *testing.T) {
 							mock.Anything,
 							mock.Anything,
 							mock.Anything,
+							mock.Anything,
 						).Return(
 							nil,
 							nil,
@@ -252,7 +387,14 @@ func TestSvcGenerateReconciliation(t *testing.T) {
 						).Maybe()
 
 						m.On(
-							"GenerateReconciliationMap",
+							"GenerateReconciliationReferenceMap",
+							mock.Anything,
+							mock.Anything,
+						).Return(nil).Maybe()
+
+						m.On(
+							"GetReconciliationMapCandidate",
+							mock.Anything,
 							mock.Anything,
 							mock.Anything,
 							mock.Anything,
@@ -261,6 +403,12 @@ func TestSvcGenerateReconciliation(t *testing.T) {
 							nil,
 						).Maybe()
 
+						m.On(
+							"ImportReconciliationMap",
+							mock.Anything,
+							mock.Anything,
+						).Return(nil).Maybe()
+
 						m.On(
 							"GetReconciliationSummary",
 							mock.Anything,
@@ -271,6 +419,7 @@ func TestSvcGenerateReconciliation(t *testing.T) {
 								TotalNotMatchedTrx:  0,
 								SumSystemTrx:        0,
 								SumMatchedTrx:       0,
+								SumNotMatchedTrx:    0,
 								SumDiscrepanciesTrx: 0,
 							},
 							nil,
@@ -363,15 +512,23 @@ bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 				bar: progressbar.NewOptions(100, progressbar.OptionSetWidth(10), progressbar.OptionSetWriter(&bf)),
 			},
 			wantReturnData: ReconciliationSummary{
-				FileMissingBankTrx:              nil,
-				FileMissingSystemTrx:            "",
-				FileMatchedSystemTrx:            "",
+				FileMissingBankTrx:   nil,
+				FileMissingSystemTrx: "",
+				FileMatchedSystemTrx: "",
+				FileBalanceCheck:     ReportPath + "/balance/balance_1742017753.csv",
+				FileCoverage:         ReportPath + "/coverage/coverage_1742017753.csv",
+				CoverageGapByBank: map[string][]string{
+					"bca": {"2025-03-07", "2025-03-08", "2025-03-09"},
+					"bni": {"2025-03-06", "2025-03-07", "2025-03-08"},
+				},
 				TotalProcessedSystemTrx:         0,
 				TotalMatchedSystemTrx:           0,
 				TotalNotMatchedSystemTrx:        0,
 				SumAmountProcessedSystemTrx:     0,
 				SumAmountMatchedSystemTrx:       0,
 				SumAmountDiscrepanciesSystemTrx: 0,
+				TotalNoBalanceStatement:         2,
+				TotalCoverageGap:                6,
 			},
 			wantErr: false,
 		},
@@ -424,6 +581,7 @@ bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 							mock.Anything,
 							mock.Anything,
 							mock.Anything,
+							mock.Anything,
 						).Return(
 							nil,
 							nil,
@@ -452,7 +610,14 @@ bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 						).Maybe()
 
 						m.On(
-							"GenerateReconciliationMap",
+							"GenerateReconciliationReferenceMap",
+							mock.Anything,
+							mock.Anything,
+						).Return(nil).Maybe()
+
+						m.On(
+							"GetReconciliationMapCandidate",
+							mock.Anything,
 							mock.Anything,
 							mock.Anything,
 							mock.Anything,
@@ -461,6 +626,12 @@ bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 							nil,
 						).Maybe()
 
+						m.On(
+							"ImportReconciliationMap",
+							mock.Anything,
+							mock.Anything,
+						).Return(nil).Maybe()
+
 						m.On(
 							"GetReconciliationSummary",
 							mock.Anything,
@@ -471,6 +642,7 @@ bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 								TotalNotMatchedTrx:  0,
 								SumSystemTrx:        0,
 								SumMatchedTrx:       0,
+								SumNotMatchedTrx:    0,
 								SumDiscrepanciesTrx: 0,
 							},
 							nil,
@@ -563,15 +735,23 @@ bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 				bar: progressbar.NewOptions(100, progressbar.OptionSetWidth(10), progressbar.OptionSetWriter(&bf)),
 			},
 			wantReturnData: ReconciliationSummary{
-				FileMissingBankTrx:              nil,
-				FileMissingSystemTrx:            "",
-				FileMatchedSystemTrx:            "",
+				FileMissingBankTrx:   nil,
+				FileMissingSystemTrx: "",
+				FileMatchedSystemTrx: "",
+				FileBalanceCheck:     ReportPath + "/balance/balance_1742017753.csv",
+				FileCoverage:         ReportPath + "/coverage/coverage_1742017753.csv",
+				CoverageGapByBank: map[string][]string{
+					"bca": {"2025-03-07", "2025-03-08", "2025-03-09"},
+					"bni": {"2025-03-06", "2025-03-07", "2025-03-08"},
+				},
 				TotalProcessedSystemTrx:         0,
 				TotalMatchedSystemTrx:           0,
 				TotalNotMatchedSystemTrx:        0,
 				SumAmountProcessedSystemTrx:     0,
 				SumAmountMatchedSystemTrx:       0,
 				SumAmountDiscrepanciesSystemTrx: 0,
+				TotalNoBalanceStatement:         2,
+				TotalCoverageGap:                6,
 			},
 			wantErr: false,
 		},
@@ -583,6 +763,7 @@ bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 				tt.fields.comp,
 				tt.fields.repo,
 				tt.fields.parserRegistry,
+				newTestGatewayParserRegistry(),
 			)
 
 			gotReturnData, err := s.GenerateReconciliation(tt.args.ctxFn(ctx), tt.args.afs, tt.args.bar)
@@ -616,17 +797,21 @@ func TestSvcGenerateReconciliationFiles(t *testing.T) {
 	}
 
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
+		name                                 string
+		fields                               fields
+		args                                 args
+		wantTotalNotMatchedSystemTrxByReason map[string]int
+		wantTotalNotMatchedBankTrxByReason   map[string]int
+		wantFileMissingBankTrx               []string
+		wantTotalReviewSystemTrx             int64
+		wantErr                              bool
 	}{
 		{
 			name: "Ok - nil reconciliationSummary",
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
-					&cconfig.Config{},
+					&cconfig.Config{Data: &config.Data{}},
 					&clogger.Logger{},
 					&cerror.Error{},
 					&csqlite.DBSqlite{},
@@ -984,6 +1169,7 @@ func TestSvcGenerateReconciliationFiles(t *testing.T) {
 									TransactionTime: TrxDateTimeOne,
 									Type:            "DEBIT",
 									Amount:          41000,
+									Reason:          process.UnmatchedReasonAmountMismatch,
 								},
 							},
 							nil,
@@ -999,6 +1185,7 @@ func TestSvcGenerateReconciliationFiles(t *testing.T) {
 									Bank:             "bca",
 									Date:             DateFrom,
 									Amount:           41000,
+									Reason:           process.UnmatchedReasonNoTrxOnDate,
 								},
 							},
 							nil,
@@ -1017,49 +1204,12 @@ func TestSvcGenerateReconciliationFiles(t *testing.T) {
 				}(),
 				isDeleteDirectory: true,
 			},
-			wantErr: false,
+			wantTotalNotMatchedSystemTrxByReason: map[string]int{process.UnmatchedReasonAmountMismatch: 1},
+			wantTotalNotMatchedBankTrxByReason:   map[string]int{process.UnmatchedReasonNoTrxOnDate: 1},
+			wantErr:                              false,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := &Svc{
-				comp:           tt.fields.comp,
-				repo:           tt.fields.repo,
-				parserRegistry: tt.fields.parserRegistry,
-			}
-
-			if err := s.generateReconciliationFiles(ctx, tt.args.reconciliationSummary, tt.args.fs, tt.args.isDeleteDirectory); (err != nil) != tt.wantErr {
-				t.Errorf("generateReconciliationFiles() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
-func TestSvcGenerateReconciliationSummaryAndFiles(t *testing.T) {
-	ctx := context.Background()
-	testRegistry := newTestParserRegistry()
-	type fields struct {
-		comp           *component.Components
-		repo           *repository.Repositories
-		parserRegistry *banks.ParserRegistry
-	}
-
-	type args struct {
-		ctxFn             func(context.Context) context.Context
-		fs                afero.Fs
-		isDeleteDirectory bool
-	}
-
-	tests := []struct {
-		name           string
-		fields         fields
-		args           args
-		wantReturnData ReconciliationSummary
-		wantErr        bool
-	}{
 		{
-			name: "Ok",
+			name: "Ok - with suggestion",
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
@@ -1068,6 +1218,11 @@ func TestSvcGenerateReconciliationSummaryAndFiles(t *testing.T) {
 							Data: &config.Data{
 								Reconciliation: reconciliation.Reconciliation{
 									ReportTRXPath: ReportPath,
+									Suggestion: reconciliation.Suggestion{
+										MaxCount:         3,
+										Days:             3,
+										AmountPercentage: 5,
+									},
 								},
 							},
 						}
@@ -1084,59 +1239,75 @@ func TestSvcGenerateReconciliationSummaryAndFiles(t *testing.T) {
 						m := mockprocess.NewRepository(t)
 
 						m.On(
-							"GetReconciliationSummary",
+							"GetMatchedTrx",
 							mock.Anything,
 						).Return(
-							process.ReconciliationSummary{
-								TotalSystemTrx:      0,
-								TotalMatchedTrx:     0,
-								TotalNotMatchedTrx:  0,
-								SumSystemTrx:        0,
-								SumMatchedTrx:       0,
-								SumDiscrepanciesTrx: 0,
+							[]process.MatchedTrx{
+								{
+									SystemTrxTrxID:           "006630c83821fac6bea13b92b480feb2",
+									BankTrxUniqueIdentifier:  BCAUniqueUUID,
+									SystemTrxTransactionTime: TrxDateTimeOne,
+									BankTrxDate:              DateFrom,
+									SystemTrxType:            "DEBIT",
+									Bank:                     "bca",
+									SystemTrxAmount:          41000,
+									BankTrxAmount:            41000,
+								},
 							},
 							nil,
 						).Maybe()
 
 						m.On(
-							"GetMatchedTrx",
+							"GetNotMatchedSystemTrx",
 							mock.Anything,
 						).Return(
-							[]process.MatchedTrx{
+							[]process.NotMatchedSystemTrx{
 								{
-									SystemTrxTrxID:           "",
-									BankTrxUniqueIdentifier:  "",
-									SystemTrxTransactionTime: "",
-									BankTrxDate:              "",
-									SystemTrxType:            "",
-									Bank:                     "",
-									SystemTrxAmount:          0,
-									BankTrxAmount:            0,
+									TrxID:           "006630c83821fac6bea13b92b480feb2",
+									TransactionTime: TrxDateTimeOne,
+									Type:            "DEBIT",
+									Amount:          41000,
+									Reason:          process.UnmatchedReasonAmountMismatch,
 								},
 							},
 							nil,
 						).Maybe()
 
 						m.On(
-							"GetNotMatchedSystemTrx",
+							"GetNotMatchedSystemTrxSuggestion",
 							mock.Anything,
+							3,
+							3,
+							float64(5),
 						).Return(
-							[]process.NotMatchedSystemTrx{
+							[]process.NotMatchedSystemTrxSuggestion{
 								{
-									TrxID:           "",
-									TransactionTime: "",
-									Type:            "",
-									Amount:          0,
+									TrxID:                   "006630c83821fac6bea13b92b480feb2",
+									Rank:                    1,
+									BankTrxUniqueIdentifier: BCAUniqueUUID,
+									Bank:                    "bca",
+									BankTrxDate:             DateFrom,
+									BankTrxAmount:           -41000,
+									DateDifference:          1,
+									Distance:                0.3333,
 								},
 							},
 							nil,
-						).Maybe()
+						)
 
 						m.On(
 							"GetNotMatchedBankTrx",
 							mock.Anything,
 						).Return(
-							[]process.NotMatchedBankTrx{},
+							[]process.NotMatchedBankTrx{
+								{
+									UniqueIdentifier: BCAUniqueUUID,
+									Bank:             "bca",
+									Date:             DateFrom,
+									Amount:           41000,
+									Reason:           process.UnmatchedReasonNoTrxOnDate,
+								},
+							},
 							nil,
 						).Maybe()
 
@@ -1146,73 +1317,19 @@ func TestSvcGenerateReconciliationSummaryAndFiles(t *testing.T) {
 				parserRegistry: testRegistry,
 			},
 			args: args{
-				ctxFn: func(c context.Context) context.Context {
-					ctx, _ := testclock.UseTime(c, time.Unix(1742017753, 0))
-					return ctx
-				},
+				reconciliationSummary: &ReconciliationSummary{},
 				fs: func() afero.Fs {
 					f := afero.NewMemMapFs()
 					return f
 				}(),
 				isDeleteDirectory: true,
 			},
-			wantReturnData: ReconciliationSummary{
-				FileMissingBankTrx:              nil,
-				FileMissingSystemTrx:            "/report/system/not_matched/not_matched_1742017753.csv",
-				FileMatchedSystemTrx:            "/report/system/matched/matched_1742017753.csv",
-				TotalProcessedSystemTrx:         0,
-				TotalMatchedSystemTrx:           0,
-				TotalNotMatchedSystemTrx:        0,
-				SumAmountProcessedSystemTrx:     0,
-				SumAmountMatchedSystemTrx:       0,
-				SumAmountDiscrepanciesSystemTrx: 0,
-			},
-			wantErr: false,
+			wantTotalNotMatchedSystemTrxByReason: map[string]int{process.UnmatchedReasonAmountMismatch: 1},
+			wantTotalNotMatchedBankTrxByReason:   map[string]int{process.UnmatchedReasonNoTrxOnDate: 1},
+			wantErr:                              false,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := &Svc{
-				comp:           tt.fields.comp,
-				repo:           tt.fields.repo,
-				parserRegistry: tt.fields.parserRegistry,
-			}
-
-			gotReturnData, err := s.generateReconciliationSummaryAndFiles(tt.args.ctxFn(ctx), tt.args.fs, tt.args.isDeleteDirectory)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("generateReconciliationSummaryAndFiles() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-
-			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
-				t.Errorf("generateReconciliationSummaryAndFiles() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
-			}
-		})
-	}
-}
-
-func TestSvcImportReconcileBankDataToDB(t *testing.T) {
-	ctx := context.Background()
-	testRegistry := newTestParserRegistry()
-	type fields struct {
-		comp           *component.Components
-		repo           *repository.Repositories
-		parserRegistry *banks.ParserRegistry
-	}
-
-	type args struct {
-		data []*banks.BankTrxData
-	}
-
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
-	}{
 		{
-			name: "Ok",
+			name: "Error - GetNotMatchedSystemTrxSuggestion",
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
@@ -1220,7 +1337,12 @@ func TestSvcImportReconcileBankDataToDB(t *testing.T) {
 						return &cconfig.Config{
 							Data: &config.Data{
 								Reconciliation: reconciliation.Reconciliation{
-									NumberWorker: 2,
+									ReportTRXPath: ReportPath,
+									Suggestion: reconciliation.Suggestion{
+										MaxCount:         3,
+										Days:             3,
+										AmountPercentage: 5,
+									},
 								},
 							},
 						}
@@ -1235,149 +1357,86 @@ func TestSvcImportReconcileBankDataToDB(t *testing.T) {
 					mocksample.NewRepository(t),
 					func() process.Repository {
 						m := mockprocess.NewRepository(t)
+
 						m.On(
-							"ImportBankTrx",
-							mock.Anything,
-							mock.Anything,
-							mock.Anything,
+							"GetMatchedTrx",
 							mock.Anything,
-						).Return(nil).Maybe()
-						return m
-					}(),
-				),
-				parserRegistry: testRegistry,
-			},
-			args: args{
-				data: []*banks.BankTrxData{
-					{
-						UniqueIdentifier: BCAUniqueUUID,
-						Date: func() time.Time {
-							t, _ := time.Parse(DateFormat, DateFrom)
-							return t
-						}(),
-						Type:     "CREDIT",
-						Bank:     "BCA",
-						FilePath: FileCSVPath,
-						Amount:   7700,
-					},
-					{
-						UniqueIdentifier: BNIUniqueUUID,
-						Date: func() time.Time {
-							t, _ := time.Parse(DateFormat, DateTo)
-							return t
-						}(),
-						Type:     "DEBIT",
-						Bank:     "BNI",
-						FilePath: "/random_string/bni/any_string.csv",
-						Amount:   71200,
-					},
-				},
-			},
-			wantErr: false,
-		},
-		{
-			name: "Error",
-			fields: fields{
-				comp: component.NewComponents(
-					ctx,
-					func() *cconfig.Config {
-						return &cconfig.Config{
-							Data: &config.Data{
-								Reconciliation: reconciliation.Reconciliation{
-									NumberWorker: 2,
+						).Return(
+							[]process.MatchedTrx{
+								{
+									SystemTrxTrxID:           "006630c83821fac6bea13b92b480feb2",
+									BankTrxUniqueIdentifier:  BCAUniqueUUID,
+									SystemTrxTransactionTime: TrxDateTimeOne,
+									BankTrxDate:              DateFrom,
+									SystemTrxType:            "DEBIT",
+									Bank:                     "bca",
+									SystemTrxAmount:          41000,
+									BankTrxAmount:            41000,
 								},
 							},
-						}
-					}(),
-					&clogger.Logger{},
-					&cerror.Error{},
-					&csqlite.DBSqlite{},
-					&cfs.Fs{},
-					&cprofiler.Profiler{},
-				),
-				repo: repository.NewRepositories(
-					mocksample.NewRepository(t),
-					func() process.Repository {
-						m := mockprocess.NewRepository(t)
+							nil,
+						).Maybe()
+
 						m.On(
-							"ImportBankTrx",
-							mock.Anything,
+							"GetNotMatchedSystemTrx",
 							mock.Anything,
+						).Return(
+							[]process.NotMatchedSystemTrx{
+								{
+									TrxID:           "006630c83821fac6bea13b92b480feb2",
+									TransactionTime: TrxDateTimeOne,
+									Type:            "DEBIT",
+									Amount:          41000,
+									Reason:          process.UnmatchedReasonAmountMismatch,
+								},
+							},
+							nil,
+						).Maybe()
+
+						m.On(
+							"GetNotMatchedSystemTrxSuggestion",
 							mock.Anything,
+							3,
+							3,
+							float64(5),
+						).Return(
+							nil,
+							errors.New("GetNotMatchedSystemTrxSuggestion error"),
+						)
+
+						m.On(
+							"GetNotMatchedBankTrx",
 							mock.Anything,
-						).Return(errors.New("error")).Maybe()
+						).Return(
+							[]process.NotMatchedBankTrx{
+								{
+									UniqueIdentifier: BCAUniqueUUID,
+									Bank:             "bca",
+									Date:             DateFrom,
+									Amount:           41000,
+									Reason:           process.UnmatchedReasonNoTrxOnDate,
+								},
+							},
+							nil,
+						).Maybe()
+
 						return m
 					}(),
 				),
 				parserRegistry: testRegistry,
 			},
 			args: args{
-				data: []*banks.BankTrxData{
-					{
-						UniqueIdentifier: BCAUniqueUUID,
-						Date: func() time.Time {
-							t, _ := time.Parse(DateFormat, DateFrom)
-							return t
-						}(),
-						Type:     "CREDIT",
-						Bank:     "BCA",
-						FilePath: FileCSVPath,
-						Amount:   7700,
-					},
-					{
-						UniqueIdentifier: BNIUniqueUUID,
-						Date: func() time.Time {
-							t, _ := time.Parse(DateFormat, DateTo)
-							return t
-						}(),
-						Type:     "DEBIT",
-						Bank:     "BNI",
-						FilePath: "/random_string/bni/any_string.csv",
-						Amount:   71200,
-					},
-				},
+				reconciliationSummary: &ReconciliationSummary{},
+				fs: func() afero.Fs {
+					f := afero.NewMemMapFs()
+					return f
+				}(),
+				isDeleteDirectory: true,
 			},
 			wantErr: true,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := &Svc{
-				comp:           tt.fields.comp,
-				repo:           tt.fields.repo,
-				parserRegistry: tt.fields.parserRegistry,
-			}
-
-			if err := s.importReconcileBankDataToDB(ctx, tt.args.data); (err != nil) != tt.wantErr {
-				t.Errorf("importReconcileBankDataToDB() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
-func TestSvcImportReconcileMapToDB(t *testing.T) {
-	ctx := context.Background()
-	testRegistry := newTestParserRegistry()
-	type fields struct {
-		comp           *component.Components
-		repo           *repository.Repositories
-		parserRegistry *banks.ParserRegistry
-	}
-
-	type args struct {
-		min float64
-		max float64
-	}
-
-	tests := []struct {
-		fields  fields
-		name    string
-		args    args
-		wantErr bool
-	}{
 		{
-			name: "Ok",
+			name: "Ok - below review confidence threshold",
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
@@ -1385,7 +1444,8 @@ func TestSvcImportReconcileMapToDB(t *testing.T) {
 						return &cconfig.Config{
 							Data: &config.Data{
 								Reconciliation: reconciliation.Reconciliation{
-									NumberWorker: 2,
+									ReportTRXPath:             ReportPath,
+									ReviewConfidenceThreshold: 0.8,
 								},
 							},
 						}
@@ -1400,25 +1460,72 @@ func TestSvcImportReconcileMapToDB(t *testing.T) {
 					mocksample.NewRepository(t),
 					func() process.Repository {
 						m := mockprocess.NewRepository(t)
+
 						m.On(
-							"GenerateReconciliationMap",
+							"GetMatchedTrx",
 							mock.Anything,
+						).Return(
+							[]process.MatchedTrx{
+								{
+									SystemTrxTrxID:           "006630c83821fac6bea13b92b480feb2",
+									BankTrxUniqueIdentifier:  BCAUniqueUUID,
+									SystemTrxTransactionTime: TrxDateTimeOne,
+									BankTrxDate:              DateFrom,
+									SystemTrxType:            "DEBIT",
+									Bank:                     "bca",
+									SystemTrxAmount:          41000,
+									BankTrxAmount:            41000,
+									Confidence:               1,
+								},
+								{
+									SystemTrxTrxID:           "0012d068c53eb0971fc8563343c5d81f",
+									BankTrxUniqueIdentifier:  BNIUniqueUUID,
+									SystemTrxTransactionTime: TrxDateTimeOne,
+									BankTrxDate:              DateFrom,
+									SystemTrxType:            "DEBIT",
+									Bank:                     "bni",
+									SystemTrxAmount:          41000,
+									BankTrxAmount:            41000,
+									Confidence:               0.5,
+								},
+							},
+							nil,
+						).Maybe()
+
+						m.On(
+							"GetNotMatchedSystemTrx",
 							mock.Anything,
+						).Return(
+							nil,
+							nil,
+						).Maybe()
+
+						m.On(
+							"GetNotMatchedBankTrx",
 							mock.Anything,
-						).Return(nil).Maybe()
+						).Return(
+							nil,
+							nil,
+						).Maybe()
+
 						return m
 					}(),
 				),
 				parserRegistry: testRegistry,
 			},
 			args: args{
-				min: 1,
-				max: 10,
+				reconciliationSummary: &ReconciliationSummary{},
+				fs: func() afero.Fs {
+					f := afero.NewMemMapFs()
+					return f
+				}(),
+				isDeleteDirectory: true,
 			},
-			wantErr: false,
+			wantTotalReviewSystemTrx: 1,
+			wantErr:                  false,
 		},
 		{
-			name: "Error",
+			name: "Ok - bank reports per account",
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
@@ -1426,7 +1533,7 @@ func TestSvcImportReconcileMapToDB(t *testing.T) {
 						return &cconfig.Config{
 							Data: &config.Data{
 								Reconciliation: reconciliation.Reconciliation{
-									NumberWorker: 2,
+									ReportTRXPath: ReportPath,
 								},
 							},
 						}
@@ -1441,22 +1548,70 @@ func TestSvcImportReconcileMapToDB(t *testing.T) {
 					mocksample.NewRepository(t),
 					func() process.Repository {
 						m := mockprocess.NewRepository(t)
+
 						m.On(
-							"GenerateReconciliationMap",
+							"GetMatchedTrx",
 							mock.Anything,
+						).Return(
+							nil,
+							nil,
+						).Maybe()
+
+						m.On(
+							"GetNotMatchedSystemTrx",
 							mock.Anything,
+						).Return(
+							nil,
+							nil,
+						).Maybe()
+
+						m.On(
+							"GetNotMatchedBankTrx",
 							mock.Anything,
-						).Return(errors.New("error")).Maybe()
+						).Return(
+							[]process.NotMatchedBankTrx{
+								{
+									UniqueIdentifier: BCAUniqueUUID,
+									Bank:             "BCA",
+									Account:          "1234567890",
+									Date:             DateFrom,
+									Amount:           41000,
+									Reason:           process.UnmatchedReasonNoTrxOnDate,
+								},
+								{
+									UniqueIdentifier: "bca-2",
+									Bank:             "bca",
+									Account:          "0987654321",
+									Date:             DateFrom,
+									Amount:           42000,
+									Reason:           process.UnmatchedReasonNoTrxOnDate,
+								},
+								{
+									UniqueIdentifier: BNIUniqueUUID,
+									Bank:             "bni",
+									Date:             DateFrom,
+									Amount:           43000,
+									Reason:           process.UnmatchedReasonNoTrxOnDate,
+								},
+							},
+							nil,
+						).Maybe()
+
 						return m
 					}(),
 				),
 				parserRegistry: testRegistry,
 			},
 			args: args{
-				min: 1,
-				max: 10,
+				reconciliationSummary: &ReconciliationSummary{},
+				fs:                    afero.NewMemMapFs(),
+				isDeleteDirectory:     true,
 			},
-			wantErr: true,
+			wantTotalNotMatchedBankTrxByReason: map[string]int{
+				process.UnmatchedReasonNoTrxOnDate: 3,
+			},
+			wantFileMissingBankTrx: []string{"bca_0987654321", "bca_1234567890", "bni"},
+			wantErr:                false,
 		},
 	}
 
@@ -1468,34 +1623,2710 @@ func TestSvcImportReconcileMapToDB(t *testing.T) {
 				parserRegistry: tt.fields.parserRegistry,
 			}
 
-			if err := s.importReconcileMapToDB(ctx, tt.args.min, tt.args.max); (err != nil) != tt.wantErr {
-				t.Errorf("importReconcileMapToDB() error = %v, wantErr %v", err, tt.wantErr)
-			}
+			if err := s.generateReconciliationFiles(ctx, tt.args.reconciliationSummary, tt.args.fs, tt.args.isDeleteDirectory); (err != nil) != tt.wantErr {
+				t.Errorf("generateReconciliationFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr || tt.args.reconciliationSummary == nil {
+				return
+			}
+
+			if tt.args.reconciliationSummary.TotalReviewSystemTrx != tt.wantTotalReviewSystemTrx {
+				t.Errorf("generateReconciliationFiles() TotalReviewSystemTrx = %v, want %v", tt.args.reconciliationSummary.TotalReviewSystemTrx, tt.wantTotalReviewSystemTrx)
+			}
+
+			if !reflect.DeepEqual(tt.args.reconciliationSummary.TotalNotMatchedSystemTrxByReason, tt.wantTotalNotMatchedSystemTrxByReason) {
+				t.Errorf("generateReconciliationFiles() TotalNotMatchedSystemTrxByReason = %v, want %v", tt.args.reconciliationSummary.TotalNotMatchedSystemTrxByReason, tt.wantTotalNotMatchedSystemTrxByReason)
+			}
+
+			if !reflect.DeepEqual(tt.args.reconciliationSummary.TotalNotMatchedBankTrxByReason, tt.wantTotalNotMatchedBankTrxByReason) {
+				t.Errorf("generateReconciliationFiles() TotalNotMatchedBankTrxByReason = %v, want %v", tt.args.reconciliationSummary.TotalNotMatchedBankTrxByReason, tt.wantTotalNotMatchedBankTrxByReason)
+			}
+
+			if tt.wantFileMissingBankTrx != nil {
+				gotFileMissingBankTrx := lo.Keys(tt.args.reconciliationSummary.FileMissingBankTrx)
+				sort.Strings(gotFileMissingBankTrx)
+				if !reflect.DeepEqual(gotFileMissingBankTrx, tt.wantFileMissingBankTrx) {
+					t.Errorf("generateReconciliationFiles() FileMissingBankTrx = %v, want %v", gotFileMissingBankTrx, tt.wantFileMissingBankTrx)
+				}
+			}
+		})
+	}
+}
+
+func TestSvcGenerateBalanceFile(t *testing.T) {
+	ctx, _ := testclock.UseTime(context.Background(), time.Unix(1742017753, 0))
+	comp := component.NewComponents(
+		ctx,
+		&cconfig.Config{
+			Data: &config.Data{
+				Reconciliation: reconciliation.Reconciliation{
+					ReportTRXPath:         ReportPath,
+					CurrencyDecimalPlaces: 2,
+				},
+			},
+		},
+		&clogger.Logger{},
+		&cerror.Error{},
+		&csqlite.DBSqlite{},
+		&cfs.Fs{},
+		&cprofiler.Profiler{},
+	)
+
+	type args struct {
+		fs       afero.Fs
+		balances []balance.Result
+	}
+
+	tests := []struct {
+		args        args
+		name        string
+		wantSummary ReconciliationSummary
+		wantFile    string
+		wantErr     bool
+	}{
+		{
+			name: "Ok",
+			args: args{
+				fs: afero.NewMemMapFs(),
+				balances: []balance.Result{
+					{Bank: "bca", Account: "111", FilePath: "/bank/bca/111/a.csv", Status: balance.StatusBalanced, OpeningBalance: 1000, Movement: 500, ClosingBalance: 1500, TotalLine: 2},
+					{Bank: "bca", Account: "222", FilePath: "/bank/bca/222/a.csv", Status: balance.StatusNotBalanced, FirstBreakID: "u2", OpeningBalance: 1000, Movement: 500, ClosingBalance: 1400, Difference: -100, TotalLine: 2, TotalBreak: 1},
+					{Bank: "bni", FilePath: "/bank/bni/a.csv", Status: balance.StatusNoBalance, Movement: 500, TotalLine: 1},
+				},
+			},
+			wantSummary: ReconciliationSummary{
+				FileBalanceCheck:          ReportPath + "/balance/balance_1742017753.csv",
+				TotalBalancedStatement:    1,
+				TotalNotBalancedStatement: 1,
+				TotalNoBalanceStatement:   1,
+			},
+			wantFile: "Bank,Account,FilePath,Status,FirstBreakID,OpeningBalance,Movement,ClosingBalance,Difference,TotalLine,TotalBreak\n" +
+				"bca,111,/bank/bca/111/a.csv,BALANCED,,10.00,5.00,15.00,0.00,2,0\n" +
+				"bca,222,/bank/bca/222/a.csv,NOT_BALANCED,u2,10.00,5.00,14.00,-1.00,2,1\n" +
+				"bni,,/bank/bni/a.csv,NO_BALANCE,,0.00,5.00,0.00,0.00,1,0\n",
+			wantErr: false,
+		},
+		{
+			name: "Ok - no statement",
+			args: args{
+				fs:       afero.NewMemMapFs(),
+				balances: nil,
+			},
+			wantSummary: ReconciliationSummary{},
+			wantErr:     false,
+		},
+		{
+			name: "Error - read only fs",
+			args: args{
+				fs: afero.NewReadOnlyFs(afero.NewMemMapFs()),
+				balances: []balance.Result{
+					{Bank: "bca", FilePath: "/bank/bca/a.csv", Status: balance.StatusBalanced},
+				},
+			},
+			wantSummary: ReconciliationSummary{
+				TotalBalancedStatement: 1,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp: comp,
+			}
+
+			gotSummary := ReconciliationSummary{}
+			if err := s.generateBalanceFile(ctx, &gotSummary, tt.args.balances, tt.args.fs, false); (err != nil) != tt.wantErr {
+				t.Errorf("generateBalanceFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(gotSummary, tt.wantSummary) {
+				t.Errorf("generateBalanceFile() summary = %v, want %v", gotSummary, tt.wantSummary)
+			}
+
+			if tt.wantFile == "" {
+				return
+			}
+
+			if got, _ := afero.ReadFile(tt.args.fs, gotSummary.FileBalanceCheck); string(got) != tt.wantFile {
+				t.Errorf("generateBalanceFile() file = %q, want %q", got, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestSvcGenerateCoverageFile(t *testing.T) {
+	ctx, _ := testclock.UseTime(context.Background(), time.Unix(1742017753, 0))
+	comp := component.NewComponents(
+		ctx,
+		&cconfig.Config{
+			Data: &config.Data{
+				Reconciliation: reconciliation.Reconciliation{
+					ReportTRXPath: ReportPath,
+				},
+			},
+		},
+		&clogger.Logger{},
+		&cerror.Error{},
+		&csqlite.DBSqlite{},
+		&cfs.Fs{},
+		&cprofiler.Profiler{},
+	)
+
+	type args struct {
+		fs       afero.Fs
+		coverage []BankCoverage
+	}
+
+	tests := []struct {
+		args        args
+		name        string
+		wantSummary ReconciliationSummary
+		wantFile    string
+		wantErr     bool
+	}{
+		{
+			name: "Ok",
+			args: args{
+				fs: afero.NewMemMapFs(),
+				coverage: []BankCoverage{
+					{Bank: "bca", Date: "2025-03-06", Status: CoverageStatusCovered, TotalBankTrx: 2},
+					{Bank: "bca", Date: "2025-03-07", Status: CoverageStatusGap},
+					{Bank: "bni", Date: "2025-03-06", Status: CoverageStatusGap},
+					{Bank: "bni", Date: "2025-03-07", Status: CoverageStatusGap},
+				},
+			},
+			wantSummary: ReconciliationSummary{
+				FileCoverage: ReportPath + "/coverage/coverage_1742017753.csv",
+				CoverageGapByBank: map[string][]string{
+					"bca": {"2025-03-07"},
+					"bni": {"2025-03-06", "2025-03-07"},
+				},
+				TotalCoverageGap: 3,
+			},
+			wantFile: "Bank,Date,Status,TotalBankTrx\n" +
+				"bca,2025-03-06,COVERED,2\n" +
+				"bca,2025-03-07,GAP,0\n" +
+				"bni,2025-03-06,GAP,0\n" +
+				"bni,2025-03-07,GAP,0\n",
+			wantErr: false,
+		},
+		{
+			name: "Ok - no bank",
+			args: args{
+				fs:       afero.NewMemMapFs(),
+				coverage: nil,
+			},
+			wantSummary: ReconciliationSummary{},
+			wantErr:     false,
+		},
+		{
+			name: "Error - read only fs",
+			args: args{
+				fs: afero.NewReadOnlyFs(afero.NewMemMapFs()),
+				coverage: []BankCoverage{
+					{Bank: "bca", Date: "2025-03-06", Status: CoverageStatusCovered, TotalBankTrx: 2},
+				},
+			},
+			wantSummary: ReconciliationSummary{},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp: comp,
+			}
+
+			gotSummary := ReconciliationSummary{}
+			if err := s.generateCoverageFile(ctx, &gotSummary, tt.args.coverage, tt.args.fs, false); (err != nil) != tt.wantErr {
+				t.Errorf("generateCoverageFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(gotSummary, tt.wantSummary) {
+				t.Errorf("generateCoverageFile() summary = %v, want %v", gotSummary, tt.wantSummary)
+			}
+
+			if tt.wantFile == "" {
+				return
+			}
+
+			if got, _ := afero.ReadFile(tt.args.fs, gotSummary.FileCoverage); string(got) != tt.wantFile {
+				t.Errorf("generateCoverageFile() file = %q, want %q", got, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestSvcGenerateDuplicateFile(t *testing.T) {
+	ctx, _ := testclock.UseTime(context.Background(), time.Unix(1742017753, 0))
+	comp := component.NewComponents(
+		ctx,
+		&cconfig.Config{
+			Data: &config.Data{
+				Reconciliation: reconciliation.Reconciliation{
+					ReportTRXPath:         ReportPath,
+					CurrencyDecimalPlaces: 2,
+				},
+			},
+		},
+		&clogger.Logger{},
+		&cerror.Error{},
+		&csqlite.DBSqlite{},
+		&cfs.Fs{},
+		&cprofiler.Profiler{},
+	)
+
+	type args struct {
+		fs         afero.Fs
+		duplicates []DuplicateTrx
+	}
+
+	tests := []struct {
+		args        args
+		name        string
+		wantSummary ReconciliationSummary
+		wantFile    string
+		wantErr     bool
+	}{
+		{
+			name: "Ok",
+			args: args{
+				fs: afero.NewMemMapFs(),
+				duplicates: []DuplicateTrx{
+					{Source: DuplicateSourceSystem, ID: "t1", Kind: duplicate.KindExactKey, Action: duplicate.ActionKept, Amount: 100},
+					{Source: DuplicateSourceSystem, ID: "t1", DuplicateOf: "t1", Kind: duplicate.KindExactKey, Action: duplicate.ActionRejected, Amount: 100},
+					{Source: DuplicateSourceBank, Bank: "bca", ID: "u1", Kind: duplicate.KindContent, Action: duplicate.ActionRejected, Amount: 100},
+					{Source: DuplicateSourceBank, Bank: "bca", ID: "u2", DuplicateOf: "u1", Kind: duplicate.KindContent, Action: duplicate.ActionRejected, Amount: 100},
+				},
+			},
+			wantSummary: ReconciliationSummary{
+				FileDuplicateTrx:          ReportPath + "/duplicate/duplicate_1742017753.csv",
+				TotalDuplicateSystemTrx:   1,
+				TotalDuplicateBankTrx:     1,
+				TotalRejectedDuplicateTrx: 3,
+			},
+			wantFile: "Source,Bank,Account,ID,DuplicateOf,Kind,Action,Date,Type,Amount,Currency,OriginalAmount,FilePath\n" +
+				"SYSTEM,,,t1,,EXACT_KEY,KEPT,,,1.00,,0.00,\n" +
+				"SYSTEM,,,t1,t1,EXACT_KEY,REJECTED,,,1.00,,0.00,\n" +
+				"BANK,bca,,u1,,CONTENT,REJECTED,,,1.00,,0.00,\n" +
+				"BANK,bca,,u2,u1,CONTENT,REJECTED,,,1.00,,0.00,\n",
+			wantErr: false,
+		},
+		{
+			name: "Ok - no duplicate",
+			args: args{
+				fs:         afero.NewMemMapFs(),
+				duplicates: nil,
+			},
+			wantSummary: ReconciliationSummary{},
+			wantErr:     false,
+		},
+		{
+			name: "Error - read only fs",
+			args: args{
+				fs: afero.NewReadOnlyFs(afero.NewMemMapFs()),
+				duplicates: []DuplicateTrx{
+					{Source: DuplicateSourceSystem, ID: "t1", DuplicateOf: "t1", Kind: duplicate.KindExactKey, Action: duplicate.ActionKept},
+				},
+			},
+			wantSummary: ReconciliationSummary{
+				TotalDuplicateSystemTrx: 1,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp: comp,
+			}
+
+			gotSummary := ReconciliationSummary{}
+			if err := s.generateDuplicateFile(ctx, &gotSummary, tt.args.duplicates, tt.args.fs, false); (err != nil) != tt.wantErr {
+				t.Errorf("generateDuplicateFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(gotSummary, tt.wantSummary) {
+				t.Errorf("generateDuplicateFile() summary = %v, want %v", gotSummary, tt.wantSummary)
+			}
+
+			if tt.wantFile == "" {
+				return
+			}
+
+			if got, _ := afero.ReadFile(tt.args.fs, gotSummary.FileDuplicateTrx); string(got) != tt.wantFile {
+				t.Errorf("generateDuplicateFile() file = %q, want %q", got, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestSvcGenerateReversalFile(t *testing.T) {
+	ctx, _ := testclock.UseTime(context.Background(), time.Unix(1742017753, 0))
+	newComp := func(isEnabled bool) *component.Components {
+		return component.NewComponents(
+			ctx,
+			&cconfig.Config{
+				Data: &config.Data{
+					Reconciliation: reconciliation.Reconciliation{
+						ReportTRXPath:         ReportPath,
+						CurrencyDecimalPlaces: 2,
+						Reversal: reconciliation.Reversal{
+							IsEnabled: isEnabled,
+							Days:      1,
+						},
+					},
+				},
+			},
+			&clogger.Logger{},
+			&cerror.Error{},
+			&csqlite.DBSqlite{},
+			&cfs.Fs{},
+			&cprofiler.Profiler{},
+		)
+	}
+
+	newRepo := func(data []process.ReversalTrx, err error) *repository.Repositories {
+		m := mockprocess.NewRepository(t)
+		m.On("GetReversalTrx", mock.Anything).Return(data, err).Maybe()
+		return repository.NewRepositories(mocksample.NewRepository(t), m)
+	}
+
+	reversals := []process.ReversalTrx{
+		{Source: process.ReversalSourceSystem, DebitID: "t1", DebitDate: "2025-03-01", CreditID: "t2", CreditDate: "2025-03-02", Amount: 100, Currency: "IDR", OriginalAmount: 100, DateDifference: 1},
+		{Source: process.ReversalSourceBank, Bank: "bca", DebitID: "u1", DebitDate: "2025-03-01", CreditID: "u2", CreditDate: "2025-03-01", Reference: "r1", Amount: 250, Currency: "IDR", OriginalAmount: 250},
+	}
+
+	type fields struct {
+		comp *component.Components
+		repo *repository.Repositories
+	}
+
+	tests := []struct {
+		fields      fields
+		fs          afero.Fs
+		name        string
+		wantSummary ReconciliationSummary
+		wantFile    string
+		wantErr     bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				comp: newComp(true),
+				repo: newRepo(reversals, nil),
+			},
+			fs: afero.NewMemMapFs(),
+			wantSummary: ReconciliationSummary{
+				FileReversalTrx:        ReportPath + "/reversal/reversal_1742017753.csv",
+				TotalReversalSystemTrx: 2,
+				TotalReversalBankTrx:   2,
+			},
+			wantFile: "Source,Bank,DebitID,DebitDate,CreditID,CreditDate,Reference,Amount,Currency,OriginalAmount,DateDifference\n" +
+				"SYSTEM,,t1,2025-03-01,t2,2025-03-02,,1.00,IDR,1.00,1\n" +
+				"BANK,bca,u1,2025-03-01,u2,2025-03-01,r1,2.50,IDR,2.50,0\n",
+			wantErr: false,
+		},
+		{
+			name: "Ok - disabled",
+			fields: fields{
+				comp: newComp(false),
+				repo: newRepo(reversals, nil),
+			},
+			fs:          afero.NewMemMapFs(),
+			wantSummary: ReconciliationSummary{},
+			wantErr:     false,
+		},
+		{
+			name: "Ok - no reversal",
+			fields: fields{
+				comp: newComp(true),
+				repo: newRepo(nil, nil),
+			},
+			fs:          afero.NewMemMapFs(),
+			wantSummary: ReconciliationSummary{},
+			wantErr:     false,
+		},
+		{
+			name: "Error - GetReversalTrx",
+			fields: fields{
+				comp: newComp(true),
+				repo: newRepo(nil, errors.New("error")),
+			},
+			fs:          afero.NewMemMapFs(),
+			wantSummary: ReconciliationSummary{},
+			wantErr:     true,
+		},
+		{
+			name: "Error - read only fs",
+			fields: fields{
+				comp: newComp(true),
+				repo: newRepo(reversals, nil),
+			},
+			fs: afero.NewReadOnlyFs(afero.NewMemMapFs()),
+			wantSummary: ReconciliationSummary{
+				TotalReversalSystemTrx: 2,
+				TotalReversalBankTrx:   2,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp: tt.fields.comp,
+				repo: tt.fields.repo,
+			}
+
+			gotSummary := ReconciliationSummary{}
+			if err := s.generateReversalFile(ctx, &gotSummary, tt.fs, false); (err != nil) != tt.wantErr {
+				t.Errorf("generateReversalFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(gotSummary, tt.wantSummary) {
+				t.Errorf("generateReversalFile() summary = %v, want %v", gotSummary, tt.wantSummary)
+			}
+
+			if tt.wantFile == "" {
+				return
+			}
+
+			if got, _ := afero.ReadFile(tt.fs, gotSummary.FileReversalTrx); string(got) != tt.wantFile {
+				t.Errorf("generateReversalFile() file = %q, want %q", got, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestSvcGenerateTransferFile(t *testing.T) {
+	ctx, _ := testclock.UseTime(context.Background(), time.Unix(1742017753, 0))
+	newComp := func(isEnabled bool) *component.Components {
+		return component.NewComponents(
+			ctx,
+			&cconfig.Config{
+				Data: &config.Data{
+					Reconciliation: reconciliation.Reconciliation{
+						ReportTRXPath:         ReportPath,
+						CurrencyDecimalPlaces: 2,
+						Transfer: reconciliation.Transfer{
+							IsEnabled: isEnabled,
+							Days:      1,
+						},
+					},
+				},
+			},
+			&clogger.Logger{},
+			&cerror.Error{},
+			&csqlite.DBSqlite{},
+			&cfs.Fs{},
+			&cprofiler.Profiler{},
+		)
+	}
+
+	newRepo := func(data []process.TransferTrx, err error) *repository.Repositories {
+		m := mockprocess.NewRepository(t)
+		m.On("GetTransferTrx", mock.Anything).Return(data, err).Maybe()
+		return repository.NewRepositories(mocksample.NewRepository(t), m)
+	}
+
+	transfers := []process.TransferTrx{
+		{DebitID: "bca-1", DebitBank: "bca", DebitAccount: "111", DebitDate: "2025-03-01", CreditID: "bni-1", CreditBank: "bni", CreditAccount: "222", CreditDate: "2025-03-02", Amount: 50000, Currency: "IDR", OriginalAmount: 50000, DateDifference: 1},
+	}
+
+	type fields struct {
+		comp *component.Components
+		repo *repository.Repositories
+	}
+
+	tests := []struct {
+		fields      fields
+		fs          afero.Fs
+		name        string
+		wantSummary ReconciliationSummary
+		wantFile    string
+		wantErr     bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				comp: newComp(true),
+				repo: newRepo(transfers, nil),
+			},
+			fs: afero.NewMemMapFs(),
+			wantSummary: ReconciliationSummary{
+				FileTransferTrx:      ReportPath + "/transfer/transfer_1742017753.csv",
+				TotalTransferBankTrx: 2,
+			},
+			wantFile: "DebitID,DebitBank,DebitAccount,DebitDate,CreditID,CreditBank,CreditAccount,CreditDate,Amount,Currency,OriginalAmount,DateDifference\n" +
+				"bca-1,bca,111,2025-03-01,bni-1,bni,222,2025-03-02,500.00,IDR,500.00,1\n",
+			wantErr: false,
+		},
+		{
+			name: "Ok - disabled",
+			fields: fields{
+				comp: newComp(false),
+				repo: newRepo(transfers, nil),
+			},
+			fs:          afero.NewMemMapFs(),
+			wantSummary: ReconciliationSummary{},
+			wantErr:     false,
+		},
+		{
+			name: "Ok - no transfer",
+			fields: fields{
+				comp: newComp(true),
+				repo: newRepo(nil, nil),
+			},
+			fs:          afero.NewMemMapFs(),
+			wantSummary: ReconciliationSummary{},
+			wantErr:     false,
+		},
+		{
+			name: "Error - GetTransferTrx",
+			fields: fields{
+				comp: newComp(true),
+				repo: newRepo(nil, errors.New("error")),
+			},
+			fs:          afero.NewMemMapFs(),
+			wantSummary: ReconciliationSummary{},
+			wantErr:     true,
+		},
+		{
+			name: "Error - read only fs",
+			fields: fields{
+				comp: newComp(true),
+				repo: newRepo(transfers, nil),
+			},
+			fs: afero.NewReadOnlyFs(afero.NewMemMapFs()),
+			wantSummary: ReconciliationSummary{
+				TotalTransferBankTrx: 2,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp: tt.fields.comp,
+				repo: tt.fields.repo,
+			}
+
+			gotSummary := ReconciliationSummary{}
+			if err := s.generateTransferFile(ctx, &gotSummary, tt.fs, false); (err != nil) != tt.wantErr {
+				t.Errorf("generateTransferFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(gotSummary, tt.wantSummary) {
+				t.Errorf("generateTransferFile() summary = %v, want %v", gotSummary, tt.wantSummary)
+			}
+
+			if tt.wantFile == "" {
+				return
+			}
+
+			if got, _ := afero.ReadFile(tt.fs, gotSummary.FileTransferTrx); string(got) != tt.wantFile {
+				t.Errorf("generateTransferFile() file = %q, want %q", got, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestSvcGenerateGatewayFiles(t *testing.T) {
+	ctx, _ := testclock.UseTime(context.Background(), time.Unix(1742017753, 0))
+	newComp := func(isEnabled bool) *component.Components {
+		gateway := reconciliation.Gateway{Days: 1, PayoutDays: 2}
+		if isEnabled {
+			gateway.TRXPath = "/gateway"
+			gateway.ListGateway = []string{"xendit"}
+		}
+
+		return component.NewComponents(
+			ctx,
+			&cconfig.Config{
+				Data: &config.Data{
+					Reconciliation: reconciliation.Reconciliation{
+						ReportTRXPath:         ReportPath,
+						CurrencyDecimalPlaces: 2,
+						Gateway:               gateway,
+					},
+				},
+			},
+			&clogger.Logger{},
+			&cerror.Error{},
+			&csqlite.DBSqlite{},
+			&cfs.Fs{},
+			&cprofiler.Profiler{},
+		)
+	}
+
+	newRepo := func(trx []process.NotMatchedGatewayTrx, trxErr error, payout []process.NotMatchedGatewayPayout, payoutErr error) *repository.Repositories {
+		m := mockprocess.NewRepository(t)
+		m.On("GetNotMatchedGatewayTrx", mock.Anything).Return(trx, trxErr).Maybe()
+		m.On("GetNotMatchedGatewayPayout", mock.Anything).Return(payout, payoutErr).Maybe()
+		return repository.NewRepositories(mocksample.NewRepository(t), m)
+	}
+
+	trx := []process.NotMatchedGatewayTrx{
+		{UniqueIdentifier: "g1", Gateway: "xendit", Date: TrxDateTimeOne, Amount: 10000, Fee: 300, Reference: "t1", PayoutID: "p1"},
+	}
+
+	payouts := []process.NotMatchedGatewayPayout{
+		{Gateway: "xendit", PayoutID: "p1", PayoutDate: DateFrom, TotalTrx: 2, GrossAmount: 15000, Fee: 450, Amount: 14550},
+	}
+
+	type fields struct {
+		comp *component.Components
+		repo *repository.Repositories
+	}
+
+	tests := []struct {
+		fields         fields
+		fs             afero.Fs
+		name           string
+		wantSummary    ReconciliationSummary
+		wantTrxFile    string
+		wantPayoutFile string
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				comp: newComp(true),
+				repo: newRepo(trx, nil, payouts, nil),
+			},
+			fs: afero.NewMemMapFs(),
+			wantSummary: ReconciliationSummary{
+				FileMissingGatewayTrx:        ReportPath + "/gateway/not_matched/not_matched_1742017753.csv",
+				FileMissingGatewayPayout:     ReportPath + "/gateway/payout/not_matched_payout_1742017753.csv",
+				TotalNotMatchedGatewayTrx:    1,
+				TotalNotMatchedGatewayPayout: 1,
+			},
+			wantTrxFile: "UniqueIdentifier,Gateway,Date,Amount,Fee,Reference,PayoutID\n" +
+				"g1,xendit,2025-03-06 17:09:21,100.00,3.00,t1,p1\n",
+			wantPayoutFile: "Gateway,PayoutID,PayoutDate,TotalTrx,GrossAmount,Fee,Amount\n" +
+				"xendit,p1,2025-03-06,2,150.00,4.50,145.50\n",
+			wantErr: false,
+		},
+		{
+			name: "Ok - disabled",
+			fields: fields{
+				comp: newComp(false),
+				repo: newRepo(trx, nil, payouts, nil),
+			},
+			fs:          afero.NewMemMapFs(),
+			wantSummary: ReconciliationSummary{},
+			wantErr:     false,
+		},
+		{
+			name: "Ok - no break",
+			fields: fields{
+				comp: newComp(true),
+				repo: newRepo(nil, nil, nil, nil),
+			},
+			fs:          afero.NewMemMapFs(),
+			wantSummary: ReconciliationSummary{},
+			wantErr:     false,
+		},
+		{
+			name: "Error - GetNotMatchedGatewayTrx",
+			fields: fields{
+				comp: newComp(true),
+				repo: newRepo(nil, errors.New("error"), payouts, nil),
+			},
+			fs:          afero.NewMemMapFs(),
+			wantSummary: ReconciliationSummary{},
+			wantErr:     true,
+		},
+		{
+			name: "Error - GetNotMatchedGatewayPayout",
+			fields: fields{
+				comp: newComp(true),
+				repo: newRepo(nil, nil, nil, errors.New("error")),
+			},
+			fs:          afero.NewMemMapFs(),
+			wantSummary: ReconciliationSummary{},
+			wantErr:     true,
+		},
+		{
+			name: "Error - read only fs",
+			fields: fields{
+				comp: newComp(true),
+				repo: newRepo(trx, nil, payouts, nil),
+			},
+			fs:          afero.NewReadOnlyFs(afero.NewMemMapFs()),
+			wantSummary: ReconciliationSummary{},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp: tt.fields.comp,
+				repo: tt.fields.repo,
+			}
+
+			gotSummary := ReconciliationSummary{}
+			if err := s.generateGatewayFiles(ctx, &gotSummary, tt.fs, false); (err != nil) != tt.wantErr {
+				t.Errorf("generateGatewayFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(gotSummary, tt.wantSummary) {
+				t.Errorf("generateGatewayFiles() summary = %v, want %v", gotSummary, tt.wantSummary)
+			}
+
+			if tt.wantTrxFile != "" {
+				if got, _ := afero.ReadFile(tt.fs, gotSummary.FileMissingGatewayTrx); string(got) != tt.wantTrxFile {
+					t.Errorf("generateGatewayFiles() trx file = %q, want %q", got, tt.wantTrxFile)
+				}
+			}
+
+			if tt.wantPayoutFile != "" {
+				if got, _ := afero.ReadFile(tt.fs, gotSummary.FileMissingGatewayPayout); string(got) != tt.wantPayoutFile {
+					t.Errorf("generateGatewayFiles() payout file = %q, want %q", got, tt.wantPayoutFile)
+				}
+			}
+		})
+	}
+}
+
+func TestSvcGenerateReconciliationSummaryAndFiles(t *testing.T) {
+	ctx := context.Background()
+	testRegistry := newTestParserRegistry()
+	type fields struct {
+		comp           *component.Components
+		repo           *repository.Repositories
+		parserRegistry *banks.ParserRegistry
+	}
+
+	type args struct {
+		ctxFn             func(context.Context) context.Context
+		fs                afero.Fs
+		isDeleteDirectory bool
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantReturnData ReconciliationSummary
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									ReportTRXPath: ReportPath,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+
+						m.On(
+							"GetReconciliationSummary",
+							mock.Anything,
+						).Return(
+							process.ReconciliationSummary{
+								TotalSystemTrx:      0,
+								TotalMatchedTrx:     0,
+								TotalNotMatchedTrx:  0,
+								SumSystemTrx:        0,
+								SumMatchedTrx:       0,
+								SumNotMatchedTrx:    0,
+								SumDiscrepanciesTrx: 0,
+							},
+							nil,
+						).Maybe()
+
+						m.On(
+							"GetMatchedTrx",
+							mock.Anything,
+						).Return(
+							[]process.MatchedTrx{
+								{
+									SystemTrxTrxID:           "",
+									BankTrxUniqueIdentifier:  "",
+									SystemTrxTransactionTime: "",
+									BankTrxDate:              "",
+									SystemTrxType:            "",
+									Bank:                     "",
+									SystemTrxAmount:          0,
+									BankTrxAmount:            0,
+								},
+								{
+									SystemTrxTrxID: "foo",
+									Bank:           "BCA",
+									Fee:            250,
+								},
+								{
+									SystemTrxTrxID: "bar",
+									Bank:           "bca",
+									Fee:            300,
+								},
+								{
+									SystemTrxTrxID: "baz",
+									Bank:           "bca",
+									Fee:            1000,
+									MatchType:      process.MatchTypeGateway,
+								},
+							},
+							nil,
+						).Maybe()
+
+						m.On(
+							"GetNotMatchedSystemTrx",
+							mock.Anything,
+						).Return(
+							[]process.NotMatchedSystemTrx{
+								{
+									TrxID:           "",
+									TransactionTime: "",
+									Type:            "",
+									Amount:          0,
+									Reason:          process.UnmatchedReasonNoTrxOnDate,
+								},
+							},
+							nil,
+						).Maybe()
+
+						m.On(
+							"GetNotMatchedBankTrx",
+							mock.Anything,
+						).Return(
+							[]process.NotMatchedBankTrx{},
+							nil,
+						).Maybe()
+
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				ctxFn: func(c context.Context) context.Context {
+					ctx, _ := testclock.UseTime(c, time.Unix(1742017753, 0))
+					return ctx
+				},
+				fs: func() afero.Fs {
+					f := afero.NewMemMapFs()
+					return f
+				}(),
+				isDeleteDirectory: true,
+			},
+			wantReturnData: ReconciliationSummary{
+				FileMissingBankTrx:               nil,
+				FileMissingSystemTrx:             "/report/system/not_matched/not_matched_1742017753.csv",
+				FileMatchedSystemTrx:             "/report/system/matched/matched_1742017753.csv",
+				SumFeeByBank:                     map[string]money.Amount{"bca": 550},
+				TotalNotMatchedSystemTrxByReason: map[string]int{process.UnmatchedReasonNoTrxOnDate: 1},
+				TotalProcessedSystemTrx:          0,
+				TotalMatchedSystemTrx:            0,
+				TotalNotMatchedSystemTrx:         0,
+				SumAmountProcessedSystemTrx:      0,
+				SumAmountMatchedSystemTrx:        0,
+				SumAmountDiscrepanciesSystemTrx:  0,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp:           tt.fields.comp,
+				repo:           tt.fields.repo,
+				parserRegistry: tt.fields.parserRegistry,
+			}
+
+			gotReturnData, err := s.generateReconciliationSummaryAndFiles(tt.args.ctxFn(ctx), tt.args.fs, tt.args.isDeleteDirectory)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("generateReconciliationSummaryAndFiles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("generateReconciliationSummaryAndFiles() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestSvcImportReconcileBankDataToDB(t *testing.T) {
+	ctx := context.Background()
+	testRegistry := newTestParserRegistry()
+	type fields struct {
+		comp           *component.Components
+		repo           *repository.Repositories
+		parserRegistry *banks.ParserRegistry
+	}
+
+	type args struct {
+		data []*banks.BankTrxData
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"ImportBankTrx",
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+						).Return(nil).Maybe()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				data: []*banks.BankTrxData{
+					{
+						UniqueIdentifier: BCAUniqueUUID,
+						Date: func() time.Time {
+							t, _ := time.Parse(DateFormat, DateFrom)
+							return t
+						}(),
+						Type:     "CREDIT",
+						Bank:     "BCA",
+						FilePath: FileCSVPath,
+						Amount:   7700,
+					},
+					{
+						UniqueIdentifier: BNIUniqueUUID,
+						Date: func() time.Time {
+							t, _ := time.Parse(DateFormat, DateTo)
+							return t
+						}(),
+						Type:     "DEBIT",
+						Bank:     "BNI",
+						FilePath: "/random_string/bni/any_string.csv",
+						Amount:   71200,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"ImportBankTrx",
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+						).Return(errors.New("error")).Maybe()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				data: []*banks.BankTrxData{
+					{
+						UniqueIdentifier: BCAUniqueUUID,
+						Date: func() time.Time {
+							t, _ := time.Parse(DateFormat, DateFrom)
+							return t
+						}(),
+						Type:     "CREDIT",
+						Bank:     "BCA",
+						FilePath: FileCSVPath,
+						Amount:   7700,
+					},
+					{
+						UniqueIdentifier: BNIUniqueUUID,
+						Date: func() time.Time {
+							t, _ := time.Parse(DateFormat, DateTo)
+							return t
+						}(),
+						Type:     "DEBIT",
+						Bank:     "BNI",
+						FilePath: "/random_string/bni/any_string.csv",
+						Amount:   71200,
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp:           tt.fields.comp,
+				repo:           tt.fields.repo,
+				parserRegistry: tt.fields.parserRegistry,
+			}
+
+			if err := s.importReconcileBankDataToDB(ctx, tt.args.data); (err != nil) != tt.wantErr {
+				t.Errorf("importReconcileBankDataToDB() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSvcImportReconcileGatewayDataToDB(t *testing.T) {
+	ctx := context.Background()
+	newComp := func() *component.Components {
+		return component.NewComponents(
+			ctx,
+			&cconfig.Config{
+				Data: &config.Data{
+					Reconciliation: reconciliation.Reconciliation{
+						NumberWorker: 2,
+					},
+				},
+			},
+			&clogger.Logger{},
+			&cerror.Error{},
+			&csqlite.DBSqlite{},
+			&cfs.Fs{},
+			&cprofiler.Profiler{},
+		)
+	}
+
+	newRepo := func(err error) *repository.Repositories {
+		m := mockprocess.NewRepository(t)
+		m.On(
+			"ImportGatewayTrx",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return(err).Maybe()
+		return repository.NewRepositories(mocksample.NewRepository(t), m)
+	}
+
+	data := []*gateways.GatewayTrxData{
+		{UniqueIdentifier: "g1", Gateway: "XENDIT", Type: gateways.CREDIT, PayoutID: "p1", Amount: 10000, Fee: 300},
+		{UniqueIdentifier: "g2", Gateway: "XENDIT", Type: gateways.DEBIT, PayoutID: "p1", Amount: 5000},
+	}
+
+	type fields struct {
+		comp *component.Components
+		repo *repository.Repositories
+	}
+
+	type args struct {
+		data []*gateways.GatewayTrxData
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				comp: newComp(),
+				repo: newRepo(nil),
+			},
+			args: args{
+				data: data,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				comp: newComp(),
+				repo: newRepo(errors.New("error")),
+			},
+			args: args{
+				data: data,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp: tt.fields.comp,
+				repo: tt.fields.repo,
+			}
+
+			if err := s.importReconcileGatewayDataToDB(ctx, tt.args.data); (err != nil) != tt.wantErr {
+				t.Errorf("importReconcileGatewayDataToDB() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSvcImportReconcileMapToDB(t *testing.T) {
+	ctx := context.Background()
+	testRegistry := newTestParserRegistry()
+	type fields struct {
+		comp           *component.Components
+		repo           *repository.Repositories
+		parserRegistry *banks.ParserRegistry
+	}
+
+	type args struct {
+		rule reconciliation.MatchRule
+		min  money.Amount
+		max  money.Amount
+	}
+
+	tests := []struct {
+		fields  fields
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Ok - reference",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GenerateReconciliationReferenceMap",
+							mock.Anything,
+							process.MatchRule{Name: "reference"},
+						).Return(nil).Once()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				rule: reconciliation.MatchRule{
+					Name: "reference",
+					Type: reconciliation.MatchRuleTypeReference,
+				},
+				min: 1,
+				max: 10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error - reference",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GenerateReconciliationReferenceMap",
+							mock.Anything,
+							mock.Anything,
+						).Return(errors.New("error")).Once()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				rule: reconciliation.MatchRule{
+					Name: "reference",
+					Type: reconciliation.MatchRuleTypeReference,
+				},
+				min: 1,
+				max: 10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Ok - amount date",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GetReconciliationMapCandidate",
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+							process.MatchRule{
+								Name: "near",
+								Tolerance: process.AmountTolerance{
+									Absolute:   50,
+									Percentage: 0.5,
+								},
+								Window: &process.SettlementWindow{
+									DaysBefore: 1,
+									DaysAfter:  1,
+								},
+							},
+						).Return(
+							[]process.ReconciliationMapCandidate{
+								{TrxID: "s1", UniqueIdentifier: "b1", TimeDistance: 0, TrxTime: 0, BankTime: 0, Confidence: 0.5},
+								{TrxID: "s1", UniqueIdentifier: "b2", TimeDistance: 3600, TrxTime: 0, BankTime: 3600, Confidence: 0.5},
+								{TrxID: "s2", UniqueIdentifier: "b1", TimeDistance: 7200, TrxTime: 7200, BankTime: 0, Confidence: 0.5},
+							},
+							nil,
+						).Once()
+
+						m.On(
+							"GetReconciliationMapCandidate",
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+						).Return(nil, nil)
+
+						m.On(
+							"ImportReconciliationMap",
+							mock.Anything,
+							[]process.ReconciliationMatch{
+								{TrxID: "s1", UniqueIdentifier: "b2", MatchRule: "near", Confidence: 0.5},
+								{TrxID: "s2", UniqueIdentifier: "b1", MatchRule: "near", Confidence: 0.5},
+							},
+						).Return(nil).Once()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				rule: reconciliation.MatchRule{
+					Name:                      "near",
+					Type:                      reconciliation.MatchRuleTypeAmountDate,
+					AmountTolerance:           50,
+					AmountTolerancePercentage: 0.5,
+					SettlementWindow: &reconciliation.DateWindow{
+						DaysBefore: 1,
+						DaysAfter:  1,
+					},
+				},
+				min: 1,
+				max: 10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error - amount date",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GetReconciliationMapCandidate",
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+						).Return(nil, errors.New("error")).Once()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				rule: reconciliation.MatchRule{
+					Name:                      "near",
+					Type:                      reconciliation.MatchRuleTypeAmountDate,
+					AmountTolerance:           50,
+					AmountTolerancePercentage: 0.5,
+					SettlementWindow: &reconciliation.DateWindow{
+						DaysBefore: 1,
+						DaysAfter:  1,
+					},
+				},
+				min: 1,
+				max: 10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Ok - amount date candidates of several amount ranges",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GetReconciliationMapCandidate",
+							mock.Anything,
+							money.Amount(1),
+							money.Amount(4),
+							mock.Anything,
+						).Return(
+							[]process.ReconciliationMapCandidate{
+								{TrxID: "s1", UniqueIdentifier: "b1", AmountDifference: 5},
+							},
+							nil,
+						).Once()
+
+						m.On(
+							"GetReconciliationMapCandidate",
+							mock.Anything,
+							money.Amount(4),
+							money.Amount(7),
+							mock.Anything,
+						).Return(
+							[]process.ReconciliationMapCandidate{
+								{TrxID: "s2", UniqueIdentifier: "b1"},
+								{TrxID: "s2", UniqueIdentifier: "b2", AmountDifference: 5},
+							},
+							nil,
+						).Once()
+
+						m.On(
+							"GetReconciliationMapCandidate",
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+						).Return(nil, nil)
+
+						m.On(
+							"ImportReconciliationMap",
+							mock.Anything,
+							[]process.ReconciliationMatch{
+								{TrxID: "s1", UniqueIdentifier: "b1", AmountDifference: 5, MatchRule: "near"},
+								{TrxID: "s2", UniqueIdentifier: "b2", AmountDifference: 5, MatchRule: "near"},
+							},
+						).Return(nil).Once()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				rule: reconciliation.MatchRule{
+					Name: "near",
+					Type: reconciliation.MatchRuleTypeAmountDate,
+				},
+				min: 1,
+				max: 10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error - amount date import",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GetReconciliationMapCandidate",
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+						).Return(
+							[]process.ReconciliationMapCandidate{
+								{TrxID: "s1", UniqueIdentifier: "b1"},
+							},
+							nil,
+						).Once()
+
+						m.On(
+							"GetReconciliationMapCandidate",
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+						).Return(nil, nil)
+
+						m.On(
+							"ImportReconciliationMap",
+							mock.Anything,
+							mock.Anything,
+						).Return(errors.New("error")).Once()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				rule: reconciliation.MatchRule{
+					Name:                      "near",
+					Type:                      reconciliation.MatchRuleTypeAmountDate,
+					AmountTolerance:           50,
+					AmountTolerancePercentage: 0.5,
+					SettlementWindow: &reconciliation.DateWindow{
+						DaysBefore: 1,
+						DaysAfter:  1,
+					},
+				},
+				min: 1,
+				max: 10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Ok - split",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GetSplitMatchCandidate",
+							mock.Anything,
+							2,
+							process.MatchRule{Name: "split"},
+						).Return(nil, nil).Once()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				rule: reconciliation.MatchRule{
+					Name:          "split",
+					Type:          reconciliation.MatchRuleTypeSplit,
+					SplitMaxParts: 2,
+				},
+				min: 1,
+				max: 10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error - split",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GetSplitMatchCandidate",
+							mock.Anything,
+							2,
+							mock.Anything,
+						).Return(nil, errors.New("error")).Once()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				rule: reconciliation.MatchRule{
+					Name:          "split",
+					Type:          reconciliation.MatchRuleTypeSplit,
+					SplitMaxParts: 2,
+				},
+				min: 1,
+				max: 10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Ok - aggregate",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GenerateReconciliationAggregateMap",
+							mock.Anything,
+							process.MatchRule{Name: "aggregate"},
+						).Return(nil).Once()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				rule: reconciliation.MatchRule{
+					Name: "aggregate",
+					Type: reconciliation.MatchRuleTypeAggregate,
+				},
+				min: 1,
+				max: 10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error - aggregate",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GenerateReconciliationAggregateMap",
+							mock.Anything,
+							mock.Anything,
+						).Return(errors.New("error")).Once()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				rule: reconciliation.MatchRule{
+					Name: "aggregate",
+					Type: reconciliation.MatchRuleTypeAggregate,
+				},
+				min: 1,
+				max: 10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - unknown rule type",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				rule: reconciliation.MatchRule{
+					Name: "foo",
+					Type: "bar",
+				},
+				min: 1,
+				max: 10,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp:           tt.fields.comp,
+				repo:           tt.fields.repo,
+				parserRegistry: tt.fields.parserRegistry,
+			}
+
+			if err := s.importReconcileMapToDB(ctx, tt.args.rule, tt.args.min, tt.args.max); (err != nil) != tt.wantErr {
+				t.Errorf("importReconcileMapToDB() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSvcImportReconcileSplitMapToDB(t *testing.T) {
+	ctx := context.Background()
+	testRegistry := newTestParserRegistry()
+	type fields struct {
+		comp           *component.Components
+		repo           *repository.Repositories
+		parserRegistry *banks.ParserRegistry
+	}
+
+	tests := []struct {
+		fields  fields
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GetSplitMatchCandidate",
+							mock.Anything,
+							3,
+							process.MatchRule{Name: "split"},
+						).Return(
+							[]process.SplitMatchCandidate{
+								{TrxID: "trx-1", UniqueIdentifiers: `["bank-1","bank-2"]`, Parts: 2, Confidence: 0.5},
+								{TrxID: "trx-1", UniqueIdentifiers: `["bank-3","bank-4"]`, Parts: 2, Confidence: 0.5},
+								{TrxID: "trx-2", UniqueIdentifiers: `["bank-2","bank-5"]`, Parts: 2, Confidence: 0.5},
+								{TrxID: "trx-2", UniqueIdentifiers: `["bank-3","bank-5","bank-6"]`, Parts: 3, Confidence: 0.75},
+							},
+							nil,
+						)
+						m.On(
+							"ImportReconciliationSplitMap",
+							mock.Anything,
+							[]process.SplitMatch{
+								{TrxID: "trx-1", UniqueIdentifier: "bank-1", MatchRule: "split", Confidence: 0.25},
+								{TrxID: "trx-1", UniqueIdentifier: "bank-2", MatchRule: "split", Confidence: 0.25},
+								{TrxID: "trx-2", UniqueIdentifier: "bank-3", MatchRule: "split", Confidence: 0.375},
+								{TrxID: "trx-2", UniqueIdentifier: "bank-5", MatchRule: "split", Confidence: 0.375},
+								{TrxID: "trx-2", UniqueIdentifier: "bank-6", MatchRule: "split", Confidence: 0.375},
+							},
+						).Return(nil)
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - no candidate",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GetSplitMatchCandidate",
+							mock.Anything,
+							3,
+							process.MatchRule{Name: "split"},
+						).Return(nil, nil)
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error - GetSplitMatchCandidate",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GetSplitMatchCandidate",
+							mock.Anything,
+							3,
+							process.MatchRule{Name: "split"},
+						).Return(nil, errors.New("error"))
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - invalid UniqueIdentifiers",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GetSplitMatchCandidate",
+							mock.Anything,
+							3,
+							process.MatchRule{Name: "split"},
+						).Return(
+							[]process.SplitMatchCandidate{
+								{TrxID: "trx-1", UniqueIdentifiers: `bank-1`, Parts: 2},
+							},
+							nil,
+						)
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - ImportReconciliationSplitMap",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"GetSplitMatchCandidate",
+							mock.Anything,
+							3,
+							process.MatchRule{Name: "split"},
+						).Return(
+							[]process.SplitMatchCandidate{
+								{TrxID: "trx-1", UniqueIdentifiers: `["bank-1","bank-2"]`, Parts: 2},
+								{TrxID: "trx-1", UniqueIdentifiers: `["bank-3","bank-4"]`, Parts: 2},
+								{TrxID: "trx-2", UniqueIdentifiers: `["bank-2","bank-5"]`, Parts: 2},
+								{TrxID: "trx-2", UniqueIdentifiers: `["bank-3","bank-5","bank-6"]`, Parts: 3},
+							},
+							nil,
+						)
+						m.On(
+							"ImportReconciliationSplitMap",
+							mock.Anything,
+							[]process.SplitMatch{
+								{TrxID: "trx-1", UniqueIdentifier: "bank-1", MatchRule: "split"},
+								{TrxID: "trx-1", UniqueIdentifier: "bank-2", MatchRule: "split"},
+								{TrxID: "trx-2", UniqueIdentifier: "bank-3", MatchRule: "split"},
+								{TrxID: "trx-2", UniqueIdentifier: "bank-5", MatchRule: "split"},
+								{TrxID: "trx-2", UniqueIdentifier: "bank-6", MatchRule: "split"},
+							},
+						).Return(errors.New("error"))
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp:           tt.fields.comp,
+				repo:           tt.fields.repo,
+				parserRegistry: tt.fields.parserRegistry,
+			}
+
+			if err := s.importReconcileSplitMapToDB(ctx, 3, process.MatchRule{Name: "split"}); (err != nil) != tt.wantErr {
+				t.Errorf("importReconcileSplitMapToDB() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSvcImportReconcileSystemDataToDB(t *testing.T) {
+	ctx := context.Background()
+	testRegistry := newTestParserRegistry()
+	type fields struct {
+		comp           *component.Components
+		repo           *repository.Repositories
+		parserRegistry *banks.ParserRegistry
+	}
+
+	type args struct {
+		data []*systems.SystemTrxData
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 10,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"ImportSystemTrx",
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+						).Return(nil).Maybe()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				data: []*systems.SystemTrxData{
+					{
+						TrxID: "0066a6264a3b04ac25bd93eed2cb3c6c",
+						TransactionTime: func() time.Time {
+							t, _ := time.Parse(DateTimeFormat, TrxDateTimeTwo)
+							return t
+						}(),
+						Type:     "CREDIT",
+						FilePath: FileCSVPathTwo,
+						Amount:   41000,
+					},
+					{
+						TrxID: "006630c83821fac6bea13b92b480feb2",
+						TransactionTime: func() time.Time {
+							t, _ := time.Parse(DateTimeFormat, TrxDateTimeThree)
+							return t
+						}(),
+						Type:     "DEBIT",
+						FilePath: FileCSVPathOne,
+						Amount:   89900,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									NumberWorker: 10,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					func() process.Repository {
+						m := mockprocess.NewRepository(t)
+						m.On(
+							"ImportSystemTrx",
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+							mock.Anything,
+						).Return(errors.New("error")).Maybe()
+						return m
+					}(),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				data: []*systems.SystemTrxData{
+					{
+						TrxID: "0066a6264a3b04ac25bd93eed2cb3c6c",
+						TransactionTime: func() time.Time {
+							t, _ := time.Parse(DateTimeFormat, TrxDateTimeTwo)
+							return t
+						}(),
+						Type:     "CREDIT",
+						FilePath: FileCSVPathTwo,
+						Amount:   41000,
+					},
+					{
+						TrxID: "006630c83821fac6bea13b92b480feb2",
+						TransactionTime: func() time.Time {
+							t, _ := time.Parse(DateTimeFormat, TrxDateTimeThree)
+							return t
+						}(),
+						Type:     "DEBIT",
+						FilePath: FileCSVPathOne,
+						Amount:   89900,
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp:           tt.fields.comp,
+				repo:           tt.fields.repo,
+				parserRegistry: tt.fields.parserRegistry,
+			}
+
+			if err := s.importReconcileSystemDataToDB(ctx, tt.args.data); (err != nil) != tt.wantErr {
+				t.Errorf("importReconcileSystemDataToDB() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSvcListBank(t *testing.T) {
+	ctx := context.Background()
+	type fields struct {
+		comp *component.Components
+	}
+
+	tests := []struct {
+		fields         fields
+		name           string
+		wantReturnData []process.Bank
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					&cconfig.Config{
+						Data: &config.Data{
+							Reconciliation: reconciliation.Reconciliation{
+								ListBank: []string{"bca", "bni"},
+								BankSettlementWindow: map[string]reconciliation.DateWindow{
+									"bca": {DaysBefore: 1, DaysAfter: 2},
+								},
+								BankFee: map[string]reconciliation.BankFee{
+									"bca": {
+										Type: reconciliation.BankFeeTypeTiered,
+										Tiers: []reconciliation.BankFeeTier{
+											{Flat: 2500},
+											{MinAmount: 1000000, Flat: 1000, Percentage: 0.5},
+										},
+									},
+								},
+								SettlementWindow:      reconciliation.DateWindow{DaysAfter: 1},
+								CurrencyDecimalPlaces: 2,
+							},
+						},
+					},
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+			},
+			wantReturnData: []process.Bank{
+				{
+					Name: "bca",
+					FeeTiers: []process.FeeTier{
+						{Flat: 250000},
+						{MinAmount: 100000000, Flat: 100000, Percentage: 0.5},
+					},
+					SettlementDaysBefore: 1,
+					SettlementDaysAfter:  2,
+				},
+				{Name: "bni", FeeTiers: []process.FeeTier{}, SettlementDaysBefore: 0, SettlementDaysAfter: 1},
+			},
+		},
+		{
+			name: "Ok - empty list bank",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					&cconfig.Config{
+						Data: &config.Data{},
+					},
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+			},
+			wantReturnData: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp: tt.fields.comp,
+			}
+
+			if gotReturnData := s.listBank(); !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("listBank() = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestSvcListGateway(t *testing.T) {
+	ctx := context.Background()
+	newComp := func(gateway reconciliation.Gateway) *component.Components {
+		return component.NewComponents(
+			ctx,
+			&cconfig.Config{
+				Data: &config.Data{
+					Reconciliation: reconciliation.Reconciliation{
+						Gateway: gateway,
+					},
+				},
+			},
+			&clogger.Logger{},
+			&cerror.Error{},
+			&csqlite.DBSqlite{},
+			&cfs.Fs{},
+			&cprofiler.Profiler{},
+		)
+	}
+
+	type fields struct {
+		comp *component.Components
+	}
+
+	tests := []struct {
+		fields         fields
+		name           string
+		wantReturnData []process.Gateway
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				comp: newComp(reconciliation.Gateway{
+					TRXPath:     "/gateway",
+					ListGateway: []string{"xendit", "midtrans"},
+					PayoutBank:  map[string]string{"xendit": "BCA"},
+					PayoutDays:  2,
+				}),
+			},
+			wantReturnData: []process.Gateway{
+				{Name: "xendit", PayoutBank: "bca", PayoutDays: 2},
+				{Name: "midtrans", PayoutBank: "", PayoutDays: 2},
+			},
+		},
+		{
+			name: "Ok - disabled",
+			fields: fields{
+				comp: newComp(reconciliation.Gateway{
+					ListGateway: []string{"xendit"},
+					PayoutDays:  2,
+				}),
+			},
+			wantReturnData: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp: tt.fields.comp,
+			}
+
+			if gotReturnData := s.listGateway(); !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("listGateway() = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestSvcRemoveDuplicates(t *testing.T) {
+	ctx := context.Background()
+	trxTime, _ := time.Parse(DateTimeFormat, TrxDateTimeOne)
+	trxData := func() parser.TrxData {
+		return parser.TrxData{
+			SystemTrx: []*systems.SystemTrxData{
+				{TrxID: "t1", TransactionTime: trxTime, Type: "CREDIT", FilePath: "/system/b.csv", Currency: "IDR", Amount: 100, OriginalAmount: 100},
+				{TrxID: "t1", TransactionTime: trxTime, Type: "CREDIT", FilePath: "/system/a.csv", Currency: "IDR", Amount: 200, OriginalAmount: 200},
+				{TrxID: "t2", TransactionTime: trxTime, Type: "DEBIT", FilePath: "/system/a.csv", Currency: "IDR", Amount: 300, OriginalAmount: 300},
+				{TrxID: "t3", TransactionTime: trxTime, Type: "DEBIT", FilePath: "/system/a.csv", Currency: "IDR", Amount: 300, OriginalAmount: 300},
+			},
+			BankTrx: []*banks.BankTrxData{
+				{UniqueIdentifier: "u1", Date: trxTime, Type: banks.CREDIT, Bank: "BCA", FilePath: "/bank/bca/2.csv", Currency: "IDR", Amount: 100, OriginalAmount: 100},
+				{UniqueIdentifier: "u1", Date: trxTime, Type: banks.CREDIT, Bank: "BCA", FilePath: "/bank/bca/1.csv", Currency: "IDR", Amount: 100, OriginalAmount: 100},
+				// same content on other accounts of the bank is not a duplicate
+				{UniqueIdentifier: "u2", Date: trxTime, Type: banks.CREDIT, Bank: "BCA", Account: "111", FilePath: "/bank/bca/111/1.csv", Currency: "IDR", Amount: 500, OriginalAmount: 500},
+				{UniqueIdentifier: "u3", Date: trxTime, Type: banks.CREDIT, Bank: "BCA", Account: "222", FilePath: "/bank/bca/222/1.csv", Currency: "IDR", Amount: 500, OriginalAmount: 500},
+				// the same amount twice a day in one file is not a duplicate, only its line in another file is
+				{UniqueIdentifier: "u4", Date: trxTime, Type: banks.CREDIT, Bank: "BCA", FilePath: "/bank/bca/1.csv", Currency: "IDR", Amount: 700, OriginalAmount: 700},
+				{UniqueIdentifier: "u5", Date: trxTime, Type: banks.CREDIT, Bank: "BCA", FilePath: "/bank/bca/1.csv", Currency: "IDR", Amount: 700, OriginalAmount: 700},
+				{UniqueIdentifier: "u6", Date: trxTime, Type: banks.CREDIT, Bank: "BCA", FilePath: "/bank/bca/2.csv", Currency: "IDR", Amount: 700, OriginalAmount: 700},
+			},
+		}
+	}
+
+	comp := func(policy string, contentPolicy string) *component.Components {
+		return component.NewComponents(
+			ctx,
+			&cconfig.Config{
+				Data: &config.Data{
+					Reconciliation: reconciliation.Reconciliation{
+						Duplicate: reconciliation.Duplicate{
+							Policy:        policy,
+							ContentPolicy: contentPolicy,
+						},
+					},
+				},
+			},
+			&clogger.Logger{},
+			&cerror.Error{},
+			&csqlite.DBSqlite{},
+			&cfs.Fs{},
+			&cprofiler.Profiler{},
+		)
+	}
+
+	type fields struct {
+		comp *component.Components
+	}
+
+	tests := []struct {
+		fields         fields
+		name           string
+		wantSystemTrx  []string
+		wantBankTrx    []string
+		wantReturnData []DuplicateTrx
+	}{
+		{
+			name: "Ok - keep first",
+			fields: fields{
+				comp: comp(reconciliation.DuplicatePolicyKeepFirst, reconciliation.DuplicatePolicyKeepAll),
+			},
+			wantSystemTrx: []string{"/system/a.csv t1", "/system/a.csv t2", "/system/a.csv t3"},
+			wantBankTrx:   []string{"/bank/bca/1.csv u1", "/bank/bca/1.csv u4", "/bank/bca/1.csv u5", "/bank/bca/111/1.csv u2", "/bank/bca/2.csv u6", "/bank/bca/222/1.csv u3"},
+			wantReturnData: []DuplicateTrx{
+				{Source: DuplicateSourceSystem, ID: "t1", Kind: duplicate.KindExactKey, Action: duplicate.ActionKept, Date: TrxDateTimeOne, Type: "CREDIT", Amount: 200, Currency: "IDR", OriginalAmount: 200, FilePath: "/system/a.csv"},
+				{Source: DuplicateSourceSystem, ID: "t1", DuplicateOf: "t1", Kind: duplicate.KindExactKey, Action: duplicate.ActionRejected, Date: TrxDateTimeOne, Type: "CREDIT", Amount: 100, Currency: "IDR", OriginalAmount: 100, FilePath: "/system/b.csv"},
+				{Source: DuplicateSourceSystem, ID: "t2", Kind: duplicate.KindContent, Action: duplicate.ActionKept, Date: TrxDateTimeOne, Type: "DEBIT", Amount: 300, Currency: "IDR", OriginalAmount: 300, FilePath: "/system/a.csv"},
+				{Source: DuplicateSourceSystem, ID: "t3", DuplicateOf: "t2", Kind: duplicate.KindContent, Action: duplicate.ActionKept, Date: TrxDateTimeOne, Type: "DEBIT", Amount: 300, Currency: "IDR", OriginalAmount: 300, FilePath: "/system/a.csv"},
+				{Source: DuplicateSourceBank, Bank: "bca", ID: "u1", Kind: duplicate.KindExactKey, Action: duplicate.ActionKept, Date: "2025-03-06", Type: "CREDIT", Amount: 100, Currency: "IDR", OriginalAmount: 100, FilePath: "/bank/bca/1.csv"},
+				{Source: DuplicateSourceBank, Bank: "bca", ID: "u1", DuplicateOf: "u1", Kind: duplicate.KindExactKey, Action: duplicate.ActionRejected, Date: "2025-03-06", Type: "CREDIT", Amount: 100, Currency: "IDR", OriginalAmount: 100, FilePath: "/bank/bca/2.csv"},
+				{Source: DuplicateSourceBank, Bank: "bca", ID: "u4", Kind: duplicate.KindContent, Action: duplicate.ActionKept, Date: "2025-03-06", Type: "CREDIT", Amount: 700, Currency: "IDR", OriginalAmount: 700, FilePath: "/bank/bca/1.csv"},
+				{Source: DuplicateSourceBank, Bank: "bca", ID: "u6", DuplicateOf: "u4", Kind: duplicate.KindContent, Action: duplicate.ActionKept, Date: "2025-03-06", Type: "CREDIT", Amount: 700, Currency: "IDR", OriginalAmount: 700, FilePath: "/bank/bca/2.csv"},
+			},
+		},
+		{
+			name: "Ok - reject all content, keep all keys",
+			fields: fields{
+				comp: comp(reconciliation.DuplicatePolicyKeepAll, reconciliation.DuplicatePolicyRejectAll),
+			},
+			wantSystemTrx: []string{"/system/a.csv t1", "/system/b.csv t1#2"},
+			wantBankTrx:   []string{"/bank/bca/1.csv u1", "/bank/bca/1.csv u5", "/bank/bca/111/1.csv u2", "/bank/bca/2.csv u1#2", "/bank/bca/222/1.csv u3"},
+			wantReturnData: []DuplicateTrx{
+				{Source: DuplicateSourceSystem, ID: "t1", Kind: duplicate.KindExactKey, Action: duplicate.ActionKept, Date: TrxDateTimeOne, Type: "CREDIT", Amount: 200, Currency: "IDR", OriginalAmount: 200, FilePath: "/system/a.csv"},
+				{Source: DuplicateSourceSystem, ID: "t1#2", DuplicateOf: "t1", Kind: duplicate.KindExactKey, Action: duplicate.ActionKept, Date: TrxDateTimeOne, Type: "CREDIT", Amount: 100, Currency: "IDR", OriginalAmount: 100, FilePath: "/system/b.csv"},
+				{Source: DuplicateSourceSystem, ID: "t2", Kind: duplicate.KindContent, Action: duplicate.ActionRejected, Date: TrxDateTimeOne, Type: "DEBIT", Amount: 300, Currency: "IDR", OriginalAmount: 300, FilePath: "/system/a.csv"},
+				{Source: DuplicateSourceSystem, ID: "t3", DuplicateOf: "t2", Kind: duplicate.KindContent, Action: duplicate.ActionRejected, Date: TrxDateTimeOne, Type: "DEBIT", Amount: 300, Currency: "IDR", OriginalAmount: 300, FilePath: "/system/a.csv"},
+				{Source: DuplicateSourceBank, Bank: "bca", ID: "u1", Kind: duplicate.KindExactKey, Action: duplicate.ActionKept, Date: "2025-03-06", Type: "CREDIT", Amount: 100, Currency: "IDR", OriginalAmount: 100, FilePath: "/bank/bca/1.csv"},
+				{Source: DuplicateSourceBank, Bank: "bca", ID: "u1#2", DuplicateOf: "u1", Kind: duplicate.KindExactKey, Action: duplicate.ActionKept, Date: "2025-03-06", Type: "CREDIT", Amount: 100, Currency: "IDR", OriginalAmount: 100, FilePath: "/bank/bca/2.csv"},
+				{Source: DuplicateSourceBank, Bank: "bca", ID: "u4", Kind: duplicate.KindContent, Action: duplicate.ActionRejected, Date: "2025-03-06", Type: "CREDIT", Amount: 700, Currency: "IDR", OriginalAmount: 700, FilePath: "/bank/bca/1.csv"},
+				{Source: DuplicateSourceBank, Bank: "bca", ID: "u6", DuplicateOf: "u4", Kind: duplicate.KindContent, Action: duplicate.ActionRejected, Date: "2025-03-06", Type: "CREDIT", Amount: 700, Currency: "IDR", OriginalAmount: 700, FilePath: "/bank/bca/2.csv"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Svc{
+				comp: tt.fields.comp,
+			}
+
+			data := trxData()
+			if gotReturnData := s.removeDuplicates(&data); !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("removeDuplicates() = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+
+			gotSystemTrx := lo.Map(data.SystemTrx, func(item *systems.SystemTrxData, _ int) string {
+				return item.FilePath + " " + item.TrxID
+			})
+
+			if !reflect.DeepEqual(gotSystemTrx, tt.wantSystemTrx) {
+				t.Errorf("removeDuplicates() SystemTrx = %v, want %v", gotSystemTrx, tt.wantSystemTrx)
+			}
+
+			gotBankTrx := lo.Map(data.BankTrx, func(item *banks.BankTrxData, _ int) string {
+				return item.FilePath + " " + item.UniqueIdentifier
+			})
+
+			if !reflect.DeepEqual(gotBankTrx, tt.wantBankTrx) {
+				t.Errorf("removeDuplicates() BankTrx = %v, want %v", gotBankTrx, tt.wantBankTrx)
+			}
 		})
 	}
 }
 
-func TestSvcImportReconcileSystemDataToDB(t *testing.T) {
-	ctx := context.Background()
-	testRegistry := newTestParserRegistry()
-	type fields struct {
-		comp           *component.Components
-		repo           *repository.Repositories
-		parserRegistry *banks.ParserRegistry
-	}
+func TestSvcParse(t *testing.T) {
+	ctx := context.Background()
+	testRegistry := newTestParserRegistry()
+	type fields struct {
+		comp           *component.Components
+		repo           *repository.Repositories
+		parserRegistry *banks.ParserRegistry
+	}
+
+	type args struct {
+		afs afero.Fs
+	}
+
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		wantTrxData parser.TrxData
+		wantErr     bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									FromDate: func() time.Time {
+										t, _ := time.Parse(DateFormat, DateFrom)
+										return t
+									}(),
+									ToDate: func() time.Time {
+										t, _ := time.Parse(DateFormat, "2025-03-07")
+										return t
+									}(),
+									SystemTRXPath: SystemPath,
+									BankTRXPath:   "/bank",
+									ListBank:      []string{"bca", "bni"},
+									FX: reconciliation.FX{
+										BaseCurrency: "IDR",
+									},
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					mockprocess.NewRepository(t),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				afs: func() afero.Fs {
+					f := afero.NewMemMapFs()
+					systemTrxFile, _ := f.Create(SystemCsvFile)
+					_, _ = systemTrxFile.Write([]byte(
+						`TrxID,TransactionTime,Type,Amount
+006630c83821fac6bea13b92b480feb2,2025-03-06 17:09:21,DEBIT,41000
+0066a6264a3b04ac25bd93eed2cb3c6c,2025-03-07 10:18:29,CREDIT,1000
+0066a6264a3b04ac25bd93eed2cb3aaa,2025-03-07 10:18:29,CREDIT,89900
+0066a6264a3b04ac25bd93eed2cb3bbb,2025-03-08 10:18:29,CREDIT,9000
+`,
+					))
+
+					_ = systemTrxFile.Close()
+
+					bankTrxFile, _ := f.Create(BankBcaCsvFile)
+					_, _ = bankTrxFile.Write([]byte(
+						`BCAUniqueIdentifier,BCADate,BCAAmount
+bca-5585fa85a971917b48ea2729bcf7d9fb,2025-03-06,7700
+`,
+					))
+
+					_ = bankTrxFile.Close()
+					bankTrxFile, _ = f.Create(BankBniCsvFile)
+
+					_, _ = bankTrxFile.Write([]byte(
+						`BNIUniqueIdentifier,BNIDate,BNIAmount
+bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
+`,
+					))
 
-	type args struct {
-		data []*systems.SystemTrxData
-	}
+					_ = bankTrxFile.Close()
 
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
-	}{
+					return f
+				}(),
+			},
+			wantTrxData: parser.TrxData{
+				SystemTrx: []*systems.SystemTrxData{
+					{
+						TrxID: "006630c83821fac6bea13b92b480feb2",
+						TransactionTime: func() time.Time {
+							t, _ := time.Parse(DateTimeFormat, TrxDateTimeOne)
+							return t
+						}(),
+						Type:           "DEBIT",
+						FilePath:       SystemCsvFile,
+						Currency:       "IDR",
+						Amount:         41000,
+						OriginalAmount: 41000,
+					},
+					{
+						TrxID: "0066a6264a3b04ac25bd93eed2cb3c6c",
+						TransactionTime: func() time.Time {
+							t, _ := time.Parse(DateTimeFormat, TrxDateTimeTwo)
+							return t
+						}(),
+						Type:           "CREDIT",
+						FilePath:       SystemCsvFile,
+						Currency:       "IDR",
+						Amount:         1000,
+						OriginalAmount: 1000,
+					},
+					{
+						TrxID: "0066a6264a3b04ac25bd93eed2cb3aaa",
+						TransactionTime: func() time.Time {
+							t, _ := time.Parse(DateTimeFormat, TrxDateTimeTwo)
+							return t
+						}(),
+						Type:           "CREDIT",
+						FilePath:       SystemCsvFile,
+						Currency:       "IDR",
+						Amount:         89900,
+						OriginalAmount: 89900,
+					},
+				},
+				BankTrx: []*banks.BankTrxData{
+					{
+						UniqueIdentifier: BCAUniqueUUID,
+						Date: func() time.Time {
+							t, _ := time.Parse(DateFormat, DateFrom)
+							return t
+						}(),
+						Type:           "CREDIT",
+						Bank:           "BCA",
+						FilePath:       BankBcaCsvFile,
+						Currency:       "IDR",
+						Amount:         7700,
+						OriginalAmount: 7700,
+					},
+				},
+				BankStatementBalances: []balance.Result{
+					{
+						Bank:      "bca",
+						FilePath:  BankBcaCsvFile,
+						Status:    balance.StatusNoBalance,
+						Movement:  7700,
+						TotalLine: 1,
+					},
+					{
+						Bank:      "bni",
+						FilePath:  BankBniCsvFile,
+						Status:    balance.StatusNoBalance,
+						Movement:  -71200,
+						TotalLine: 1,
+					},
+				},
+				MinSystemAmount: 1000,
+				MaxSystemAmount: 89900,
+			},
+			wantErr: false,
+		},
 		{
-			name: "Ok",
+			name: "Ok - bank settlement window",
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
@@ -1503,7 +4334,22 @@ func TestSvcImportReconcileSystemDataToDB(t *testing.T) {
 						return &cconfig.Config{
 							Data: &config.Data{
 								Reconciliation: reconciliation.Reconciliation{
-									NumberWorker: 10,
+									FromDate: func() time.Time {
+										t, _ := time.Parse(DateFormat, DateFrom)
+										return t
+									}(),
+									ToDate: func() time.Time {
+										t, _ := time.Parse(DateFormat, "2025-03-07")
+										return t
+									}(),
+									SystemTRXPath: SystemPath,
+									BankTRXPath:   "/bank",
+									ListBank:      []string{"bca", "bni"},
+									BankSettlementWindow: map[string]reconciliation.DateWindow{
+										"bni": {
+											DaysAfter: 2,
+										},
+									},
 								},
 							},
 						}
@@ -1516,60 +4362,322 @@ func TestSvcImportReconcileSystemDataToDB(t *testing.T) {
 				),
 				repo: repository.NewRepositories(
 					mocksample.NewRepository(t),
-					func() process.Repository {
-						m := mockprocess.NewRepository(t)
-						m.On(
-							"ImportSystemTrx",
-							mock.Anything,
-							mock.Anything,
-							mock.Anything,
-							mock.Anything,
-						).Return(nil).Maybe()
-						return m
+					mockprocess.NewRepository(t),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				afs: func() afero.Fs {
+					f := afero.NewMemMapFs()
+					systemTrxFile, _ := f.Create(SystemCsvFile)
+					_, _ = systemTrxFile.Write([]byte(
+						`TrxID,TransactionTime,Type,Amount
+0066a6264a3b04ac25bd93eed2cb3bbb,2025-03-08 10:18:29,CREDIT,9000
+`,
+					))
+
+					_ = systemTrxFile.Close()
+
+					bankTrxFile, _ := f.Create(BankBniCsvFile)
+					_, _ = bankTrxFile.Write([]byte(
+						`BNIUniqueIdentifier,BNIDate,BNIAmount
+bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
+bni-5f4b1bdf10332ea307813ce402f3d7aa,2025-03-10,-71200
+`,
+					))
+
+					_ = bankTrxFile.Close()
+
+					return f
+				}(),
+			},
+			wantTrxData: parser.TrxData{
+				SystemTrx: []*systems.SystemTrxData{},
+				BankTrx: []*banks.BankTrxData{
+					{
+						UniqueIdentifier: BNIUniqueUUID,
+						Date: func() time.Time {
+							t, _ := time.Parse(DateFormat, "2025-03-09")
+							return t
+						}(),
+						Type:           "DEBIT",
+						Bank:           "BNI",
+						FilePath:       BankBniCsvFile,
+						Amount:         71200,
+						OriginalAmount: 71200,
+					},
+				},
+				BankStatementBalances: []balance.Result{
+					{
+						Bank:      "bni",
+						FilePath:  BankBniCsvFile,
+						Status:    balance.StatusNoBalance,
+						Movement:  -142400,
+						TotalLine: 2,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - balance not balanced",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									FromDate: func() time.Time {
+										t, _ := time.Parse(DateFormat, DateFrom)
+										return t
+									}(),
+									ToDate: func() time.Time {
+										t, _ := time.Parse(DateFormat, "2025-03-07")
+										return t
+									}(),
+									SystemTRXPath: SystemPath,
+									BankTRXPath:   "/bank",
+									ListBank:      []string{"bca"},
+									Balance: reconciliation.Balance{
+										Policy: reconciliation.BalancePolicyWarn,
+									},
+								},
+							},
+						}
 					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					mockprocess.NewRepository(t),
 				),
 				parserRegistry: testRegistry,
 			},
 			args: args{
-				data: []*systems.SystemTrxData{
+				afs: func() afero.Fs {
+					f := afero.NewMemMapFs()
+					systemTrxFile, _ := f.Create(SystemCsvFile)
+					_, _ = systemTrxFile.Write([]byte(
+						`TrxID,TransactionTime,Type,Amount
+0066a6264a3b04ac25bd93eed2cb3bbb,2025-03-08 10:18:29,CREDIT,9000
+`,
+					))
+
+					_ = systemTrxFile.Close()
+
+					bankTrxFile, _ := f.Create(BankBcaCsvFile)
+					_, _ = bankTrxFile.Write([]byte(
+						`BCAUniqueIdentifier,BCADate,BCAAmount,BCAReference,BCABalance
+bca-1,2025-03-06,7700,,107700
+bca-2,2025-03-06,-700,,106000
+bca-3,2025-03-09,500,,106500
+`,
+					))
+
+					_ = bankTrxFile.Close()
+
+					return f
+				}(),
+			},
+			wantTrxData: parser.TrxData{
+				SystemTrx: []*systems.SystemTrxData{},
+				BankTrx: []*banks.BankTrxData{
 					{
-						TrxID: "0066a6264a3b04ac25bd93eed2cb3c6c",
-						TransactionTime: func() time.Time {
-							t, _ := time.Parse(DateTimeFormat, TrxDateTimeTwo)
+						UniqueIdentifier: "bca-1",
+						Date: func() time.Time {
+							t, _ := time.Parse(DateFormat, DateFrom)
+							return t
+						}(),
+						Type:           "CREDIT",
+						Bank:           "BCA",
+						FilePath:       BankBcaCsvFile,
+						Amount:         7700,
+						OriginalAmount: 7700,
+						Balance:        107700,
+						IsHaveBalance:  true,
+					},
+					{
+						UniqueIdentifier: "bca-2",
+						Date: func() time.Time {
+							t, _ := time.Parse(DateFormat, DateFrom)
+							return t
+						}(),
+						Type:           "DEBIT",
+						Bank:           "BCA",
+						FilePath:       BankBcaCsvFile,
+						Amount:         700,
+						OriginalAmount: 700,
+						Balance:        106000,
+						IsHaveBalance:  true,
+					},
+				},
+				BankStatementBalances: []balance.Result{
+					{
+						Bank:           "bca",
+						FilePath:       BankBcaCsvFile,
+						Status:         balance.StatusNotBalanced,
+						FirstBreakID:   "bca-2",
+						OpeningBalance: 100000,
+						Movement:       7500,
+						ClosingBalance: 106500,
+						Difference:     -1000,
+						TotalLine:      3,
+						TotalBreak:     1,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - statement balances not balanced",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									FromDate: func() time.Time {
+										t, _ := time.Parse(DateFormat, DateFrom)
+										return t
+									}(),
+									ToDate: func() time.Time {
+										t, _ := time.Parse(DateFormat, "2025-03-07")
+										return t
+									}(),
+									SystemTRXPath:         SystemPath,
+									BankTRXPath:           "/bank",
+									ListBank:              []string{"bca"},
+									CurrencyDecimalPlaces: 2,
+									FX: reconciliation.FX{
+										BaseCurrency: "IDR",
+									},
+									Balance: reconciliation.Balance{
+										Policy: reconciliation.BalancePolicyWarn,
+									},
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					mockprocess.NewRepository(t),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				afs: func() afero.Fs {
+					f := afero.NewMemMapFs()
+					bankTrxFile, _ := f.Create("/bank/bca/statement.sta")
+					_, _ = bankTrxFile.Write([]byte(
+						`:20:STMT1
+:25:111
+:28C:1
+:60F:C250305IDR1000,
+:61:250306C77,NTRFbca-1
+:62F:C250306IDR1077,
+-
+:20:STMT2
+:25:111
+:28C:2
+:60F:C250306IDR1077,
+:61:250307D7,NTRFbca-2
+:62F:C250307IDR1060,
+-
+`,
+					))
+
+					_ = bankTrxFile.Close()
+
+					return f
+				}(),
+			},
+			wantTrxData: parser.TrxData{
+				SystemTrx: []*systems.SystemTrxData{},
+				BankTrx: []*banks.BankTrxData{
+					{
+						UniqueIdentifier: "bca-1",
+						Reference:        "bca-1",
+						Date: func() time.Time {
+							t, _ := time.Parse(DateFormat, DateFrom)
 							return t
 						}(),
-						Type:     "CREDIT",
-						FilePath: FileCSVPathTwo,
-						Amount:   41000,
+						ValueDate: func() time.Time {
+							t, _ := time.Parse(DateFormat, DateFrom)
+							return t
+						}(),
+						Type:           "CREDIT",
+						Bank:           "BCA",
+						Account:        "111",
+						FilePath:       "/bank/bca/statement.sta",
+						Currency:       "IDR",
+						Amount:         7700,
+						OriginalAmount: 7700,
 					},
 					{
-						TrxID: "006630c83821fac6bea13b92b480feb2",
-						TransactionTime: func() time.Time {
-							t, _ := time.Parse(DateTimeFormat, TrxDateTimeThree)
+						UniqueIdentifier: "bca-2",
+						Reference:        "bca-2",
+						Date: func() time.Time {
+							t, _ := time.Parse(DateFormat, "2025-03-07")
 							return t
 						}(),
-						Type:     "DEBIT",
-						FilePath: FileCSVPathOne,
-						Amount:   89900,
+						ValueDate: func() time.Time {
+							t, _ := time.Parse(DateFormat, "2025-03-07")
+							return t
+						}(),
+						Type:           "DEBIT",
+						Bank:           "BCA",
+						Account:        "111",
+						FilePath:       "/bank/bca/statement.sta",
+						Currency:       "IDR",
+						Amount:         700,
+						OriginalAmount: 700,
+					},
+				},
+				BankStatementBalances: []balance.Result{
+					{
+						Bank:           "bca",
+						Account:        "111",
+						FilePath:       "/bank/bca/statement.sta",
+						Status:         balance.StatusNotBalanced,
+						OpeningBalance: 100000,
+						Movement:       7000,
+						ClosingBalance: 106000,
+						Difference:     -1000,
+						TotalLine:      2,
 					},
 				},
 			},
 			wantErr: false,
 		},
 		{
-			name: "Error",
+			name: "Error - balance not balanced",
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
-					func() *cconfig.Config {
-						return &cconfig.Config{
-							Data: &config.Data{
-								Reconciliation: reconciliation.Reconciliation{
-									NumberWorker: 10,
+					&cconfig.Config{
+						Data: &config.Data{
+							Reconciliation: reconciliation.Reconciliation{
+								SystemTRXPath: SystemPath,
+								BankTRXPath:   "/bank",
+								ListBank:      []string{"bca"},
+								Balance: reconciliation.Balance{
+									Policy: reconciliation.BalancePolicyFail,
 								},
 							},
-						}
-					}(),
+						},
+					},
 					&clogger.Logger{},
 					&cerror.Error{},
 					&csqlite.DBSqlite{},
@@ -1578,85 +4686,31 @@ func TestSvcImportReconcileSystemDataToDB(t *testing.T) {
 				),
 				repo: repository.NewRepositories(
 					mocksample.NewRepository(t),
-					func() process.Repository {
-						m := mockprocess.NewRepository(t)
-						m.On(
-							"ImportSystemTrx",
-							mock.Anything,
-							mock.Anything,
-							mock.Anything,
-							mock.Anything,
-						).Return(errors.New("error")).Maybe()
-						return m
-					}(),
+					mockprocess.NewRepository(t),
 				),
 				parserRegistry: testRegistry,
 			},
 			args: args{
-				data: []*systems.SystemTrxData{
-					{
-						TrxID: "0066a6264a3b04ac25bd93eed2cb3c6c",
-						TransactionTime: func() time.Time {
-							t, _ := time.Parse(DateTimeFormat, TrxDateTimeTwo)
-							return t
-						}(),
-						Type:     "CREDIT",
-						FilePath: FileCSVPathTwo,
-						Amount:   41000,
-					},
-					{
-						TrxID: "006630c83821fac6bea13b92b480feb2",
-						TransactionTime: func() time.Time {
-							t, _ := time.Parse(DateTimeFormat, TrxDateTimeThree)
-							return t
-						}(),
-						Type:     "DEBIT",
-						FilePath: FileCSVPathOne,
-						Amount:   89900,
-					},
-				},
-			},
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := &Svc{
-				comp:           tt.fields.comp,
-				repo:           tt.fields.repo,
-				parserRegistry: tt.fields.parserRegistry,
-			}
-
-			if err := s.importReconcileSystemDataToDB(ctx, tt.args.data); (err != nil) != tt.wantErr {
-				t.Errorf("importReconcileSystemDataToDB() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
-func TestSvcParse(t *testing.T) {
-	ctx := context.Background()
-	testRegistry := newTestParserRegistry()
-	type fields struct {
-		comp           *component.Components
-		repo           *repository.Repositories
-		parserRegistry *banks.ParserRegistry
-	}
+				afs: func() afero.Fs {
+					f := afero.NewMemMapFs()
+					bankTrxFile, _ := f.Create(BankBcaCsvFile)
+					_, _ = bankTrxFile.Write([]byte(
+						`BCAUniqueIdentifier,BCADate,BCAAmount,BCAReference,BCABalance
+bca-1,2025-03-06,7700,,107700
+bca-2,2025-03-06,-700,,106000
+`,
+					))
 
-	type args struct {
-		afs afero.Fs
-	}
+					_ = bankTrxFile.Close()
 
-	tests := []struct {
-		name        string
-		fields      fields
-		args        args
-		wantTrxData parser.TrxData
-		wantErr     bool
-	}{
+					return f
+				}(),
+			},
+			wantTrxData: parser.TrxData{},
+			wantErr:     true,
+		},
 		{
-			name: "Ok",
+			name: "Ok - fx",
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
@@ -1674,7 +4728,17 @@ func TestSvcParse(t *testing.T) {
 									}(),
 									SystemTRXPath: SystemPath,
 									BankTRXPath:   "/bank",
-									ListBank:      []string{"bca", "bni"},
+									ListBank:      []string{"bca"},
+									FX: reconciliation.FX{
+										BaseCurrency: "IDR",
+										RateFilePath: "/fx.csv",
+										BankCurrency: map[string]string{
+											"bca": "usd",
+										},
+									},
+									Balance: reconciliation.Balance{
+										Policy: reconciliation.BalancePolicyOff,
+									},
 								},
 							},
 						}
@@ -1694,13 +4758,20 @@ func TestSvcParse(t *testing.T) {
 			args: args{
 				afs: func() afero.Fs {
 					f := afero.NewMemMapFs()
+					fxFile, _ := f.Create("/fx.csv")
+					_, _ = fxFile.Write([]byte(
+						`Date,FromCurrency,ToCurrency,Rate
+2025-03-01,USD,IDR,16000
+2025-03-01,SGD,IDR,12000
+`,
+					))
+
+					_ = fxFile.Close()
+
 					systemTrxFile, _ := f.Create(SystemCsvFile)
 					_, _ = systemTrxFile.Write([]byte(
-						`TrxID,TransactionTime,Type,Amount
-006630c83821fac6bea13b92b480feb2,2025-03-06 17:09:21,DEBIT,41000
-0066a6264a3b04ac25bd93eed2cb3c6c,2025-03-07 10:18:29,CREDIT,1000
-0066a6264a3b04ac25bd93eed2cb3aaa,2025-03-07 10:18:29,CREDIT,89900
-0066a6264a3b04ac25bd93eed2cb3bbb,2025-03-08 10:18:29,CREDIT,9000
+						`TrxID,TransactionTime,Type,Amount,Currency
+006630c83821fac6bea13b92b480feb2,2025-03-06 17:09:21,DEBIT,41000,sgd
 `,
 					))
 
@@ -1713,15 +4784,6 @@ bca-5585fa85a971917b48ea2729bcf7d9fb,2025-03-06,7700
 `,
 					))
 
-					_ = bankTrxFile.Close()
-					bankTrxFile, _ = f.Create(BankBniCsvFile)
-
-					_, _ = bankTrxFile.Write([]byte(
-						`BNIUniqueIdentifier,BNIDate,BNIAmount
-bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
-`,
-					))
-
 					_ = bankTrxFile.Close()
 
 					return f
@@ -1735,31 +4797,95 @@ bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 							t, _ := time.Parse(DateTimeFormat, TrxDateTimeOne)
 							return t
 						}(),
-						Type:     "DEBIT",
-						FilePath: SystemCsvFile,
-						Amount:   41000,
-					},
-					{
-						TrxID: "0066a6264a3b04ac25bd93eed2cb3c6c",
-						TransactionTime: func() time.Time {
-							t, _ := time.Parse(DateTimeFormat, TrxDateTimeTwo)
-							return t
-						}(),
-						Type:     "CREDIT",
-						FilePath: SystemCsvFile,
-						Amount:   1000,
+						Type:           "DEBIT",
+						FilePath:       SystemCsvFile,
+						Currency:       "SGD",
+						Amount:         492000000,
+						OriginalAmount: 41000,
 					},
+				},
+				BankTrx: []*banks.BankTrxData{
 					{
-						TrxID: "0066a6264a3b04ac25bd93eed2cb3aaa",
-						TransactionTime: func() time.Time {
-							t, _ := time.Parse(DateTimeFormat, TrxDateTimeTwo)
+						UniqueIdentifier: BCAUniqueUUID,
+						Date: func() time.Time {
+							t, _ := time.Parse(DateFormat, DateFrom)
 							return t
 						}(),
-						Type:     "CREDIT",
-						FilePath: SystemCsvFile,
-						Amount:   89900,
+						Type:           "CREDIT",
+						Bank:           "BCA",
+						FilePath:       BankBcaCsvFile,
+						Currency:       "USD",
+						Amount:         123200000,
+						OriginalAmount: 7700,
 					},
 				},
+				MinSystemAmount: 492000000,
+				MaxSystemAmount: 492000000,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - no fx rate",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									FromDate: func() time.Time {
+										t, _ := time.Parse(DateFormat, DateFrom)
+										return t
+									}(),
+									ToDate: func() time.Time {
+										t, _ := time.Parse(DateFormat, "2025-03-07")
+										return t
+									}(),
+									SystemTRXPath: SystemPath,
+									BankTRXPath:   "/bank",
+									ListBank:      []string{"bca"},
+									FX: reconciliation.FX{
+										BaseCurrency: "IDR",
+										BankCurrency: map[string]string{
+											"bca": "USD",
+										},
+									},
+									Balance: reconciliation.Balance{
+										Policy: reconciliation.BalancePolicyOff,
+									},
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					mockprocess.NewRepository(t),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				afs: func() afero.Fs {
+					f := afero.NewMemMapFs()
+					bankTrxFile, _ := f.Create(BankBcaCsvFile)
+					_, _ = bankTrxFile.Write([]byte(
+						`BCAUniqueIdentifier,BCADate,BCAAmount
+bca-5585fa85a971917b48ea2729bcf7d9fb,2025-03-06,7700
+`,
+					))
+
+					_ = bankTrxFile.Close()
+
+					return f
+				}(),
+			},
+			wantTrxData: parser.TrxData{
+				SystemTrx: []*systems.SystemTrxData{},
 				BankTrx: []*banks.BankTrxData{
 					{
 						UniqueIdentifier: BCAUniqueUUID,
@@ -1767,17 +4893,51 @@ bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 							t, _ := time.Parse(DateFormat, DateFrom)
 							return t
 						}(),
-						Type:     "CREDIT",
-						Bank:     "BCA",
-						FilePath: BankBcaCsvFile,
-						Amount:   7700,
+						Type:            "CREDIT",
+						Bank:            "BCA",
+						FilePath:        BankBcaCsvFile,
+						Currency:        "USD",
+						Amount:          7700,
+						OriginalAmount:  7700,
+						IsMissingFXRate: true,
 					},
 				},
-				MinSystemAmount: 0,
-				MaxSystemAmount: 89900,
 			},
 			wantErr: false,
 		},
+		{
+			name: "Error - fx rate file not found",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					&cconfig.Config{
+						Data: &config.Data{
+							Reconciliation: reconciliation.Reconciliation{
+								FX: reconciliation.FX{
+									BaseCurrency: "IDR",
+									RateFilePath: "/fx.csv",
+								},
+							},
+						},
+					},
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					mockprocess.NewRepository(t),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				afs: afero.NewMemMapFs(),
+			},
+			wantTrxData: parser.TrxData{},
+			wantErr:     true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1786,6 +4946,7 @@ bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 				tt.fields.comp,
 				tt.fields.repo,
 				tt.fields.parserRegistry,
+				newTestGatewayParserRegistry(),
 			)
 
 			gotTrxData, err := s.parse(ctx, tt.args.afs)
@@ -1815,19 +4976,100 @@ func TestSvcParseBankTrxFile(t *testing.T) {
 		item FilePathBankTrx
 	}
 
-	tests := []struct {
-		name           string
-		fields         fields
-		args           args
-		wantReturnData []*banks.BankTrxData
-		wantErr        bool
-	}{
+	tests := []struct {
+		name                  string
+		fields                fields
+		args                  args
+		wantReturnData        []*banks.BankTrxData
+		wantStatementBalances []banks.StatementBalance
+		wantErr               bool
+	}{
+		{
+			name: "Ok camt.053 with account from folder",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					&cconfig.Config{Data: &config.Data{}},
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					mockprocess.NewRepository(t),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				afs: func() afero.Fs {
+					f := afero.NewMemMapFs()
+					fooFile, _ := f.Create("/bca/123/statement.xml")
+
+					_, _ = fooFile.Write([]byte(
+						`<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Id>STMT</Id>
+      <Bal><Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp><Amt Ccy="IDR">100</Amt><CdtDbtInd>CRDT</CdtDbtInd></Bal>
+      <Bal><Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp><Amt Ccy="IDR">177</Amt><CdtDbtInd>CRDT</CdtDbtInd></Bal>
+      <Ntry>
+        <Amt Ccy="IDR">77</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><Dt>2025-03-06</Dt></BookgDt>
+        <AcctSvcrRef>` + BCAUniqueUUID + `</AcctSvcrRef>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`,
+					))
+
+					_ = fooFile.Close()
+					return f
+				}(),
+				item: FilePathBankTrx{
+					Bank:     "bca",
+					Account:  "123",
+					FilePath: "/bca/123/statement.xml",
+				},
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: BCAUniqueUUID,
+					Date: func() time.Time {
+						t, _ := time.Parse(DateFormat, DateFrom)
+						return t
+					}(),
+					Type:     "CREDIT",
+					Bank:     "BCA",
+					Account:  "123",
+					FilePath: "/bca/123/statement.xml",
+					Currency: "IDR",
+					Amount:   77,
+				},
+			},
+			wantStatementBalances: []banks.StatementBalance{
+				{
+					Bank:                 "BCA",
+					Account:              "123",
+					FilePath:             "/bca/123/statement.xml",
+					OpeningBalance:       100,
+					ClosingBalance:       177,
+					IsHaveOpeningBalance: true,
+					IsHaveClosingBalance: true,
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Ok bca",
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
-					&cconfig.Config{},
+					&cconfig.Config{Data: &config.Data{}},
 					&clogger.Logger{},
 					&cerror.Error{},
 					&csqlite.DBSqlite{},
@@ -1891,7 +5133,7 @@ bca-5585fa85a971917b48ea2729bcf7d9fb,2025-03-06,7700
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
-					&cconfig.Config{},
+					&cconfig.Config{Data: &config.Data{}},
 					&clogger.Logger{},
 					&cerror.Error{},
 					&csqlite.DBSqlite{},
@@ -1955,7 +5197,7 @@ bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
-					&cconfig.Config{},
+					&cconfig.Config{Data: &config.Data{}},
 					&clogger.Logger{},
 					&cerror.Error{},
 					&csqlite.DBSqlite{},
@@ -2019,7 +5261,7 @@ foo-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
-					&cconfig.Config{},
+					&cconfig.Config{Data: &config.Data{}},
 					&clogger.Logger{},
 					&cerror.Error{},
 					&csqlite.DBSqlite{},
@@ -2038,12 +5280,6 @@ foo-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 					fooFile, _ := f.Create(FileCSVPathFoo)
 					_ = f.Chmod(FileCSVPathFoo, 0000)
 
-					_, _ = fooFile.Write([]byte(
-						`UniqueIdentifier
-foo-7b422b9abac7a628125bc1c6bc7adced,string,79500
-`,
-					))
-
 					_ = fooFile.Close()
 					return f
 				}(),
@@ -2060,7 +5296,7 @@ foo-7b422b9abac7a628125bc1c6bc7adced,string,79500
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
-					&cconfig.Config{},
+					&cconfig.Config{Data: &config.Data{}},
 					&clogger.Logger{},
 					&cerror.Error{},
 					&csqlite.DBSqlite{},
@@ -2099,7 +5335,7 @@ foo-7b422b9abac7a628125bc1c6bc7adced,string,79500
 				parserRegistry: tt.fields.parserRegistry,
 			}
 
-			gotReturnData, err := s.parseBankTrxFile(ctx, tt.args.afs, tt.args.item)
+			gotReturnData, gotStatementBalances, err := s.parseBankTrxFile(ctx, tt.args.afs, tt.args.item)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("parseBankTrxFile() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -2108,6 +5344,10 @@ foo-7b422b9abac7a628125bc1c6bc7adced,string,79500
 			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
 				t.Errorf("parseBankTrxFile() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
 			}
+
+			if !reflect.DeepEqual(gotStatementBalances, tt.wantStatementBalances) {
+				t.Errorf("parseBankTrxFile() gotStatementBalances = %v, want %v", gotStatementBalances, tt.wantStatementBalances)
+			}
 		})
 	}
 }
@@ -2126,11 +5366,12 @@ func TestSvcParseBankTrxFiles(t *testing.T) {
 	}
 
 	tests := []struct {
-		name           string
-		fields         fields
-		args           args
-		wantReturnData []*banks.BankTrxData
-		wantErr        bool
+		name                  string
+		fields                fields
+		args                  args
+		wantReturnData        []*banks.BankTrxData
+		wantStatementBalances []banks.StatementBalance
+		wantErr               bool
 	}{
 		{
 			name: "Ok",
@@ -2209,6 +5450,202 @@ bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
 			},
 			wantErr: false,
 		},
+		{
+			name: "Ok - account from folder and path pattern",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									BankTRXPath: "/random_string/foo/bar",
+									ListBank:    []string{"bca", "bni"},
+									Account: reconciliation.Account{
+										PathPattern: `statement_(\d+)\.csv$`,
+									},
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					mockprocess.NewRepository(t),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				afs: func() afero.Fs {
+					f := afero.NewMemMapFs()
+					fooFile, _ := f.Create("/random_string/foo/bar/bca/1234567890/any_string.csv")
+					_, _ = fooFile.Write([]byte(
+						`BCAUniqueIdentifier,BCADate,BCAAmount
+bca-5585fa85a971917b48ea2729bcf7d9fb,2025-03-06,7700
+`,
+					))
+
+					_ = fooFile.Close()
+
+					fooFile, _ = f.Create("/random_string/foo/bar/bni/statement_0987654321.csv")
+
+					_, _ = fooFile.Write([]byte(
+						`BNIUniqueIdentifier,BNIDate,BNIAmount
+bni-5f4b1bdf10332ea307813ce402f3d7d4,2025-03-09,-71200
+`,
+					))
+
+					_ = fooFile.Close()
+					return f
+				}(),
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: BCAUniqueUUID,
+					Date: func() time.Time {
+						t, _ := time.Parse(DateFormat, DateFrom)
+						return t
+					}(),
+					Type:     "CREDIT",
+					Bank:     "BCA",
+					Account:  "1234567890",
+					FilePath: "/random_string/foo/bar/bca/1234567890/any_string.csv",
+					Amount:   7700,
+				},
+				{
+					UniqueIdentifier: BNIUniqueUUID,
+					Date: func() time.Time {
+						t, _ := time.Parse(DateFormat, DateTo)
+						return t
+					}(),
+					Type:     "DEBIT",
+					Bank:     "BNI",
+					Account:  "0987654321",
+					FilePath: "/random_string/foo/bar/bni/statement_0987654321.csv",
+					Amount:   71200,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - MT940 statement",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									BankTRXPath:           "/random_string/foo/bar",
+									ListBank:              []string{"bca"},
+									CurrencyDecimalPlaces: 2,
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					mockprocess.NewRepository(t),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				afs: func() afero.Fs {
+					f := afero.NewMemMapFs()
+					fooFile, _ := f.Create("/random_string/foo/bar/bca/any_string.sta")
+					_, _ = fooFile.Write([]byte(
+						`:20:STMT
+:25:CENAIDJA/1234567890
+:28C:1
+:60F:C250305IDR0,
+:61:250306C77,NTRF` + BCAUniqueUUID + `
+:62F:C250306IDR77,
+`,
+					))
+
+					_ = fooFile.Close()
+					return f
+				}(),
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: BCAUniqueUUID,
+					Reference:        BCAUniqueUUID,
+					Date: func() time.Time {
+						t, _ := time.Parse(DateFormat, DateFrom)
+						return t
+					}(),
+					ValueDate: func() time.Time {
+						t, _ := time.Parse(DateFormat, DateFrom)
+						return t
+					}(),
+					Type:     "CREDIT",
+					Bank:     "BCA",
+					Account:  "1234567890",
+					FilePath: "/random_string/foo/bar/bca/any_string.sta",
+					Currency: "IDR",
+					Amount:   7700,
+				},
+			},
+			wantStatementBalances: []banks.StatementBalance{
+				{
+					Bank:                 "BCA",
+					Account:              "1234567890",
+					FilePath:             "/random_string/foo/bar/bca/any_string.sta",
+					ClosingBalance:       7700,
+					IsHaveOpeningBalance: true,
+					IsHaveClosingBalance: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error - invalid path pattern",
+			fields: fields{
+				comp: component.NewComponents(
+					ctx,
+					func() *cconfig.Config {
+						return &cconfig.Config{
+							Data: &config.Data{
+								Reconciliation: reconciliation.Reconciliation{
+									BankTRXPath: "/random_string/foo/bar",
+									ListBank:    []string{"bca"},
+									Account: reconciliation.Account{
+										PathPattern: `statement_(\d+`,
+									},
+								},
+							},
+						}
+					}(),
+					&clogger.Logger{},
+					&cerror.Error{},
+					&csqlite.DBSqlite{},
+					&cfs.Fs{},
+					&cprofiler.Profiler{},
+				),
+				repo: repository.NewRepositories(
+					mocksample.NewRepository(t),
+					mockprocess.NewRepository(t),
+				),
+				parserRegistry: testRegistry,
+			},
+			args: args{
+				afs: afero.NewMemMapFs(),
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
 		{
 			name: "Ok - bank not in the list",
 			fields: fields{
@@ -2261,9 +5698,10 @@ bca-5585fa85a971917b48ea2729bcf7d9fb,2025-03-06,7700
 				tt.fields.comp,
 				tt.fields.repo,
 				tt.fields.parserRegistry,
+				newTestGatewayParserRegistry(),
 			)
 
-			gotReturnData, err := s.parseBankTrxFiles(ctx, tt.args.afs)
+			gotReturnData, gotStatementBalances, err := s.parseBankTrxFiles(ctx, tt.args.afs)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("parseBankTrxFiles() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -2280,6 +5718,128 @@ bca-5585fa85a971917b48ea2729bcf7d9fb,2025-03-06,7700
 			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
 				t.Errorf("parseBankTrxFiles() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
 			}
+
+			if !reflect.DeepEqual(gotStatementBalances, tt.wantStatementBalances) {
+				t.Errorf("parseBankTrxFiles() gotStatementBalances = %v, want %v", gotStatementBalances, tt.wantStatementBalances)
+			}
+		})
+	}
+}
+
+func TestSvcParseGatewayTrxFiles(t *testing.T) {
+	ctx := context.Background()
+	newComp := func(listGateway []string) *component.Components {
+		return component.NewComponents(
+			ctx,
+			&cconfig.Config{
+				Data: &config.Data{
+					Reconciliation: reconciliation.Reconciliation{
+						Gateway: reconciliation.Gateway{
+							TRXPath:     "/random_string/gateway",
+							ListGateway: listGateway,
+						},
+					},
+				},
+			},
+			&clogger.Logger{},
+			&cerror.Error{},
+			&csqlite.DBSqlite{},
+			&cfs.Fs{},
+			&cprofiler.Profiler{},
+		)
+	}
+
+	newFs := func() afero.Fs {
+		f := afero.NewMemMapFs()
+		_ = afero.WriteFile(
+			f,
+			"/random_string/gateway/xendit/any_string.csv",
+			[]byte(`UniqueIdentifier,Date,Amount,Fee,Reference,PayoutID
+xendit-1,2025-03-06,7700,200,trx-1,payout-1
+xendit-2,2025-03-06,-1200,0,,payout-1
+`),
+			0o644,
+		)
+
+		return f
+	}
+
+	trxDate, _ := time.Parse(DateFormat, DateFrom)
+
+	type args struct {
+		afs afero.Fs
+	}
+
+	tests := []struct {
+		name           string
+		comp           *component.Components
+		args           args
+		wantReturnData []*gateways.GatewayTrxData
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			comp: newComp([]string{"xendit"}),
+			args: args{
+				afs: newFs(),
+			},
+			wantReturnData: []*gateways.GatewayTrxData{
+				{
+					UniqueIdentifier: "xendit-1",
+					Reference:        "trx-1",
+					PayoutID:         "payout-1",
+					Date:             trxDate,
+					Type:             gateways.CREDIT,
+					Gateway:          "XENDIT",
+					FilePath:         "/random_string/gateway/xendit/any_string.csv",
+					Amount:           7700,
+					Fee:              200,
+				},
+				{
+					UniqueIdentifier: "xendit-2",
+					PayoutID:         "payout-1",
+					Date:             trxDate,
+					Type:             gateways.DEBIT,
+					Gateway:          "XENDIT",
+					FilePath:         "/random_string/gateway/xendit/any_string.csv",
+					Amount:           1200,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - gateway not in the list",
+			comp: newComp([]string{"midtrans"}),
+			args: args{
+				afs: newFs(),
+			},
+			wantReturnData: nil,
+			wantErr:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSvc(
+				tt.comp,
+				repository.NewRepositories(mocksample.NewRepository(t), mockprocess.NewRepository(t)),
+				newTestParserRegistry(),
+				newTestGatewayParserRegistry(),
+			)
+
+			gotReturnData, err := s.parseGatewayTrxFiles(ctx, tt.args.afs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseGatewayTrxFiles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			sort.Slice(gotReturnData, func(i, j int) bool {
+				return gotReturnData[i].UniqueIdentifier < gotReturnData[j].UniqueIdentifier
+			})
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("parseGatewayTrxFiles() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
 		})
 	}
 }
@@ -2310,7 +5870,7 @@ func TestSvcParseSystemTrxFile(t *testing.T) {
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
-					&cconfig.Config{},
+					&cconfig.Config{Data: &config.Data{}},
 					&clogger.Logger{},
 					&cerror.Error{},
 					&csqlite.DBSqlite{},
@@ -2369,7 +5929,7 @@ func TestSvcParseSystemTrxFile(t *testing.T) {
 			fields: fields{
 				comp: component.NewComponents(
 					ctx,
-					&cconfig.Config{},
+					&cconfig.Config{Data: &config.Data{}},
 					&clogger.Logger{},
 					&cerror.Error{},
 					&csqlite.DBSqlite{},
@@ -8,9 +8,11 @@ import (
 	"sync"
 
 	"github.com/aaronjan/hunch"
+	"github.com/jszwec/csvutil"
 	"github.com/oprekable/bank-reconcile/internal/app/component"
 	"github.com/oprekable/bank-reconcile/internal/app/repository"
 	"github.com/oprekable/bank-reconcile/internal/app/repository/sample"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
 	entitybca "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/bca/entity"
 	entitybni "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/bni/entity"
@@ -77,31 +79,39 @@ func (s *Svc) deleteDirectorySystemTrxBankTrx(ctx context.Context, fs afero.Fs,
 	return
 }
 
-func (s *Svc) parse(data sample.TrxData) (systemTrxData systems.SystemTrxDataInterface, bankTrxData banks.BankTrxDataInterface) {
+func (s *Svc) parse(data sample.TrxData, decimalPlaces int) (systemTrxData systems.SystemTrxDataInterface, bankTrxData banks.BankTrxDataInterface) {
+	amount := money.FromMajor(data.Amount, decimalPlaces)
 	if data.IsSystemTrx {
 		systemTrxData = &default_system.CSVSystemTrxData{
 			TrxID:           data.TrxID,
 			TransactionTime: data.TransactionTime,
 			Type:            data.Type,
-			Amount:          data.Amount,
+			Amount:          amount,
 		}
 	}
 
 	if data.IsBankTrx || (!data.IsBankTrx && !data.IsSystemTrx) {
 		bank := strings.ToLower(data.Bank)
-		multiplier := float64(1)
+		multiplier := money.Amount(1)
 		if data.Type == DEBIT {
-			multiplier = float64(-1)
+			multiplier = money.Amount(-1)
 		}
 
 		switch strings.ToUpper(bank) {
 		case "BCA":
 			{
+				// bca statements carry our TrxID as reference for trx found in both sides
+				reference := ""
+				if data.IsSystemTrx && data.IsBankTrx {
+					reference = data.TrxID
+				}
+
 				bankTrxData = &entitybca.CSVBankTrxData{
 					BCAUniqueIdentifier: data.UniqueIdentifier,
 					BCADate:             data.Date,
-					BCAAmount:           data.Amount * multiplier,
+					BCAAmount:           amount * multiplier,
 					BCABank:             bank,
+					BCAReference:        reference,
 				}
 			}
 		case "BNI":
@@ -109,7 +119,7 @@ func (s *Svc) parse(data sample.TrxData) (systemTrxData systems.SystemTrxDataInt
 				bankTrxData = &entitybni.CSVBankTrxData{
 					BNIUniqueIdentifier: data.UniqueIdentifier,
 					BNIDate:             data.Date,
-					BNIAmount:           data.Amount * multiplier,
+					BNIAmount:           amount * multiplier,
 					BNIBank:             bank,
 				}
 			}
@@ -118,7 +128,7 @@ func (s *Svc) parse(data sample.TrxData) (systemTrxData systems.SystemTrxDataInt
 				bankTrxData = &entitydefaultbank.CSVBankTrxData{
 					DefaultUniqueIdentifier: data.UniqueIdentifier,
 					DefaultDate:             data.Date,
-					DefaultAmount:           data.Amount * multiplier,
+					DefaultAmount:           amount * multiplier,
 					DefaultBank:             bank,
 				}
 			}
@@ -189,7 +199,7 @@ func (s *Svc) GenerateSample(ctx context.Context, fs afero.Fs, bar *progressbar.
 			lo.ForEach(trxData, func(data sample.TrxData, _ int) {
 				pSystemTrxData := s.poolSystemTrxDataInterface.Get().(*systems.SystemTrxDataInterface)
 				pBankTrxData := s.poolBankTrxDataInterface.Get().(*banks.BankTrxDataInterface)
-				*pSystemTrxData, *pBankTrxData = s.parse(data)
+				*pSystemTrxData, *pBankTrxData = s.parse(data, s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces)
 
 				s.poolSystemTrxDataInterface.Put(pSystemTrxData)
 				s.poolBankTrxDataInterface.Put(pBankTrxData)
@@ -215,6 +225,7 @@ func (s *Svc) GenerateSample(ctx context.Context, fs afero.Fs, bar *progressbar.
 			returnSummary.FileSystemTrx = fmt.Sprintf("%s/%s.csv", s.comp.Config.Data.Reconciliation.SystemTRXPath, fileNameSuffix)
 			returnSummary.TotalSystemTrx = int64(lengthSystemTrxData)
 
+			amountMarshalers := money.CSVMarshalers(s.comp.Config.Data.Reconciliation.CurrencyDecimalPlaces)
 			executor := make([]hunch.Executable, 0, len(bankTrxData)+1)
 			executor = append(
 				executor,
@@ -225,6 +236,7 @@ func (s *Svc) GenerateSample(ctx context.Context, fs afero.Fs, bar *progressbar.
 						returnSummary.FileSystemTrx,
 						systemTrxData,
 						isDeleteDirectory,
+						amountMarshalers,
 					)
 
 					log.Err(c, "[sample.NewSvc] save csv file "+returnSummary.FileSystemTrx+" executed", er)
@@ -252,6 +264,7 @@ func (s *Svc) GenerateSample(ctx context.Context, fs afero.Fs, bar *progressbar.
 					returnSummary.FileBankTrx[bankName],
 					bankTrxData[bankName],
 					isDeleteDirectory,
+					amountMarshalers,
 				)
 
 				returnSummary.TotalBankTrx[bankName] = totalBankTrx
@@ -270,7 +283,7 @@ func (s *Svc) GenerateSample(ctx context.Context, fs afero.Fs, bar *progressbar.
 	return
 }
 
-func (s *Svc) appendExecutor(fs afero.Fs, filePath string, trxDataSlice []banks.BankTrxDataInterface, isDeleteDirectory bool) (totalData int64, executor hunch.Executable) {
+func (s *Svc) appendExecutor(fs afero.Fs, filePath string, trxDataSlice []banks.BankTrxDataInterface, isDeleteDirectory bool, amountMarshalers *csvutil.Marshalers) (totalData int64, executor hunch.Executable) {
 	if len(trxDataSlice) == 0 {
 		return 0, nil
 	}
@@ -295,6 +308,7 @@ func (s *Svc) appendExecutor(fs afero.Fs, filePath string, trxDataSlice []banks.
 					filePath,
 					bd,
 					isDeleteDirectory,
+					amountMarshalers,
 				)
 
 				bd = nil
@@ -317,6 +331,7 @@ func (s *Svc) appendExecutor(fs afero.Fs, filePath string, trxDataSlice []banks.
 					filePath,
 					bd,
 					isDeleteDirectory,
+					amountMarshalers,
 				)
 
 				bd = nil
@@ -339,6 +354,7 @@ func (s *Svc) appendExecutor(fs afero.Fs, filePath string, trxDataSlice []banks.
 					filePath,
 					bd,
 					isDeleteDirectory,
+					amountMarshalers,
 				)
 
 				bd = nil
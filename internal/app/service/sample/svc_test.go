@@ -22,6 +22,7 @@ import (
 	mockprocess "github.com/oprekable/bank-reconcile/internal/app/repository/process/_mock"
 	"github.com/oprekable/bank-reconcile/internal/app/repository/sample"
 	mocksample "github.com/oprekable/bank-reconcile/internal/app/repository/sample/_mock"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
 	entitybca "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/bca/entity"
 	entitybni "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/bni/entity"
@@ -29,6 +30,7 @@ import (
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems/default_system"
 
+	"github.com/jszwec/csvutil"
 	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/mock"
@@ -283,6 +285,7 @@ func TestSvcAppendExecutor(t *testing.T) {
 		filePath          string
 		trxDataSlice      []banks.BankTrxDataInterface
 		isDeleteDirectory bool
+		amountMarshalers  *csvutil.Marshalers
 	}
 
 	tests := []struct {
@@ -305,6 +308,7 @@ func TestSvcAppendExecutor(t *testing.T) {
 				filePath:          FileCSVPath,
 				trxDataSlice:      nil,
 				isDeleteDirectory: false,
+				amountMarshalers:  money.CSVMarshalers(2),
 			},
 			wantTotalData: 0,
 		},
@@ -331,6 +335,7 @@ func TestSvcAppendExecutor(t *testing.T) {
 					}
 				}(),
 				isDeleteDirectory: false,
+				amountMarshalers:  money.CSVMarshalers(2),
 			},
 			wantTotalData: 1,
 		},
@@ -357,6 +362,7 @@ func TestSvcAppendExecutor(t *testing.T) {
 					}
 				}(),
 				isDeleteDirectory: false,
+				amountMarshalers:  money.CSVMarshalers(2),
 			},
 			wantTotalData: 1,
 		},
@@ -383,6 +389,7 @@ func TestSvcAppendExecutor(t *testing.T) {
 					}
 				}(),
 				isDeleteDirectory: false,
+				amountMarshalers:  money.CSVMarshalers(2),
 			},
 			wantTotalData: 1,
 		},
@@ -395,7 +402,7 @@ func TestSvcAppendExecutor(t *testing.T) {
 				repo: tt.fields.repo,
 			}
 
-			gotTotalData, gotExecutor := s.appendExecutor(tt.args.fs, tt.args.filePath, tt.args.trxDataSlice, tt.args.isDeleteDirectory)
+			gotTotalData, gotExecutor := s.appendExecutor(tt.args.fs, tt.args.filePath, tt.args.trxDataSlice, tt.args.isDeleteDirectory, tt.args.amountMarshalers)
 			if gotTotalData != tt.wantTotalData {
 				t.Errorf("appendExecutor() gotTotalData = %v, want %v", gotTotalData, tt.wantTotalData)
 			}
@@ -520,7 +527,8 @@ func TestSvcParse(t *testing.T) {
 	}
 
 	type args struct {
-		data sample.TrxData
+		data          sample.TrxData
+		decimalPlaces int
 	}
 
 	tests := []struct {
@@ -548,18 +556,46 @@ func TestSvcParse(t *testing.T) {
 					IsBankTrx:        true,
 					Amount:           41000,
 				},
+				decimalPlaces: 2,
 			},
 			wantSystemTrxData: &default_system.CSVSystemTrxData{
 				TrxID:           "006630c83821fac6bea13b92b480feb2",
 				TransactionTime: TrxDateTime,
 				Type:            "DEBIT",
-				Amount:          41000,
+				Amount:          4100000,
+			},
+			wantBankTrxData: &entitybca.CSVBankTrxData{
+				BCAUniqueIdentifier: UniqueUUID,
+				BCADate:             TrxDate,
+				BCAAmount:           -4100000,
+				BCABank:             "bca",
+				BCAReference:        "006630c83821fac6bea13b92b480feb2",
+			},
+		},
+		{
+			name: "Ok - bca bank trx only",
+			fields: fields{
+				comp: nil,
+				repo: nil,
+			},
+			args: args{
+				data: sample.TrxData{
+					UniqueIdentifier: UniqueUUID,
+					Type:             "CREDIT",
+					Bank:             "bca",
+					Date:             TrxDate,
+					IsSystemTrx:      false,
+					IsBankTrx:        true,
+					Amount:           41000,
+				},
 			},
+			wantSystemTrxData: nil,
 			wantBankTrxData: &entitybca.CSVBankTrxData{
 				BCAUniqueIdentifier: UniqueUUID,
 				BCADate:             TrxDate,
-				BCAAmount:           -41000,
+				BCAAmount:           41000,
 				BCABank:             "bca",
+				BCAReference:        "",
 			},
 		},
 		{
@@ -635,7 +671,7 @@ func TestSvcParse(t *testing.T) {
 				repo: tt.fields.repo,
 			}
 
-			gotSystemTrxData, gotBankTrxData := s.parse(tt.args.data)
+			gotSystemTrxData, gotBankTrxData := s.parse(tt.args.data, tt.args.decimalPlaces)
 			if !reflect.DeepEqual(gotSystemTrxData, tt.wantSystemTrxData) {
 				t.Errorf("parse() gotSystemTrxData = %v, want %v", gotSystemTrxData, tt.wantSystemTrxData)
 			}
@@ -2,34 +2,113 @@ package service
 
 import (
 	"encoding/csv"
+	"io"
+	"strings"
 
 	"github.com/google/wire"
+	"github.com/oprekable/bank-reconcile/internal/app/component"
 	"github.com/oprekable/bank-reconcile/internal/app/service/process"
 	"github.com/oprekable/bank-reconcile/internal/app/service/sample"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/bca"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/bni"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/camt053"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/debit_credit"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/declarative"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/default_bank"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/mt940"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways/default_gateway"
 )
 
 // ProvideBankParserFactoryMap creates the map of all available bank parser factories.
 // This is the correct, high-level location for assembling all parser implementations.
-func ProvideBankParserFactoryMap() map[string]banks.BankParserFactory {
+// Banks picking a built-in parser or with a declared format are registered under their name, a declared format
+// takes precedence. Every parser reads the files of a bank with its locale.
+func ProvideBankParserFactoryMap(comp *component.Components) map[string]banks.BankParserFactory {
 	factories := make(map[string]banks.BankParserFactory)
+	locales := make(map[string]locale.Locale)
+	for bank, bankLocale := range comp.Config.Data.Reconciliation.BankLocale {
+		locales[strings.ToUpper(bank)] = bankLocale.ToLocale()
+	}
 
 	// Register BCA parser
-	factories[string(banks.BCABankParser)] = func(bankName string, reader *csv.Reader, hasHeader bool) (banks.ReconcileBankData, error) {
-		return bca.NewBankParser(bankName, reader, hasHeader)
+	factories[string(banks.BCABankParser)] = func(bankName string, reader io.Reader, hasHeader bool, decimalPlaces int) (banks.ReconcileBankData, error) {
+		return bca.NewBankParser(bankName, csv.NewReader(reader), hasHeader, decimalPlaces, locales[strings.ToUpper(bankName)])
 	}
 
 	// Register BNI parser
-	factories[string(banks.BNIBankParser)] = func(bankName string, reader *csv.Reader, hasHeader bool) (banks.ReconcileBankData, error) {
-		return bni.NewBankParser(bankName, reader, hasHeader)
+	factories[string(banks.BNIBankParser)] = func(bankName string, reader io.Reader, hasHeader bool, decimalPlaces int) (banks.ReconcileBankData, error) {
+		return bni.NewBankParser(bankName, csv.NewReader(reader), hasHeader, decimalPlaces, locales[strings.ToUpper(bankName)])
+	}
+
+	// Register Default parser
+	factories[string(banks.DefaultBankParser)] = func(bankName string, reader io.Reader, hasHeader bool, decimalPlaces int) (banks.ReconcileBankData, error) {
+		return default_bank.NewBankParser(bankName, csv.NewReader(reader), hasHeader, decimalPlaces, locales[strings.ToUpper(bankName)])
+	}
+
+	// Register Debit Credit parser
+	factories[string(banks.DebitCreditBankParser)] = func(bankName string, reader io.Reader, hasHeader bool, decimalPlaces int) (banks.ReconcileBankData, error) {
+		return debit_credit.NewBankParser(bankName, csv.NewReader(reader), hasHeader, decimalPlaces, locales[strings.ToUpper(bankName)])
+	}
+
+	// Register MT940 parser, picked for files in MT940 format whatever the bank
+	factories[string(banks.MT940BankParser)] = func(bankName string, reader io.Reader, _ bool, decimalPlaces int) (banks.ReconcileBankData, error) {
+		return mt940.NewBankParser(bankName, reader, decimalPlaces)
+	}
+
+	// Register camt.053 parser, picked for files in camt.053 format whatever the bank
+	factories[string(banks.Camt053BankParser)] = func(bankName string, reader io.Reader, _ bool, decimalPlaces int) (banks.ReconcileBankData, error) {
+		return camt053.NewBankParser(bankName, reader, decimalPlaces)
 	}
 
+	// Register banks picking a built-in parser
+	for bank, parser := range comp.Config.Data.Reconciliation.BankParser {
+		if factory, ok := factories[strings.ToUpper(parser)]; ok {
+			factories[strings.ToUpper(bank)] = factory
+		}
+	}
+
+	// Register declared bank formats
+	for bank, bankFormat := range comp.Config.Data.Reconciliation.BankFormat {
+		format := declarative.Format{
+			Columns: declarative.Columns{
+				UniqueIdentifier:    bankFormat.Columns.UniqueIdentifier,
+				Date:                bankFormat.Columns.Date,
+				Amount:              bankFormat.Columns.Amount,
+				Debit:               bankFormat.Columns.Debit,
+				Credit:              bankFormat.Columns.Credit,
+				Reference:           bankFormat.Columns.Reference,
+				Account:             bankFormat.Columns.Account,
+				Balance:             bankFormat.Columns.Balance,
+				CounterpartyAccount: bankFormat.Columns.CounterpartyAccount,
+			},
+			Locale:     locales[strings.ToUpper(bank)],
+			DateLayout: bankFormat.DateLayout,
+			Sign:       bankFormat.Sign,
+			SkipRows:   bankFormat.SkipRows,
+		}
+
+		if delimiter := []rune(bankFormat.Delimiter); len(delimiter) > 0 {
+			format.Delimiter = delimiter[0]
+		}
+
+		factories[strings.ToUpper(bank)] = func(bankName string, reader io.Reader, _ bool, decimalPlaces int) (banks.ReconcileBankData, error) {
+			return declarative.NewBankParser(bankName, csv.NewReader(reader), decimalPlaces, format)
+		}
+	}
+
+	return factories
+}
+
+// ProvideGatewayParserFactoryMap creates the map of all available gateway parser factories.
+func ProvideGatewayParserFactoryMap() map[string]gateways.GatewayParserFactory {
+	factories := make(map[string]gateways.GatewayParserFactory)
+
 	// Register Default parser
-	factories[string(banks.DefaultBankParser)] = func(bankName string, reader *csv.Reader, hasHeader bool) (banks.ReconcileBankData, error) {
-		return default_bank.NewBankParser(bankName, reader, hasHeader)
+	factories[string(gateways.DefaultGatewayParser)] = func(gatewayName string, reader *csv.Reader, hasHeader bool, decimalPlaces int) (gateways.ReconcileGatewayData, error) {
+		return default_gateway.NewGatewayParser(gatewayName, reader, hasHeader, decimalPlaces)
 	}
 
 	return factories
@@ -49,6 +128,8 @@ var Set = wire.NewSet(
 	// Provide the parser registry dependencies
 	ProvideBankParserFactoryMap,
 	banks.NewParserRegistry,
+	ProvideGatewayParserFactoryMap,
+	gateways.NewParserRegistry,
 
 	// Provide the services
 	sample.Set,
@@ -1,15 +1,22 @@
 package service
 
 import (
+	"context"
 	"encoding/csv"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/oprekable/bank-reconcile/internal/app/component"
+	"github.com/oprekable/bank-reconcile/internal/app/component/cconfig"
+	"github.com/oprekable/bank-reconcile/internal/app/config"
+	"github.com/oprekable/bank-reconcile/internal/app/config/reconciliation"
 	"github.com/oprekable/bank-reconcile/internal/app/service/process"
 	mockprocess "github.com/oprekable/bank-reconcile/internal/app/service/process/_mock"
 	"github.com/oprekable/bank-reconcile/internal/app/service/sample"
 	mocksample "github.com/oprekable/bank-reconcile/internal/app/service/sample/_mock"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
 )
 
 func TestNewServices(t *testing.T) {
@@ -50,7 +57,9 @@ func TestProvideBankParserFactoryMap(t *testing.T) {
 		name       string
 		bank       string
 		parser     string
+		content    string
 		wantParser string
+		wantTotal  int
 	}{
 		{
 			name:       "DEFAULT ok",
@@ -70,22 +79,131 @@ func TestProvideBankParserFactoryMap(t *testing.T) {
 			parser:     string(banks.BNIBankParser),
 			wantParser: string(banks.BNIBankParser),
 		},
+		{
+			name:       "DEBIT_CREDIT ok",
+			bank:       string(banks.DebitCreditBankParser),
+			parser:     string(banks.DebitCreditBankParser),
+			wantParser: string(banks.DebitCreditBankParser),
+		},
+		{
+			name:       "Picked parser ok",
+			bank:       "bri",
+			parser:     "BRI",
+			content:    "UniqueIdentifier|Date|Debit|Kredit\nbri-1|2025-03-01||100",
+			wantParser: string(banks.DebitCreditBankParser),
+			wantTotal:  1,
+		},
+		{
+			name:       "Declared format ok",
+			bank:       "mandiri",
+			parser:     "MANDIRI",
+			content:    "No Ref;Tanggal;Mutasi\nmandiri-1;2025-03-01;100",
+			wantParser: string(banks.DeclarativeBankParser),
+			wantTotal:  1,
+		},
+		{
+			name:       "MT940 ok",
+			bank:       "mandiri",
+			parser:     string(banks.MT940BankParser),
+			content:    ":20:STMT\n:25:1234567890\n:61:250301C100,NTRFmandiri-1",
+			wantParser: string(banks.MT940BankParser),
+			wantTotal:  1,
+		},
+		{
+			name:       "CAMT053 ok",
+			bank:       "mandiri",
+			parser:     string(banks.Camt053BankParser),
+			content:    "<Document><BkToCstmrStmt><Stmt><Ntry><Amt Ccy=\"IDR\">100</Amt><CdtDbtInd>CRDT</CdtDbtInd><BookgDt><Dt>2025-03-01</Dt></BookgDt></Ntry></Stmt></BkToCstmrStmt></Document>",
+			wantParser: string(banks.Camt053BankParser),
+			wantTotal:  1,
+		},
+	}
+
+	comp := &component.Components{
+		Config: &cconfig.Config{
+			Data: &config.Data{
+				Reconciliation: reconciliation.Reconciliation{
+					BankParser: map[string]string{
+						"bri":     "debit_credit",
+						"mandiri": "bca",
+					},
+					BankLocale: map[string]reconciliation.Locale{
+						"bri": {
+							Delimiter: "|",
+						},
+						"mandiri": {
+							Delimiter: "|",
+						},
+					},
+					BankFormat: map[string]reconciliation.BankFormat{
+						"mandiri": {
+							Columns: reconciliation.BankFormatColumns{
+								UniqueIdentifier: "No Ref",
+								Date:             "Tanggal",
+								Amount:           "Mutasi",
+							},
+							Sign:      reconciliation.BankFormatSignNegativeDebit,
+							Delimiter: ";",
+							SkipRows:  1,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ProvideBankParserFactoryMap()
+			got := ProvideBankParserFactoryMap(comp)
 			parserFactory, ok := got[tt.parser]
 
 			if !ok {
 				t.Errorf("ProvideBankParserFactoryMap() %v not found", tt.parser)
 			}
 
-			reconcileBankData, _ := parserFactory(tt.bank, csv.NewReader(nil), true)
+			reconcileBankData, _ := parserFactory(tt.bank, strings.NewReader(tt.content), true, 2)
 
 			if gotParser := reconcileBankData.GetParser(); string(gotParser) != tt.wantParser {
 				t.Errorf("ProvideBankParserFactoryMap() = %v, want %v", gotParser, tt.wantParser)
 			}
+
+			bankTrxData, _ := reconcileBankData.ToBankTrxData(context.Background(), "")
+			if len(bankTrxData) != tt.wantTotal {
+				t.Errorf("ProvideBankParserFactoryMap() total = %v, want %v", len(bankTrxData), tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestProvideGatewayParserFactoryMap(t *testing.T) {
+	tests := []struct {
+		name       string
+		gateway    string
+		parser     string
+		wantParser string
+	}{
+		{
+			name:       "DEFAULT ok",
+			gateway:    "xendit",
+			parser:     string(gateways.DefaultGatewayParser),
+			wantParser: string(gateways.DefaultGatewayParser),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ProvideGatewayParserFactoryMap()
+			parserFactory, ok := got[tt.parser]
+
+			if !ok {
+				t.Errorf("ProvideGatewayParserFactoryMap() %v not found", tt.parser)
+			}
+
+			reconcileGatewayData, _ := parserFactory(tt.gateway, csv.NewReader(nil), true, 2)
+
+			if gotParser := reconcileGatewayData.GetParser(); string(gotParser) != tt.wantParser {
+				t.Errorf("ProvideGatewayParserFactoryMap() = %v, want %v", gotParser, tt.wantParser)
+			}
 		})
 	}
 }
@@ -0,0 +1,619 @@
+package assignment
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+	"sort"
+)
+
+// Cost of an edge, compared by Primary first then Secondary. Neither should be negative
+type Cost struct {
+	Primary   int64
+	Secondary int64
+}
+
+func (c Cost) add(o Cost) Cost {
+	return Cost{Primary: c.Primary + o.Primary, Secondary: c.Secondary + o.Secondary}
+}
+
+func (c Cost) sub(o Cost) Cost {
+	return Cost{Primary: c.Primary - o.Primary, Secondary: c.Secondary - o.Secondary}
+}
+
+func (c Cost) less(o Cost) bool {
+	if c.Primary != o.Primary {
+		return c.Primary < o.Primary
+	}
+
+	return c.Secondary < o.Secondary
+}
+
+// Edge is a candidate pair of the Left and the Right item, LeftTime and RightTime place the items in time and should
+// be the same in every edge of the item
+type Edge struct {
+	Left      string
+	Right     string
+	LeftTime  int64
+	RightTime int64
+	Cost      Cost
+}
+
+const (
+	// maxEdgesPerItem is how many of its cheapest edges every item keeps for min cost flow, an edge stays when one of
+	// its items keeps it
+	maxEdgesPerItem = 8
+	// maxTimeOrderCells bounds the table pairing a group in time order, a larger group takes its cheapest edges first
+	maxTimeOrderCells = 16 << 20
+)
+
+// Solve picks a one to one assignment out of edges: the most pairs first, then the lowest total cost among those.
+// Items only sharing no edge do not affect each other, so every connected group of items is solved on its own. A group
+// of one Primary cost is paired in time order, min cost flow only solves the groups where Primary costs differ.
+// It returns the positions of the picked edges in ascending order, ties go to the items earlier in time then to the
+// edges listed first
+func Solve(edges []Edge) (returnData []int) {
+	items := newItems(edges)
+	all := make([]int, len(edges))
+	for i := range all {
+		all[i] = i
+	}
+
+	for _, group := range items.groupEdges(all) {
+		if isOnePrimary(edges, group) {
+			returnData = append(returnData, items.pairInTimeOrder(edges, group)...)
+			continue
+		}
+
+		returnData = append(returnData, items.solveCheapest(edges, group)...)
+	}
+
+	sort.Ints(returnData)
+	return
+}
+
+// items numbers the left and the right items in order of their first edge, the rights after the lefts. left and
+// right give the numbers of the items of every edge. parent, kept and index are scratch of groupEdges, keepCheapest
+// and pairInTimeOrder
+type items struct {
+	left   []int
+	right  []int
+	time   []int64
+	parent []int
+	kept   [][]int
+	index  []int
+}
+
+func newItems(edges []Edge) *items {
+	returnData := &items{
+		left:  make([]int, len(edges)),
+		right: make([]int, len(edges)),
+	}
+
+	lefts := make(map[string]int)
+	var leftTime []int64
+	for i, edge := range edges {
+		left, ok := lefts[edge.Left]
+		if !ok {
+			left = len(lefts)
+			lefts[edge.Left] = left
+			leftTime = append(leftTime, edge.LeftTime)
+		}
+
+		returnData.left[i] = left
+	}
+
+	returnData.time = leftTime
+	rights := make(map[string]int)
+	for i, edge := range edges {
+		right, ok := rights[edge.Right]
+		if !ok {
+			right = len(lefts) + len(rights)
+			rights[edge.Right] = right
+			returnData.time = append(returnData.time, edge.RightTime)
+		}
+
+		returnData.right[i] = right
+	}
+
+	returnData.parent = make([]int, len(returnData.time))
+	returnData.kept = make([][]int, len(returnData.time))
+	returnData.index = make([]int, len(returnData.time))
+	return returnData
+}
+
+// solveCheapest runs min cost flow on the edges of the group kept by keepCheapest, items left without a pair get
+// another round with the edges among them
+func (it *items) solveCheapest(edges []Edge, group []int) (returnData []int) {
+	open := slices.Clone(group)
+	for len(open) > 0 {
+		isPaired := make(map[int]struct{})
+		for _, kept := range it.groupEdges(it.keepCheapest(edges, open)) {
+			for _, i := range solveGroup(edges, kept) {
+				isPaired[it.left[i]] = struct{}{}
+				isPaired[it.right[i]] = struct{}{}
+				returnData = append(returnData, i)
+			}
+		}
+
+		open = slices.DeleteFunc(open, func(i int) bool {
+			_, isLeft := isPaired[it.left[i]]
+			_, isRight := isPaired[it.right[i]]
+			return isLeft || isRight
+		})
+	}
+
+	return
+}
+
+// keepCheapest returns the positions out of open, in their order, of the edges among the maxEdgesPerItem cheapest of
+// their left or of their right item
+func (it *items) keepCheapest(edges []Edge, open []int) (returnData []int) {
+	cheaper := func(a int, b int) bool {
+		if c := compareCost(edges[a].Cost, edges[b].Cost); c != 0 {
+			return c < 0
+		}
+
+		return a < b
+	}
+
+	// the cheapest edges of every item so far, cheapest first
+	keep := func(item int, i int) {
+		list := it.kept[item]
+		if len(list) == maxEdgesPerItem && !cheaper(i, list[len(list)-1]) {
+			return
+		}
+
+		if len(list) < maxEdgesPerItem {
+			list = append(list, i)
+		}
+
+		n := len(list) - 1
+		for ; n > 0 && cheaper(i, list[n-1]); n-- {
+			list[n] = list[n-1]
+		}
+
+		list[n] = i
+		it.kept[item] = list
+	}
+
+	for _, i := range open {
+		keep(it.left[i], i)
+		keep(it.right[i], i)
+	}
+
+	isKept := make(map[int]struct{})
+	for _, i := range open {
+		for _, item := range []int{it.left[i], it.right[i]} {
+			for _, j := range it.kept[item] {
+				isKept[j] = struct{}{}
+			}
+
+			it.kept[item] = it.kept[item][:0]
+		}
+	}
+
+	for _, i := range open {
+		if _, ok := isKept[i]; ok {
+			returnData = append(returnData, i)
+		}
+	}
+
+	return
+}
+
+// groupEdges returns the positions out of positions per connected group of items, in order of their first edge
+func (it *items) groupEdges(positions []int) (returnData [][]int) {
+	for _, i := range positions {
+		it.parent[it.left[i]] = it.left[i]
+		it.parent[it.right[i]] = it.right[i]
+	}
+
+	var find func(int) int
+	find = func(item int) int {
+		if it.parent[item] != item {
+			it.parent[item] = find(it.parent[item])
+		}
+
+		return it.parent[item]
+	}
+
+	for _, i := range positions {
+		left, right := find(it.left[i]), find(it.right[i])
+		if left != right {
+			it.parent[right] = left
+		}
+	}
+
+	position := make(map[int]int)
+	for _, i := range positions {
+		root := find(it.left[i])
+		p, ok := position[root]
+		if !ok {
+			p = len(returnData)
+			position[root] = p
+			returnData = append(returnData, nil)
+		}
+
+		returnData[p] = append(returnData[p], i)
+	}
+
+	return
+}
+
+// isOnePrimary tells whether every edge of the group has the same Primary cost
+func isOnePrimary(edges []Edge, group []int) bool {
+	for _, i := range group {
+		if edges[i].Cost.Primary != edges[group[0]].Cost.Primary {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pairInTimeOrder pairs a group of one Primary cost without min cost flow. Both sides are sorted by time, ties in order
+// of their first edge, and paired without crossing at the lowest cost, a group over maxTimeOrderCells takes its
+// cheapest edges first instead. Left items still without a pair then take one along alternating paths, so the group
+// ends with the most pairs
+func (it *items) pairInTimeOrder(edges []Edge, group []int) []int {
+	var lefts, rights []int
+	for _, i := range group {
+		it.index[it.left[i]], it.index[it.right[i]] = -1, -1
+	}
+
+	for _, i := range group {
+		if it.index[it.left[i]] < 0 {
+			it.index[it.left[i]] = 0
+			lefts = append(lefts, it.left[i])
+		}
+
+		if it.index[it.right[i]] < 0 {
+			it.index[it.right[i]] = 0
+			rights = append(rights, it.right[i])
+		}
+	}
+
+	for _, list := range [][]int{lefts, rights} {
+		slices.SortStableFunc(list, func(a, b int) int {
+			return cmp.Compare(it.time[a], it.time[b])
+		})
+
+		for n, item := range list {
+			it.index[item] = n
+		}
+	}
+
+	// the cheapest edge of every pair of items, first listed on ties, in time order of the right item
+	leftEdges := make([][]int, len(lefts))
+	for _, i := range group {
+		n := it.index[it.left[i]]
+		leftEdges[n] = append(leftEdges[n], i)
+	}
+
+	for n, list := range leftEdges {
+		slices.SortStableFunc(list, func(a, b int) int {
+			if c := cmp.Compare(it.index[it.right[a]], it.index[it.right[b]]); c != 0 {
+				return c
+			}
+
+			return compareCost(edges[a].Cost, edges[b].Cost)
+		})
+
+		leftEdges[n] = slices.CompactFunc(list, func(a, b int) bool {
+			return it.right[a] == it.right[b]
+		})
+	}
+
+	var picked []int
+	if len(lefts)*len(rights) > maxTimeOrderCells {
+		picked = it.cheapestFirst(edges, group)
+	} else {
+		picked = it.pairWithoutCrossing(edges, leftEdges, len(rights))
+	}
+
+	return it.addPairs(leftEdges, len(rights), picked)
+}
+
+// pairWithoutCrossing finds the most pairs at the lowest cost where every left item pairs with a later right item than
+// the left items before it. leftEdges are the edges of every left item in order of the right item, numbered by index
+func (it *items) pairWithoutCrossing(edges []Edge, leftEdges [][]int, rights int) (returnData []int) {
+	type value struct {
+		pairs int
+		cost  Cost
+	}
+
+	better := func(a value, b value) bool {
+		if a.pairs != b.pairs {
+			return a.pairs > b.pairs
+		}
+
+		return a.cost.less(b.cost)
+	}
+
+	const (
+		skipLeft byte = iota
+		skipRight
+		pair
+	)
+
+	// previous and current are the best of the left items before and up to the current one with the first j right
+	// items, pairs win ties
+	choice := make([]byte, len(leftEdges)*rights)
+	previous, current := make([]value, rights+1), make([]value, rights+1)
+	for n, list := range leftEdges {
+		for j := 1; j <= rights; j++ {
+			best, c := previous[j], skipLeft
+			if better(current[j-1], best) {
+				best, c = current[j-1], skipRight
+			}
+
+			if len(list) > 0 && it.index[it.right[list[0]]] == j-1 {
+				if v := (value{pairs: previous[j-1].pairs + 1, cost: previous[j-1].cost.add(edges[list[0]].Cost)}); !better(best, v) {
+					best, c = v, pair
+				}
+
+				list = list[1:]
+			}
+
+			current[j] = best
+			choice[n*rights+j-1] = c
+		}
+
+		previous, current = current, previous
+	}
+
+	for n, j := len(leftEdges), rights; n > 0 && j > 0; {
+		switch choice[(n-1)*rights+j-1] {
+		case pair:
+			k, _ := slices.BinarySearchFunc(leftEdges[n-1], j-1, func(i int, right int) int {
+				return cmp.Compare(it.index[it.right[i]], right)
+			})
+
+			returnData = append(returnData, leftEdges[n-1][k])
+			n, j = n-1, j-1
+		case skipLeft:
+			n--
+		default:
+			j--
+		}
+	}
+
+	return
+}
+
+// cheapestFirst takes the edges of the group from the cheapest one while both of its items are without a pair
+func (it *items) cheapestFirst(edges []Edge, group []int) (returnData []int) {
+	sorted := slices.Clone(group)
+	slices.SortStableFunc(sorted, func(a, b int) int {
+		return compareCost(edges[a].Cost, edges[b].Cost)
+	})
+
+	isPaired := make(map[int]struct{})
+	for _, i := range sorted {
+		_, isLeft := isPaired[it.left[i]]
+		_, isRight := isPaired[it.right[i]]
+		if isLeft || isRight {
+			continue
+		}
+
+		isPaired[it.left[i]] = struct{}{}
+		isPaired[it.right[i]] = struct{}{}
+		returnData = append(returnData, i)
+	}
+
+	return
+}
+
+// addPairs gives the left items without a pair, in time order, one along an alternating path when there is one. Right
+// items seen on a failed path stay visited until a path is found, they can not lead to a right item without a pair
+// meanwhile. leftEdges are the edges of every left item, numbered by index
+func (it *items) addPairs(leftEdges [][]int, rights int, picked []int) (returnData []int) {
+	leftPair := make([]int, len(leftEdges))
+	rightPair := make([]int, rights)
+	for _, list := range [][]int{leftPair, rightPair} {
+		for n := range list {
+			list[n] = -1
+		}
+	}
+
+	for _, i := range picked {
+		leftPair[it.index[it.left[i]]] = i
+		rightPair[it.index[it.right[i]]] = i
+	}
+
+	visited := make([]bool, rights)
+	var find func(int) bool
+	find = func(left int) bool {
+		for _, i := range leftEdges[left] {
+			right := it.index[it.right[i]]
+			if visited[right] {
+				continue
+			}
+
+			visited[right] = true
+			if rightPair[right] < 0 || find(it.index[it.left[rightPair[right]]]) {
+				leftPair[left] = i
+				rightPair[right] = i
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for left := range leftEdges {
+		if leftPair[left] < 0 && find(left) {
+			clear(visited)
+		}
+	}
+
+	for _, i := range leftPair {
+		if i >= 0 {
+			returnData = append(returnData, i)
+		}
+	}
+
+	return
+}
+
+// compareCost orders costs the way less does
+func compareCost(a Cost, b Cost) int {
+	switch {
+	case a.less(b):
+		return -1
+	case b.less(a):
+		return 1
+	default:
+		return 0
+	}
+}
+
+type arc struct {
+	to   int
+	rev  int
+	edge int
+	cap  int
+	cost Cost
+}
+
+// solveGroup runs successive shortest paths from a source linked to every left item to a sink linked to every
+// right item, each augmenting path adds one pair at the lowest extra cost
+func solveGroup(edges []Edge, group []int) (returnData []int) {
+	nodes := map[string]int{}
+	node := func(key string) int {
+		if n, ok := nodes[key]; ok {
+			return n
+		}
+
+		nodes[key] = len(nodes) + 2
+		return nodes[key]
+	}
+
+	const source, sink = 0, 1
+	var graph [][]arc
+	addArc := func(from int, to int, edge int, cost Cost) {
+		for len(graph) <= max(from, to) {
+			graph = append(graph, nil)
+		}
+
+		graph[from] = append(graph[from], arc{to: to, rev: len(graph[to]), edge: edge, cap: 1, cost: cost})
+		graph[to] = append(graph[to], arc{to: from, rev: len(graph[from]) - 1, edge: -1, cap: 0, cost: Cost{}.sub(cost)})
+	}
+
+	linked := map[int]bool{}
+	for _, i := range group {
+		left, right := node("L"+edges[i].Left), node("R"+edges[i].Right)
+		if !linked[left] {
+			linked[left] = true
+			addArc(source, left, -1, Cost{})
+		}
+
+		if !linked[right] {
+			linked[right] = true
+			addArc(right, sink, -1, Cost{})
+		}
+
+		addArc(left, right, i, edges[i].Cost)
+	}
+
+	dual := make([]Cost, len(graph))
+	for augment(graph, dual, source, sink) {
+	}
+
+	for _, arcs := range graph {
+		for _, a := range arcs {
+			if a.edge >= 0 && a.cap == 0 {
+				returnData = append(returnData, a.edge)
+			}
+		}
+	}
+
+	return
+}
+
+// augment finds the cheapest path from source to sink with Dijkstra on costs reduced by dual, moves one unit along
+// it and updates dual so reduced costs stay non negative. It returns false when the sink is not reachable anymore
+func augment(graph [][]arc, dual []Cost, source int, sink int) bool {
+	dist := make([]Cost, len(graph))
+	reached := make([]bool, len(graph))
+	visited := make([]bool, len(graph))
+	prevNode := make([]int, len(graph))
+	prevArc := make([]int, len(graph))
+
+	reached[source] = true
+	queue := &priorityQueue{{node: source}}
+	for queue.Len() > 0 {
+		v := heap.Pop(queue).(item).node
+		if visited[v] {
+			continue
+		}
+
+		visited[v] = true
+		if v == sink {
+			break
+		}
+
+		for i, a := range graph[v] {
+			if a.cap == 0 {
+				continue
+			}
+
+			d := dist[v].add(a.cost.sub(dual[a.to]).add(dual[v]))
+			if !reached[a.to] || d.less(dist[a.to]) {
+				reached[a.to] = true
+				dist[a.to] = d
+				prevNode[a.to] = v
+				prevArc[a.to] = i
+				heap.Push(queue, item{node: a.to, dist: d})
+			}
+		}
+	}
+
+	if !visited[sink] {
+		return false
+	}
+
+	for v := range graph {
+		if visited[v] {
+			dual[v] = dual[v].sub(dist[sink].sub(dist[v]))
+		}
+	}
+
+	for v := sink; v != source; v = prevNode[v] {
+		a := &graph[prevNode[v]][prevArc[v]]
+		a.cap--
+		graph[v][a.rev].cap++
+	}
+
+	return true
+}
+
+type item struct {
+	node int
+	dist Cost
+}
+
+type priorityQueue []item
+
+func (q priorityQueue) Len() int { return len(q) }
+
+func (q priorityQueue) Less(i, j int) bool {
+	if q[i].dist != q[j].dist {
+		return q[i].dist.less(q[j].dist)
+	}
+
+	return q[i].node < q[j].node
+}
+
+func (q priorityQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *priorityQueue) Push(x any) { *q = append(*q, x.(item)) }
+
+func (q *priorityQueue) Pop() any {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[:n-1]
+	return x
+}
@@ -0,0 +1,140 @@
+package assignment
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name  string
+		edges []Edge
+		want  []int
+	}{
+		{
+			name:  "Ok - no edge",
+			edges: nil,
+			want:  nil,
+		},
+		{
+			name: "Ok - most pairs over cheapest edge",
+			edges: []Edge{
+				{Left: "s1", Right: "b1", Cost: Cost{}},
+				{Left: "s1", Right: "b2", Cost: Cost{Secondary: 1}},
+				{Left: "s2", Right: "b1", Cost: Cost{Secondary: 5}},
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "Ok - closest times",
+			edges: []Edge{
+				{Left: "s1", Right: "b1", LeftTime: 0, RightTime: 110, Cost: Cost{Secondary: 110}},
+				{Left: "s1", Right: "b2", LeftTime: 0, RightTime: 10, Cost: Cost{Secondary: 10}},
+				{Left: "s2", Right: "b1", LeftTime: 100, RightTime: 110, Cost: Cost{Secondary: 10}},
+				{Left: "s2", Right: "b2", LeftTime: 100, RightTime: 10, Cost: Cost{Secondary: 90}},
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "Ok - time order, more bank than system",
+			edges: []Edge{
+				{Left: "s1", Right: "b1", LeftTime: 0, RightTime: 90, Cost: Cost{Secondary: 90}},
+				{Left: "s1", Right: "b2", LeftTime: 0, RightTime: 5, Cost: Cost{Secondary: 5}},
+				{Left: "s1", Right: "b3", LeftTime: 0, RightTime: 200, Cost: Cost{Secondary: 200}},
+				{Left: "s2", Right: "b1", LeftTime: 100, RightTime: 90, Cost: Cost{Secondary: 10}},
+				{Left: "s2", Right: "b2", LeftTime: 100, RightTime: 5, Cost: Cost{Secondary: 95}},
+				{Left: "s2", Right: "b3", LeftTime: 100, RightTime: 200, Cost: Cost{Secondary: 100}},
+			},
+			want: []int{1, 3},
+		},
+		{
+			name: "Ok - primary first",
+			edges: []Edge{
+				{Left: "s1", Right: "b1", Cost: Cost{Secondary: 1000}},
+				{Left: "s1", Right: "b2", Cost: Cost{Primary: 1}},
+			},
+			want: []int{0},
+		},
+		{
+			name: "Ok - ties go to the first edge",
+			edges: []Edge{
+				{Left: "s1", Right: "b1"},
+				{Left: "s1", Right: "b2"},
+				{Left: "s2", Right: "b1"},
+				{Left: "s2", Right: "b2"},
+			},
+			want: []int{0, 3},
+		},
+		{
+			name: "Ok - separate groups, more bank than system",
+			edges: []Edge{
+				{Left: "s1", Right: "b1", Cost: Cost{Secondary: 3}},
+				{Left: "s2", Right: "b3", Cost: Cost{Secondary: 1}},
+				{Left: "s1", Right: "b2", Cost: Cost{Secondary: 2}},
+				{Left: "s3", Right: "b3", Cost: Cost{Secondary: 2}},
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "Ok - edge dropped for cheaper ones gets another round",
+			edges: func() (returnData []Edge) {
+				for i := 1; i <= 8; i++ {
+					returnData = append(returnData, Edge{Left: "s0", Right: fmt.Sprintf("b%d", i), Cost: Cost{Primary: int64(i)}})
+				}
+
+				returnData = append(returnData, Edge{Left: "s0", Right: "b9", Cost: Cost{Primary: 9}})
+				for i := 1; i <= 8; i++ {
+					returnData = append(returnData, Edge{Left: fmt.Sprintf("t%d", i), Right: fmt.Sprintf("b%d", i)})
+				}
+
+				for i := 1; i <= 8; i++ {
+					returnData = append(returnData, Edge{Left: fmt.Sprintf("u%d", i), Right: "b9", Cost: Cost{Primary: 1}})
+				}
+
+				for i := 1; i <= 8; i++ {
+					returnData = append(returnData, Edge{Left: fmt.Sprintf("u%d", i), Right: fmt.Sprintf("c%d", i)})
+				}
+
+				return
+			}(),
+			want: []int{8, 9, 10, 11, 12, 13, 14, 15, 16, 25, 26, 27, 28, 29, 30, 31, 32},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Solve(tt.edges); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Solve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkSolveEqualAmountBucket(b *testing.B) {
+	// one amount on many system and bank trx spread over three days, every pair within a day is a candidate
+	const items = 1000
+	const step = 3 * 86400 / items
+	var edges []Edge
+	for i := range items {
+		for j := range items {
+			leftTime, rightTime := int64(i*step), int64(j*step+step/2)
+			if distance := max(leftTime-rightTime, rightTime-leftTime); distance <= 86400 {
+				edges = append(edges, Edge{
+					Left:      fmt.Sprintf("s%d", i),
+					Right:     fmt.Sprintf("b%d", j),
+					LeftTime:  leftTime,
+					RightTime: rightTime,
+					Cost:      Cost{Secondary: distance},
+				})
+			}
+		}
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		if got := Solve(edges); len(got) != items {
+			b.Fatalf("Solve() = %d pairs, want %d", len(got), items)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package balance
+
+import "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+
+// Status of a statement
+const (
+	// StatusBalanced the opening balance plus the movements is the closing balance and every stated running balance
+	// follows the line before
+	StatusBalanced = "BALANCED"
+	// StatusNotBalanced lines are missing, repeated or altered somewhere in the statement
+	StatusNotBalanced = "NOT_BALANCED"
+	// StatusNoBalance the statement states no balance at all, it can not be checked
+	StatusNoBalance = "NO_BALANCE"
+)
+
+// Line is what the check sees of a statement line, Movement is signed (a debit is negative) and Balance is the running
+// balance of the account after the line when IsHaveBalance
+type Line struct {
+	ID            string
+	Movement      money.Amount
+	Balance       money.Amount
+	IsHaveBalance bool
+}
+
+// Statement is the lines of one account in one statement file in their order. Formats stating the opening or the
+// closing balance of the statement set them, otherwise they are taken from the running balance of the lines
+type Statement struct {
+	Bank                 string
+	Account              string
+	FilePath             string
+	Lines                []Line
+	OpeningBalance       money.Amount
+	ClosingBalance       money.Amount
+	IsHaveOpeningBalance bool
+	IsHaveClosingBalance bool
+}
+
+// Result is the check of a statement. Difference is ClosingBalance less OpeningBalance and Movement, TotalBreak counts
+// the lines whose running balance does not follow the line before, FirstBreakID is the first of them
+type Result struct {
+	Bank           string
+	Account        string
+	FilePath       string
+	Status         string
+	FirstBreakID   string
+	OpeningBalance money.Amount
+	Movement       money.Amount
+	ClosingBalance money.Amount
+	Difference     money.Amount
+	TotalLine      int
+	TotalBreak     int
+}
+
+// Check walks the lines from the opening balance, a running balance that does not follow is a break and the walk goes
+// on from the stated balance. Without stated opening balance it is the running balance of the first line stating one
+// less the movements up to it, without stated closing balance it is where the walk ends
+func Check(statement Statement) (returnData Result) {
+	returnData = Result{
+		Bank:           statement.Bank,
+		Account:        statement.Account,
+		FilePath:       statement.FilePath,
+		OpeningBalance: statement.OpeningBalance,
+		TotalLine:      len(statement.Lines),
+	}
+
+	isHaveOpeningBalance := statement.IsHaveOpeningBalance
+	if !isHaveOpeningBalance {
+		var movement money.Amount
+		for _, line := range statement.Lines {
+			movement += line.Movement
+			if line.IsHaveBalance {
+				returnData.OpeningBalance = line.Balance - movement
+				isHaveOpeningBalance = true
+				break
+			}
+		}
+	}
+
+	for _, line := range statement.Lines {
+		returnData.Movement += line.Movement
+	}
+
+	if !isHaveOpeningBalance {
+		returnData.Status = StatusNoBalance
+		return
+	}
+
+	running := returnData.OpeningBalance
+	for _, line := range statement.Lines {
+		running += line.Movement
+		if !line.IsHaveBalance || line.Balance == running {
+			continue
+		}
+
+		if returnData.TotalBreak == 0 {
+			returnData.FirstBreakID = line.ID
+		}
+
+		returnData.TotalBreak++
+		running = line.Balance
+	}
+
+	returnData.ClosingBalance = running
+	if statement.IsHaveClosingBalance {
+		returnData.ClosingBalance = statement.ClosingBalance
+	}
+
+	returnData.Difference = returnData.ClosingBalance - returnData.OpeningBalance - returnData.Movement
+	returnData.Status = StatusBalanced
+	if returnData.TotalBreak > 0 || returnData.Difference != 0 {
+		returnData.Status = StatusNotBalanced
+	}
+
+	return
+}
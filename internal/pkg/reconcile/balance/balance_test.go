@@ -0,0 +1,138 @@
+package balance
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement Statement
+		want      Result
+	}{
+		{
+			name: "Ok - running balance",
+			statement: Statement{
+				Bank:     "bca",
+				Account:  "111",
+				FilePath: "/bank/bca/111/a.csv",
+				Lines: []Line{
+					{ID: "a", Movement: 100, Balance: 1100, IsHaveBalance: true},
+					{ID: "b", Movement: -50, Balance: 1050, IsHaveBalance: true},
+					{ID: "c", Movement: 20, Balance: 1070, IsHaveBalance: true},
+				},
+			},
+			want: Result{
+				Bank:           "bca",
+				Account:        "111",
+				FilePath:       "/bank/bca/111/a.csv",
+				Status:         StatusBalanced,
+				OpeningBalance: 1000,
+				Movement:       70,
+				ClosingBalance: 1070,
+				TotalLine:      3,
+			},
+		},
+		{
+			name: "Ok - lines without balance in between",
+			statement: Statement{
+				Lines: []Line{
+					{ID: "a", Movement: 100},
+					{ID: "b", Movement: -50, Balance: 1050, IsHaveBalance: true},
+					{ID: "c", Movement: 20},
+					{ID: "d", Movement: 30, Balance: 1100, IsHaveBalance: true},
+				},
+			},
+			want: Result{
+				Status:         StatusBalanced,
+				OpeningBalance: 1000,
+				Movement:       100,
+				ClosingBalance: 1100,
+				TotalLine:      4,
+			},
+		},
+		{
+			name: "Ok - missing line",
+			statement: Statement{
+				Lines: []Line{
+					{ID: "a", Movement: 100, Balance: 1100, IsHaveBalance: true},
+					{ID: "c", Movement: 20, Balance: 1070, IsHaveBalance: true},
+					{ID: "d", Movement: 30, Balance: 1100, IsHaveBalance: true},
+				},
+			},
+			want: Result{
+				Status:         StatusNotBalanced,
+				FirstBreakID:   "c",
+				OpeningBalance: 1000,
+				Movement:       150,
+				ClosingBalance: 1100,
+				Difference:     -50,
+				TotalLine:      3,
+				TotalBreak:     1,
+			},
+		},
+		{
+			name: "Ok - stated balances",
+			statement: Statement{
+				Lines: []Line{
+					{ID: "a", Movement: 100},
+					{ID: "b", Movement: -50},
+				},
+				OpeningBalance:       1000,
+				ClosingBalance:       1050,
+				IsHaveOpeningBalance: true,
+				IsHaveClosingBalance: true,
+			},
+			want: Result{
+				Status:         StatusBalanced,
+				OpeningBalance: 1000,
+				Movement:       50,
+				ClosingBalance: 1050,
+				TotalLine:      2,
+			},
+		},
+		{
+			name: "Ok - truncated against stated closing balance",
+			statement: Statement{
+				Lines: []Line{
+					{ID: "a", Movement: 100, Balance: 1100, IsHaveBalance: true},
+				},
+				ClosingBalance:       1050,
+				IsHaveClosingBalance: true,
+			},
+			want: Result{
+				Status:         StatusNotBalanced,
+				OpeningBalance: 1000,
+				Movement:       100,
+				ClosingBalance: 1050,
+				Difference:     -50,
+				TotalLine:      1,
+			},
+		},
+		{
+			name: "Ok - no balance",
+			statement: Statement{
+				Lines: []Line{
+					{ID: "a", Movement: 100},
+					{ID: "b", Movement: -50},
+				},
+				ClosingBalance:       1050,
+				IsHaveClosingBalance: true,
+			},
+			want: Result{
+				Status:    StatusNoBalance,
+				Movement:  50,
+				TotalLine: 2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Check(tt.statement); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package duplicate
+
+import "strconv"
+
+// Kind of duplicate
+const (
+	// KindExactKey the trx repeats the TrxID or UniqueIdentifier of an earlier trx
+	KindExactKey = "EXACT_KEY"
+	// KindContent the trx repeats the date, type, currency and amount of an earlier trx under another ID
+	KindContent = "CONTENT"
+)
+
+// Policy of a kind of duplicate, the same values as the reconciliation duplicate config
+const (
+	PolicyKeepFirst = "keep_first"
+	PolicyRejectAll = "reject_all"
+	PolicyKeepAll   = "keep_all"
+)
+
+// Action taken on a trx of a duplicate group
+const (
+	ActionKept     = "KEPT"
+	ActionRejected = "REJECTED"
+)
+
+// Record is what the check sees of a trx, Key is its ID and Content what makes two trx of other IDs the same
+type Record struct {
+	Key     string
+	Content string
+}
+
+// Entry is a trx of a duplicate group, the first trx of the group included. Index and FirstIndex are the positions
+// of the trx and of the first trx of its group in the checked records
+type Entry struct {
+	Kind       string
+	Action     string
+	Index      int
+	FirstIndex int
+}
+
+// Result Keep lists the position of the records to load in their order, Keys the ID to load every record with:
+// exact key duplicates kept by keep_all get the suffix "#<n>" so every ID stays unique
+type Result struct {
+	Keep    []int
+	Keys    []string
+	Entries []Entry
+}
+
+// Resolve checks the records in order, the first trx of a group is the one keep_first keeps. Exact key duplicates
+// are resolved first, content is only compared among the first trx of every key
+func Resolve(records []Record, keyPolicy string, contentPolicy string) (returnData Result) {
+	returnData.Keys = make([]string, len(records))
+	usedKeys := make(map[string]struct{}, len(records))
+	all := make([]int, len(records))
+	for i, record := range records {
+		returnData.Keys[i] = record.Key
+		usedKeys[record.Key] = struct{}{}
+		all[i] = i
+	}
+
+	rejected := make(map[int]struct{})
+	apply := func(kind string, policy string, groups [][]int) {
+		for _, group := range groups {
+			for n, index := range group {
+				action := ActionKept
+				switch {
+				case policy == PolicyRejectAll, policy == PolicyKeepFirst && n > 0:
+					action = ActionRejected
+					rejected[index] = struct{}{}
+				case kind == KindExactKey && n > 0:
+					returnData.Keys[index] = uniqueKey(records[index].Key, n+1, usedKeys)
+				}
+
+				returnData.Entries = append(returnData.Entries, Entry{
+					Kind:       kind,
+					Action:     action,
+					Index:      index,
+					FirstIndex: group[0],
+				})
+			}
+		}
+	}
+
+	keyGroups := groupBy(all, func(i int) string {
+		return records[i].Key
+	})
+
+	apply(KindExactKey, keyPolicy, keyGroups)
+
+	// later copies of a key are already reported, content is compared among the other trx left
+	repeated := make(map[int]struct{})
+	for _, group := range keyGroups {
+		for _, index := range group[1:] {
+			repeated[index] = struct{}{}
+		}
+	}
+
+	var left []int
+	for _, i := range all {
+		_, isRejected := rejected[i]
+		_, isRepeated := repeated[i]
+		if !isRejected && !isRepeated {
+			left = append(left, i)
+		}
+	}
+
+	apply(KindContent, contentPolicy, groupBy(left, func(i int) string {
+		return records[i].Content
+	}))
+
+	for _, i := range all {
+		if _, ok := rejected[i]; !ok {
+			returnData.Keep = append(returnData.Keep, i)
+		}
+	}
+
+	return
+}
+
+// groupBy returns the indexes sharing the same value of by, in order of their first index, values of one index only
+// are left out
+func groupBy(indexes []int, by func(int) string) (returnData [][]int) {
+	position := make(map[string]int)
+	var groups [][]int
+	for _, i := range indexes {
+		value := by(i)
+		if p, ok := position[value]; ok {
+			groups[p] = append(groups[p], i)
+			continue
+		}
+
+		position[value] = len(groups)
+		groups = append(groups, []int{i})
+	}
+
+	for _, group := range groups {
+		if len(group) > 1 {
+			returnData = append(returnData, group)
+		}
+	}
+
+	return
+}
+
+// uniqueKey returns key#n, counting n up while the key is taken
+func uniqueKey(key string, n int, usedKeys map[string]struct{}) string {
+	for {
+		candidate := key + "#" + strconv.Itoa(n)
+		if _, ok := usedKeys[candidate]; !ok {
+			usedKeys[candidate] = struct{}{}
+			return candidate
+		}
+
+		n++
+	}
+}
@@ -0,0 +1,104 @@
+package duplicate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	records := []Record{
+		{Key: "a", Content: "2025-03-01|CREDIT|IDR|100"},
+		{Key: "b", Content: "2025-03-01|CREDIT|IDR|200"},
+		{Key: "a", Content: "2025-03-01|CREDIT|IDR|100"},
+		{Key: "c", Content: "2025-03-01|CREDIT|IDR|200"},
+		{Key: "a#2", Content: "2025-03-02|DEBIT|IDR|300"},
+	}
+
+	type args struct {
+		keyPolicy     string
+		contentPolicy string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want Result
+	}{
+		{
+			name: "Ok - keep first",
+			args: args{
+				keyPolicy:     PolicyKeepFirst,
+				contentPolicy: PolicyKeepFirst,
+			},
+			want: Result{
+				Keep: []int{0, 1, 4},
+				Keys: []string{"a", "b", "a", "c", "a#2"},
+				Entries: []Entry{
+					{Kind: KindExactKey, Action: ActionKept, Index: 0, FirstIndex: 0},
+					{Kind: KindExactKey, Action: ActionRejected, Index: 2, FirstIndex: 0},
+					{Kind: KindContent, Action: ActionKept, Index: 1, FirstIndex: 1},
+					{Kind: KindContent, Action: ActionRejected, Index: 3, FirstIndex: 1},
+				},
+			},
+		},
+		{
+			name: "Ok - reject all",
+			args: args{
+				keyPolicy:     PolicyRejectAll,
+				contentPolicy: PolicyRejectAll,
+			},
+			want: Result{
+				Keep: []int{4},
+				Keys: []string{"a", "b", "a", "c", "a#2"},
+				Entries: []Entry{
+					{Kind: KindExactKey, Action: ActionRejected, Index: 0, FirstIndex: 0},
+					{Kind: KindExactKey, Action: ActionRejected, Index: 2, FirstIndex: 0},
+					{Kind: KindContent, Action: ActionRejected, Index: 1, FirstIndex: 1},
+					{Kind: KindContent, Action: ActionRejected, Index: 3, FirstIndex: 1},
+				},
+			},
+		},
+		{
+			name: "Ok - keep all renames exact key duplicates",
+			args: args{
+				keyPolicy:     PolicyKeepAll,
+				contentPolicy: PolicyKeepAll,
+			},
+			want: Result{
+				Keep: []int{0, 1, 2, 3, 4},
+				Keys: []string{"a", "b", "a#3", "c", "a#2"},
+				Entries: []Entry{
+					{Kind: KindExactKey, Action: ActionKept, Index: 0, FirstIndex: 0},
+					{Kind: KindExactKey, Action: ActionKept, Index: 2, FirstIndex: 0},
+					{Kind: KindContent, Action: ActionKept, Index: 1, FirstIndex: 1},
+					{Kind: KindContent, Action: ActionKept, Index: 3, FirstIndex: 1},
+				},
+			},
+		},
+		{
+			name: "Ok - keep first key, flag content",
+			args: args{
+				keyPolicy:     PolicyKeepFirst,
+				contentPolicy: PolicyKeepAll,
+			},
+			want: Result{
+				Keep: []int{0, 1, 3, 4},
+				Keys: []string{"a", "b", "a", "c", "a#2"},
+				Entries: []Entry{
+					{Kind: KindExactKey, Action: ActionKept, Index: 0, FirstIndex: 0},
+					{Kind: KindExactKey, Action: ActionRejected, Index: 2, FirstIndex: 0},
+					{Kind: KindContent, Action: ActionKept, Index: 1, FirstIndex: 1},
+					{Kind: KindContent, Action: ActionKept, Index: 3, FirstIndex: 1},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Resolve(records, tt.args.keyPolicy, tt.args.contentPolicy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
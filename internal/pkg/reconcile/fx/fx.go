@@ -0,0 +1,137 @@
+package fx
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jszwec/csvutil"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+)
+
+const dateFormat = "2006-01-02"
+
+// Rate is one line of the rate file, 1 FromCurrency is worth Rate ToCurrency from Date on
+type Rate struct {
+	Date         string  `csv:"Date"`
+	FromCurrency string  `csv:"FromCurrency"`
+	ToCurrency   string  `csv:"ToCurrency"`
+	Rate         float64 `csv:"Rate"`
+}
+
+type datedRate struct {
+	date string
+	rate float64
+}
+
+// RateTable looks up the rate of a currency pair on a date, the latest rate dated on or before it is used so
+// days without a quote (weekends, holidays) take the last known one
+type RateTable struct {
+	rates map[string][]datedRate
+}
+
+func pairKey(from string, to string) string {
+	return strings.ToUpper(from) + "/" + strings.ToUpper(to)
+}
+
+// NewRateTable builds the table, rates should be positive with a valid date
+func NewRateTable(rates []Rate) (*RateTable, error) {
+	t := &RateTable{
+		rates: make(map[string][]datedRate),
+	}
+
+	for _, r := range rates {
+		if _, err := time.Parse(dateFormat, r.Date); err != nil {
+			return nil, fmt.Errorf("fx rate %s/%s: date %q is not %s", r.FromCurrency, r.ToCurrency, r.Date, dateFormat)
+		}
+
+		if r.Rate <= 0 || math.IsInf(r.Rate, 0) || math.IsNaN(r.Rate) {
+			return nil, fmt.Errorf("fx rate %s/%s on %s: rate %v should be positive", r.FromCurrency, r.ToCurrency, r.Date, r.Rate)
+		}
+
+		key := pairKey(r.FromCurrency, r.ToCurrency)
+		t.rates[key] = append(t.rates[key], datedRate{date: r.Date, rate: r.Rate})
+	}
+
+	for key := range t.rates {
+		sort.SliceStable(t.rates[key], func(i, j int) bool {
+			return t.rates[key][i].date < t.rates[key][j].date
+		})
+	}
+
+	return t, nil
+}
+
+// ReadRateTable reads a csv rate file with header Date,FromCurrency,ToCurrency,Rate
+func ReadRateTable(reader io.Reader) (*RateTable, error) {
+	dec, err := csvutil.NewDecoder(csv.NewReader(reader))
+	if errors.Is(err, io.EOF) {
+		return NewRateTable(nil)
+	} else if err != nil {
+		return nil, err
+	}
+
+	var rates []Rate
+	for {
+		r := Rate{}
+		if err = dec.Decode(&r); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, err
+		}
+
+		rates = append(rates, r)
+	}
+
+	return NewRateTable(rates)
+}
+
+func (t *RateTable) lookup(key string, date string) (float64, bool) {
+	rates := t.rates[key]
+	i := sort.Search(len(rates), func(i int) bool {
+		return rates[i].date > date
+	})
+
+	if i == 0 {
+		return 0, false
+	}
+
+	return rates[i-1].rate, true
+}
+
+// Get returns how much 1 from is worth in to on date, a missing pair is looked up the other way round
+func (t *RateTable) Get(date time.Time, from string, to string) (float64, error) {
+	if strings.EqualFold(from, to) {
+		return 1, nil
+	}
+
+	d := date.Format(dateFormat)
+	if t != nil {
+		if rate, ok := t.lookup(pairKey(from, to), d); ok {
+			return rate, nil
+		}
+
+		if rate, ok := t.lookup(pairKey(to, from), d); ok {
+			return 1 / rate, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no fx rate %s to %s on or before %s", strings.ToUpper(from), strings.ToUpper(to), d)
+}
+
+// Convert converts amount of from into to with the rate of date, rounding half away from zero. Both amounts are in
+// minor units of the same number of decimal places
+func (t *RateTable) Convert(amount money.Amount, date time.Time, from string, to string) (returnData money.Amount, rate float64, err error) {
+	if rate, err = t.Get(date, from, to); err != nil {
+		return 0, 0, err
+	}
+
+	return money.Amount(math.Round(float64(amount) * rate)), rate, nil
+}
@@ -0,0 +1,166 @@
+package fx
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+)
+
+const rateFile = `Date,FromCurrency,ToCurrency,Rate
+2025-03-03,USD,IDR,16400
+2025-03-01,usd,idr,16300
+2025-03-01,IDR,SGD,0.00008
+`
+
+func TestNewRateTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		rates   []Rate
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			rates: []Rate{
+				{Date: "2025-03-01", FromCurrency: "USD", ToCurrency: "IDR", Rate: 16300},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Ok - empty",
+			rates:   nil,
+			wantErr: false,
+		},
+		{
+			name: "Error - date",
+			rates: []Rate{
+				{Date: "01/03/2025", FromCurrency: "USD", ToCurrency: "IDR", Rate: 16300},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - rate",
+			rates: []Rate{
+				{Date: "2025-03-01", FromCurrency: "USD", ToCurrency: "IDR", Rate: 0},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRateTable(tt.rates); (err != nil) != tt.wantErr {
+				t.Errorf("NewRateTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadRateTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name:    "Ok",
+			data:    rateFile,
+			wantErr: false,
+		},
+		{
+			name:    "Ok - empty file",
+			data:    "",
+			wantErr: false,
+		},
+		{
+			name:    "Error - rate is not a number",
+			data:    "Date,FromCurrency,ToCurrency,Rate\n2025-03-01,USD,IDR,foo\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadRateTable(strings.NewReader(tt.data)); (err != nil) != tt.wantErr {
+				t.Errorf("ReadRateTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRateTableConvert(t *testing.T) {
+	table, _ := ReadRateTable(strings.NewReader(rateFile))
+
+	type args struct {
+		date   string
+		from   string
+		to     string
+		amount money.Amount
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		want     money.Amount
+		wantRate float64
+		wantErr  bool
+	}{
+		{
+			name:     "Ok - same currency",
+			args:     args{date: "2025-02-01", from: "IDR", to: "idr", amount: 1050},
+			want:     1050,
+			wantRate: 1,
+		},
+		{
+			name:     "Ok - rate of the date",
+			args:     args{date: "2025-03-01", from: "USD", to: "IDR", amount: 1050},
+			want:     17115000,
+			wantRate: 16300,
+		},
+		{
+			name:     "Ok - last known rate",
+			args:     args{date: "2025-03-02", from: "USD", to: "IDR", amount: 1050},
+			want:     17115000,
+			wantRate: 16300,
+		},
+		{
+			name:     "Ok - later rate",
+			args:     args{date: "2025-03-09", from: "USD", to: "IDR", amount: 1050},
+			want:     17220000,
+			wantRate: 16400,
+		},
+		{
+			name:     "Ok - reverse pair",
+			args:     args{date: "2025-03-01", from: "SGD", to: "IDR", amount: 100},
+			want:     1250000,
+			wantRate: 12500,
+		},
+		{
+			name:    "Error - before first rate",
+			args:    args{date: "2025-02-28", from: "USD", to: "IDR", amount: 1050},
+			wantErr: true,
+		},
+		{
+			name:    "Error - unknown pair",
+			args:    args{date: "2025-03-01", from: "EUR", to: "IDR", amount: 1050},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, _ := time.Parse(dateFormat, tt.args.date)
+			got, gotRate, err := table.Convert(tt.args.amount, date, tt.args.from, tt.args.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Convert() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want || math.Abs(gotRate-tt.wantRate) > 1e-9*tt.wantRate {
+				t.Errorf("Convert() = %v, %v, want %v, %v", got, gotRate, tt.want, tt.wantRate)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package locale
+
+import (
+	"encoding/csv"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jszwec/csvutil"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+)
+
+// Month names the dates are written with
+const (
+	MonthNamesEnglish    = "en"
+	MonthNamesIndonesian = "id"
+)
+
+// DefaultDateLayouts are tried when a locale has no date layouts, a day optionally followed by a time
+var DefaultDateLayouts = []string{time.DateTime, time.DateOnly}
+
+// indonesianMonths are the Indonesian month names, full and short, that differ from the English ones
+var indonesianMonths = map[string]string{
+	"januari":  "January",
+	"februari": "February",
+	"pebruari": "February",
+	"maret":    "March",
+	"mei":      "May",
+	"juni":     "June",
+	"juli":     "July",
+	"agustus":  "August",
+	"oktober":  "October",
+	"nopember": "November",
+	"desember": "December",
+	"peb":      "Feb",
+	"agu":      "Aug",
+	"agt":      "Aug",
+	"okt":      "Oct",
+	"nop":      "Nov",
+	"des":      "Dec",
+}
+
+var regexWord = regexp.MustCompile(`\pL+`)
+
+// Locale is how amounts and dates are written in a csv file. An empty DecimalSeparator is a dot, an empty
+// ThousandsSeparator is no grouping, empty DateLayouts are DefaultDateLayouts and a zero Delimiter keeps the reader
+// delimiter. DateLayouts are Go time layouts tried in order, MonthNames tells the language of the month names in them
+type Locale struct {
+	DecimalSeparator   string
+	ThousandsSeparator string
+	MonthNames         string
+	DateLayouts        []string
+	Delimiter          rune
+}
+
+// ParseAmount reads an amount like "-1.234,50" into minor units
+func (l Locale) ParseAmount(text string, decimalPlaces int) (money.Amount, error) {
+	return money.ParseGrouped(text, decimalPlaces, l.ThousandsSeparator, l.DecimalSeparator)
+}
+
+// CSVUnmarshalers lets csvutil decode Amount fields written in the locale
+func (l Locale) CSVUnmarshalers(decimalPlaces int) *csvutil.Unmarshalers {
+	return csvutil.UnmarshalFunc(func(data []byte, a *money.Amount) (err error) {
+		*a, err = l.ParseAmount(string(data), decimalPlaces)
+		return err
+	})
+}
+
+// ParseDate parses a date with the first layout it matches, isHaveTime tells whether that layout states the hour.
+// The error is the one of the last layout
+func (l Locale) ParseDate(value string) (returnData time.Time, isHaveTime bool, err error) {
+	if l.MonthNames == MonthNamesIndonesian {
+		value = regexWord.ReplaceAllStringFunc(value, func(word string) string {
+			if month, ok := indonesianMonths[strings.ToLower(word)]; ok {
+				return month
+			}
+
+			return word
+		})
+	}
+
+	layouts := l.DateLayouts
+	if len(layouts) == 0 {
+		layouts = DefaultDateLayouts
+	}
+
+	for _, layout := range layouts {
+		if returnData, err = time.Parse(layout, value); err == nil {
+			return returnData, isLayoutHaveTime(layout), nil
+		}
+	}
+
+	return returnData, false, err
+}
+
+// ApplyDelimiter sets the delimiter of the reader, when the locale has one
+func (l Locale) ApplyDelimiter(csvReader *csv.Reader) {
+	if l.Delimiter != 0 {
+		csvReader.Comma = l.Delimiter
+	}
+}
+
+// isLayoutHaveTime tells whether the layout states the hour
+func isLayoutHaveTime(layout string) bool {
+	return strings.Contains(layout, "15") || strings.Contains(layout, "03") || strings.Contains(layout, "3:")
+}
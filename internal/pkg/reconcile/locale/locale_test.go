@@ -0,0 +1,198 @@
+package locale
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jszwec/csvutil"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+)
+
+func TestLocaleParseAmount(t *testing.T) {
+	type args struct {
+		text          string
+		decimalPlaces int
+	}
+
+	tests := []struct {
+		name    string
+		locale  Locale
+		args    args
+		want    money.Amount
+		wantErr bool
+	}{
+		{
+			name: "Ok - default",
+			args: args{
+				text:          "-1234.5",
+				decimalPlaces: 2,
+			},
+			want: -123450,
+		},
+		{
+			name: "Ok - indonesian",
+			locale: Locale{
+				DecimalSeparator:   ",",
+				ThousandsSeparator: ".",
+			},
+			args: args{
+				text:          "1.234.567,89",
+				decimalPlaces: 2,
+			},
+			want: 123456789,
+		},
+		{
+			name: "Error - grouped amount without thousands separator",
+			args: args{
+				text:          "1,234.50",
+				decimalPlaces: 2,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.locale.ParseAmount(tt.args.text, tt.args.decimalPlaces)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseAmount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseAmount() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleCSVUnmarshalers(t *testing.T) {
+	type row struct {
+		Amount  money.Amount  `csv:"Amount"`
+		Balance *money.Amount `csv:"Balance"`
+	}
+
+	dec, _ := csvutil.NewDecoder(csv.NewReader(bytes.NewBufferString("Amount,Balance\n\"1.234,5\",")))
+	dec.WithUnmarshalers(Locale{DecimalSeparator: ",", ThousandsSeparator: "."}.CSVUnmarshalers(2))
+
+	var got row
+	if err := dec.Decode(&got); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if want := (row{Amount: 123450}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() got = %v, want %v", got, want)
+	}
+}
+
+func TestLocaleParseDate(t *testing.T) {
+	tests := []struct {
+		want           time.Time
+		name           string
+		value          string
+		locale         Locale
+		wantIsHaveTime bool
+		wantErr        bool
+	}{
+		{
+			name:           "Ok - default with time",
+			value:          "2025-08-17 10:30:00",
+			want:           time.Date(2025, 8, 17, 10, 30, 0, 0, time.UTC),
+			wantIsHaveTime: true,
+		},
+		{
+			name:  "Ok - default without time",
+			value: "2025-08-17",
+			want:  time.Date(2025, 8, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Ok - second layout",
+			locale: Locale{
+				DateLayouts: []string{"02/01/2006 15:04", "02/01/2006"},
+			},
+			value: "17/08/2025",
+			want:  time.Date(2025, 8, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Ok - indonesian short month name",
+			locale: Locale{
+				MonthNames:  MonthNamesIndonesian,
+				DateLayouts: []string{"2 Jan 2006"},
+			},
+			value: "17 Agu 2025",
+			want:  time.Date(2025, 8, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Ok - indonesian full month name",
+			locale: Locale{
+				MonthNames:  MonthNamesIndonesian,
+				DateLayouts: []string{"2 January 2006 15:04"},
+			},
+			value:          "1 desember 2025 08:15",
+			want:           time.Date(2025, 12, 1, 8, 15, 0, 0, time.UTC),
+			wantIsHaveTime: true,
+		},
+		{
+			name: "Error - indonesian month name without indonesian locale",
+			locale: Locale{
+				DateLayouts: []string{"2 Jan 2006"},
+			},
+			value:   "17 Agu 2025",
+			wantErr: true,
+		},
+		{
+			name:    "Error - no layout matches",
+			value:   "17/08/2025",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotIsHaveTime, err := tt.locale.ParseDate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !got.Equal(tt.want) || gotIsHaveTime != tt.wantIsHaveTime {
+				t.Errorf("ParseDate() got = %v, %v, want %v, %v", got, gotIsHaveTime, tt.want, tt.wantIsHaveTime)
+			}
+		})
+	}
+}
+
+func TestLocaleApplyDelimiter(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale Locale
+		want   rune
+	}{
+		{
+			name: "Ok - keep reader delimiter",
+			want: ',',
+		},
+		{
+			name:   "Ok - semicolon",
+			locale: Locale{Delimiter: ';'},
+			want:   ';',
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := csv.NewReader(nil)
+			tt.locale.ApplyDelimiter(r)
+			if r.Comma != tt.want {
+				t.Errorf("ApplyDelimiter() got = %q, want %q", r.Comma, tt.want)
+			}
+		})
+	}
+}
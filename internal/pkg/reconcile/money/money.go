@@ -0,0 +1,137 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jszwec/csvutil"
+)
+
+// MaxDecimalPlaces is the most minor unit digits a currency can be configured with.
+const MaxDecimalPlaces = 4
+
+// Amount is a money amount in minor units of the currency (cents when the currency has 2 decimal places),
+// so sums and equality never drift like float64 does.
+type Amount int64
+
+// Parse reads a plain decimal text like "-1234.5" into minor units. It fails instead of rounding when the text has
+// more non-zero decimals than the currency.
+func Parse(text string, decimalPlaces int) (Amount, error) {
+	return ParseGrouped(text, decimalPlaces, "", ".")
+}
+
+// ParseGrouped is Parse of a text with the integer digits grouped by thousands, like "-1.234,50". An empty
+// thousandSeparator is no grouping, an empty decimalSeparator is a dot.
+func ParseGrouped(text string, decimalPlaces int, thousandSeparator string, decimalSeparator string) (Amount, error) {
+	s := strings.TrimSpace(text)
+	isNegative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+
+	if thousandSeparator != "" {
+		s = strings.ReplaceAll(s, thousandSeparator, "")
+	}
+
+	if decimalSeparator == "" {
+		decimalSeparator = "."
+	}
+
+	integerPart, fractionPart, _ := strings.Cut(s, decimalSeparator)
+	if (integerPart == "" && fractionPart == "") || !isDigits(integerPart) || !isDigits(fractionPart) {
+		return 0, fmt.Errorf("amount %q is not a decimal number", text)
+	}
+
+	fractionPart = strings.TrimRight(fractionPart, "0")
+	if len(fractionPart) > decimalPlaces {
+		return 0, fmt.Errorf("amount %q has more than %d decimal places", text, decimalPlaces)
+	}
+
+	digits := strings.TrimLeft(integerPart+fractionPart+strings.Repeat("0", decimalPlaces-len(fractionPart)), "0")
+	if digits == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount %q is out of range", text)
+	}
+
+	if isNegative {
+		v = -v
+	}
+
+	return Amount(v), nil
+}
+
+// FromMajor converts a value in major units, like a configured tolerance, rounding half away from zero.
+func FromMajor(value float64, decimalPlaces int) Amount {
+	return Amount(math.Round(value * math.Pow10(decimalPlaces)))
+}
+
+func (a Amount) Abs() Amount {
+	if a < 0 {
+		return -a
+	}
+
+	return a
+}
+
+// Format writes the amount in major units with all decimal places, like "-1234.50".
+func (a Amount) Format(decimalPlaces int) string {
+	return a.FormatGrouped(decimalPlaces, "", ".")
+}
+
+// FormatGrouped is Format with the integer digits grouped by thousands, like "-1.234,50".
+func (a Amount) FormatGrouped(decimalPlaces int, thousandSeparator string, decimalSeparator string) string {
+	digits := strconv.FormatUint(uint64(a.Abs()), 10)
+	if len(digits) <= decimalPlaces {
+		digits = strings.Repeat("0", decimalPlaces-len(digits)+1) + digits
+	}
+
+	integerPart := digits[:len(digits)-decimalPlaces]
+	sb := strings.Builder{}
+	if a < 0 {
+		sb.WriteString("-")
+	}
+
+	for i, r := range integerPart {
+		if i > 0 && (len(integerPart)-i)%3 == 0 {
+			sb.WriteString(thousandSeparator)
+		}
+
+		sb.WriteRune(r)
+	}
+
+	if decimalPlaces > 0 {
+		sb.WriteString(decimalSeparator)
+		sb.WriteString(digits[len(digits)-decimalPlaces:])
+	}
+
+	return sb.String()
+}
+
+// CSVUnmarshalers lets csvutil decode Amount fields from decimal text of the currency.
+func CSVUnmarshalers(decimalPlaces int) *csvutil.Unmarshalers {
+	return csvutil.UnmarshalFunc(func(data []byte, a *Amount) (err error) {
+		*a, err = Parse(string(data), decimalPlaces)
+		return err
+	})
+}
+
+// CSVMarshalers lets csvutil encode Amount fields as decimal text of the currency.
+func CSVMarshalers(decimalPlaces int) *csvutil.Marshalers {
+	return csvutil.MarshalFunc(func(a Amount) ([]byte, error) {
+		return []byte(a.Format(decimalPlaces)), nil
+	})
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,356 @@
+package money
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/jszwec/csvutil"
+)
+
+func TestParse(t *testing.T) {
+	type args struct {
+		text          string
+		decimalPlaces int
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    Amount
+		wantErr bool
+	}{
+		{
+			name: "Ok - integer",
+			args: args{
+				text:          "41000",
+				decimalPlaces: 2,
+			},
+			want: 4100000,
+		},
+		{
+			name: "Ok - decimals",
+			args: args{
+				text:          " -1234.5 ",
+				decimalPlaces: 2,
+			},
+			want: -123450,
+		},
+		{
+			name: "Ok - trailing zero decimals of currency without decimals",
+			args: args{
+				text:          "41000.00",
+				decimalPlaces: 0,
+			},
+			want: 41000,
+		},
+		{
+			name: "Ok - only decimals",
+			args: args{
+				text:          "+.07",
+				decimalPlaces: 2,
+			},
+			want: 7,
+		},
+		{
+			name: "Ok - zero",
+			args: args{
+				text:          "-0.00",
+				decimalPlaces: 2,
+			},
+			want: 0,
+		},
+		{
+			name: "Error - more decimals than currency",
+			args: args{
+				text:          "0.005",
+				decimalPlaces: 2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - not a number",
+			args: args{
+				text:          "1e3",
+				decimalPlaces: 2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - empty",
+			args: args{
+				text:          "-",
+				decimalPlaces: 2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error - out of range",
+			args: args{
+				text:          "92233720368547758.08",
+				decimalPlaces: 2,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.args.text, tt.args.decimalPlaces)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGrouped(t *testing.T) {
+	type args struct {
+		text              string
+		thousandSeparator string
+		decimalSeparator  string
+		decimalPlaces     int
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    Amount
+		wantErr bool
+	}{
+		{
+			name: "Ok - dot grouping and comma decimals",
+			args: args{
+				text:              "-1.234.567,89",
+				thousandSeparator: ".",
+				decimalSeparator:  ",",
+				decimalPlaces:     2,
+			},
+			want: -123456789,
+		},
+		{
+			name: "Ok - space grouping",
+			args: args{
+				text:              "1 234 567",
+				thousandSeparator: " ",
+				decimalSeparator:  ",",
+				decimalPlaces:     2,
+			},
+			want: 123456700,
+		},
+		{
+			name: "Ok - empty separators",
+			args: args{
+				text:          "1234.5",
+				decimalPlaces: 2,
+			},
+			want: 123450,
+		},
+		{
+			name: "Error - dot decimals with comma decimal separator",
+			args: args{
+				text:             "1234.50",
+				decimalSeparator: ",",
+				decimalPlaces:    2,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseGrouped(tt.args.text, tt.args.decimalPlaces, tt.args.thousandSeparator, tt.args.decimalSeparator)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseGrouped() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseGrouped() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromMajor(t *testing.T) {
+	type args struct {
+		value         float64
+		decimalPlaces int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want Amount
+	}{
+		{
+			name: "Ok",
+			args: args{
+				value:         0.1 + 0.2,
+				decimalPlaces: 2,
+			},
+			want: 30,
+		},
+		{
+			name: "Ok - half away from zero",
+			args: args{
+				value:         -2.5,
+				decimalPlaces: 0,
+			},
+			want: -3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromMajor(tt.args.value, tt.args.decimalPlaces); got != tt.want {
+				t.Errorf("FromMajor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountFormatGrouped(t *testing.T) {
+	type args struct {
+		decimalPlaces     int
+		thousandSeparator string
+		decimalSeparator  string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		a    Amount
+		want string
+	}{
+		{
+			name: "Ok",
+			a:    -123456789,
+			args: args{
+				decimalPlaces:     2,
+				thousandSeparator: ".",
+				decimalSeparator:  ",",
+			},
+			want: "-1.234.567,89",
+		},
+		{
+			name: "Ok - below one",
+			a:    -5,
+			args: args{
+				decimalPlaces:     3,
+				thousandSeparator: ".",
+				decimalSeparator:  ",",
+			},
+			want: "-0,005",
+		},
+		{
+			name: "Ok - without decimals",
+			a:    100000,
+			args: args{
+				decimalPlaces:     0,
+				thousandSeparator: ",",
+				decimalSeparator:  ".",
+			},
+			want: "100,000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.FormatGrouped(tt.args.decimalPlaces, tt.args.thousandSeparator, tt.args.decimalSeparator); got != tt.want {
+				t.Errorf("FormatGrouped() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountFormat(t *testing.T) {
+	tests := []struct {
+		name          string
+		a             Amount
+		decimalPlaces int
+		want          string
+	}{
+		{
+			name:          "Ok",
+			a:             123450,
+			decimalPlaces: 2,
+			want:          "1234.50",
+		},
+		{
+			name:          "Ok - zero",
+			a:             0,
+			decimalPlaces: 2,
+			want:          "0.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Format(tt.decimalPlaces); got != tt.want {
+				t.Errorf("Format() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVMarshalers(t *testing.T) {
+	type row struct {
+		Amount Amount `csv:"Amount"`
+	}
+
+	tests := []struct {
+		name          string
+		data          string
+		decimalPlaces int
+		want          string
+		wantErr       bool
+	}{
+		{
+			name:          "Ok",
+			data:          "Amount\n-41000.5\n",
+			decimalPlaces: 2,
+			want:          "Amount\n-41000.50\n",
+		},
+		{
+			name:          "Error - more decimals than currency",
+			data:          "Amount\n41000.5\n",
+			decimalPlaces: 0,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []row
+			dec, _ := csvutil.NewDecoder(csv.NewReader(strings.NewReader(tt.data)))
+			dec.WithUnmarshalers(CSVUnmarshalers(tt.decimalPlaces))
+			err := dec.Decode(&rows)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			buf := bytes.Buffer{}
+			w := csv.NewWriter(&buf)
+			enc := csvutil.NewEncoder(w)
+			enc.WithMarshalers(CSVMarshalers(tt.decimalPlaces))
+			_ = enc.Encode(rows)
+			w.Flush()
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Encode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,8 +3,12 @@
 package _mock
 
 import (
+	locale "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
 	banks "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+
 	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 )
 
 // BankTrxDataInterface is an autogenerated mock type for the BankTrxDataInterface type
@@ -13,36 +17,36 @@ type BankTrxDataInterface struct {
 }
 
 // GetAbsAmount provides a mock function with no fields
-func (_m *BankTrxDataInterface) GetAbsAmount() float64 {
+func (_m *BankTrxDataInterface) GetAbsAmount() money.Amount {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAbsAmount")
 	}
 
-	var r0 float64
-	if rf, ok := ret.Get(0).(func() float64); ok {
+	var r0 money.Amount
+	if rf, ok := ret.Get(0).(func() money.Amount); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(float64)
+		r0 = ret.Get(0).(money.Amount)
 	}
 
 	return r0
 }
 
 // GetAmount provides a mock function with no fields
-func (_m *BankTrxDataInterface) GetAmount() float64 {
+func (_m *BankTrxDataInterface) GetAmount() money.Amount {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAmount")
 	}
 
-	var r0 float64
-	if rf, ok := ret.Get(0).(func() float64); ok {
+	var r0 money.Amount
+	if rf, ok := ret.Get(0).(func() money.Amount); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(float64)
+		r0 = ret.Get(0).(money.Amount)
 	}
 
 	return r0
@@ -84,6 +88,24 @@ func (_m *BankTrxDataInterface) GetDate() string {
 	return r0
 }
 
+// GetReference provides a mock function with no fields
+func (_m *BankTrxDataInterface) GetReference() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReference")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // GetType provides a mock function with no fields
 func (_m *BankTrxDataInterface) GetType() banks.TrxType {
 	ret := _m.Called()
@@ -120,9 +142,9 @@ func (_m *BankTrxDataInterface) GetUniqueIdentifier() string {
 	return r0
 }
 
-// ToBankTrxData provides a mock function with no fields
-func (_m *BankTrxDataInterface) ToBankTrxData() (*banks.BankTrxData, error) {
-	ret := _m.Called()
+// ToBankTrxData provides a mock function with given fields: loc
+func (_m *BankTrxDataInterface) ToBankTrxData(loc locale.Locale) (*banks.BankTrxData, error) {
+	ret := _m.Called(loc)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ToBankTrxData")
@@ -130,19 +152,19 @@ func (_m *BankTrxDataInterface) ToBankTrxData() (*banks.BankTrxData, error) {
 
 	var r0 *banks.BankTrxData
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*banks.BankTrxData, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(locale.Locale) (*banks.BankTrxData, error)); ok {
+		return rf(loc)
 	}
-	if rf, ok := ret.Get(0).(func() *banks.BankTrxData); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(locale.Locale) *banks.BankTrxData); ok {
+		r0 = rf(loc)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*banks.BankTrxData)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(locale.Locale) error); ok {
+		r1 = rf(loc)
 	} else {
 		r1 = ret.Error(1)
 	}
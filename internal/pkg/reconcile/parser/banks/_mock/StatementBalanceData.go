@@ -0,0 +1,47 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package _mock
+
+import (
+	banks "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StatementBalanceData is an autogenerated mock type for the StatementBalanceData type
+type StatementBalanceData struct {
+	mock.Mock
+}
+
+// GetStatementBalances provides a mock function with no fields
+func (_m *StatementBalanceData) GetStatementBalances() []banks.StatementBalance {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatementBalances")
+	}
+
+	var r0 []banks.StatementBalance
+	if rf, ok := ret.Get(0).(func() []banks.StatementBalance); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]banks.StatementBalance)
+		}
+	}
+
+	return r0
+}
+
+// NewStatementBalanceData creates a new instance of StatementBalanceData. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStatementBalanceData(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StatementBalanceData {
+	mock := &StatementBalanceData{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
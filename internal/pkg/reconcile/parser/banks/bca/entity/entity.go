@@ -1,17 +1,24 @@
 package entity
 
 import (
-	"math"
-	"time"
+	"strings"
 
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/samber/lo"
 )
 
 type CSVBankTrxData struct {
-	BCAUniqueIdentifier string  `csv:"BCAUniqueIdentifier"`
-	BCADate             string  `csv:"BCADate"`
-	BCABank             string  `csv:"-"`
-	BCAAmount           float64 `csv:"BCAAmount"`
+	BCAUniqueIdentifier string       `csv:"BCAUniqueIdentifier"`
+	BCADate             string       `csv:"BCADate"`
+	BCABank             string       `csv:"-"`
+	BCAAmount           money.Amount `csv:"BCAAmount"`
+	BCAReference        string       `csv:"BCAReference"`
+	// BCABalance is optional, the running balance after the line
+	BCABalance *money.Amount `csv:"BCABalance"`
+	// BCACounterpartyAccount is optional, the account the money came from or went to
+	BCACounterpartyAccount string `csv:"BCACounterpartyAccount"`
 }
 
 func (u *CSVBankTrxData) GetUniqueIdentifier() string {
@@ -22,12 +29,16 @@ func (u *CSVBankTrxData) GetDate() string {
 	return u.BCADate
 }
 
-func (u *CSVBankTrxData) GetAmount() float64 {
+func (u *CSVBankTrxData) GetReference() string {
+	return u.BCAReference
+}
+
+func (u *CSVBankTrxData) GetAmount() money.Amount {
 	return u.BCAAmount
 }
 
-func (u *CSVBankTrxData) GetAbsAmount() float64 {
-	return math.Abs(u.BCAAmount)
+func (u *CSVBankTrxData) GetAbsAmount() money.Amount {
+	return u.BCAAmount.Abs()
 }
 
 func (u *CSVBankTrxData) GetType() banks.TrxType {
@@ -42,18 +53,23 @@ func (u *CSVBankTrxData) GetBank() string {
 	return u.BCABank
 }
 
-func (u *CSVBankTrxData) ToBankTrxData() (returnData *banks.BankTrxData, err error) {
-	t, e := time.Parse("2006-01-02", u.BCADate)
+func (u *CSVBankTrxData) ToBankTrxData(loc locale.Locale) (returnData *banks.BankTrxData, err error) {
+	t, isHaveTime, e := loc.ParseDate(u.BCADate)
 	if e != nil {
 		return nil, e
 	}
 
 	return &banks.BankTrxData{
-		UniqueIdentifier: u.BCAUniqueIdentifier,
-		Date:             t,
-		Type:             u.GetType(),
-		Bank:             u.BCABank,
-		FilePath:         "",
-		Amount:           u.GetAbsAmount(),
+		UniqueIdentifier:    u.BCAUniqueIdentifier,
+		Reference:           u.BCAReference,
+		Date:                t,
+		IsHaveTime:          isHaveTime,
+		Type:                u.GetType(),
+		Bank:                u.BCABank,
+		FilePath:            "",
+		Amount:              u.GetAbsAmount(),
+		Balance:             lo.FromPtr(u.BCABalance),
+		IsHaveBalance:       u.BCABalance != nil,
+		CounterpartyAccount: strings.TrimSpace(u.BCACounterpartyAccount),
 	}, nil
 }
@@ -5,18 +5,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
 )
 
 func TestCSVBankTrxDataGetAbsAmount(t *testing.T) {
 	type fields struct {
-		BCAAmount float64
+		BCAAmount money.Amount
 	}
 
 	tests := []struct {
 		name   string
 		fields fields
-		want   float64
+		want   money.Amount
 	}{
 		{
 			name: "Ok - positive",
@@ -49,13 +51,13 @@ func TestCSVBankTrxDataGetAbsAmount(t *testing.T) {
 
 func TestCSVBankTrxDataGetAmount(t *testing.T) {
 	type fields struct {
-		BCAAmount float64
+		BCAAmount money.Amount
 	}
 
 	tests := []struct {
 		name   string
 		fields fields
-		want   float64
+		want   money.Amount
 	}{
 		{
 			name: "Ok",
@@ -142,9 +144,48 @@ func TestCSVBankTrxDataGetDate(t *testing.T) {
 	}
 }
 
+func TestCSVBankTrxDataGetReference(t *testing.T) {
+	type fields struct {
+		BCAReference string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				BCAReference: "006630c83821fac6bea13b92b480feb2",
+			},
+			want: "006630c83821fac6bea13b92b480feb2",
+		},
+		{
+			name: "Ok - no reference",
+			fields: fields{
+				BCAReference: "",
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &CSVBankTrxData{
+				BCAReference: tt.fields.BCAReference,
+			}
+
+			if got := u.GetReference(); got != tt.want {
+				t.Errorf("GetReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCSVBankTrxDataGetType(t *testing.T) {
 	type fields struct {
-		BCAAmount float64
+		BCAAmount money.Amount
 	}
 
 	tests := []struct {
@@ -220,7 +261,9 @@ func TestCSVBankTrxDataToBankTrxData(t *testing.T) {
 		BCAUniqueIdentifier string
 		BCADate             string
 		BCABank             string
-		BCAAmount           float64
+		BCAAmount           money.Amount
+		BCAReference        string
+		BCABalance          *money.Amount
 	}
 
 	tests := []struct {
@@ -236,9 +279,11 @@ func TestCSVBankTrxDataToBankTrxData(t *testing.T) {
 				BCADate:             "1999-01-01",
 				BCABank:             string(banks.BCABankParser),
 				BCAAmount:           1000,
+				BCAReference:        "006630c83821fac6bea13b92b480feb2",
 			},
 			wantReturnData: &banks.BankTrxData{
 				UniqueIdentifier: UniqueUUID,
+				Reference:        "006630c83821fac6bea13b92b480feb2",
 				Date: func() time.Time {
 					t, _ := time.Parse("2006-01-02", "1999-01-01")
 					return t
@@ -250,6 +295,57 @@ func TestCSVBankTrxDataToBankTrxData(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Ok - with time",
+			fields: fields{
+				BCAUniqueIdentifier: UniqueUUID,
+				BCADate:             "1999-01-01 10:30:00",
+				BCABank:             string(banks.BCABankParser),
+				BCAAmount:           1000,
+				BCAReference:        "006630c83821fac6bea13b92b480feb2",
+			},
+			wantReturnData: &banks.BankTrxData{
+				UniqueIdentifier: UniqueUUID,
+				Reference:        "006630c83821fac6bea13b92b480feb2",
+				Date: func() time.Time {
+					t, _ := time.Parse("2006-01-02 15:04:05", "1999-01-01 10:30:00")
+					return t
+				}(),
+				IsHaveTime: true,
+				Type:       banks.CREDIT,
+				Bank:       string(banks.BCABankParser),
+				FilePath:   "",
+				Amount:     1000,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - with balance",
+			fields: fields{
+				BCAUniqueIdentifier: UniqueUUID,
+				BCADate:             "1999-01-01",
+				BCABank:             string(banks.BCABankParser),
+				BCAAmount:           1000,
+				BCABalance: func() *money.Amount {
+					balance := money.Amount(5000)
+					return &balance
+				}(),
+			},
+			wantReturnData: &banks.BankTrxData{
+				UniqueIdentifier: UniqueUUID,
+				Date: func() time.Time {
+					t, _ := time.Parse("2006-01-02", "1999-01-01")
+					return t
+				}(),
+				Type:          banks.CREDIT,
+				Bank:          string(banks.BCABankParser),
+				FilePath:      "",
+				Amount:        1000,
+				Balance:       5000,
+				IsHaveBalance: true,
+			},
+			wantErr: false,
+		},
 		{
 			name: "Error invalid date",
 			fields: fields{
@@ -270,9 +366,11 @@ func TestCSVBankTrxDataToBankTrxData(t *testing.T) {
 				BCADate:             tt.fields.BCADate,
 				BCABank:             tt.fields.BCABank,
 				BCAAmount:           tt.fields.BCAAmount,
+				BCAReference:        tt.fields.BCAReference,
+				BCABalance:          tt.fields.BCABalance,
 			}
 
-			gotReturnData, err := u.ToBankTrxData()
+			gotReturnData, err := u.ToBankTrxData(locale.Locale{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ToBankTrxData() error = %v, wantErr %v", err, tt.wantErr)
 				return
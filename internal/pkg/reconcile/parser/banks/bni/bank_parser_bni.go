@@ -5,16 +5,19 @@ import (
 	"encoding/csv"
 	"errors"
 
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/bni/entity"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/helper"
 )
 
 type BankParser struct {
-	csvReader    *csv.Reader
-	parser       banks.BankParserType
-	bank         string
-	isHaveHeader bool
+	csvReader     *csv.Reader
+	parser        banks.BankParserType
+	bank          string
+	locale        locale.Locale
+	isHaveHeader  bool
+	decimalPlaces int
 }
 
 var _ banks.ReconcileBankData = (*BankParser)(nil)
@@ -23,16 +26,20 @@ func NewBankParser(
 	bank string,
 	csvReader *csv.Reader,
 	isHaveHeader bool,
+	decimalPlaces int,
+	loc locale.Locale,
 ) (*BankParser, error) {
 	if csvReader == nil {
 		return nil, errors.New("csvReader or dataStruct is nil")
 	}
 
 	return &BankParser{
-		parser:       banks.BNIBankParser,
-		csvReader:    csvReader,
-		isHaveHeader: isHaveHeader,
-		bank:         bank,
+		parser:        banks.BNIBankParser,
+		csvReader:     csvReader,
+		isHaveHeader:  isHaveHeader,
+		decimalPlaces: decimalPlaces,
+		locale:        loc,
+		bank:          bank,
 	}, nil
 }
 
@@ -50,6 +57,8 @@ func (d *BankParser) ToBankTrxData(ctx context.Context, filePath string) (return
 		filePath,
 		d.isHaveHeader,
 		d.bank,
+		d.decimalPlaces,
+		d.locale,
 		d.csvReader,
 		&entity.CSVBankTrxData{},
 	)
@@ -1,17 +1,24 @@
 package entity
 
 import (
-	"math"
-	"time"
+	"strings"
 
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/samber/lo"
 )
 
 type CSVBankTrxData struct {
-	BNIUniqueIdentifier string  `csv:"BNIUniqueIdentifier"`
-	BNIDate             string  `csv:"BNIDate"`
-	BNIBank             string  `csv:"-"`
-	BNIAmount           float64 `csv:"BNIAmount"`
+	BNIUniqueIdentifier string       `csv:"BNIUniqueIdentifier"`
+	BNIDate             string       `csv:"BNIDate"`
+	BNIBank             string       `csv:"-"`
+	BNIAmount           money.Amount `csv:"BNIAmount"`
+	BNIReference        string       `csv:"BNIReference"`
+	// BNIBalance is optional, the running balance after the line
+	BNIBalance *money.Amount `csv:"BNIBalance"`
+	// BNICounterpartyAccount is optional, the account the money came from or went to
+	BNICounterpartyAccount string `csv:"BNICounterpartyAccount"`
 }
 
 func (u *CSVBankTrxData) GetUniqueIdentifier() string {
@@ -22,12 +29,16 @@ func (u *CSVBankTrxData) GetDate() string {
 	return u.BNIDate
 }
 
-func (u *CSVBankTrxData) GetAmount() float64 {
+func (u *CSVBankTrxData) GetReference() string {
+	return u.BNIReference
+}
+
+func (u *CSVBankTrxData) GetAmount() money.Amount {
 	return u.BNIAmount
 }
 
-func (u *CSVBankTrxData) GetAbsAmount() float64 {
-	return math.Abs(u.BNIAmount)
+func (u *CSVBankTrxData) GetAbsAmount() money.Amount {
+	return u.BNIAmount.Abs()
 }
 
 func (u *CSVBankTrxData) GetType() banks.TrxType {
@@ -42,18 +53,23 @@ func (u *CSVBankTrxData) GetBank() string {
 	return u.BNIBank
 }
 
-func (u *CSVBankTrxData) ToBankTrxData() (returnData *banks.BankTrxData, err error) {
-	t, e := time.Parse("2006-01-02", u.BNIDate)
+func (u *CSVBankTrxData) ToBankTrxData(loc locale.Locale) (returnData *banks.BankTrxData, err error) {
+	t, isHaveTime, e := loc.ParseDate(u.BNIDate)
 	if e != nil {
 		return nil, e
 	}
 
 	return &banks.BankTrxData{
-		UniqueIdentifier: u.BNIUniqueIdentifier,
-		Date:             t,
-		Type:             u.GetType(),
-		Bank:             u.BNIBank,
-		FilePath:         "",
-		Amount:           u.GetAbsAmount(),
+		UniqueIdentifier:    u.BNIUniqueIdentifier,
+		Reference:           u.BNIReference,
+		Date:                t,
+		IsHaveTime:          isHaveTime,
+		Type:                u.GetType(),
+		Bank:                u.BNIBank,
+		FilePath:            "",
+		Amount:              u.GetAbsAmount(),
+		Balance:             lo.FromPtr(u.BNIBalance),
+		IsHaveBalance:       u.BNIBalance != nil,
+		CounterpartyAccount: strings.TrimSpace(u.BNICounterpartyAccount),
 	}, nil
 }
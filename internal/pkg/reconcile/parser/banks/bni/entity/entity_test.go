@@ -5,18 +5,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
 )
 
 func TestCSVBankTrxDataGetAbsAmount(t *testing.T) {
 	type fields struct {
-		BNIAmount float64
+		BNIAmount money.Amount
 	}
 
 	tests := []struct {
 		name   string
 		fields fields
-		want   float64
+		want   money.Amount
 	}{
 		{
 			name: "Ok - positive",
@@ -49,13 +51,13 @@ func TestCSVBankTrxDataGetAbsAmount(t *testing.T) {
 
 func TestCSVBankTrxDataGetAmount(t *testing.T) {
 	type fields struct {
-		BNIAmount float64
+		BNIAmount money.Amount
 	}
 
 	tests := []struct {
 		name   string
 		fields fields
-		want   float64
+		want   money.Amount
 	}{
 		{
 			name: "Ok",
@@ -142,9 +144,48 @@ func TestCSVBankTrxDataGetDate(t *testing.T) {
 	}
 }
 
+func TestCSVBankTrxDataGetReference(t *testing.T) {
+	type fields struct {
+		BNIReference string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				BNIReference: "006630c83821fac6bea13b92b480feb2",
+			},
+			want: "006630c83821fac6bea13b92b480feb2",
+		},
+		{
+			name: "Ok - no reference",
+			fields: fields{
+				BNIReference: "",
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &CSVBankTrxData{
+				BNIReference: tt.fields.BNIReference,
+			}
+
+			if got := u.GetReference(); got != tt.want {
+				t.Errorf("GetReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCSVBankTrxDataGetType(t *testing.T) {
 	type fields struct {
-		BNIAmount float64
+		BNIAmount money.Amount
 	}
 
 	tests := []struct {
@@ -220,7 +261,9 @@ func TestCSVBankTrxDataToBankTrxData(t *testing.T) {
 		BNIUniqueIdentifier string
 		BNIDate             string
 		BNIBank             string
-		BNIAmount           float64
+		BNIAmount           money.Amount
+		BNIReference        string
+		BNIBalance          *money.Amount
 	}
 
 	tests := []struct {
@@ -236,9 +279,11 @@ func TestCSVBankTrxDataToBankTrxData(t *testing.T) {
 				BNIDate:             "1999-01-01",
 				BNIBank:             string(banks.BNIBankParser),
 				BNIAmount:           1000,
+				BNIReference:        "006630c83821fac6bea13b92b480feb2",
 			},
 			wantReturnData: &banks.BankTrxData{
 				UniqueIdentifier: UniqueUUID,
+				Reference:        "006630c83821fac6bea13b92b480feb2",
 				Date: func() time.Time {
 					t, _ := time.Parse("2006-01-02", "1999-01-01")
 					return t
@@ -250,6 +295,57 @@ func TestCSVBankTrxDataToBankTrxData(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Ok - with time",
+			fields: fields{
+				BNIUniqueIdentifier: UniqueUUID,
+				BNIDate:             "1999-01-01 10:30:00",
+				BNIBank:             string(banks.BNIBankParser),
+				BNIAmount:           1000,
+				BNIReference:        "006630c83821fac6bea13b92b480feb2",
+			},
+			wantReturnData: &banks.BankTrxData{
+				UniqueIdentifier: UniqueUUID,
+				Reference:        "006630c83821fac6bea13b92b480feb2",
+				Date: func() time.Time {
+					t, _ := time.Parse("2006-01-02 15:04:05", "1999-01-01 10:30:00")
+					return t
+				}(),
+				IsHaveTime: true,
+				Type:       banks.CREDIT,
+				Bank:       string(banks.BNIBankParser),
+				FilePath:   "",
+				Amount:     1000,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - with balance",
+			fields: fields{
+				BNIUniqueIdentifier: UniqueUUID,
+				BNIDate:             "1999-01-01",
+				BNIBank:             string(banks.BNIBankParser),
+				BNIAmount:           1000,
+				BNIBalance: func() *money.Amount {
+					balance := money.Amount(5000)
+					return &balance
+				}(),
+			},
+			wantReturnData: &banks.BankTrxData{
+				UniqueIdentifier: UniqueUUID,
+				Date: func() time.Time {
+					t, _ := time.Parse("2006-01-02", "1999-01-01")
+					return t
+				}(),
+				Type:          banks.CREDIT,
+				Bank:          string(banks.BNIBankParser),
+				FilePath:      "",
+				Amount:        1000,
+				Balance:       5000,
+				IsHaveBalance: true,
+			},
+			wantErr: false,
+		},
 		{
 			name: "Error invalid date",
 			fields: fields{
@@ -270,9 +366,11 @@ func TestCSVBankTrxDataToBankTrxData(t *testing.T) {
 				BNIDate:             tt.fields.BNIDate,
 				BNIBank:             tt.fields.BNIBank,
 				BNIAmount:           tt.fields.BNIAmount,
+				BNIReference:        tt.fields.BNIReference,
+				BNIBalance:          tt.fields.BNIBalance,
 			}
 
-			gotReturnData, err := u.ToBankTrxData()
+			gotReturnData, err := u.ToBankTrxData(locale.Locale{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ToBankTrxData() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,326 @@
+package camt053
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/utils/log"
+)
+
+// Codes of the statement read from the document
+const (
+	credit = "CRDT"
+	debit  = "DBIT"
+	// booked the entry is booked on the account, the other entries (pending or for information) are not
+	booked = "BOOK"
+	// openingBooked, previouslyClosedBooked and closingBooked are the balances of the statement
+	openingBooked          = "OPBD"
+	previouslyClosedBooked = "PRCD"
+	closingBooked          = "CLBD"
+	// notProvided is the end to end identification of a transaction the debtor gave none
+	notProvided = "NOTPROVIDED"
+)
+
+// document is the part of a camt.053 document the parser reads, elements are matched whatever the namespace (version)
+type document struct {
+	Statements []statement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type statement struct {
+	ID       string    `xml:"Id"`
+	Account  account   `xml:"Acct"`
+	Balances []balance `xml:"Bal"`
+	Entries  []entry   `xml:"Ntry"`
+}
+
+type account struct {
+	IBAN  string `xml:"Id>IBAN"`
+	Other string `xml:"Id>Othr>Id"`
+}
+
+type balance struct {
+	Code      string `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    amount `xml:"Amt"`
+	CdtDbtInd string `xml:"CdtDbtInd"`
+}
+
+type amount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type entry struct {
+	Reference   string        `xml:"NtryRef"`
+	Amount      amount        `xml:"Amt"`
+	CdtDbtInd   string        `xml:"CdtDbtInd"`
+	Status      status        `xml:"Sts"`
+	BookingDate date          `xml:"BookgDt"`
+	ValueDate   date          `xml:"ValDt"`
+	AcctSvcrRef string        `xml:"AcctSvcrRef"`
+	Details     []transaction `xml:"NtryDtls>TxDtls"`
+}
+
+// status is a code up to version 7 of camt.053, a code in Cd from version 8
+type status struct {
+	Code  string `xml:"Cd"`
+	Value string `xml:",chardata"`
+}
+
+// date is a day or a date time
+type date struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+type transaction struct {
+	AcctSvcrRef     string  `xml:"Refs>AcctSvcrRef"`
+	EndToEndID      string  `xml:"Refs>EndToEndId"`
+	DebtorAccount   account `xml:"RltdPties>DbtrAcct"`
+	CreditorAccount account `xml:"RltdPties>CdtrAcct"`
+}
+
+type BankParser struct {
+	reader            io.Reader
+	parser            banks.BankParserType
+	bank              string
+	statementBalances []banks.StatementBalance
+	decimalPlaces     int
+}
+
+var _ banks.ReconcileBankData = (*BankParser)(nil)
+var _ banks.StatementBalanceData = (*BankParser)(nil)
+
+func NewBankParser(
+	bank string,
+	reader io.Reader,
+	decimalPlaces int,
+) (*BankParser, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+
+	return &BankParser{
+		parser:        banks.Camt053BankParser,
+		bank:          bank,
+		decimalPlaces: decimalPlaces,
+		reader:        reader,
+	}, nil
+}
+
+// ToBankTrxData reads the booked entries (Ntry) of every statement in the document, entries failing to parse are
+// logged and skipped. The opening (OPBD, else PRCD) and closing (CLBD) balances are kept as the statement balances
+func (d *BankParser) ToBankTrxData(ctx context.Context, filePath string) (returnData []*banks.BankTrxData, err error) {
+	var doc document
+	if err = xml.NewDecoder(d.reader).Decode(&doc); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+
+		log.AddErr(ctx, err)
+		return nil, err
+	}
+
+	for statementIndex, stmt := range doc.Statements {
+		accountID := stmt.Account.id()
+		if statementBalance, e := d.toStatementBalance(stmt); e != nil {
+			log.AddErr(ctx, fmt.Errorf("%s statement %d: %w", filePath, statementIndex+1, e))
+		} else if statementBalance.IsHaveOpeningBalance || statementBalance.IsHaveClosingBalance {
+			statementBalance.Bank = d.bank
+			statementBalance.Account = accountID
+			statementBalance.FilePath = filePath
+			d.statementBalances = append(d.statementBalances, statementBalance)
+		}
+
+		for entryIndex, item := range stmt.Entries {
+			if entryStatus := item.Status.code(); entryStatus != "" && entryStatus != booked {
+				continue
+			}
+
+			bankTrxData, e := d.toBankTrxData(item)
+			if e != nil {
+				log.AddErr(ctx, fmt.Errorf("%s statement %d entry %d: %w", filePath, statementIndex+1, entryIndex+1, e))
+				continue
+			}
+
+			if bankTrxData.UniqueIdentifier == "" {
+				bankTrxData.UniqueIdentifier = fmt.Sprintf("%s/%d", strings.TrimSpace(stmt.ID), entryIndex+1)
+			}
+
+			bankTrxData.Bank = d.bank
+			bankTrxData.Account = accountID
+			bankTrxData.FilePath = filePath
+			returnData = append(returnData, bankTrxData)
+		}
+	}
+
+	return returnData, nil
+}
+
+func (d *BankParser) GetParser() banks.BankParserType {
+	return d.parser
+}
+
+func (d *BankParser) GetBank() string {
+	return d.bank
+}
+
+func (d *BankParser) GetStatementBalances() []banks.StatementBalance {
+	return d.statementBalances
+}
+
+// toBankTrxData reads an entry. The unique identifier is the reference of the bank (AcctSvcrRef of the entry, else of
+// its only transaction), else the entry reference. The reference is the end to end identification of its only
+// transaction, a batch of transactions booked as one entry has none
+func (d *BankParser) toBankTrxData(item entry) (returnData *banks.BankTrxData, err error) {
+	returnData = &banks.BankTrxData{
+		Currency: strings.TrimSpace(item.Amount.Currency),
+	}
+
+	if returnData.Date, returnData.IsHaveTime, err = item.BookingDate.parse(); err != nil {
+		return nil, fmt.Errorf("booking date: %w", err)
+	}
+
+	if item.ValueDate != (date{}) {
+		if returnData.ValueDate, _, err = item.ValueDate.parse(); err != nil {
+			return nil, fmt.Errorf("value date: %w", err)
+		}
+	}
+
+	if returnData.Type, err = trxType(item.CdtDbtInd); err != nil {
+		return nil, err
+	}
+
+	if returnData.Amount, err = money.ParseGrouped(strings.TrimSpace(item.Amount.Value), d.decimalPlaces, "", "."); err != nil {
+		return nil, err
+	}
+
+	returnData.UniqueIdentifier = strings.TrimSpace(item.AcctSvcrRef)
+	if len(item.Details) == 1 {
+		detail := item.Details[0]
+		if endToEndID := strings.TrimSpace(detail.EndToEndID); !strings.EqualFold(endToEndID, notProvided) {
+			returnData.Reference = endToEndID
+		}
+
+		if returnData.UniqueIdentifier == "" {
+			returnData.UniqueIdentifier = strings.TrimSpace(detail.AcctSvcrRef)
+		}
+
+		// the counterparty is the debtor of a credit and the creditor of a debit
+		returnData.CounterpartyAccount = detail.CreditorAccount.id()
+		if returnData.Type == banks.CREDIT {
+			returnData.CounterpartyAccount = detail.DebtorAccount.id()
+		}
+	}
+
+	if returnData.UniqueIdentifier == "" {
+		returnData.UniqueIdentifier = strings.TrimSpace(item.Reference)
+	}
+
+	return returnData, nil
+}
+
+// toStatementBalance reads the opening and the closing balance of a statement, a debit balance is negative
+func (d *BankParser) toStatementBalance(stmt statement) (returnData banks.StatementBalance, err error) {
+	var opening, previouslyClosed *balance
+	for index, item := range stmt.Balances {
+		switch strings.TrimSpace(item.Code) {
+		case openingBooked:
+			opening = &stmt.Balances[index]
+		case previouslyClosedBooked:
+			previouslyClosed = &stmt.Balances[index]
+		case closingBooked:
+			if returnData.ClosingBalance, err = d.balanceAmount(item); err != nil {
+				return returnData, fmt.Errorf("closing balance: %w", err)
+			}
+
+			returnData.IsHaveClosingBalance = true
+		}
+	}
+
+	if opening == nil {
+		opening = previouslyClosed
+	}
+
+	if opening != nil {
+		if returnData.OpeningBalance, err = d.balanceAmount(*opening); err != nil {
+			return returnData, fmt.Errorf("opening balance: %w", err)
+		}
+
+		returnData.IsHaveOpeningBalance = true
+	}
+
+	return returnData, nil
+}
+
+func (d *BankParser) balanceAmount(item balance) (returnData money.Amount, err error) {
+	if returnData, err = money.ParseGrouped(strings.TrimSpace(item.Amount.Value), d.decimalPlaces, "", "."); err != nil {
+		return 0, err
+	}
+
+	trxType, err := trxType(item.CdtDbtInd)
+	if err != nil {
+		return 0, err
+	}
+
+	if trxType == banks.DEBIT {
+		returnData = -returnData
+	}
+
+	return returnData, nil
+}
+
+// id is the IBAN of the account, else its other identification
+func (a account) id() string {
+	if iban := strings.TrimSpace(a.IBAN); iban != "" {
+		return iban
+	}
+
+	return strings.TrimSpace(a.Other)
+}
+
+func (s status) code() string {
+	if code := strings.TrimSpace(s.Code); code != "" {
+		return code
+	}
+
+	return strings.TrimSpace(s.Value)
+}
+
+// parse reads the day or the date time, isHaveTime tells it is a date time. A date time is kept on the wall clock of
+// the statement like the dates of the other formats, its time zone is left out
+func (d date) parse() (returnData time.Time, isHaveTime bool, err error) {
+	if dateTime := strings.TrimSpace(d.DateTime); dateTime != "" {
+		if returnData, err = time.Parse(time.RFC3339, dateTime); err == nil {
+			returnData = time.Date(
+				returnData.Year(), returnData.Month(), returnData.Day(),
+				returnData.Hour(), returnData.Minute(), returnData.Second(), returnData.Nanosecond(),
+				time.UTC,
+			)
+		} else {
+			returnData, err = time.Parse("2006-01-02T15:04:05", dateTime)
+		}
+
+		return returnData, err == nil, err
+	}
+
+	returnData, err = time.Parse(time.DateOnly, strings.TrimSpace(d.Date))
+	return returnData, false, err
+}
+
+func trxType(cdtDbtInd string) (banks.TrxType, error) {
+	switch strings.TrimSpace(cdtDbtInd) {
+	case credit:
+		return banks.CREDIT, nil
+	case debit:
+		return banks.DEBIT, nil
+	default:
+		return "", fmt.Errorf("credit debit indicator %q is not valid", cdtDbtInd)
+	}
+}
@@ -0,0 +1,351 @@
+package camt053
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+)
+
+const FileCamt053Path = "/foo/bar.xml"
+
+func TestBankParserGetBank(t *testing.T) {
+	type fields struct {
+		bank string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				bank: "mandiri",
+			},
+			want: "mandiri",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &BankParser{
+				bank: tt.fields.bank,
+			}
+
+			if got := d.GetBank(); got != tt.want {
+				t.Errorf("GetBank() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBankParserGetParser(t *testing.T) {
+	type fields struct {
+		parser banks.BankParserType
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   banks.BankParserType
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				parser: banks.Camt053BankParser,
+			},
+			want: banks.Camt053BankParser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &BankParser{
+				parser: tt.fields.parser,
+			}
+
+			if got := d.GetParser(); got != tt.want {
+				t.Errorf("GetParser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBankParserToBankTrxData(t *testing.T) {
+	tests := []struct {
+		name                  string
+		content               string
+		wantReturnData        []*banks.BankTrxData
+		wantStatementBalances []banks.StatementBalance
+		wantErr               bool
+	}{
+		{
+			name: "Ok - version 2",
+			content: `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <GrpHdr><MsgId>MSG1</MsgId></GrpHdr>
+    <Stmt>
+      <Id>STMT250301</Id>
+      <Acct><Id><IBAN>ID12BMRI1234567890</IBAN></Id><Ccy>IDR</Ccy></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="IDR">1000000.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2025-02-28</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="IDR">978300.50</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2025-03-02</Dt></Dt>
+      </Bal>
+      <Ntry>
+        <Amt Ccy="IDR">20500.50</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2025-03-01</Dt></BookgDt>
+        <ValDt><Dt>2025-03-01</Dt></ValDt>
+        <AcctSvcrRef>BNK0001</AcctSvcrRef>
+        <NtryDtls>
+          <TxDtls>
+            <Refs><EndToEndId>0012d068c53eb097</EndToEndId></Refs>
+            <RltdPties><DbtrAcct><Id><Othr><Id>0987654321</Id></Othr></Id></DbtrAcct></RltdPties>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="IDR">42100</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><DtTm>2025-03-02T08:15:00+07:00</DtTm></BookgDt>
+        <ValDt><Dt>2025-03-01</Dt></ValDt>
+        <NtryDtls>
+          <TxDtls>
+            <Refs><AcctSvcrRef>BNK0002</AcctSvcrRef><EndToEndId>NOTPROVIDED</EndToEndId></Refs>
+            <RltdPties><CdtrAcct><Id><IBAN>ID98BBCA0000000001</IBAN></Id></CdtrAcct></RltdPties>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <NtryRef>BATCH1</NtryRef>
+        <Amt Ccy="IDR">100</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2025-03-02</Dt></BookgDt>
+        <NtryDtls>
+          <TxDtls><Refs><EndToEndId>e2e-1</EndToEndId></Refs></TxDtls>
+          <TxDtls><Refs><EndToEndId>e2e-2</EndToEndId></Refs></TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="IDR">5000</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>PDNG</Sts>
+        <BookgDt><Dt>2025-03-02</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`,
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier:    "BNK0001",
+					Reference:           "0012d068c53eb097",
+					Date:                time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+					ValueDate:           time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+					Type:                banks.CREDIT,
+					Bank:                "mandiri",
+					Account:             "ID12BMRI1234567890",
+					FilePath:            FileCamt053Path,
+					Currency:            "IDR",
+					Amount:              2050050,
+					CounterpartyAccount: "0987654321",
+				},
+				{
+					UniqueIdentifier:    "BNK0002",
+					Date:                time.Date(2025, 3, 2, 8, 15, 0, 0, time.UTC),
+					ValueDate:           time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+					IsHaveTime:          true,
+					Type:                banks.DEBIT,
+					Bank:                "mandiri",
+					Account:             "ID12BMRI1234567890",
+					FilePath:            FileCamt053Path,
+					Currency:            "IDR",
+					Amount:              4210000,
+					CounterpartyAccount: "ID98BBCA0000000001",
+				},
+				{
+					UniqueIdentifier: "BATCH1",
+					Date:             time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC),
+					Type:             banks.DEBIT,
+					Bank:             "mandiri",
+					Account:          "ID12BMRI1234567890",
+					FilePath:         FileCamt053Path,
+					Currency:         "IDR",
+					Amount:           10000,
+				},
+			},
+			wantStatementBalances: []banks.StatementBalance{
+				{
+					Bank:                 "mandiri",
+					Account:              "ID12BMRI1234567890",
+					FilePath:             FileCamt053Path,
+					OpeningBalance:       100000000,
+					ClosingBalance:       97830050,
+					IsHaveOpeningBalance: true,
+					IsHaveClosingBalance: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - version 8 status, previously closed balance, invalid entry skipped",
+			content: `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Id>A</Id>
+      <Acct><Id><Othr><Id>111</Id></Othr></Id></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>PRCD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="USD">5.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+      </Bal>
+      <Ntry>
+        <Amt Ccy="USD">5</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2025-01-02</Dt></BookgDt>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="USD">7</Amt>
+        <CdtDbtInd>X</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2025-01-02</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+    <Stmt>
+      <Id>B</Id>
+      <Acct><Id><Othr><Id>222</Id></Othr></Id></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="IDR">abc</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+      </Bal>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`,
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "A/1",
+					Date:             time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+					Type:             banks.CREDIT,
+					Bank:             "mandiri",
+					Account:          "111",
+					FilePath:         FileCamt053Path,
+					Currency:         "USD",
+					Amount:           500,
+				},
+			},
+			wantStatementBalances: []banks.StatementBalance{
+				{
+					Bank:                 "mandiri",
+					Account:              "111",
+					FilePath:             FileCamt053Path,
+					OpeningBalance:       -500,
+					IsHaveOpeningBalance: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Ok - empty file",
+			content:        ``,
+			wantReturnData: nil,
+			wantErr:        false,
+		},
+		{
+			name:           "Error - not XML",
+			content:        `<Document><BkToCstmrStmt>`,
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _ := NewBankParser("mandiri", bytes.NewBufferString(tt.content), 2)
+			gotReturnData, err := d.ToBankTrxData(context.Background(), FileCamt053Path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToBankTrxData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("ToBankTrxData() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+
+			if gotStatementBalances := d.GetStatementBalances(); !reflect.DeepEqual(gotStatementBalances, tt.wantStatementBalances) {
+				t.Errorf("GetStatementBalances() = %v, want %v", gotStatementBalances, tt.wantStatementBalances)
+			}
+		})
+	}
+}
+
+func TestNewBankParser(t *testing.T) {
+	type args struct {
+		reader        io.Reader
+		bank          string
+		decimalPlaces int
+	}
+
+	tests := []struct {
+		want    *BankParser
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			args: args{
+				bank:          "mandiri",
+				reader:        bytes.NewBufferString(""),
+				decimalPlaces: 2,
+			},
+			want: &BankParser{
+				reader:        bytes.NewBufferString(""),
+				parser:        banks.Camt053BankParser,
+				bank:          "mandiri",
+				decimalPlaces: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error nil reader",
+			args: args{
+				bank:   "mandiri",
+				reader: nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBankParser(tt.args.bank, tt.args.reader, tt.args.decimalPlaces)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewBankParser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewBankParser() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package debit_credit
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/debit_credit/entity"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/helper"
+)
+
+type BankParser struct {
+	csvReader     *csv.Reader
+	parser        banks.BankParserType
+	bank          string
+	locale        locale.Locale
+	isHaveHeader  bool
+	decimalPlaces int
+}
+
+var _ banks.ReconcileBankData = (*BankParser)(nil)
+
+func NewBankParser(
+	bank string,
+	csvReader *csv.Reader,
+	isHaveHeader bool,
+	decimalPlaces int,
+	loc locale.Locale,
+) (*BankParser, error) {
+	if csvReader == nil {
+		return nil, errors.New("csvReader or dataStruct is nil")
+	}
+
+	return &BankParser{
+		parser:        banks.DebitCreditBankParser,
+		bank:          bank,
+		csvReader:     csvReader,
+		isHaveHeader:  isHaveHeader,
+		decimalPlaces: decimalPlaces,
+		locale:        loc,
+	}, nil
+}
+
+func (d *BankParser) GetParser() banks.BankParserType {
+	return d.parser
+}
+
+func (d *BankParser) GetBank() string {
+	return d.bank
+}
+
+func (d *BankParser) ToBankTrxData(ctx context.Context, filePath string) (returnData []*banks.BankTrxData, err error) {
+	return helper.ToBankTrxData(
+		ctx,
+		filePath,
+		d.isHaveHeader,
+		d.bank,
+		d.decimalPlaces,
+		d.locale,
+		d.csvReader,
+		&entity.CSVBankTrxData{},
+	)
+}
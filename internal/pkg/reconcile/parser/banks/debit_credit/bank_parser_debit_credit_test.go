@@ -0,0 +1,233 @@
+package debit_credit
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+)
+
+const FileCSVPath = "/foo/bar.csv"
+
+func TestBankParserGetBank(t *testing.T) {
+	type fields struct {
+		bank string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				bank: string(banks.DebitCreditBankParser),
+			},
+			want: string(banks.DebitCreditBankParser),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &BankParser{
+				bank: tt.fields.bank,
+			}
+
+			if got := d.GetBank(); got != tt.want {
+				t.Errorf("GetBank() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBankParserGetParser(t *testing.T) {
+	type fields struct {
+		parser banks.BankParserType
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   banks.BankParserType
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				parser: banks.DebitCreditBankParser,
+			},
+			want: banks.DebitCreditBankParser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &BankParser{
+				parser: tt.fields.parser,
+			}
+
+			if got := d.GetParser(); got != tt.want {
+				t.Errorf("GetParser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBankParserToBankTrxData(t *testing.T) {
+	layoutTime := "2006-01-02"
+
+	type fields struct {
+		csvReader     *csv.Reader
+		parser        banks.BankParserType
+		bank          string
+		isHaveHeader  bool
+		decimalPlaces int
+	}
+
+	type args struct {
+		filePath string
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantReturnData []*banks.BankTrxData
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`UniqueIdentifier,Date,Debit,Kredit
+0012d068c53eb0971fc8563343c5d81f,2025-03-15,,20500
+005dcbc9e27365a072be5393ea8d0f37,2025-03-14,42100.50,0
+00a1b2c3d4e5f60718293a4b5c6d7e8f,2025-03-14,100,100
+00f1e2d3c4b5a69788796a5b4c3d2e1f,2025-03-14,,`,
+					)
+					return csv.NewReader(f)
+				}(),
+				parser:        banks.DebitCreditBankParser,
+				bank:          "mandiri",
+				isHaveHeader:  true,
+				decimalPlaces: 2,
+			},
+			args: args{
+				filePath: FileCSVPath,
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "0012d068c53eb0971fc8563343c5d81f",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-15")
+						return t
+					}(),
+					Type:     banks.CREDIT,
+					Bank:     "mandiri",
+					FilePath: FileCSVPath,
+					Amount:   2050000,
+				},
+				{
+					UniqueIdentifier: "005dcbc9e27365a072be5393ea8d0f37",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-14")
+						return t
+					}(),
+					Type:     banks.DEBIT,
+					Bank:     "mandiri",
+					FilePath: FileCSVPath,
+					Amount:   4210050,
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &BankParser{
+				csvReader:     tt.fields.csvReader,
+				parser:        tt.fields.parser,
+				bank:          tt.fields.bank,
+				isHaveHeader:  tt.fields.isHaveHeader,
+				decimalPlaces: tt.fields.decimalPlaces,
+			}
+
+			gotReturnData, err := d.ToBankTrxData(context.Background(), tt.args.filePath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToBankTrxData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("ToBankTrxData() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestNewBankParser(t *testing.T) {
+	type args struct {
+		csvReader     *csv.Reader
+		bank          string
+		loc           locale.Locale
+		isHaveHeader  bool
+		decimalPlaces int
+	}
+
+	tests := []struct {
+		want    *BankParser
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			args: args{
+				bank:          string(banks.DebitCreditBankParser),
+				csvReader:     csv.NewReader(nil),
+				isHaveHeader:  false,
+				decimalPlaces: 2,
+				loc:           locale.Locale{Delimiter: ';'},
+			},
+			want: &BankParser{
+				csvReader:     csv.NewReader(nil),
+				parser:        banks.DebitCreditBankParser,
+				bank:          string(banks.DebitCreditBankParser),
+				isHaveHeader:  false,
+				decimalPlaces: 2,
+				locale:        locale.Locale{Delimiter: ';'},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error nil csvReader",
+			args: args{
+				bank:         string(banks.DebitCreditBankParser),
+				csvReader:    nil,
+				isHaveHeader: false,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBankParser(tt.args.bank, tt.args.csvReader, tt.args.isHaveHeader, tt.args.decimalPlaces, tt.args.loc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewBankParser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewBankParser() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/samber/lo"
+)
+
+// CSVBankTrxData is a line of an export stating the debit and the credit in two columns (Mandiri and BRI internet
+// banking), the line is stated in exactly one of them
+type CSVBankTrxData struct {
+	DebitCreditUniqueIdentifier string        `csv:"UniqueIdentifier"`
+	DebitCreditDate             string        `csv:"Date"`
+	DebitCreditBank             string        `csv:"-"`
+	DebitCreditDebit            *money.Amount `csv:"Debit"`
+	DebitCreditCredit           *money.Amount `csv:"Kredit"`
+	DebitCreditReference        string        `csv:"Reference"`
+	// DebitCreditAccount is optional, empty takes the account from the statement path
+	DebitCreditAccount string `csv:"Account"`
+	// DebitCreditBalance is optional, the running balance after the line
+	DebitCreditBalance *money.Amount `csv:"Balance"`
+	// DebitCreditCounterpartyAccount is optional, the account the money came from or went to
+	DebitCreditCounterpartyAccount string `csv:"CounterpartyAccount"`
+}
+
+func (u *CSVBankTrxData) GetUniqueIdentifier() string {
+	return u.DebitCreditUniqueIdentifier
+}
+
+func (u *CSVBankTrxData) GetDate() string {
+	return u.DebitCreditDate
+}
+
+func (u *CSVBankTrxData) GetReference() string {
+	return u.DebitCreditReference
+}
+
+// GetAmount is the credit less the debit
+func (u *CSVBankTrxData) GetAmount() money.Amount {
+	return lo.FromPtr(u.DebitCreditCredit).Abs() - lo.FromPtr(u.DebitCreditDebit).Abs()
+}
+
+func (u *CSVBankTrxData) GetAbsAmount() money.Amount {
+	return u.GetAmount().Abs()
+}
+
+// GetType is the column the line is stated in, empty when it is stated in both or neither
+func (u *CSVBankTrxData) GetType() banks.TrxType {
+	_, trxType, _ := banks.DebitCredit(u.DebitCreditDebit, u.DebitCreditCredit)
+	return trxType
+}
+
+func (u *CSVBankTrxData) GetBank() string {
+	return u.DebitCreditBank
+}
+
+func (u *CSVBankTrxData) ToBankTrxData(loc locale.Locale) (returnData *banks.BankTrxData, err error) {
+	amount, trxType, err := banks.DebitCredit(u.DebitCreditDebit, u.DebitCreditCredit)
+	if err != nil {
+		return nil, err
+	}
+
+	t, isHaveTime, e := loc.ParseDate(u.DebitCreditDate)
+	if e != nil {
+		return nil, e
+	}
+
+	return &banks.BankTrxData{
+		UniqueIdentifier:    u.DebitCreditUniqueIdentifier,
+		Reference:           u.DebitCreditReference,
+		Date:                t,
+		IsHaveTime:          isHaveTime,
+		Type:                trxType,
+		Bank:                u.DebitCreditBank,
+		Account:             strings.TrimSpace(u.DebitCreditAccount),
+		FilePath:            "",
+		Amount:              amount,
+		Balance:             lo.FromPtr(u.DebitCreditBalance),
+		IsHaveBalance:       u.DebitCreditBalance != nil,
+		CounterpartyAccount: strings.TrimSpace(u.DebitCreditCounterpartyAccount),
+	}, nil
+}
@@ -0,0 +1,263 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+)
+
+const UniqueUUID = "610085c5-89d7-470b-8158-b4252a9b429d"
+
+func amount(value money.Amount) *money.Amount {
+	return &value
+}
+
+func TestCSVBankTrxDataGetAmount(t *testing.T) {
+	type fields struct {
+		DebitCreditDebit  *money.Amount
+		DebitCreditCredit *money.Amount
+	}
+
+	tests := []struct {
+		name          string
+		fields        fields
+		want          money.Amount
+		wantAbsAmount money.Amount
+	}{
+		{
+			name: "Ok - debit",
+			fields: fields{
+				DebitCreditDebit: amount(1000),
+			},
+			want:          -1000,
+			wantAbsAmount: 1000,
+		},
+		{
+			name: "Ok - credit",
+			fields: fields{
+				DebitCreditDebit:  amount(0),
+				DebitCreditCredit: amount(1000),
+			},
+			want:          1000,
+			wantAbsAmount: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &CSVBankTrxData{
+				DebitCreditDebit:  tt.fields.DebitCreditDebit,
+				DebitCreditCredit: tt.fields.DebitCreditCredit,
+			}
+
+			if got := u.GetAmount(); got != tt.want {
+				t.Errorf("GetAmount() = %v, want %v", got, tt.want)
+			}
+
+			if got := u.GetAbsAmount(); got != tt.wantAbsAmount {
+				t.Errorf("GetAbsAmount() = %v, want %v", got, tt.wantAbsAmount)
+			}
+		})
+	}
+}
+
+func TestCSVBankTrxDataGetType(t *testing.T) {
+	type fields struct {
+		DebitCreditDebit  *money.Amount
+		DebitCreditCredit *money.Amount
+	}
+
+	tests := []struct {
+		name   string
+		want   banks.TrxType
+		fields fields
+	}{
+		{
+			name: "DEBIT",
+			fields: fields{
+				DebitCreditDebit: amount(1000),
+			},
+			want: banks.DEBIT,
+		},
+		{
+			name: "CREDIT",
+			fields: fields{
+				DebitCreditCredit: amount(1000),
+			},
+			want: banks.CREDIT,
+		},
+		{
+			name: "Both",
+			fields: fields{
+				DebitCreditDebit:  amount(1000),
+				DebitCreditCredit: amount(1000),
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &CSVBankTrxData{
+				DebitCreditDebit:  tt.fields.DebitCreditDebit,
+				DebitCreditCredit: tt.fields.DebitCreditCredit,
+			}
+
+			if got := u.GetType(); got != tt.want {
+				t.Errorf("GetType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVBankTrxDataGetter(t *testing.T) {
+	u := &CSVBankTrxData{
+		DebitCreditUniqueIdentifier: UniqueUUID,
+		DebitCreditDate:             "1999-01-01",
+		DebitCreditBank:             "mandiri",
+		DebitCreditReference:        "006630c83821fac6bea13b92b480feb2",
+	}
+
+	if got := u.GetUniqueIdentifier(); got != UniqueUUID {
+		t.Errorf("GetUniqueIdentifier() = %v, want %v", got, UniqueUUID)
+	}
+
+	if got := u.GetDate(); got != "1999-01-01" {
+		t.Errorf("GetDate() = %v, want %v", got, "1999-01-01")
+	}
+
+	if got := u.GetBank(); got != "mandiri" {
+		t.Errorf("GetBank() = %v, want %v", got, "mandiri")
+	}
+
+	if got := u.GetReference(); got != "006630c83821fac6bea13b92b480feb2" {
+		t.Errorf("GetReference() = %v, want %v", got, "006630c83821fac6bea13b92b480feb2")
+	}
+}
+
+func TestCSVBankTrxDataToBankTrxData(t *testing.T) {
+	type fields struct {
+		DebitCreditDebit               *money.Amount
+		DebitCreditCredit              *money.Amount
+		DebitCreditBalance             *money.Amount
+		DebitCreditUniqueIdentifier    string
+		DebitCreditDate                string
+		DebitCreditBank                string
+		DebitCreditReference           string
+		DebitCreditAccount             string
+		DebitCreditCounterpartyAccount string
+	}
+
+	tests := []struct {
+		wantReturnData *banks.BankTrxData
+		name           string
+		fields         fields
+		wantErr        bool
+	}{
+		{
+			name: "Ok - debit",
+			fields: fields{
+				DebitCreditUniqueIdentifier:    UniqueUUID,
+				DebitCreditDate:                "1999-01-01",
+				DebitCreditBank:                "mandiri",
+				DebitCreditDebit:               amount(1000),
+				DebitCreditCredit:              amount(0),
+				DebitCreditBalance:             amount(5000),
+				DebitCreditReference:           "006630c83821fac6bea13b92b480feb2",
+				DebitCreditAccount:             " 111 ",
+				DebitCreditCounterpartyAccount: "222",
+			},
+			wantReturnData: &banks.BankTrxData{
+				UniqueIdentifier:    UniqueUUID,
+				Reference:           "006630c83821fac6bea13b92b480feb2",
+				Date:                time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC),
+				Type:                banks.DEBIT,
+				Bank:                "mandiri",
+				Account:             "111",
+				Amount:              1000,
+				Balance:             5000,
+				IsHaveBalance:       true,
+				CounterpartyAccount: "222",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - credit with time",
+			fields: fields{
+				DebitCreditUniqueIdentifier: UniqueUUID,
+				DebitCreditDate:             "1999-01-01 10:30:00",
+				DebitCreditBank:             "mandiri",
+				DebitCreditCredit:           amount(1000),
+			},
+			wantReturnData: &banks.BankTrxData{
+				UniqueIdentifier: UniqueUUID,
+				Date:             time.Date(1999, 1, 1, 10, 30, 0, 0, time.UTC),
+				IsHaveTime:       true,
+				Type:             banks.CREDIT,
+				Bank:             "mandiri",
+				Amount:           1000,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error both debit and credit",
+			fields: fields{
+				DebitCreditUniqueIdentifier: UniqueUUID,
+				DebitCreditDate:             "1999-01-01",
+				DebitCreditDebit:            amount(1000),
+				DebitCreditCredit:           amount(1000),
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+		{
+			name: "Error neither debit nor credit",
+			fields: fields{
+				DebitCreditUniqueIdentifier: UniqueUUID,
+				DebitCreditDate:             "1999-01-01",
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+		{
+			name: "Error invalid date",
+			fields: fields{
+				DebitCreditUniqueIdentifier: UniqueUUID,
+				DebitCreditDate:             "any string",
+				DebitCreditCredit:           amount(1000),
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &CSVBankTrxData{
+				DebitCreditUniqueIdentifier:    tt.fields.DebitCreditUniqueIdentifier,
+				DebitCreditDate:                tt.fields.DebitCreditDate,
+				DebitCreditBank:                tt.fields.DebitCreditBank,
+				DebitCreditDebit:               tt.fields.DebitCreditDebit,
+				DebitCreditCredit:              tt.fields.DebitCreditCredit,
+				DebitCreditReference:           tt.fields.DebitCreditReference,
+				DebitCreditAccount:             tt.fields.DebitCreditAccount,
+				DebitCreditBalance:             tt.fields.DebitCreditBalance,
+				DebitCreditCounterpartyAccount: tt.fields.DebitCreditCounterpartyAccount,
+			}
+
+			gotReturnData, err := u.ToBankTrxData(locale.Locale{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToBankTrxData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("ToBankTrxData() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package declarative
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/utils/log"
+)
+
+// Sign convention of the amount column
+const (
+	// SignNegativeDebit a negative (or zero) amount is a debit
+	SignNegativeDebit = "negative_debit"
+	// SignPositiveDebit a positive amount is a debit
+	SignPositiveDebit = "positive_debit"
+)
+
+// Columns are the columns of the statement lines, a column is its name in the header or its position from 1. Empty
+// columns are not in the file, Debit and Credit replace Amount in exports stating them apart
+type Columns struct {
+	UniqueIdentifier    string
+	Date                string
+	Amount              string
+	Debit               string
+	Credit              string
+	Reference           string
+	Account             string
+	Balance             string
+	CounterpartyAccount string
+}
+
+// Format is a bank statement csv export. SkipRows lines come before the first statement line, columns given by name
+// are looked up in the last of them. DateLayout is a Go time layout, empty for the date layouts of the locale.
+// Delimiter takes precedence over the delimiter of the locale
+type Format struct {
+	Columns    Columns
+	Locale     locale.Locale
+	DateLayout string
+	Sign       string
+	Delimiter  rune
+	SkipRows   int
+}
+
+// columnIndex is the position of every column in the record, -1 when the file does not have it
+type columnIndex struct {
+	uniqueIdentifier    int
+	date                int
+	amount              int
+	debit               int
+	credit              int
+	reference           int
+	account             int
+	balance             int
+	counterpartyAccount int
+}
+
+type BankParser struct {
+	csvReader     *csv.Reader
+	parser        banks.BankParserType
+	bank          string
+	format        Format
+	decimalPlaces int
+}
+
+var _ banks.ReconcileBankData = (*BankParser)(nil)
+
+func NewBankParser(
+	bank string,
+	csvReader *csv.Reader,
+	decimalPlaces int,
+	format Format,
+) (*BankParser, error) {
+	if csvReader == nil {
+		return nil, errors.New("csvReader is nil")
+	}
+
+	format.Locale.ApplyDelimiter(csvReader)
+	if format.Delimiter != 0 {
+		csvReader.Comma = format.Delimiter
+	}
+
+	// preamble and footer rows rarely have as many fields as the statement lines
+	csvReader.FieldsPerRecord = -1
+
+	return &BankParser{
+		parser:        banks.DeclarativeBankParser,
+		bank:          bank,
+		format:        format,
+		decimalPlaces: decimalPlaces,
+		csvReader:     csvReader,
+	}, nil
+}
+
+func (d *BankParser) ToBankTrxData(ctx context.Context, filePath string) (returnData []*banks.BankTrxData, err error) {
+	var header []string
+	for i := 0; i < d.format.SkipRows; i++ {
+		if header, err = d.csvReader.Read(); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+
+			return
+		}
+	}
+
+	var index columnIndex
+	if index, err = d.columnIndex(header); err != nil {
+		log.AddErr(ctx, err)
+		return nil, err
+	}
+
+	for {
+		var record []string
+		if record, err = d.csvReader.Read(); err != nil {
+			break
+		}
+
+		if isBlank(record) {
+			continue
+		}
+
+		bankTrxData, e := d.toBankTrxData(record, index)
+		if e != nil {
+			line, _ := d.csvReader.FieldPos(0)
+			log.AddErr(ctx, fmt.Errorf("%s line %d: %w", filePath, line, e))
+			continue
+		}
+
+		bankTrxData.Bank = d.bank
+		bankTrxData.FilePath = filePath
+		returnData = append(returnData, bankTrxData)
+	}
+
+	if err == io.EOF {
+		err = nil
+	}
+
+	return returnData, err
+}
+
+func (d *BankParser) GetParser() banks.BankParserType {
+	return d.parser
+}
+
+func (d *BankParser) GetBank() string {
+	return d.bank
+}
+
+func (d *BankParser) columnIndex(header []string) (returnData columnIndex, err error) {
+	columns := d.format.Columns
+	for _, item := range []struct {
+		index  *int
+		column string
+	}{
+		{&returnData.uniqueIdentifier, columns.UniqueIdentifier},
+		{&returnData.date, columns.Date},
+		{&returnData.amount, columns.Amount},
+		{&returnData.debit, columns.Debit},
+		{&returnData.credit, columns.Credit},
+		{&returnData.reference, columns.Reference},
+		{&returnData.account, columns.Account},
+		{&returnData.balance, columns.Balance},
+		{&returnData.counterpartyAccount, columns.CounterpartyAccount},
+	} {
+		if *item.index, err = findColumn(header, item.column); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (d *BankParser) toBankTrxData(record []string, index columnIndex) (returnData *banks.BankTrxData, err error) {
+	returnData = &banks.BankTrxData{
+		UniqueIdentifier:    field(record, index.uniqueIdentifier),
+		Reference:           field(record, index.reference),
+		Account:             field(record, index.account),
+		CounterpartyAccount: field(record, index.counterpartyAccount),
+	}
+
+	if returnData.UniqueIdentifier == "" {
+		return nil, errors.New("unique identifier is empty")
+	}
+
+	if returnData.Date, returnData.IsHaveTime, err = d.parseDate(field(record, index.date)); err != nil {
+		return nil, err
+	}
+
+	if returnData.Amount, returnData.Type, err = d.amount(record, index); err != nil {
+		return nil, err
+	}
+
+	if balance := field(record, index.balance); balance != "" {
+		if returnData.Balance, err = d.format.Locale.ParseAmount(balance, d.decimalPlaces); err != nil {
+			return nil, err
+		}
+
+		returnData.IsHaveBalance = true
+	}
+
+	return returnData, nil
+}
+
+// amount is without sign. Exports stating the debit and the credit apart state the line in exactly one of them
+func (d *BankParser) amount(record []string, index columnIndex) (returnData money.Amount, trxType banks.TrxType, err error) {
+	if index.amount < 0 {
+		var debit, credit *money.Amount
+		if debit, err = d.optionalAmount(field(record, index.debit)); err != nil {
+			return
+		}
+
+		if credit, err = d.optionalAmount(field(record, index.credit)); err != nil {
+			return
+		}
+
+		return banks.DebitCredit(debit, credit)
+	}
+
+	if returnData, err = d.format.Locale.ParseAmount(field(record, index.amount), d.decimalPlaces); err != nil {
+		return 0, "", err
+	}
+
+	if d.format.Sign == SignPositiveDebit {
+		returnData = -returnData
+	}
+
+	trxType = banks.CREDIT
+	if returnData <= 0 {
+		trxType = banks.DEBIT
+	}
+
+	return returnData.Abs(), trxType, nil
+}
+
+// optionalAmount is nil for an empty value
+func (d *BankParser) optionalAmount(value string) (*money.Amount, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	amount, err := d.format.Locale.ParseAmount(value, d.decimalPlaces)
+	return &amount, err
+}
+
+func (d *BankParser) parseDate(value string) (returnData time.Time, isHaveTime bool, err error) {
+	loc := d.format.Locale
+	if d.format.DateLayout != "" {
+		loc.DateLayouts = []string{d.format.DateLayout}
+	}
+
+	return loc.ParseDate(value)
+}
+
+// findColumn returns the position of the column, by position from 1 or by name in the header, -1 for no column
+func findColumn(header []string, column string) (int, error) {
+	column = strings.TrimSpace(column)
+	if column == "" {
+		return -1, nil
+	}
+
+	if position, err := strconv.Atoi(column); err == nil {
+		if position < 1 {
+			return 0, fmt.Errorf("column position %d should be at least 1", position)
+		}
+
+		return position - 1, nil
+	}
+
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), column) {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("column %q not found in header %q", column, header)
+}
+
+// field is the trimmed value of the column, empty when the record is shorter
+func field(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[index])
+}
+
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
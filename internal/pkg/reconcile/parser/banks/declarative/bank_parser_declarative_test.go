@@ -0,0 +1,382 @@
+package declarative
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+)
+
+const FileCSVPath = "/foo/bar.csv"
+
+func TestBankParserGetBank(t *testing.T) {
+	type fields struct {
+		bank string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				bank: "mandiri",
+			},
+			want: "mandiri",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &BankParser{
+				bank: tt.fields.bank,
+			}
+
+			if got := d.GetBank(); got != tt.want {
+				t.Errorf("GetBank() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBankParserGetParser(t *testing.T) {
+	type fields struct {
+		parser banks.BankParserType
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   banks.BankParserType
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				parser: banks.DeclarativeBankParser,
+			},
+			want: banks.DeclarativeBankParser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &BankParser{
+				parser: tt.fields.parser,
+			}
+
+			if got := d.GetParser(); got != tt.want {
+				t.Errorf("GetParser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBankParserToBankTrxData(t *testing.T) {
+	type args struct {
+		format  Format
+		content string
+	}
+
+	tests := []struct {
+		name           string
+		args           args
+		wantReturnData []*banks.BankTrxData
+		wantErr        bool
+	}{
+		{
+			name: "Ok - columns by name after preamble",
+			args: args{
+				format: Format{
+					Columns: Columns{
+						UniqueIdentifier: "No Ref",
+						Date:             "Tanggal",
+						Amount:           "Mutasi",
+						Reference:        "Keterangan",
+						Balance:          "Saldo",
+					},
+					DateLayout: "02/01/2006",
+					Sign:       SignNegativeDebit,
+					Delimiter:  ';',
+					SkipRows:   3,
+				},
+				content: `Rekening;1234567890
+Periode;01/03/2025 - 31/03/2025
+Tanggal;No Ref;Keterangan;Mutasi;Saldo
+15/03/2025;r1;TRF 0012d068;20500;120500
+;;;;
+14/03/2025;r2;;-42100.50;78399.50
+Saldo Akhir;;;;78399.50`,
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "r1",
+					Reference:        "TRF 0012d068",
+					Date:             time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC),
+					Type:             banks.CREDIT,
+					Bank:             "mandiri",
+					FilePath:         FileCSVPath,
+					Amount:           2050000,
+					Balance:          12050000,
+					IsHaveBalance:    true,
+				},
+				{
+					UniqueIdentifier: "r2",
+					Date:             time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+					Type:             banks.DEBIT,
+					Bank:             "mandiri",
+					FilePath:         FileCSVPath,
+					Amount:           4210050,
+					Balance:          7839950,
+					IsHaveBalance:    true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - columns by position without header, positive debit",
+			args: args{
+				format: Format{
+					Columns: Columns{
+						UniqueIdentifier:    "2",
+						Date:                "1",
+						Amount:              "3",
+						Account:             "4",
+						CounterpartyAccount: "5",
+					},
+					DateLayout: "2006-01-02 15:04",
+					Sign:       SignPositiveDebit,
+				},
+				content: `2025-03-15 10:30,r1,20500,111,222
+2025-03-14 08:00,r2,-100`,
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier:    "r1",
+					Date:                time.Date(2025, 3, 15, 10, 30, 0, 0, time.UTC),
+					IsHaveTime:          true,
+					Type:                banks.DEBIT,
+					Bank:                "mandiri",
+					Account:             "111",
+					CounterpartyAccount: "222",
+					FilePath:            FileCSVPath,
+					Amount:              2050000,
+				},
+				{
+					UniqueIdentifier: "r2",
+					Date:             time.Date(2025, 3, 14, 8, 0, 0, 0, time.UTC),
+					IsHaveTime:       true,
+					Type:             banks.CREDIT,
+					Bank:             "mandiri",
+					FilePath:         FileCSVPath,
+					Amount:           10000,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - debit and credit columns",
+			args: args{
+				format: Format{
+					Columns: Columns{
+						UniqueIdentifier: "ID",
+						Date:             "Date",
+						Debit:            "Debit",
+						Credit:           "Credit",
+					},
+					SkipRows: 1,
+				},
+				content: `ID,Date,Debit,Credit
+r1,2025-03-15,,20500
+r2,2025-03-14,42100.50,0.00
+r3,2025-03-14,,
+r4,2025-03-14,100,100`,
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "r1",
+					Date:             time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC),
+					Type:             banks.CREDIT,
+					Bank:             "mandiri",
+					FilePath:         FileCSVPath,
+					Amount:           2050000,
+				},
+				{
+					UniqueIdentifier: "r2",
+					Date:             time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+					Type:             banks.DEBIT,
+					Bank:             "mandiri",
+					FilePath:         FileCSVPath,
+					Amount:           4210050,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - locale amounts and dates",
+			args: args{
+				format: Format{
+					Columns: Columns{
+						UniqueIdentifier: "ID",
+						Date:             "Tanggal",
+						Amount:           "Mutasi",
+						Balance:          "Saldo",
+					},
+					Locale: locale.Locale{
+						DecimalSeparator:   ",",
+						ThousandsSeparator: ".",
+						MonthNames:         locale.MonthNamesIndonesian,
+						DateLayouts:        []string{"2 Jan 2006"},
+						Delimiter:          ';',
+					},
+					SkipRows: 1,
+				},
+				content: `ID;Tanggal;Mutasi;Saldo
+r1;17 Agu 2025;-1.234.567,89;10.000,00`,
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "r1",
+					Date:             time.Date(2025, 8, 17, 0, 0, 0, 0, time.UTC),
+					Type:             banks.DEBIT,
+					Bank:             "mandiri",
+					FilePath:         FileCSVPath,
+					Amount:           123456789,
+					Balance:          1000000,
+					IsHaveBalance:    true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - empty file",
+			args: args{
+				format: Format{
+					Columns: Columns{
+						UniqueIdentifier: "ID",
+						Date:             "Date",
+						Amount:           "Amount",
+					},
+					SkipRows: 1,
+				},
+				content: ``,
+			},
+			wantReturnData: nil,
+			wantErr:        false,
+		},
+		{
+			name: "Error - column not found",
+			args: args{
+				format: Format{
+					Columns: Columns{
+						UniqueIdentifier: "ID",
+						Date:             "Date",
+						Amount:           "Nominal",
+					},
+					SkipRows: 1,
+				},
+				content: `ID,Date,Amount
+r1,2025-03-15,20500`,
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+		{
+			name: "Error - column by name without header",
+			args: args{
+				format: Format{
+					Columns: Columns{
+						UniqueIdentifier: "ID",
+						Date:             "2",
+						Amount:           "3",
+					},
+				},
+				content: `r1,2025-03-15,20500`,
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _ := NewBankParser("mandiri", csv.NewReader(bytes.NewBufferString(tt.args.content)), 2, tt.args.format)
+			gotReturnData, err := d.ToBankTrxData(context.Background(), FileCSVPath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToBankTrxData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("ToBankTrxData() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestNewBankParser(t *testing.T) {
+	type args struct {
+		csvReader     *csv.Reader
+		bank          string
+		format        Format
+		decimalPlaces int
+	}
+
+	tests := []struct {
+		want    *BankParser
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			args: args{
+				bank:          "mandiri",
+				csvReader:     csv.NewReader(nil),
+				format:        Format{Delimiter: ';'},
+				decimalPlaces: 2,
+			},
+			want: &BankParser{
+				csvReader: func() *csv.Reader {
+					r := csv.NewReader(nil)
+					r.Comma = ';'
+					r.FieldsPerRecord = -1
+					return r
+				}(),
+				parser:        banks.DeclarativeBankParser,
+				bank:          "mandiri",
+				format:        Format{Delimiter: ';'},
+				decimalPlaces: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error nil csvReader",
+			args: args{
+				bank:      "mandiri",
+				csvReader: nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBankParser(tt.args.bank, tt.args.csvReader, tt.args.decimalPlaces, tt.args.format)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewBankParser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewBankParser() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
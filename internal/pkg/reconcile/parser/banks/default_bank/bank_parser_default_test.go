@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
 )
 
@@ -81,10 +82,11 @@ func TestBankParserToBankTrxData(t *testing.T) {
 	layoutTime := "2006-01-02"
 
 	type fields struct {
-		csvReader    *csv.Reader
-		parser       banks.BankParserType
-		bank         string
-		isHaveHeader bool
+		csvReader     *csv.Reader
+		parser        banks.BankParserType
+		bank          string
+		isHaveHeader  bool
+		decimalPlaces int
 	}
 
 	type args struct {
@@ -105,13 +107,14 @@ func TestBankParserToBankTrxData(t *testing.T) {
 					f := bytes.NewBufferString(
 						`UniqueIdentifier,Date,Amount
 0012d068c53eb0971fc8563343c5d81f,2025-03-15,20500
-005dcbc9e27365a072be5393ea8d0f37,2025-03-14,-42100`,
+005dcbc9e27365a072be5393ea8d0f37,2025-03-14,-42100.50`,
 					)
 					return csv.NewReader(f)
 				}(),
-				parser:       banks.DefaultBankParser,
-				bank:         string(banks.DefaultBankParser),
-				isHaveHeader: true,
+				parser:        banks.DefaultBankParser,
+				bank:          string(banks.DefaultBankParser),
+				isHaveHeader:  true,
+				decimalPlaces: 2,
 			},
 			args: args{
 				filePath: FileCSVPath,
@@ -126,7 +129,7 @@ func TestBankParserToBankTrxData(t *testing.T) {
 					Type:     banks.CREDIT,
 					Bank:     string(banks.DefaultBankParser),
 					FilePath: FileCSVPath,
-					Amount:   20500,
+					Amount:   2050000,
 				},
 				{
 					UniqueIdentifier: "005dcbc9e27365a072be5393ea8d0f37",
@@ -137,7 +140,7 @@ func TestBankParserToBankTrxData(t *testing.T) {
 					Type:     banks.DEBIT,
 					Bank:     string(banks.DefaultBankParser),
 					FilePath: FileCSVPath,
-					Amount:   42100,
+					Amount:   4210050,
 				},
 			},
 			wantErr: false,
@@ -147,10 +150,11 @@ func TestBankParserToBankTrxData(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := &BankParser{
-				csvReader:    tt.fields.csvReader,
-				parser:       tt.fields.parser,
-				bank:         tt.fields.bank,
-				isHaveHeader: tt.fields.isHaveHeader,
+				csvReader:     tt.fields.csvReader,
+				parser:        tt.fields.parser,
+				bank:          tt.fields.bank,
+				isHaveHeader:  tt.fields.isHaveHeader,
+				decimalPlaces: tt.fields.decimalPlaces,
 			}
 
 			gotReturnData, err := d.ToBankTrxData(context.Background(), tt.args.filePath)
@@ -168,9 +172,11 @@ func TestBankParserToBankTrxData(t *testing.T) {
 
 func TestNewBankParser(t *testing.T) {
 	type args struct {
-		csvReader    *csv.Reader
-		bank         string
-		isHaveHeader bool
+		csvReader     *csv.Reader
+		bank          string
+		loc           locale.Locale
+		isHaveHeader  bool
+		decimalPlaces int
 	}
 
 	tests := []struct {
@@ -182,15 +188,19 @@ func TestNewBankParser(t *testing.T) {
 		{
 			name: "Ok",
 			args: args{
-				bank:         string(banks.DefaultBankParser),
-				csvReader:    csv.NewReader(nil),
-				isHaveHeader: false,
+				bank:          string(banks.DefaultBankParser),
+				csvReader:     csv.NewReader(nil),
+				isHaveHeader:  false,
+				decimalPlaces: 2,
+				loc:           locale.Locale{Delimiter: ';'},
 			},
 			want: &BankParser{
-				csvReader:    csv.NewReader(nil),
-				parser:       banks.DefaultBankParser,
-				bank:         string(banks.DefaultBankParser),
-				isHaveHeader: false,
+				csvReader:     csv.NewReader(nil),
+				parser:        banks.DefaultBankParser,
+				bank:          string(banks.DefaultBankParser),
+				isHaveHeader:  false,
+				decimalPlaces: 2,
+				locale:        locale.Locale{Delimiter: ';'},
 			},
 			wantErr: false,
 		},
@@ -208,7 +218,7 @@ func TestNewBankParser(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := NewBankParser(tt.args.bank, tt.args.csvReader, tt.args.isHaveHeader)
+			got, err := NewBankParser(tt.args.bank, tt.args.csvReader, tt.args.isHaveHeader, tt.args.decimalPlaces, tt.args.loc)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewBankParser() error = %v, wantErr %v", err, tt.wantErr)
 				return
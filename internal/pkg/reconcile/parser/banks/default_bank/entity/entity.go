@@ -1,17 +1,26 @@
 package entity
 
 import (
-	"math"
-	"time"
+	"strings"
 
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/samber/lo"
 )
 
 type CSVBankTrxData struct {
-	DefaultUniqueIdentifier string  `csv:"UniqueIdentifier"`
-	DefaultDate             string  `csv:"Date"`
-	DefaultBank             string  `csv:"-"`
-	DefaultAmount           float64 `csv:"Amount"`
+	DefaultUniqueIdentifier string       `csv:"UniqueIdentifier"`
+	DefaultDate             string       `csv:"Date"`
+	DefaultBank             string       `csv:"-"`
+	DefaultAmount           money.Amount `csv:"Amount"`
+	DefaultReference        string       `csv:"Reference"`
+	// DefaultAccount is optional, empty takes the account from the statement path
+	DefaultAccount string `csv:"Account"`
+	// DefaultBalance is optional, the running balance after the line
+	DefaultBalance *money.Amount `csv:"Balance"`
+	// DefaultCounterpartyAccount is optional, the account the money came from or went to
+	DefaultCounterpartyAccount string `csv:"CounterpartyAccount"`
 }
 
 func (u *CSVBankTrxData) GetUniqueIdentifier() string {
@@ -22,12 +31,16 @@ func (u *CSVBankTrxData) GetDate() string {
 	return u.DefaultDate
 }
 
-func (u *CSVBankTrxData) GetAmount() float64 {
+func (u *CSVBankTrxData) GetReference() string {
+	return u.DefaultReference
+}
+
+func (u *CSVBankTrxData) GetAmount() money.Amount {
 	return u.DefaultAmount
 }
 
-func (u *CSVBankTrxData) GetAbsAmount() float64 {
-	return math.Abs(u.DefaultAmount)
+func (u *CSVBankTrxData) GetAbsAmount() money.Amount {
+	return u.DefaultAmount.Abs()
 }
 
 func (u *CSVBankTrxData) GetType() banks.TrxType {
@@ -42,18 +55,24 @@ func (u *CSVBankTrxData) GetBank() string {
 	return u.DefaultBank
 }
 
-func (u *CSVBankTrxData) ToBankTrxData() (returnData *banks.BankTrxData, err error) {
-	t, e := time.Parse("2006-01-02", u.DefaultDate)
+func (u *CSVBankTrxData) ToBankTrxData(loc locale.Locale) (returnData *banks.BankTrxData, err error) {
+	t, isHaveTime, e := loc.ParseDate(u.DefaultDate)
 	if e != nil {
 		return nil, e
 	}
 
 	return &banks.BankTrxData{
-		UniqueIdentifier: u.DefaultUniqueIdentifier,
-		Date:             t,
-		Type:             u.GetType(),
-		Bank:             u.DefaultBank,
-		FilePath:         "",
-		Amount:           u.GetAbsAmount(),
+		UniqueIdentifier:    u.DefaultUniqueIdentifier,
+		Reference:           u.DefaultReference,
+		Date:                t,
+		IsHaveTime:          isHaveTime,
+		Type:                u.GetType(),
+		Bank:                u.DefaultBank,
+		Account:             strings.TrimSpace(u.DefaultAccount),
+		FilePath:            "",
+		Amount:              u.GetAbsAmount(),
+		Balance:             lo.FromPtr(u.DefaultBalance),
+		IsHaveBalance:       u.DefaultBalance != nil,
+		CounterpartyAccount: strings.TrimSpace(u.DefaultCounterpartyAccount),
 	}, nil
 }
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
 )
 
@@ -12,13 +14,13 @@ const UniqueUUID = "610085c5-89d7-470b-8158-b4252a9b429d"
 
 func TestCSVBankTrxDataGetAbsAmount(t *testing.T) {
 	type fields struct {
-		DefaultAmount float64
+		DefaultAmount money.Amount
 	}
 
 	tests := []struct {
 		name   string
 		fields fields
-		want   float64
+		want   money.Amount
 	}{
 		{
 			name: "Ok - positive",
@@ -51,13 +53,13 @@ func TestCSVBankTrxDataGetAbsAmount(t *testing.T) {
 
 func TestCSVBankTrxDataGetAmount(t *testing.T) {
 	type fields struct {
-		DefaultAmount float64
+		DefaultAmount money.Amount
 	}
 
 	tests := []struct {
 		name   string
 		fields fields
-		want   float64
+		want   money.Amount
 	}{
 		{
 			name: "Ok",
@@ -144,9 +146,48 @@ func TestCSVBankTrxDataGetDate(t *testing.T) {
 	}
 }
 
+func TestCSVBankTrxDataGetReference(t *testing.T) {
+	type fields struct {
+		DefaultReference string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				DefaultReference: "006630c83821fac6bea13b92b480feb2",
+			},
+			want: "006630c83821fac6bea13b92b480feb2",
+		},
+		{
+			name: "Ok - no reference",
+			fields: fields{
+				DefaultReference: "",
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &CSVBankTrxData{
+				DefaultReference: tt.fields.DefaultReference,
+			}
+
+			if got := u.GetReference(); got != tt.want {
+				t.Errorf("GetReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCSVBankTrxDataGetType(t *testing.T) {
 	type fields struct {
-		DefaultAmount float64
+		DefaultAmount money.Amount
 	}
 
 	tests := []struct {
@@ -220,7 +261,8 @@ func TestCSVBankTrxDataToBankTrxData(t *testing.T) {
 		DefaultUniqueIdentifier string
 		DefaultDate             string
 		DefaultBank             string
-		DefaultAmount           float64
+		DefaultAmount           money.Amount
+		DefaultReference        string
 	}
 
 	tests := []struct {
@@ -236,9 +278,11 @@ func TestCSVBankTrxDataToBankTrxData(t *testing.T) {
 				DefaultDate:             "1999-01-01",
 				DefaultBank:             "danamon",
 				DefaultAmount:           1000,
+				DefaultReference:        "006630c83821fac6bea13b92b480feb2",
 			},
 			wantReturnData: &banks.BankTrxData{
 				UniqueIdentifier: UniqueUUID,
+				Reference:        "006630c83821fac6bea13b92b480feb2",
 				Date: func() time.Time {
 					t, _ := time.Parse("2006-01-02", "1999-01-01")
 					return t
@@ -250,6 +294,30 @@ func TestCSVBankTrxDataToBankTrxData(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Ok - with time",
+			fields: fields{
+				DefaultUniqueIdentifier: UniqueUUID,
+				DefaultDate:             "1999-01-01 10:30:00",
+				DefaultBank:             "danamon",
+				DefaultAmount:           1000,
+				DefaultReference:        "006630c83821fac6bea13b92b480feb2",
+			},
+			wantReturnData: &banks.BankTrxData{
+				UniqueIdentifier: UniqueUUID,
+				Reference:        "006630c83821fac6bea13b92b480feb2",
+				Date: func() time.Time {
+					t, _ := time.Parse("2006-01-02 15:04:05", "1999-01-01 10:30:00")
+					return t
+				}(),
+				IsHaveTime: true,
+				Type:       banks.CREDIT,
+				Bank:       "danamon",
+				FilePath:   "",
+				Amount:     1000,
+			},
+			wantErr: false,
+		},
 		{
 			name: "Error invalid date",
 			fields: fields{
@@ -270,9 +338,10 @@ func TestCSVBankTrxDataToBankTrxData(t *testing.T) {
 				DefaultDate:             tt.fields.DefaultDate,
 				DefaultBank:             tt.fields.DefaultBank,
 				DefaultAmount:           tt.fields.DefaultAmount,
+				DefaultReference:        tt.fields.DefaultReference,
 			}
 
-			gotReturnData, err := u.ToBankTrxData()
+			gotReturnData, err := u.ToBankTrxData(locale.Locale{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ToBankTrxData() error = %v, wantErr %v", err, tt.wantErr)
 				return
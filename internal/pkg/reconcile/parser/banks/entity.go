@@ -1,13 +1,23 @@
 package banks
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+)
 
 type BankParserType string
 
 const (
-	DefaultBankParser BankParserType = "DEFAULT"
-	BCABankParser     BankParserType = "BCA"
-	BNIBankParser     BankParserType = "BNI"
+	DefaultBankParser     BankParserType = "DEFAULT"
+	BCABankParser         BankParserType = "BCA"
+	BNIBankParser         BankParserType = "BNI"
+	DebitCreditBankParser BankParserType = "DEBIT_CREDIT"
+	DeclarativeBankParser BankParserType = "DECLARATIVE"
+	MT940BankParser       BankParserType = "MT940"
+	Camt053BankParser     BankParserType = "CAMT053"
 )
 
 type TrxType string
@@ -17,11 +27,60 @@ const (
 	CREDIT TrxType = "CREDIT"
 )
 
+// BankTrxData Amount is in the base currency, OriginalAmount is the amount of the statement in Currency.
+// IsMissingFXRate tells no rate converts Currency to the base currency, Amount is then left unconverted. IsHaveTime
+// tells whether the statement states the time of Date or only the day. Account is the account of the statement
+// within Bank, empty when the bank has one account. Balance is the running balance of the account after the line
+// when IsHaveBalance. CounterpartyAccount is the account the money came from or went to, when the statement states it.
+// Date is the day the line is booked, ValueDate the day the money is valued when the statement states it apart
 type BankTrxData struct {
-	UniqueIdentifier string
-	Date             time.Time
-	Type             TrxType
-	Bank             string
-	FilePath         string
-	Amount           float64
+	UniqueIdentifier    string
+	Reference           string
+	Date                time.Time
+	ValueDate           time.Time
+	IsHaveTime          bool
+	Type                TrxType
+	Bank                string
+	Account             string
+	FilePath            string
+	Currency            string
+	Amount              money.Amount
+	OriginalAmount      money.Amount
+	IsMissingFXRate     bool
+	Balance             money.Amount
+	IsHaveBalance       bool
+	CounterpartyAccount string
+}
+
+// StatementBalance is the opening and the closing balance a statement file states for an account, apart from the
+// running balance of its lines
+type StatementBalance struct {
+	Bank                 string
+	Account              string
+	FilePath             string
+	OpeningBalance       money.Amount
+	ClosingBalance       money.Amount
+	IsHaveOpeningBalance bool
+	IsHaveClosingBalance bool
+}
+
+// ParseDate parses the date of a bank statement, a day optionally followed by a time
+func ParseDate(value string) (returnData time.Time, isHaveTime bool, err error) {
+	return locale.Locale{}.ParseDate(value)
+}
+
+// DebitCredit reads a line of an export stating the debit and the credit in two columns, the line should be stated in
+// exactly one of them. A column is not stated when empty or zero, the amount is returned without sign
+func DebitCredit(debit *money.Amount, credit *money.Amount) (amount money.Amount, trxType TrxType, err error) {
+	isHaveDebit, isHaveCredit := debit != nil && *debit != 0, credit != nil && *credit != 0
+	switch {
+	case isHaveDebit && isHaveCredit:
+		return 0, "", errors.New("both debit and credit are stated")
+	case isHaveDebit:
+		return debit.Abs(), DEBIT, nil
+	case isHaveCredit:
+		return credit.Abs(), CREDIT, nil
+	default:
+		return 0, "", errors.New("neither debit nor credit is stated")
+	}
 }
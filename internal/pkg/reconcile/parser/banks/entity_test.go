@@ -0,0 +1,117 @@
+package banks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		want           time.Time
+		name           string
+		value          string
+		wantIsHaveTime bool
+		wantErr        bool
+	}{
+		{
+			name:           "Ok - day",
+			value:          "2025-03-01",
+			want:           time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			wantIsHaveTime: false,
+			wantErr:        false,
+		},
+		{
+			name:           "Ok - day and time",
+			value:          "2025-03-01 10:30:15",
+			want:           time.Date(2025, 3, 1, 10, 30, 15, 0, time.UTC),
+			wantIsHaveTime: true,
+			wantErr:        false,
+		},
+		{
+			name:    "Error - invalid date",
+			value:   "01/03/2025",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotIsHaveTime, err := ParseDate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !got.Equal(tt.want) || gotIsHaveTime != tt.wantIsHaveTime {
+				t.Errorf("ParseDate() = %v, %v, want %v, %v", got, gotIsHaveTime, tt.want, tt.wantIsHaveTime)
+			}
+		})
+	}
+}
+
+func TestDebitCredit(t *testing.T) {
+	amount := func(value money.Amount) *money.Amount {
+		return &value
+	}
+
+	tests := []struct {
+		debit       *money.Amount
+		credit      *money.Amount
+		name        string
+		wantTrxType TrxType
+		wantAmount  money.Amount
+		wantErr     bool
+	}{
+		{
+			name:        "Ok - debit",
+			debit:       amount(42100),
+			wantAmount:  42100,
+			wantTrxType: DEBIT,
+		},
+		{
+			name:        "Ok - negative debit",
+			debit:       amount(-42100),
+			credit:      amount(0),
+			wantAmount:  42100,
+			wantTrxType: DEBIT,
+		},
+		{
+			name:        "Ok - credit",
+			debit:       amount(0),
+			credit:      amount(20500),
+			wantAmount:  20500,
+			wantTrxType: CREDIT,
+		},
+		{
+			name:    "Error - both",
+			debit:   amount(100),
+			credit:  amount(100),
+			wantErr: true,
+		},
+		{
+			name:    "Error - neither",
+			debit:   amount(0),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAmount, gotTrxType, err := DebitCredit(tt.debit, tt.credit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DebitCredit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if gotAmount != tt.wantAmount || gotTrxType != tt.wantTrxType {
+				t.Errorf("DebitCredit() = %v, %v, want %v, %v", gotAmount, gotTrxType, tt.wantAmount, tt.wantTrxType)
+			}
+		})
+	}
+}
@@ -3,11 +3,13 @@ package helper
 import (
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"sync"
 
 	"github.com/jszwec/csvutil"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
 	"github.com/oprekable/bank-reconcile/internal/pkg/utils/log"
 )
@@ -18,7 +20,7 @@ var poolBankTrxData = &sync.Pool{
 	},
 }
 
-func ToBankTrxData(ctx context.Context, filePath string, isHaveHeader bool, bank string, csvReader *csv.Reader, originalData banks.BankTrxDataInterface) (returnData []*banks.BankTrxData, err error) {
+func ToBankTrxData(ctx context.Context, filePath string, isHaveHeader bool, bank string, decimalPlaces int, loc locale.Locale, csvReader *csv.Reader, originalData banks.BankTrxDataInterface) (returnData []*banks.BankTrxData, err error) {
 	var dec *csvutil.Decoder
 	defer func() {
 		if r := recover(); r != nil {
@@ -28,6 +30,7 @@ func ToBankTrxData(ctx context.Context, filePath string, isHaveHeader bool, bank
 		}
 	}()
 
+	loc.ApplyDelimiter(csvReader)
 	if isHaveHeader {
 		dec, err = csvutil.NewDecoder(csvReader)
 		if err != nil || dec == nil {
@@ -41,22 +44,35 @@ func ToBankTrxData(ctx context.Context, filePath string, isHaveHeader bool, bank
 			log.AddErr(ctx, err)
 			return nil, err
 		}
+
+		// trailing optional columns (the reference) may be left out of files without header
+		dec.AlignRecord = true
 	}
 
+	dec.WithUnmarshalers(loc.CSVUnmarshalers(decimalPlaces))
+
 	for {
 		err = dec.Decode(originalData)
 		if err != nil {
-			break
+			// a row failing to decode is logged and skipped, the reader goes on with the next row
+			line, isRowErr := rowLine(csvReader, err)
+			if !isRowErr {
+				break
+			}
+
+			log.AddErr(ctx, fmt.Errorf("%s line %d: %w", filePath, line, err))
+			continue
 		}
 
 		//nolint:all
 		//lint:ignore SA4006 sync.pool pattern just like this
 		bankTrxData := poolBankTrxData.Get().(*banks.BankTrxData)
-		bankTrxData, err = originalData.ToBankTrxData()
+		bankTrxData, err = originalData.ToBankTrxData(loc)
 		poolBankTrxData.Put(bankTrxData)
 
 		if err != nil {
-			log.AddErr(ctx, err)
+			line, _ := csvReader.FieldPos(0)
+			log.AddErr(ctx, fmt.Errorf("%s line %d: %w", filePath, line, err))
 			continue
 		}
 
@@ -72,3 +88,20 @@ func ToBankTrxData(ctx context.Context, filePath string, isHaveHeader bool, bank
 
 	return returnData, err
 }
+
+// rowLine returns the line of the row a decode error is about, isRowErr is false when the error is not about one row
+// (the end of the file or a failing reader)
+func rowLine(csvReader *csv.Reader, err error) (line int, isRowErr bool) {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Line, true
+	}
+
+	var decodeErr *csvutil.DecodeError
+	if errors.As(err, &decodeErr) || errors.Is(err, csvutil.ErrFieldCount) {
+		line, _ = csvReader.FieldPos(0)
+		return line, true
+	}
+
+	return 0, false
+}
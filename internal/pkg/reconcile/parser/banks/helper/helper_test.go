@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks/default_bank/entity"
 )
@@ -20,11 +21,13 @@ func TestToBankTrxData(t *testing.T) {
 	layoutTime := "2006-01-02 15:04:05"
 
 	type args struct {
-		originalData banks.BankTrxDataInterface
-		csvReader    *csv.Reader
-		filePath     string
-		bank         string
-		isHaveHeader bool
+		originalData  banks.BankTrxDataInterface
+		csvReader     *csv.Reader
+		filePath      string
+		bank          string
+		loc           locale.Locale
+		isHaveHeader  bool
+		decimalPlaces int
 	}
 
 	tests := []struct {
@@ -75,6 +78,337 @@ func TestToBankTrxData(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Ok with header and reference",
+			args: args{
+				filePath:     FileCSVPath,
+				isHaveHeader: true,
+				bank:         "danamon",
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`UniqueIdentifier,Date,Amount,Reference
+0012d068c53eb0971fc8563343c5d81f,2025-03-15,20500,006630c83821fac6bea13b92b480feb2
+005dcbc9e27365a072be5393ea8d0f37,2025-03-14,-42100,`,
+					)
+					return csv.NewReader(f)
+				}(),
+				originalData: &entity.CSVBankTrxData{},
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "0012d068c53eb0971fc8563343c5d81f",
+					Reference:        "006630c83821fac6bea13b92b480feb2",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-15 00:00:00")
+						return t
+					}(),
+					Type:     "CREDIT",
+					Bank:     "danamon",
+					FilePath: FileCSVPath,
+					Amount:   20500,
+				},
+				{
+					UniqueIdentifier: "005dcbc9e27365a072be5393ea8d0f37",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-14 00:00:00")
+						return t
+					}(),
+					Type:     "DEBIT",
+					Bank:     "danamon",
+					FilePath: FileCSVPath,
+					Amount:   42100,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok with header and account",
+			args: args{
+				filePath:     FileCSVPath,
+				isHaveHeader: true,
+				bank:         "danamon",
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`UniqueIdentifier,Date,Amount,Reference,Account
+0012d068c53eb0971fc8563343c5d81f,2025-03-15,20500,,1234567890
+005dcbc9e27365a072be5393ea8d0f37,2025-03-14,-42100,,`,
+					)
+					return csv.NewReader(f)
+				}(),
+				originalData: &entity.CSVBankTrxData{},
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "0012d068c53eb0971fc8563343c5d81f",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-15 00:00:00")
+						return t
+					}(),
+					Type:     "CREDIT",
+					Bank:     "danamon",
+					Account:  "1234567890",
+					FilePath: FileCSVPath,
+					Amount:   20500,
+				},
+				{
+					UniqueIdentifier: "005dcbc9e27365a072be5393ea8d0f37",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-14 00:00:00")
+						return t
+					}(),
+					Type:     "DEBIT",
+					Bank:     "danamon",
+					FilePath: FileCSVPath,
+					Amount:   42100,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok with header and balance",
+			args: args{
+				filePath:     FileCSVPath,
+				isHaveHeader: true,
+				bank:         "danamon",
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`UniqueIdentifier,Date,Amount,Reference,Account,Balance
+0012d068c53eb0971fc8563343c5d81f,2025-03-15,20500,,,120500
+005dcbc9e27365a072be5393ea8d0f37,2025-03-14,-42100,,,`,
+					)
+					return csv.NewReader(f)
+				}(),
+				originalData: &entity.CSVBankTrxData{},
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "0012d068c53eb0971fc8563343c5d81f",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-15 00:00:00")
+						return t
+					}(),
+					Type:          "CREDIT",
+					Bank:          "danamon",
+					FilePath:      FileCSVPath,
+					Amount:        20500,
+					Balance:       120500,
+					IsHaveBalance: true,
+				},
+				{
+					UniqueIdentifier: "005dcbc9e27365a072be5393ea8d0f37",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-14 00:00:00")
+						return t
+					}(),
+					Type:     "DEBIT",
+					Bank:     "danamon",
+					FilePath: FileCSVPath,
+					Amount:   42100,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok with header and counterparty account",
+			args: args{
+				filePath:     FileCSVPath,
+				isHaveHeader: true,
+				bank:         "danamon",
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`UniqueIdentifier,Date,Amount,Reference,Account,Balance,CounterpartyAccount
+0012d068c53eb0971fc8563343c5d81f,2025-03-15,-20500,,,, 0987654321 
+005dcbc9e27365a072be5393ea8d0f37,2025-03-14,42100,,,,`,
+					)
+					return csv.NewReader(f)
+				}(),
+				originalData: &entity.CSVBankTrxData{},
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "0012d068c53eb0971fc8563343c5d81f",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-15 00:00:00")
+						return t
+					}(),
+					Type:                "DEBIT",
+					Bank:                "danamon",
+					FilePath:            FileCSVPath,
+					Amount:              20500,
+					CounterpartyAccount: "0987654321",
+				},
+				{
+					UniqueIdentifier: "005dcbc9e27365a072be5393ea8d0f37",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-14 00:00:00")
+						return t
+					}(),
+					Type:     "CREDIT",
+					Bank:     "danamon",
+					FilePath: FileCSVPath,
+					Amount:   42100,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok with header and decimals",
+			args: args{
+				filePath:      FileCSVPath,
+				isHaveHeader:  true,
+				bank:          "danamon",
+				decimalPlaces: 2,
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`UniqueIdentifier,Date,Amount
+0012d068c53eb0971fc8563343c5d81f,2025-03-15,20500.5
+005dcbc9e27365a072be5393ea8d0f37,2025-03-14,-42100`,
+					)
+					return csv.NewReader(f)
+				}(),
+				originalData: &entity.CSVBankTrxData{},
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "0012d068c53eb0971fc8563343c5d81f",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-15 00:00:00")
+						return t
+					}(),
+					Type:     "CREDIT",
+					Bank:     "danamon",
+					FilePath: FileCSVPath,
+					Amount:   2050050,
+				},
+				{
+					UniqueIdentifier: "005dcbc9e27365a072be5393ea8d0f37",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-14 00:00:00")
+						return t
+					}(),
+					Type:     "DEBIT",
+					Bank:     "danamon",
+					FilePath: FileCSVPath,
+					Amount:   4210000,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok with header and locale",
+			args: args{
+				filePath:      FileCSVPath,
+				isHaveHeader:  true,
+				bank:          "danamon",
+				decimalPlaces: 2,
+				loc: locale.Locale{
+					DecimalSeparator:   ",",
+					ThousandsSeparator: ".",
+					MonthNames:         locale.MonthNamesIndonesian,
+					DateLayouts:        []string{"02/01/2006", "2 Jan 2006"},
+					Delimiter:          ';',
+				},
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`UniqueIdentifier;Date;Amount
+0012d068c53eb0971fc8563343c5d81f;15/03/2025;20.500,5
+005dcbc9e27365a072be5393ea8d0f37;17 Agu 2025;-1.042.100`,
+					)
+					return csv.NewReader(f)
+				}(),
+				originalData: &entity.CSVBankTrxData{},
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "0012d068c53eb0971fc8563343c5d81f",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-15 00:00:00")
+						return t
+					}(),
+					Type:     "CREDIT",
+					Bank:     "danamon",
+					FilePath: FileCSVPath,
+					Amount:   2050050,
+				},
+				{
+					UniqueIdentifier: "005dcbc9e27365a072be5393ea8d0f37",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-08-17 00:00:00")
+						return t
+					}(),
+					Type:     "DEBIT",
+					Bank:     "danamon",
+					FilePath: FileCSVPath,
+					Amount:   104210000,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok amount with more decimals than currency skipped",
+			args: args{
+				filePath:      FileCSVPath,
+				isHaveHeader:  true,
+				bank:          "danamon",
+				decimalPlaces: 0,
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`UniqueIdentifier,Date,Amount
+0012d068c53eb0971fc8563343c5d81f,2025-03-15,20500.5
+005dcbc9e27365a072be5393ea8d0f37,2025-03-14,-42100`,
+					)
+					return csv.NewReader(f)
+				}(),
+				originalData: &entity.CSVBankTrxData{},
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "005dcbc9e27365a072be5393ea8d0f37",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-14 00:00:00")
+						return t
+					}(),
+					Type:     "DEBIT",
+					Bank:     "danamon",
+					FilePath: FileCSVPath,
+					Amount:   42100,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok invalid rows skipped",
+			args: args{
+				filePath:     FileCSVPath,
+				isHaveHeader: true,
+				bank:         "danamon",
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`UniqueIdentifier,Date,Amount
+0012d068c53eb0971fc8563343c5d81f,2025-03-15,abc
+0012d068c53eb0971fc8563343c5d81e,2025-03-15
+0012d068c53eb0971fc8563343c5d81d,2025-03-15,1"0
+005dcbc9e27365a072be5393ea8d0f37,2025-03-14,-42100`,
+					)
+					return csv.NewReader(f)
+				}(),
+				originalData: &entity.CSVBankTrxData{},
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "005dcbc9e27365a072be5393ea8d0f37",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-14 00:00:00")
+						return t
+					}(),
+					Type:     "DEBIT",
+					Bank:     "danamon",
+					FilePath: FileCSVPath,
+					Amount:   42100,
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Error decode with header",
 			args: args{
@@ -151,6 +485,48 @@ func TestToBankTrxData(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Ok without header and reference",
+			args: args{
+				filePath:     FileCSVPath,
+				isHaveHeader: false,
+				bank:         "danamon",
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`0012d068c53eb0971fc8563343c5d81f,2025-03-15,20500,006630c83821fac6bea13b92b480feb2
+005dcbc9e27365a072be5393ea8d0f37,2025-03-14,-42100,`,
+					)
+					return csv.NewReader(f)
+				}(),
+				originalData: &entity.CSVBankTrxData{},
+			},
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "0012d068c53eb0971fc8563343c5d81f",
+					Reference:        "006630c83821fac6bea13b92b480feb2",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-15 00:00:00")
+						return t
+					}(),
+					Type:     "CREDIT",
+					Bank:     "danamon",
+					FilePath: FileCSVPath,
+					Amount:   20500,
+				},
+				{
+					UniqueIdentifier: "005dcbc9e27365a072be5393ea8d0f37",
+					Date: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-14 00:00:00")
+						return t
+					}(),
+					Type:     "DEBIT",
+					Bank:     "danamon",
+					FilePath: FileCSVPath,
+					Amount:   42100,
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Error nil csvReader without header",
 			args: args{
@@ -201,7 +577,7 @@ func TestToBankTrxData(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotReturnData, err := ToBankTrxData(context.Background(), tt.args.filePath, tt.args.isHaveHeader, tt.args.bank, tt.args.csvReader, tt.args.originalData)
+			gotReturnData, err := ToBankTrxData(context.Background(), tt.args.filePath, tt.args.isHaveHeader, tt.args.bank, tt.args.decimalPlaces, tt.args.loc, tt.args.csvReader, tt.args.originalData)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ToBankTrxData() error = %v, wantErr %v", err, tt.wantErr)
@@ -1,6 +1,11 @@
 package banks
 
-import "context"
+import (
+	"context"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+)
 
 //go:generate mockery --name "ReconcileBankData" --output "./_mock" --outpkg "_mock"
 type ReconcileBankData interface {
@@ -9,13 +14,22 @@ type ReconcileBankData interface {
 	ToBankTrxData(ctx context.Context, filePath string) (returnData []*BankTrxData, err error)
 }
 
+// StatementBalanceData is implemented by parsers of formats stating the balances of their statements (like MT940 and
+// camt.053), they are known once ToBankTrxData has read the file
+//
+//go:generate mockery --name "StatementBalanceData" --output "./_mock" --outpkg "_mock"
+type StatementBalanceData interface {
+	GetStatementBalances() []StatementBalance
+}
+
 //go:generate mockery --name "BankTrxDataInterface" --output "./_mock" --outpkg "_mock"
 type BankTrxDataInterface interface {
 	GetUniqueIdentifier() string
 	GetDate() string
-	GetAmount() float64
-	GetAbsAmount() float64
+	GetReference() string
+	GetAmount() money.Amount
+	GetAbsAmount() money.Amount
 	GetType() TrxType
 	GetBank() string
-	ToBankTrxData() (returnData *BankTrxData, err error)
+	ToBankTrxData(loc locale.Locale) (returnData *BankTrxData, err error)
 }
@@ -0,0 +1,297 @@
+package mt940
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/utils/log"
+)
+
+// noReference is the reference for the account owner of lines without one
+const noReference = "NONREF"
+
+var (
+	regexField = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+	// regexStatementLine value date, optional entry date, debit or credit mark, optional funds code, amount,
+	// transaction type then the reference for the account owner with the optional one of the bank after //
+	regexStatementLine = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+(?:,\d*)?)([NSF][A-Z0-9]{3})(.*)$`)
+	// regexBalance debit or credit mark, date, currency and amount of an opening or closing balance
+	regexBalance = regexp.MustCompile(`^([CD])(\d{6})([A-Z]{3})(\d+(?:,\d*)?)$`)
+)
+
+// field is a tag of the statement with its content, line is the line of the file the field starts at
+type field struct {
+	tag     string
+	content string
+	line    int
+}
+
+// statement is what the statement lines take from the fields before them, isHaveBalance tells whether the last of the
+// statement balances is the one of this statement
+type statement struct {
+	reference     string
+	number        string
+	account       string
+	currency      string
+	totalLine     int
+	isHaveBalance bool
+}
+
+type BankParser struct {
+	reader            io.Reader
+	parser            banks.BankParserType
+	bank              string
+	statementBalances []banks.StatementBalance
+	decimalPlaces     int
+}
+
+var _ banks.ReconcileBankData = (*BankParser)(nil)
+var _ banks.StatementBalanceData = (*BankParser)(nil)
+
+func NewBankParser(
+	bank string,
+	reader io.Reader,
+	decimalPlaces int,
+) (*BankParser, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+
+	return &BankParser{
+		parser:        banks.MT940BankParser,
+		bank:          bank,
+		decimalPlaces: decimalPlaces,
+		reader:        reader,
+	}, nil
+}
+
+// ToBankTrxData reads the :61: statement lines of every statement in the file. The :86: information of a line is its
+// reference when the line has no reference for the account owner, lines and balances failing to parse are logged and
+// skipped. The opening (:60F:, :60M:) and closing (:62F:, :62M:) balances are kept as the statement balances
+func (d *BankParser) ToBankTrxData(ctx context.Context, filePath string) (returnData []*banks.BankTrxData, err error) {
+	var fields []field
+	if fields, err = readFields(d.reader); err != nil {
+		log.AddErr(ctx, err)
+		return nil, err
+	}
+
+	var current statement
+	var previous *banks.BankTrxData
+	for _, f := range fields {
+		switch f.tag {
+		case "20":
+			current = statement{reference: strings.TrimSpace(f.content)}
+		case "25":
+			current.account = account(f.content)
+		case "28C":
+			current.number = strings.TrimSpace(f.content)
+		case "60F", "60M":
+			currency, amount, e := d.balance(f.content)
+			if e != nil {
+				log.AddErr(ctx, fmt.Errorf("%s line %d: %w", filePath, f.line, e))
+				break
+			}
+
+			current.currency = currency
+			current.isHaveBalance = true
+			d.statementBalances = append(d.statementBalances, banks.StatementBalance{
+				Account:              current.account,
+				OpeningBalance:       amount,
+				IsHaveOpeningBalance: true,
+			})
+		case "62F", "62M":
+			_, amount, e := d.balance(f.content)
+			if e != nil {
+				log.AddErr(ctx, fmt.Errorf("%s line %d: %w", filePath, f.line, e))
+				break
+			}
+
+			if !current.isHaveBalance {
+				current.isHaveBalance = true
+				d.statementBalances = append(d.statementBalances, banks.StatementBalance{Account: current.account})
+			}
+
+			d.statementBalances[len(d.statementBalances)-1].ClosingBalance = amount
+			d.statementBalances[len(d.statementBalances)-1].IsHaveClosingBalance = true
+		case "61":
+			current.totalLine++
+			bankTrxData, e := d.toBankTrxData(f.content, current)
+			if e != nil {
+				log.AddErr(ctx, fmt.Errorf("%s line %d: %w", filePath, f.line, e))
+				previous = nil
+				continue
+			}
+
+			bankTrxData.Bank = d.bank
+			bankTrxData.FilePath = filePath
+			returnData = append(returnData, bankTrxData)
+			previous = bankTrxData
+			continue
+		case "86":
+			if previous != nil && previous.Reference == "" {
+				previous.Reference = strings.Join(strings.Fields(f.content), " ")
+			}
+		}
+
+		previous = nil
+	}
+
+	for index := range d.statementBalances {
+		d.statementBalances[index].Bank = d.bank
+		d.statementBalances[index].FilePath = filePath
+	}
+
+	return returnData, nil
+}
+
+func (d *BankParser) GetParser() banks.BankParserType {
+	return d.parser
+}
+
+func (d *BankParser) GetBank() string {
+	return d.bank
+}
+
+func (d *BankParser) GetStatementBalances() []banks.StatementBalance {
+	return d.statementBalances
+}
+
+// balance reads an opening or closing balance, a debit balance is negative
+func (d *BankParser) balance(content string) (currency string, amount money.Amount, err error) {
+	match := regexBalance.FindStringSubmatch(strings.TrimSpace(content))
+	if match == nil {
+		return "", 0, fmt.Errorf("balance %q is not valid", content)
+	}
+
+	if amount, err = money.ParseGrouped(match[4], d.decimalPlaces, "", ","); err != nil {
+		return "", 0, err
+	}
+
+	if match[1] == "D" {
+		amount = -amount
+	}
+
+	return match[3], amount, nil
+}
+
+// toBankTrxData reads a :61: statement line, the supplementary details on its second line are left out. The unique
+// identifier is the reference of the bank, else the one of the account owner, else the line number in the statement
+func (d *BankParser) toBankTrxData(content string, current statement) (returnData *banks.BankTrxData, err error) {
+	firstLine, _, _ := strings.Cut(content, "\n")
+	match := regexStatementLine.FindStringSubmatch(strings.TrimSpace(firstLine))
+	if match == nil {
+		return nil, fmt.Errorf("statement line %q is not valid", firstLine)
+	}
+
+	returnData = &banks.BankTrxData{
+		Account:  current.account,
+		Currency: current.currency,
+	}
+
+	if returnData.ValueDate, err = time.Parse("060102", match[1]); err != nil {
+		return nil, err
+	}
+
+	returnData.Date = returnData.ValueDate
+	if match[2] != "" {
+		if returnData.Date, err = entryDate(returnData.ValueDate, match[2]); err != nil {
+			return nil, err
+		}
+	}
+
+	returnData.Type = banks.CREDIT
+	if match[3] == "D" || match[3] == "RC" {
+		returnData.Type = banks.DEBIT
+	}
+
+	if returnData.Amount, err = money.ParseGrouped(match[5], d.decimalPlaces, "", ","); err != nil {
+		return nil, err
+	}
+
+	ownerReference, bankReference, _ := strings.Cut(match[7], "//")
+	ownerReference, bankReference = strings.TrimSpace(ownerReference), strings.TrimSpace(bankReference)
+	if !strings.EqualFold(ownerReference, noReference) {
+		returnData.Reference = ownerReference
+	}
+
+	returnData.UniqueIdentifier = bankReference
+	if returnData.UniqueIdentifier == "" {
+		returnData.UniqueIdentifier = returnData.Reference
+	}
+
+	if returnData.UniqueIdentifier == "" {
+		returnData.UniqueIdentifier = fmt.Sprintf("%s/%s/%d", current.reference, current.number, current.totalLine)
+	}
+
+	return returnData, nil
+}
+
+// readFields splits the file in fields, a line not starting with a tag continues the field before. The SWIFT blocks
+// around the text block are left out
+func readFields(reader io.Reader) (returnData []field, err error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+
+	current := -1
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if _, text, ok := strings.Cut(line, "{4:"); ok {
+			line = text
+		}
+
+		// end of the text block or of a statement, or a block header
+		if line == "-" || strings.HasPrefix(line, "-}") || strings.HasPrefix(line, "{") {
+			current = -1
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if match := regexField.FindStringSubmatch(line); match != nil {
+			returnData = append(returnData, field{tag: match[1], content: match[2], line: lineNumber})
+			current = len(returnData) - 1
+			continue
+		}
+
+		if current >= 0 {
+			returnData[current].content += "\n" + line
+		}
+	}
+
+	return returnData, scanner.Err()
+}
+
+// account is the account number of the account identification, it may follow the bank code
+func account(identification string) string {
+	identification = strings.TrimSpace(identification)
+	return identification[strings.LastIndex(identification, "/")+1:]
+}
+
+// entryDate is the day of the month and month of the entry date in the year closest to the value date, the entry
+// may be booked in the year before or after across the turn of the year
+func entryDate(valueDate time.Time, monthDay string) (time.Time, error) {
+	t, err := time.Parse("0102", monthDay)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	returnData := time.Date(valueDate.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch difference := returnData.Sub(valueDate); {
+	case difference > 183*24*time.Hour:
+		returnData = returnData.AddDate(-1, 0, 0)
+	case difference < -183*24*time.Hour:
+		returnData = returnData.AddDate(1, 0, 0)
+	}
+
+	return returnData, nil
+}
@@ -0,0 +1,321 @@
+package mt940
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+)
+
+const FileMT940Path = "/foo/bar.sta"
+
+func TestBankParserGetBank(t *testing.T) {
+	type fields struct {
+		bank string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				bank: "mandiri",
+			},
+			want: "mandiri",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &BankParser{
+				bank: tt.fields.bank,
+			}
+
+			if got := d.GetBank(); got != tt.want {
+				t.Errorf("GetBank() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBankParserGetParser(t *testing.T) {
+	type fields struct {
+		parser banks.BankParserType
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   banks.BankParserType
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				parser: banks.MT940BankParser,
+			},
+			want: banks.MT940BankParser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &BankParser{
+				parser: tt.fields.parser,
+			}
+
+			if got := d.GetParser(); got != tt.want {
+				t.Errorf("GetParser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBankParserToBankTrxData(t *testing.T) {
+	tests := []struct {
+		name                  string
+		content               string
+		wantReturnData        []*banks.BankTrxData
+		wantStatementBalances []banks.StatementBalance
+		wantErr               bool
+	}{
+		{
+			name: "Ok - SWIFT blocks",
+			content: "{1:F01BMRIIDJAXXXX0000000000}{2:O9401200250301BMRIIDJAXXXX00000000002503011200N}{4:\r\n" +
+				":20:STMT250301\r\n" +
+				":25:BMRIIDJA/1234567890\r\n" +
+				":28C:59/1\r\n" +
+				":60F:C250228IDR1000000,00\r\n" +
+				":61:2503010301C20500,50NTRF0012d068c53eb097//BNK0001\r\n" +
+				"SUPPLEMENTARY DETAILS\r\n" +
+				":86:TRANSFER FROM\r\n" +
+				" PT FOO\r\n" +
+				":61:2503010302D42100,NCHGNONREF//BNK0002\r\n" +
+				":86:ADMIN FEE\r\n" +
+				":61:250302RC100,NTRFNONREF\r\n" +
+				":62F:C250302IDR978300,50\r\n" +
+				":86:STATEMENT INFORMATION\r\n" +
+				"-}{5:{CHK:0123456789AB}}\r\n",
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "BNK0001",
+					Reference:        "0012d068c53eb097",
+					Date:             time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+					ValueDate:        time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+					Type:             banks.CREDIT,
+					Bank:             "mandiri",
+					Account:          "1234567890",
+					FilePath:         FileMT940Path,
+					Currency:         "IDR",
+					Amount:           2050050,
+				},
+				{
+					UniqueIdentifier: "BNK0002",
+					Reference:        "ADMIN FEE",
+					Date:             time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC),
+					ValueDate:        time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+					Type:             banks.DEBIT,
+					Bank:             "mandiri",
+					Account:          "1234567890",
+					FilePath:         FileMT940Path,
+					Currency:         "IDR",
+					Amount:           4210000,
+				},
+				{
+					UniqueIdentifier: "STMT250301/59/1/3",
+					Date:             time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC),
+					ValueDate:        time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC),
+					Type:             banks.DEBIT,
+					Bank:             "mandiri",
+					Account:          "1234567890",
+					FilePath:         FileMT940Path,
+					Currency:         "IDR",
+					Amount:           10000,
+				},
+			},
+			wantStatementBalances: []banks.StatementBalance{
+				{
+					Bank:                 "mandiri",
+					Account:              "1234567890",
+					FilePath:             FileMT940Path,
+					OpeningBalance:       100000000,
+					ClosingBalance:       97830050,
+					IsHaveOpeningBalance: true,
+					IsHaveClosingBalance: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - statements across the turn of the year, invalid line skipped",
+			content: `:20:A
+:25:111
+:28C:1
+:60F:C241231USD0,
+:61:2412310102X5,NMSCREF1
+:61:2412310102RD5,NMSCREF2
+:62F:C250102USD5,
+-
+:20:B
+:25:222
+:28C:1
+:60M:D250101IDR0,
+:61:2501011231C7,NTRFREF3
+:62M:C250101IDR7,`,
+			wantReturnData: []*banks.BankTrxData{
+				{
+					UniqueIdentifier: "REF2",
+					Reference:        "REF2",
+					Date:             time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+					ValueDate:        time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+					Type:             banks.CREDIT,
+					Bank:             "mandiri",
+					Account:          "111",
+					FilePath:         FileMT940Path,
+					Currency:         "USD",
+					Amount:           500,
+				},
+				{
+					UniqueIdentifier: "REF3",
+					Reference:        "REF3",
+					Date:             time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+					ValueDate:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					Type:             banks.CREDIT,
+					Bank:             "mandiri",
+					Account:          "222",
+					FilePath:         FileMT940Path,
+					Currency:         "IDR",
+					Amount:           700,
+				},
+			},
+			wantStatementBalances: []banks.StatementBalance{
+				{
+					Bank:                 "mandiri",
+					Account:              "111",
+					FilePath:             FileMT940Path,
+					ClosingBalance:       500,
+					IsHaveOpeningBalance: true,
+					IsHaveClosingBalance: true,
+				},
+				{
+					Bank:                 "mandiri",
+					Account:              "222",
+					FilePath:             FileMT940Path,
+					ClosingBalance:       700,
+					IsHaveOpeningBalance: true,
+					IsHaveClosingBalance: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok - amount with more decimals than currency and invalid opening balance skipped",
+			content: `:20:A
+:25:111
+:60F:C250228IDRX
+:61:250301C1,005NTRFREF1
+:62F:D250301IDR1,`,
+			wantReturnData: nil,
+			wantStatementBalances: []banks.StatementBalance{
+				{
+					Bank:                 "mandiri",
+					Account:              "111",
+					FilePath:             FileMT940Path,
+					ClosingBalance:       -100,
+					IsHaveClosingBalance: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Ok - empty file",
+			content:        ``,
+			wantReturnData: nil,
+			wantErr:        false,
+		},
+		{
+			name:           "Error - line too long",
+			content:        ":20:" + string(bytes.Repeat([]byte("A"), 2*1024*1024)),
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _ := NewBankParser("mandiri", bytes.NewBufferString(tt.content), 2)
+			gotReturnData, err := d.ToBankTrxData(context.Background(), FileMT940Path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToBankTrxData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("ToBankTrxData() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+
+			if gotStatementBalances := d.GetStatementBalances(); !reflect.DeepEqual(gotStatementBalances, tt.wantStatementBalances) {
+				t.Errorf("GetStatementBalances() = %v, want %v", gotStatementBalances, tt.wantStatementBalances)
+			}
+		})
+	}
+}
+
+func TestNewBankParser(t *testing.T) {
+	type args struct {
+		reader        io.Reader
+		bank          string
+		decimalPlaces int
+	}
+
+	tests := []struct {
+		want    *BankParser
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			args: args{
+				bank:          "mandiri",
+				reader:        bytes.NewBufferString(""),
+				decimalPlaces: 2,
+			},
+			want: &BankParser{
+				reader:        bytes.NewBufferString(""),
+				parser:        banks.MT940BankParser,
+				bank:          "mandiri",
+				decimalPlaces: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error nil reader",
+			args: args{
+				bank:   "mandiri",
+				reader: nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBankParser(tt.args.bank, tt.args.reader, tt.args.decimalPlaces)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewBankParser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewBankParser() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,13 +1,18 @@
 package banks
 
 import (
-	"encoding/csv"
+	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 )
 
+// sniffSize is how much of the start of a file is looked at to tell its format
+const sniffSize = 512
+
 // BankParserFactory defines the signature for a function that creates a new bank parser.
-type BankParserFactory func(bankName string, reader *csv.Reader, hasHeader bool) (ReconcileBankData, error)
+// The reader is the whole statement file, parsers of csv formats wrap it in a csv reader.
+type BankParserFactory func(bankName string, reader io.Reader, hasHeader bool, decimalPlaces int) (ReconcileBankData, error)
 
 // ParserRegistry holds the collection of available bank parser factories.
 // It is managed by the dependency injection container.
@@ -20,8 +25,18 @@ func NewParserRegistry(factories map[string]BankParserFactory) *ParserRegistry {
 	return &ParserRegistry{factories: factories}
 }
 
-// GetParser retrieves a parser instance from the registry.
-func (r *ParserRegistry) GetParser(bankName string, fileReader io.Reader, hasHeader bool) (ReconcileBankData, error) {
+// GetParser retrieves a parser instance from the registry, amounts are read in minor units of decimalPlaces.
+// A file in a statement format told from its content (like MT940 or camt.053) is read by the parser of that format whatever the
+// bank, other files by the parser of the bank.
+func (r *ParserRegistry) GetParser(bankName string, fileReader io.Reader, hasHeader bool, decimalPlaces int) (ReconcileBankData, error) {
+	reader := bufio.NewReader(fileReader)
+	head, _ := reader.Peek(sniffSize)
+	if parser := DetectParser(head); parser != "" {
+		if factory, ok := r.factories[string(parser)]; ok {
+			return factory(bankName, reader, hasHeader, decimalPlaces)
+		}
+	}
+
 	factory, ok := r.factories[bankName]
 	if !ok {
 		// Fallback to a default parser if the specific one is not found
@@ -29,7 +44,25 @@ func (r *ParserRegistry) GetParser(bankName string, fileReader io.Reader, hasHea
 		if !defaultOk {
 			return nil, fmt.Errorf("bank parser for '%s' not found and no default parser is registered", bankName)
 		}
-		return defaultFactory(bankName, csv.NewReader(fileReader), hasHeader)
+		return defaultFactory(bankName, reader, hasHeader, decimalPlaces)
 	}
-	return factory(bankName, csv.NewReader(fileReader), hasHeader)
+	return factory(bankName, reader, hasHeader, decimalPlaces)
+}
+
+// DetectParser tells the parser of a statement format recognised from the start of the file, empty when it is none
+// of them (csv)
+func DetectParser(head []byte) BankParserType {
+	head = bytes.TrimLeft(bytes.TrimPrefix(head, []byte("\xef\xbb\xbf")), " \t\r\n")
+
+	// MT940 starts with the SWIFT basic header block or with the transaction reference number field
+	if bytes.HasPrefix(head, []byte("{1:")) || bytes.HasPrefix(head, []byte(":20:")) {
+		return MT940BankParser
+	}
+
+	// camt.053 is an XML document of the ISO 20022 bank to customer statement message
+	if bytes.HasPrefix(head, []byte("<")) && (bytes.Contains(head, []byte("camt.053")) || bytes.Contains(head, []byte("<BkToCstmrStmt"))) {
+		return Camt053BankParser
+	}
+
+	return ""
 }
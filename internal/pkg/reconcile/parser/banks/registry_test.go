@@ -2,8 +2,8 @@ package banks
 
 import (
 	"context"
-	"encoding/csv"
 	"errors"
+	"io"
 	"strings"
 	"testing"
 
@@ -36,12 +36,17 @@ func TestParserRegistry(t *testing.T) {
 	// 1. Setup: Create mock factories locally for the test.
 	factories := make(map[string]BankParserFactory)
 
-	factories["MOCK_BCA"] = func(bankName string, reader *csv.Reader, hasHeader bool) (ReconcileBankData, error) {
+	factories["MOCK_BCA"] = func(bankName string, reader io.Reader, hasHeader bool, decimalPlaces int) (ReconcileBankData, error) {
 		return &mockParser{bankName: bankName, parserType: "MOCK_BCA_PARSER"}, nil
 	}
-	factories["DEFAULT"] = func(bankName string, reader *csv.Reader, hasHeader bool) (ReconcileBankData, error) {
+	factories["DEFAULT"] = func(bankName string, reader io.Reader, hasHeader bool, decimalPlaces int) (ReconcileBankData, error) {
 		return &mockParser{bankName: bankName, parserType: DefaultBankParser}, nil
 	}
+	factories[string(MT940BankParser)] = func(bankName string, reader io.Reader, hasHeader bool, decimalPlaces int) (ReconcileBankData, error) {
+		// the sniffed start of the file should still be read by the parser
+		head, _ := io.ReadAll(reader)
+		return &mockParser{bankName: bankName, parserType: BankParserType(head[:4])}, nil
+	}
 
 	registry := NewParserRegistry(factories)
 
@@ -49,7 +54,7 @@ func TestParserRegistry(t *testing.T) {
 	dummyReader := strings.NewReader("")
 
 	t.Run("should get a specific parser for a registered bank", func(t *testing.T) {
-		parser, err := registry.GetParser("MOCK_BCA", dummyReader, true)
+		parser, err := registry.GetParser("MOCK_BCA", dummyReader, true, 2)
 		assert.NoError(t, err)
 		assert.NotNil(t, parser)
 		assert.Equal(t, "MOCK_BCA", parser.GetBank())
@@ -57,21 +62,81 @@ func TestParserRegistry(t *testing.T) {
 	})
 
 	t.Run("should fall back to default parser for an unregistered bank", func(t *testing.T) {
-		parser, err := registry.GetParser("UNKNOWN_BANK", dummyReader, true)
+		parser, err := registry.GetParser("UNKNOWN_BANK", dummyReader, true, 2)
 		assert.NoError(t, err)
 		assert.NotNil(t, parser)
 		assert.Equal(t, "UNKNOWN_BANK", parser.GetBank())
 		assert.Equal(t, DefaultBankParser, parser.GetParser())
 	})
 
+	t.Run("should get the parser of the format told from the content whatever the bank", func(t *testing.T) {
+		parser, err := registry.GetParser("MOCK_BCA", strings.NewReader(":20:STMT\n:25:123"), true, 2)
+		assert.NoError(t, err)
+		assert.NotNil(t, parser)
+		assert.Equal(t, "MOCK_BCA", parser.GetBank())
+		assert.Equal(t, BankParserType(":20:"), parser.GetParser())
+	})
+
 	t.Run("should return an error if no parser is found and no default is registered", func(t *testing.T) {
 		// Setup for this specific case: Create a registry without a default parser.
 		emptyFactories := make(map[string]BankParserFactory)
 		emptyRegistry := NewParserRegistry(emptyFactories)
 
-		parser, err := emptyRegistry.GetParser("ANYBANK", dummyReader, true)
+		parser, err := emptyRegistry.GetParser("ANYBANK", dummyReader, true, 2)
 		assert.Error(t, err)
 		assert.Nil(t, parser)
 		assert.Contains(t, err.Error(), "not found and no default parser is registered")
 	})
 }
+
+func TestDetectParser(t *testing.T) {
+	tests := []struct {
+		name string
+		head string
+		want BankParserType
+	}{
+		{
+			name: "MT940 with SWIFT blocks",
+			head: "{1:F01BMRIIDJAXXXX0000000000}{2:O940}{4:\r\n:20:STMT",
+			want: MT940BankParser,
+		},
+		{
+			name: "MT940 text block with byte order mark",
+			head: "\xef\xbb\xbf\r\n:20:STMT\r\n:25:123",
+			want: MT940BankParser,
+		},
+		{
+			name: "camt.053 with namespace",
+			head: "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<Document xmlns=\"urn:iso:std:iso:20022:tech:xsd:camt.053.001.02\">",
+			want: Camt053BankParser,
+		},
+		{
+			name: "camt.053 without namespace",
+			head: "<Document><BkToCstmrStmt><GrpHdr>",
+			want: Camt053BankParser,
+		},
+		{
+			name: "other XML",
+			head: "<?xml version=\"1.0\"?>\n<Document xmlns=\"urn:iso:std:iso:20022:tech:xsd:pain.001.001.03\">",
+			want: "",
+		},
+		{
+			name: "csv",
+			head: "UniqueIdentifier,Date,Amount",
+			want: "",
+		},
+		{
+			name: "empty",
+			head: "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectParser([]byte(tt.head)); got != tt.want {
+				t.Errorf("DetectParser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,13 +1,20 @@
 package parser
 
 import (
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/balance"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems"
 )
 
+// TrxData BankStatementBalances is the balance check of every bank statement file per account, on all its lines
+// including the ones outside the date range
 type TrxData struct {
-	SystemTrx       []*systems.SystemTrxData
-	BankTrx         []*banks.BankTrxData
-	MinSystemAmount float64
-	MaxSystemAmount float64
+	SystemTrx             []*systems.SystemTrxData
+	BankTrx               []*banks.BankTrxData
+	GatewayTrx            []*gateways.GatewayTrxData
+	BankStatementBalances []balance.Result
+	MinSystemAmount       money.Amount
+	MaxSystemAmount       money.Amount
 }
@@ -0,0 +1,221 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package _mock
+
+import (
+	gateways "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
+	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+)
+
+// GatewayTrxDataInterface is an autogenerated mock type for the GatewayTrxDataInterface type
+type GatewayTrxDataInterface struct {
+	mock.Mock
+}
+
+// GetAbsAmount provides a mock function with no fields
+func (_m *GatewayTrxDataInterface) GetAbsAmount() money.Amount {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAbsAmount")
+	}
+
+	var r0 money.Amount
+	if rf, ok := ret.Get(0).(func() money.Amount); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(money.Amount)
+	}
+
+	return r0
+}
+
+// GetAmount provides a mock function with no fields
+func (_m *GatewayTrxDataInterface) GetAmount() money.Amount {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAmount")
+	}
+
+	var r0 money.Amount
+	if rf, ok := ret.Get(0).(func() money.Amount); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(money.Amount)
+	}
+
+	return r0
+}
+
+// GetDate provides a mock function with no fields
+func (_m *GatewayTrxDataInterface) GetDate() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDate")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetFee provides a mock function with no fields
+func (_m *GatewayTrxDataInterface) GetFee() money.Amount {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFee")
+	}
+
+	var r0 money.Amount
+	if rf, ok := ret.Get(0).(func() money.Amount); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(money.Amount)
+	}
+
+	return r0
+}
+
+// GetGateway provides a mock function with no fields
+func (_m *GatewayTrxDataInterface) GetGateway() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGateway")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetPayoutID provides a mock function with no fields
+func (_m *GatewayTrxDataInterface) GetPayoutID() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPayoutID")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetReference provides a mock function with no fields
+func (_m *GatewayTrxDataInterface) GetReference() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReference")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetType provides a mock function with no fields
+func (_m *GatewayTrxDataInterface) GetType() gateways.TrxType {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetType")
+	}
+
+	var r0 gateways.TrxType
+	if rf, ok := ret.Get(0).(func() gateways.TrxType); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(gateways.TrxType)
+	}
+
+	return r0
+}
+
+// GetUniqueIdentifier provides a mock function with no fields
+func (_m *GatewayTrxDataInterface) GetUniqueIdentifier() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUniqueIdentifier")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ToGatewayTrxData provides a mock function with no fields
+func (_m *GatewayTrxDataInterface) ToGatewayTrxData() (*gateways.GatewayTrxData, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ToGatewayTrxData")
+	}
+
+	var r0 *gateways.GatewayTrxData
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*gateways.GatewayTrxData, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *gateways.GatewayTrxData); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gateways.GatewayTrxData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewGatewayTrxDataInterface creates a new instance of GatewayTrxDataInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGatewayTrxDataInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GatewayTrxDataInterface {
+	mock := &GatewayTrxDataInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
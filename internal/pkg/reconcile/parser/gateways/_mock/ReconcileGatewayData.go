@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package _mock
+
+import (
+	context "context"
+
+	gateways "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReconcileGatewayData is an autogenerated mock type for the ReconcileGatewayData type
+type ReconcileGatewayData struct {
+	mock.Mock
+}
+
+// GetGateway provides a mock function with no fields
+func (_m *ReconcileGatewayData) GetGateway() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGateway")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetParser provides a mock function with no fields
+func (_m *ReconcileGatewayData) GetParser() gateways.GatewayParserType {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParser")
+	}
+
+	var r0 gateways.GatewayParserType
+	if rf, ok := ret.Get(0).(func() gateways.GatewayParserType); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(gateways.GatewayParserType)
+	}
+
+	return r0
+}
+
+// ToGatewayTrxData provides a mock function with given fields: ctx, filePath
+func (_m *ReconcileGatewayData) ToGatewayTrxData(ctx context.Context, filePath string) ([]*gateways.GatewayTrxData, error) {
+	ret := _m.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ToGatewayTrxData")
+	}
+
+	var r0 []*gateways.GatewayTrxData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*gateways.GatewayTrxData, error)); ok {
+		return rf(ctx, filePath)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*gateways.GatewayTrxData); ok {
+		r0 = rf(ctx, filePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gateways.GatewayTrxData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReconcileGatewayData creates a new instance of ReconcileGatewayData. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReconcileGatewayData(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReconcileGatewayData {
+	mock := &ReconcileGatewayData{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
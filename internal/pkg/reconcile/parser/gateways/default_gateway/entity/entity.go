@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/banks"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
+)
+
+// CSVGatewayTrxData is a line of the default settlement report, Amount is negative for refunds
+type CSVGatewayTrxData struct {
+	DefaultUniqueIdentifier string       `csv:"UniqueIdentifier"`
+	DefaultDate             string       `csv:"Date"`
+	DefaultGateway          string       `csv:"-"`
+	DefaultAmount           money.Amount `csv:"Amount"`
+	DefaultFee              money.Amount `csv:"Fee"`
+	DefaultReference        string       `csv:"Reference"`
+	DefaultPayoutID         string       `csv:"PayoutID"`
+}
+
+func (u *CSVGatewayTrxData) GetUniqueIdentifier() string {
+	return u.DefaultUniqueIdentifier
+}
+
+func (u *CSVGatewayTrxData) GetDate() string {
+	return u.DefaultDate
+}
+
+func (u *CSVGatewayTrxData) GetReference() string {
+	return u.DefaultReference
+}
+
+func (u *CSVGatewayTrxData) GetPayoutID() string {
+	return u.DefaultPayoutID
+}
+
+func (u *CSVGatewayTrxData) GetAmount() money.Amount {
+	return u.DefaultAmount
+}
+
+func (u *CSVGatewayTrxData) GetAbsAmount() money.Amount {
+	return u.DefaultAmount.Abs()
+}
+
+func (u *CSVGatewayTrxData) GetFee() money.Amount {
+	return u.DefaultFee
+}
+
+func (u *CSVGatewayTrxData) GetType() gateways.TrxType {
+	if u.DefaultAmount < 0 {
+		return gateways.DEBIT
+	}
+
+	return gateways.CREDIT
+}
+
+func (u *CSVGatewayTrxData) GetGateway() string {
+	return u.DefaultGateway
+}
+
+func (u *CSVGatewayTrxData) ToGatewayTrxData() (returnData *gateways.GatewayTrxData, err error) {
+	t, _, e := banks.ParseDate(u.DefaultDate)
+	if e != nil {
+		return nil, e
+	}
+
+	return &gateways.GatewayTrxData{
+		UniqueIdentifier: u.DefaultUniqueIdentifier,
+		Reference:        u.DefaultReference,
+		PayoutID:         u.DefaultPayoutID,
+		Date:             t,
+		Type:             u.GetType(),
+		Gateway:          u.DefaultGateway,
+		FilePath:         "",
+		Amount:           u.GetAbsAmount(),
+		Fee:              u.DefaultFee,
+	}, nil
+}
@@ -0,0 +1,165 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
+)
+
+func TestCSVGatewayTrxDataGetType(t *testing.T) {
+	type fields struct {
+		DefaultAmount money.Amount
+	}
+
+	tests := []struct {
+		name   string
+		want   gateways.TrxType
+		fields fields
+	}{
+		{
+			name: "DEBIT",
+			fields: fields{
+				DefaultAmount: -1000,
+			},
+			want: gateways.DEBIT,
+		},
+		{
+			name: "CREDIT",
+			fields: fields{
+				DefaultAmount: 1000,
+			},
+			want: gateways.CREDIT,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &CSVGatewayTrxData{
+				DefaultAmount: tt.fields.DefaultAmount,
+			}
+
+			if got := u.GetType(); got != tt.want {
+				t.Errorf("GetType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVGatewayTrxDataGetAbsAmount(t *testing.T) {
+	type fields struct {
+		DefaultAmount money.Amount
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   money.Amount
+	}{
+		{
+			name: "Ok - positive",
+			fields: fields{
+				DefaultAmount: 1000,
+			},
+			want: 1000,
+		},
+		{
+			name: "Ok - negative",
+			fields: fields{
+				DefaultAmount: -1000,
+			},
+			want: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &CSVGatewayTrxData{
+				DefaultAmount: tt.fields.DefaultAmount,
+			}
+
+			if got := u.GetAbsAmount(); got != tt.want {
+				t.Errorf("GetAbsAmount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVGatewayTrxDataToGatewayTrxData(t *testing.T) {
+	type fields struct {
+		DefaultUniqueIdentifier string
+		DefaultDate             string
+		DefaultGateway          string
+		DefaultReference        string
+		DefaultPayoutID         string
+		DefaultAmount           money.Amount
+		DefaultFee              money.Amount
+	}
+
+	tests := []struct {
+		wantReturnData *gateways.GatewayTrxData
+		name           string
+		fields         fields
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				DefaultUniqueIdentifier: "gw-1",
+				DefaultDate:             "1999-01-01 10:30:00",
+				DefaultGateway:          "xendit",
+				DefaultReference:        "006630c83821fac6bea13b92b480feb2",
+				DefaultPayoutID:         "po-1",
+				DefaultAmount:           1000,
+				DefaultFee:              25,
+			},
+			wantReturnData: &gateways.GatewayTrxData{
+				UniqueIdentifier: "gw-1",
+				Reference:        "006630c83821fac6bea13b92b480feb2",
+				PayoutID:         "po-1",
+				Date:             time.Date(1999, 1, 1, 10, 30, 0, 0, time.UTC),
+				Type:             gateways.CREDIT,
+				Gateway:          "xendit",
+				Amount:           1000,
+				Fee:              25,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error invalid date",
+			fields: fields{
+				DefaultUniqueIdentifier: "gw-1",
+				DefaultDate:             "any string",
+				DefaultAmount:           1000,
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &CSVGatewayTrxData{
+				DefaultUniqueIdentifier: tt.fields.DefaultUniqueIdentifier,
+				DefaultDate:             tt.fields.DefaultDate,
+				DefaultGateway:          tt.fields.DefaultGateway,
+				DefaultAmount:           tt.fields.DefaultAmount,
+				DefaultFee:              tt.fields.DefaultFee,
+				DefaultReference:        tt.fields.DefaultReference,
+				DefaultPayoutID:         tt.fields.DefaultPayoutID,
+			}
+
+			gotReturnData, err := u.ToGatewayTrxData()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToGatewayTrxData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("ToGatewayTrxData() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
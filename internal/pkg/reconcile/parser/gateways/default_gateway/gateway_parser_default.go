@@ -0,0 +1,60 @@
+package default_gateway
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways/default_gateway/entity"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways/helper"
+)
+
+type GatewayParser struct {
+	csvReader     *csv.Reader
+	parser        gateways.GatewayParserType
+	gateway       string
+	isHaveHeader  bool
+	decimalPlaces int
+}
+
+var _ gateways.ReconcileGatewayData = (*GatewayParser)(nil)
+
+func NewGatewayParser(
+	gateway string,
+	csvReader *csv.Reader,
+	isHaveHeader bool,
+	decimalPlaces int,
+) (*GatewayParser, error) {
+	if csvReader == nil {
+		return nil, errors.New("csvReader is nil")
+	}
+
+	return &GatewayParser{
+		parser:        gateways.DefaultGatewayParser,
+		gateway:       gateway,
+		csvReader:     csvReader,
+		isHaveHeader:  isHaveHeader,
+		decimalPlaces: decimalPlaces,
+	}, nil
+}
+
+func (d *GatewayParser) GetParser() gateways.GatewayParserType {
+	return d.parser
+}
+
+func (d *GatewayParser) GetGateway() string {
+	return d.gateway
+}
+
+func (d *GatewayParser) ToGatewayTrxData(ctx context.Context, filePath string) (returnData []*gateways.GatewayTrxData, err error) {
+	return helper.ToGatewayTrxData(
+		ctx,
+		filePath,
+		d.isHaveHeader,
+		d.gateway,
+		d.decimalPlaces,
+		d.csvReader,
+		&entity.CSVGatewayTrxData{},
+	)
+}
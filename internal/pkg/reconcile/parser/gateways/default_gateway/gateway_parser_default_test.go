@@ -0,0 +1,225 @@
+package default_gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
+)
+
+const FileCSVPath = "/foo/bar.csv"
+
+func TestGatewayParserGetGateway(t *testing.T) {
+	type fields struct {
+		gateway string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				gateway: "xendit",
+			},
+			want: "xendit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &GatewayParser{
+				gateway: tt.fields.gateway,
+			}
+
+			if got := d.GetGateway(); got != tt.want {
+				t.Errorf("GetGateway() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGatewayParserGetParser(t *testing.T) {
+	type fields struct {
+		parser gateways.GatewayParserType
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   gateways.GatewayParserType
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				parser: gateways.DefaultGatewayParser,
+			},
+			want: gateways.DefaultGatewayParser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &GatewayParser{
+				parser: tt.fields.parser,
+			}
+
+			if got := d.GetParser(); got != tt.want {
+				t.Errorf("GetParser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGatewayParserToGatewayTrxData(t *testing.T) {
+	type fields struct {
+		csvReader     *csv.Reader
+		parser        gateways.GatewayParserType
+		gateway       string
+		isHaveHeader  bool
+		decimalPlaces int
+	}
+
+	type args struct {
+		filePath string
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantReturnData []*gateways.GatewayTrxData
+		wantErr        bool
+	}{
+		{
+			name: "Ok",
+			fields: fields{
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`UniqueIdentifier,Date,Amount,Fee,Reference,PayoutID
+gw-1,2025-03-15 10:30:00,20500,500,0012d068c53eb0971fc8563343c5d81f,po-1
+gw-2,2025-03-15 11:00:00,-4210.50,0,005dcbc9e27365a072be5393ea8d0f37,po-1`,
+					)
+					return csv.NewReader(f)
+				}(),
+				parser:        gateways.DefaultGatewayParser,
+				gateway:       "xendit",
+				isHaveHeader:  true,
+				decimalPlaces: 2,
+			},
+			args: args{
+				filePath: FileCSVPath,
+			},
+			wantReturnData: []*gateways.GatewayTrxData{
+				{
+					UniqueIdentifier: "gw-1",
+					Reference:        "0012d068c53eb0971fc8563343c5d81f",
+					PayoutID:         "po-1",
+					Date:             time.Date(2025, 3, 15, 10, 30, 0, 0, time.UTC),
+					Type:             gateways.CREDIT,
+					Gateway:          "xendit",
+					FilePath:         FileCSVPath,
+					Amount:           2050000,
+					Fee:              50000,
+				},
+				{
+					UniqueIdentifier: "gw-2",
+					Reference:        "005dcbc9e27365a072be5393ea8d0f37",
+					PayoutID:         "po-1",
+					Date:             time.Date(2025, 3, 15, 11, 0, 0, 0, time.UTC),
+					Type:             gateways.DEBIT,
+					Gateway:          "xendit",
+					FilePath:         FileCSVPath,
+					Amount:           421050,
+					Fee:              0,
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &GatewayParser{
+				csvReader:     tt.fields.csvReader,
+				parser:        tt.fields.parser,
+				gateway:       tt.fields.gateway,
+				isHaveHeader:  tt.fields.isHaveHeader,
+				decimalPlaces: tt.fields.decimalPlaces,
+			}
+
+			gotReturnData, err := d.ToGatewayTrxData(context.Background(), tt.args.filePath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToGatewayTrxData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("ToGatewayTrxData() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
+
+func TestNewGatewayParser(t *testing.T) {
+	type args struct {
+		csvReader     *csv.Reader
+		gateway       string
+		isHaveHeader  bool
+		decimalPlaces int
+	}
+
+	tests := []struct {
+		want    *GatewayParser
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			args: args{
+				gateway:       "xendit",
+				csvReader:     csv.NewReader(nil),
+				isHaveHeader:  false,
+				decimalPlaces: 2,
+			},
+			want: &GatewayParser{
+				csvReader:     csv.NewReader(nil),
+				parser:        gateways.DefaultGatewayParser,
+				gateway:       "xendit",
+				isHaveHeader:  false,
+				decimalPlaces: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error nil csvReader",
+			args: args{
+				gateway:      "xendit",
+				csvReader:    nil,
+				isHaveHeader: false,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGatewayParser(tt.args.gateway, tt.args.csvReader, tt.args.isHaveHeader, tt.args.decimalPlaces)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGatewayParser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewGatewayParser() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package gateways
+
+import (
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+)
+
+type GatewayParserType string
+
+const (
+	DefaultGatewayParser GatewayParserType = "DEFAULT"
+)
+
+type TrxType string
+
+const (
+	DEBIT  TrxType = "DEBIT"
+	CREDIT TrxType = "CREDIT"
+)
+
+// GatewayTrxData is a line of a payment gateway settlement report. Reference is the system TrxID the gateway was given,
+// Amount is the gross amount in the base currency, Fee what the gateway keeps of it and PayoutID the payout batch
+// transferring the net amount to the bank
+type GatewayTrxData struct {
+	UniqueIdentifier string
+	Reference        string
+	PayoutID         string
+	Date             time.Time
+	Type             TrxType
+	Gateway          string
+	FilePath         string
+	Amount           money.Amount
+	Fee              money.Amount
+}
@@ -0,0 +1,68 @@
+package helper
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/jszwec/csvutil"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
+	"github.com/oprekable/bank-reconcile/internal/pkg/utils/log"
+)
+
+func ToGatewayTrxData(ctx context.Context, filePath string, isHaveHeader bool, gateway string, decimalPlaces int, csvReader *csv.Reader, originalData gateways.GatewayTrxDataInterface) (returnData []*gateways.GatewayTrxData, err error) {
+	var dec *csvutil.Decoder
+	defer func() {
+		if r := recover(); r != nil {
+			errRecovery := fmt.Errorf("recovered from panic: %s", r)
+			log.AddErr(ctx, errRecovery)
+			return
+		}
+	}()
+
+	if isHaveHeader {
+		dec, err = csvutil.NewDecoder(csvReader)
+		if err != nil || dec == nil {
+			log.AddErr(ctx, err)
+			return nil, err
+		}
+	} else {
+		header, _ := csvutil.Header(originalData, "csv")
+		dec, err = csvutil.NewDecoder(csvReader, header...)
+		if err != nil {
+			log.AddErr(ctx, err)
+			return nil, err
+		}
+
+		// trailing optional columns (reference and payout) may be left out of files without header
+		dec.AlignRecord = true
+	}
+
+	dec.WithUnmarshalers(money.CSVUnmarshalers(decimalPlaces))
+
+	for {
+		err = dec.Decode(originalData)
+		if err != nil {
+			break
+		}
+
+		var gatewayTrxData *gateways.GatewayTrxData
+		gatewayTrxData, err = originalData.ToGatewayTrxData()
+		if err != nil {
+			log.AddErr(ctx, err)
+			continue
+		}
+
+		gatewayTrxData.Gateway = gateway
+		gatewayTrxData.FilePath = filePath
+		returnData = append(returnData, gatewayTrxData)
+	}
+
+	if err == io.EOF {
+		err = nil
+	}
+
+	return returnData, err
+}
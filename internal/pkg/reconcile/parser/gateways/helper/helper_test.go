@@ -0,0 +1,154 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/gateways/default_gateway/entity"
+)
+
+const (
+	FileCSVPath = "/foo/bar.csv"
+)
+
+func TestToGatewayTrxData(t *testing.T) {
+	type args struct {
+		originalData  gateways.GatewayTrxDataInterface
+		csvReader     *csv.Reader
+		filePath      string
+		gateway       string
+		isHaveHeader  bool
+		decimalPlaces int
+	}
+
+	tests := []struct {
+		name           string
+		args           args
+		wantReturnData []*gateways.GatewayTrxData
+		wantErr        bool
+	}{
+		{
+			name: "Ok with header",
+			args: args{
+				filePath:     FileCSVPath,
+				isHaveHeader: true,
+				gateway:      "xendit",
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`UniqueIdentifier,Date,Amount,Fee,Reference,PayoutID
+gw-1,2025-03-15,20500,500,0012d068c53eb0971fc8563343c5d81f,po-1`,
+					)
+					return csv.NewReader(f)
+				}(),
+				originalData: &entity.CSVGatewayTrxData{},
+			},
+			wantReturnData: []*gateways.GatewayTrxData{
+				{
+					UniqueIdentifier: "gw-1",
+					Reference:        "0012d068c53eb0971fc8563343c5d81f",
+					PayoutID:         "po-1",
+					Date:             time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC),
+					Type:             gateways.CREDIT,
+					Gateway:          "xendit",
+					FilePath:         FileCSVPath,
+					Amount:           20500,
+					Fee:              500,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok without header and payout",
+			args: args{
+				filePath:     FileCSVPath,
+				isHaveHeader: false,
+				gateway:      "xendit",
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`gw-1,2025-03-15,-20500,0,0012d068c53eb0971fc8563343c5d81f`,
+					)
+					return csv.NewReader(f)
+				}(),
+				originalData: &entity.CSVGatewayTrxData{},
+			},
+			wantReturnData: []*gateways.GatewayTrxData{
+				{
+					UniqueIdentifier: "gw-1",
+					Reference:        "0012d068c53eb0971fc8563343c5d81f",
+					Date:             time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC),
+					Type:             gateways.DEBIT,
+					Gateway:          "xendit",
+					FilePath:         FileCSVPath,
+					Amount:           20500,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error decode with header",
+			args: args{
+				filePath:     FileCSVPath,
+				isHaveHeader: true,
+				gateway:      "xendit",
+				csvReader: func() *csv.Reader {
+					return csv.NewReader(bytes.NewBufferString(``))
+				}(),
+				originalData: &entity.CSVGatewayTrxData{},
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+		{
+			name: "Error parse Date",
+			args: args{
+				filePath:     FileCSVPath,
+				isHaveHeader: true,
+				gateway:      "xendit",
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`UniqueIdentifier,Date,Amount,Fee
+gw-1,random string,20500,0`,
+					)
+					return csv.NewReader(f)
+				}(),
+				originalData: &entity.CSVGatewayTrxData{},
+			},
+			wantReturnData: nil,
+			wantErr:        false,
+		},
+		{
+			name: "Error nil originalData without header",
+			args: args{
+				filePath:     FileCSVPath,
+				isHaveHeader: false,
+				gateway:      "xendit",
+				csvReader: func() *csv.Reader {
+					return csv.NewReader(bytes.NewBufferString(``))
+				}(),
+				originalData: nil,
+			},
+			wantReturnData: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotReturnData, err := ToGatewayTrxData(context.Background(), tt.args.filePath, tt.args.isHaveHeader, tt.args.gateway, tt.args.decimalPlaces, tt.args.csvReader, tt.args.originalData)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToGatewayTrxData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
+				t.Errorf("ToGatewayTrxData() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package gateways
+
+import (
+	"context"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+)
+
+//go:generate mockery --name "ReconcileGatewayData" --output "./_mock" --outpkg "_mock"
+type ReconcileGatewayData interface {
+	GetGateway() string
+	GetParser() GatewayParserType
+	ToGatewayTrxData(ctx context.Context, filePath string) (returnData []*GatewayTrxData, err error)
+}
+
+//go:generate mockery --name "GatewayTrxDataInterface" --output "./_mock" --outpkg "_mock"
+type GatewayTrxDataInterface interface {
+	GetUniqueIdentifier() string
+	GetDate() string
+	GetReference() string
+	GetPayoutID() string
+	GetAmount() money.Amount
+	GetAbsAmount() money.Amount
+	GetFee() money.Amount
+	GetType() TrxType
+	GetGateway() string
+	ToGatewayTrxData() (returnData *GatewayTrxData, err error)
+}
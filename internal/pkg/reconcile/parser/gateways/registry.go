@@ -0,0 +1,35 @@
+package gateways
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// GatewayParserFactory defines the signature for a function that creates a new gateway parser.
+type GatewayParserFactory func(gatewayName string, reader *csv.Reader, hasHeader bool, decimalPlaces int) (ReconcileGatewayData, error)
+
+// ParserRegistry holds the collection of available gateway parser factories.
+// It is managed by the dependency injection container.
+type ParserRegistry struct {
+	factories map[string]GatewayParserFactory
+}
+
+// NewParserRegistry creates a new instance of ParserRegistry.
+func NewParserRegistry(factories map[string]GatewayParserFactory) *ParserRegistry {
+	return &ParserRegistry{factories: factories}
+}
+
+// GetParser retrieves a parser instance from the registry, amounts are read in minor units of decimalPlaces.
+func (r *ParserRegistry) GetParser(gatewayName string, fileReader io.Reader, hasHeader bool, decimalPlaces int) (ReconcileGatewayData, error) {
+	factory, ok := r.factories[gatewayName]
+	if !ok {
+		// Fallback to a default parser if the specific one is not found
+		defaultFactory, defaultOk := r.factories[string(DefaultGatewayParser)]
+		if !defaultOk {
+			return nil, fmt.Errorf("gateway parser for '%s' not found and no default parser is registered", gatewayName)
+		}
+		return defaultFactory(gatewayName, csv.NewReader(fileReader), hasHeader, decimalPlaces)
+	}
+	return factory(gatewayName, csv.NewReader(fileReader), hasHeader, decimalPlaces)
+}
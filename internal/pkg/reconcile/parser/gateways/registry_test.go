@@ -0,0 +1,77 @@
+package gateways
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// --- Mock Implementations for Testing ---
+
+type mockParser struct {
+	gatewayName string
+	parserType  GatewayParserType
+}
+
+func (m *mockParser) ToGatewayTrxData(_ context.Context, _ string) ([]*GatewayTrxData, error) {
+	return nil, errors.New("not implemented for mock")
+}
+
+func (m *mockParser) GetParser() GatewayParserType {
+	return m.parserType
+}
+
+func (m *mockParser) GetGateway() string {
+	return m.gatewayName
+}
+
+// --- Test Cases ---
+
+// TestParserRegistry tests the functionality of the ParserRegistry in isolation.
+func TestParserRegistry(t *testing.T) {
+	// 1. Setup: Create mock factories locally for the test.
+	factories := make(map[string]GatewayParserFactory)
+
+	factories["MOCK_XENDIT"] = func(gatewayName string, reader *csv.Reader, hasHeader bool, decimalPlaces int) (ReconcileGatewayData, error) {
+		return &mockParser{gatewayName: gatewayName, parserType: "MOCK_XENDIT_PARSER"}, nil
+	}
+	factories["DEFAULT"] = func(gatewayName string, reader *csv.Reader, hasHeader bool, decimalPlaces int) (ReconcileGatewayData, error) {
+		return &mockParser{gatewayName: gatewayName, parserType: DefaultGatewayParser}, nil
+	}
+
+	registry := NewParserRegistry(factories)
+
+	// Dummy reader for tests, as the content doesn't matter for this test.
+	dummyReader := strings.NewReader("")
+
+	t.Run("should get a specific parser for a registered gateway", func(t *testing.T) {
+		parser, err := registry.GetParser("MOCK_XENDIT", dummyReader, true, 2)
+		assert.NoError(t, err)
+		assert.NotNil(t, parser)
+		assert.Equal(t, "MOCK_XENDIT", parser.GetGateway())
+		assert.Equal(t, GatewayParserType("MOCK_XENDIT_PARSER"), parser.GetParser())
+	})
+
+	t.Run("should fall back to default parser for an unregistered gateway", func(t *testing.T) {
+		parser, err := registry.GetParser("UNKNOWN_GATEWAY", dummyReader, true, 2)
+		assert.NoError(t, err)
+		assert.NotNil(t, parser)
+		assert.Equal(t, "UNKNOWN_GATEWAY", parser.GetGateway())
+		assert.Equal(t, DefaultGatewayParser, parser.GetParser())
+	})
+
+	t.Run("should return an error if no parser is found and no default is registered", func(t *testing.T) {
+		// Setup for this specific case: Create a registry without a default parser.
+		emptyFactories := make(map[string]GatewayParserFactory)
+		emptyRegistry := NewParserRegistry(emptyFactories)
+
+		parser, err := emptyRegistry.GetParser("ANYGATEWAY", dummyReader, true, 2)
+		assert.Error(t, err)
+		assert.Nil(t, parser)
+		assert.Contains(t, err.Error(), "not found and no default parser is registered")
+	})
+}
@@ -3,8 +3,12 @@
 package _mock
 
 import (
-	systems "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems"
+	locale "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
 	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+
+	systems "github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems"
 )
 
 // SystemTrxDataInterface is an autogenerated mock type for the SystemTrxDataInterface type
@@ -13,18 +17,18 @@ type SystemTrxDataInterface struct {
 }
 
 // GetAmount provides a mock function with no fields
-func (_m *SystemTrxDataInterface) GetAmount() float64 {
+func (_m *SystemTrxDataInterface) GetAmount() money.Amount {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAmount")
 	}
 
-	var r0 float64
-	if rf, ok := ret.Get(0).(func() float64); ok {
+	var r0 money.Amount
+	if rf, ok := ret.Get(0).(func() money.Amount); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(float64)
+		r0 = ret.Get(0).(money.Amount)
 	}
 
 	return r0
@@ -84,9 +88,9 @@ func (_m *SystemTrxDataInterface) GetType() systems.TrxType {
 	return r0
 }
 
-// ToSystemTrxData provides a mock function with no fields
-func (_m *SystemTrxDataInterface) ToSystemTrxData() (*systems.SystemTrxData, error) {
-	ret := _m.Called()
+// ToSystemTrxData provides a mock function with given fields: loc
+func (_m *SystemTrxDataInterface) ToSystemTrxData(loc locale.Locale) (*systems.SystemTrxData, error) {
+	ret := _m.Called(loc)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ToSystemTrxData")
@@ -94,19 +98,19 @@ func (_m *SystemTrxDataInterface) ToSystemTrxData() (*systems.SystemTrxData, err
 
 	var r0 *systems.SystemTrxData
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*systems.SystemTrxData, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(locale.Locale) (*systems.SystemTrxData, error)); ok {
+		return rf(loc)
 	}
-	if rf, ok := ret.Get(0).(func() *systems.SystemTrxData); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(locale.Locale) *systems.SystemTrxData); ok {
+		r0 = rf(loc)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*systems.SystemTrxData)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(locale.Locale) error); ok {
+		r1 = rf(loc)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -6,20 +6,28 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"math"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/jszwec/csvutil"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems"
 	"github.com/oprekable/bank-reconcile/internal/pkg/utils/log"
 )
 
 type CSVSystemTrxData struct {
-	TrxID           string  `csv:"TrxID"`
-	TransactionTime string  `csv:"TransactionTime"`
-	Type            string  `csv:"Type"`
-	Amount          float64 `csv:"Amount"`
+	TrxID           string       `csv:"TrxID"`
+	TransactionTime string       `csv:"TransactionTime"`
+	Type            string       `csv:"Type"`
+	Amount          money.Amount `csv:"Amount"`
+	// Currency is optional, empty is the base currency
+	Currency string `csv:"Currency,omitempty"`
+	// Account is optional, the bank account the trx settles on
+	Account string `csv:"Account"`
+	// Reference is optional, the TrxID of the trx a reversal or a refund cancels or a reference both share
+	Reference string `csv:"Reference"`
 }
 
 func (u *CSVSystemTrxData) GetTrxID() string {
@@ -30,15 +38,15 @@ func (u *CSVSystemTrxData) GetTransactionTime() string {
 	return u.TransactionTime
 }
 
-func (u *CSVSystemTrxData) GetAmount() float64 {
-	return math.Abs(u.Amount)
+func (u *CSVSystemTrxData) GetAmount() money.Amount {
+	return u.Amount.Abs()
 }
 
 func (u *CSVSystemTrxData) GetType() systems.TrxType {
 	return systems.TrxType(u.Type)
 }
-func (u *CSVSystemTrxData) ToSystemTrxData() (returnData *systems.SystemTrxData, err error) {
-	t, e := time.Parse("2006-01-02 15:04:05", u.TransactionTime)
+func (u *CSVSystemTrxData) ToSystemTrxData(loc locale.Locale) (returnData *systems.SystemTrxData, err error) {
+	t, _, e := loc.ParseDate(u.TransactionTime)
 	if e != nil {
 		return nil, e
 	}
@@ -48,6 +56,9 @@ func (u *CSVSystemTrxData) ToSystemTrxData() (returnData *systems.SystemTrxData,
 		TransactionTime: t,
 		Type:            systems.TrxType(u.Type),
 		FilePath:        "",
+		Currency:        strings.ToUpper(strings.TrimSpace(u.Currency)),
+		Account:         strings.TrimSpace(u.Account),
+		Reference:       strings.TrimSpace(u.Reference),
 		Amount:          u.Amount,
 	}, nil
 }
@@ -57,7 +68,9 @@ type SystemParser struct {
 	csvReader         *csv.Reader
 	poolSystemTrxData *sync.Pool
 	parser            systems.SystemParserType
+	locale            locale.Locale
 	isHaveHeader      bool
+	decimalPlaces     int
 }
 
 var _ systems.SystemDataConverter = (*SystemParser)(nil)
@@ -66,16 +79,25 @@ func NewSystemParser(
 	dataStruct systems.SystemTrxDataInterface,
 	csvReader *csv.Reader,
 	isHaveHeader bool,
+	decimalPlaces int,
+	loc locale.Locale,
 ) (*SystemParser, error) {
 	if csvReader == nil || dataStruct == nil {
 		return nil, errors.New("csvReader or dataStruct is nil")
 	}
 
+	// the transaction time states the hour unless the locale says otherwise
+	if len(loc.DateLayouts) == 0 {
+		loc.DateLayouts = []string{time.DateTime}
+	}
+
 	return &SystemParser{
-		dataStruct:   dataStruct,
-		parser:       systems.DefaultSystemParser,
-		csvReader:    csvReader,
-		isHaveHeader: isHaveHeader,
+		dataStruct:    dataStruct,
+		parser:        systems.DefaultSystemParser,
+		csvReader:     csvReader,
+		isHaveHeader:  isHaveHeader,
+		decimalPlaces: decimalPlaces,
+		locale:        loc,
 		poolSystemTrxData: &sync.Pool{
 			New: func() interface{} {
 				return &systems.SystemTrxData{}
@@ -94,6 +116,7 @@ func (d *SystemParser) ToSystemTrxData(ctx context.Context, filePath string) (re
 		}
 	}()
 
+	d.locale.ApplyDelimiter(d.csvReader)
 	if d.isHaveHeader {
 		dec, err = csvutil.NewDecoder(d.csvReader)
 		if err != nil || dec == nil {
@@ -107,8 +130,13 @@ func (d *SystemParser) ToSystemTrxData(ctx context.Context, filePath string) (re
 			log.AddErr(ctx, err)
 			return nil, err
 		}
+
+		// trailing optional columns (the currency) may be left out of files without header
+		dec.AlignRecord = true
 	}
 
+	dec.WithUnmarshalers(d.locale.CSVUnmarshalers(d.decimalPlaces))
+
 	for {
 		originalData := d.dataStruct
 		err = dec.Decode(originalData)
@@ -119,7 +147,7 @@ func (d *SystemParser) ToSystemTrxData(ctx context.Context, filePath string) (re
 		//nolint:all
 		//lint:ignore SA4006 sync.pool pattern just like this
 		ptrSystemTrxData := d.poolSystemTrxData.Get().(*systems.SystemTrxData)
-		ptrSystemTrxData, err = originalData.ToSystemTrxData()
+		ptrSystemTrxData, err = originalData.ToSystemTrxData(d.locale)
 		d.poolSystemTrxData.Put(ptrSystemTrxData)
 
 		if err != nil {
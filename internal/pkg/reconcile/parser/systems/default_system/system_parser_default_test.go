@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
 	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/parser/systems"
 )
 
@@ -20,13 +22,13 @@ const (
 
 func TestCSVSystemTrxDataGetAmount(t *testing.T) {
 	type fields struct {
-		Amount float64
+		Amount money.Amount
 	}
 
 	tests := []struct {
 		name   string
 		fields fields
-		want   float64
+		want   money.Amount
 	}{
 		{
 			name: "Ok - positive",
@@ -163,13 +165,14 @@ func TestCSVSystemTrxDataToSystemTrxData(t *testing.T) {
 		TrxID           string
 		TransactionTime string
 		Type            string
-		Amount          float64
+		Amount          money.Amount
 	}
 
 	tests := []struct {
 		wantReturnData *systems.SystemTrxData
 		name           string
 		fields         fields
+		loc            locale.Locale
 		wantErr        bool
 	}{
 		{
@@ -192,6 +195,27 @@ func TestCSVSystemTrxDataToSystemTrxData(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Ok - locale",
+			fields: fields{
+				TrxID:           TrxID,
+				TransactionTime: "10 Nop 1999 22.58",
+				Type:            "DEBIT",
+				Amount:          10000,
+			},
+			loc: locale.Locale{
+				MonthNames:  locale.MonthNamesIndonesian,
+				DateLayouts: []string{"2 Jan 2006 15.04"},
+			},
+			wantReturnData: &systems.SystemTrxData{
+				TrxID:           TrxID,
+				TransactionTime: time.Date(1999, 11, 10, 22, 58, 0, 0, time.UTC),
+				Type:            "DEBIT",
+				FilePath:        "",
+				Amount:          10000,
+			},
+			wantErr: false,
+		},
 		{
 			name: "Error time parse",
 			fields: fields{
@@ -214,7 +238,7 @@ func TestCSVSystemTrxDataToSystemTrxData(t *testing.T) {
 				Amount:          tt.fields.Amount,
 			}
 
-			gotReturnData, err := u.ToSystemTrxData()
+			gotReturnData, err := u.ToSystemTrxData(tt.loc)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ToSystemTrxData() error = %v, wantErr %v", err, tt.wantErr)
@@ -230,9 +254,11 @@ func TestCSVSystemTrxDataToSystemTrxData(t *testing.T) {
 
 func TestNewSystemParser(t *testing.T) {
 	type args struct {
-		dataStruct   systems.SystemTrxDataInterface
-		csvReader    *csv.Reader
-		isHaveHeader bool
+		dataStruct    systems.SystemTrxDataInterface
+		csvReader     *csv.Reader
+		loc           locale.Locale
+		isHaveHeader  bool
+		decimalPlaces int
 	}
 
 	tests := []struct {
@@ -244,15 +270,18 @@ func TestNewSystemParser(t *testing.T) {
 		{
 			name: "Ok",
 			args: args{
-				dataStruct:   &CSVSystemTrxData{},
-				csvReader:    csv.NewReader(nil),
-				isHaveHeader: true,
+				dataStruct:    &CSVSystemTrxData{},
+				csvReader:     csv.NewReader(nil),
+				isHaveHeader:  true,
+				decimalPlaces: 2,
 			},
 			want: &SystemParser{
-				dataStruct:   &CSVSystemTrxData{},
-				csvReader:    csv.NewReader(nil),
-				parser:       systems.DefaultSystemParser,
-				isHaveHeader: true,
+				dataStruct:    &CSVSystemTrxData{},
+				csvReader:     csv.NewReader(nil),
+				parser:        systems.DefaultSystemParser,
+				isHaveHeader:  true,
+				decimalPlaces: 2,
+				locale:        locale.Locale{DateLayouts: []string{time.DateTime}},
 				poolSystemTrxData: &sync.Pool{
 					New: func() interface{} {
 						return &systems.SystemTrxData{}
@@ -261,6 +290,25 @@ func TestNewSystemParser(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Ok - locale",
+			args: args{
+				dataStruct:    &CSVSystemTrxData{},
+				csvReader:     csv.NewReader(nil),
+				loc:           locale.Locale{DecimalSeparator: ",", DateLayouts: []string{"02/01/2006 15:04"}},
+				isHaveHeader:  true,
+				decimalPlaces: 2,
+			},
+			want: &SystemParser{
+				dataStruct:    &CSVSystemTrxData{},
+				csvReader:     csv.NewReader(nil),
+				parser:        systems.DefaultSystemParser,
+				isHaveHeader:  true,
+				decimalPlaces: 2,
+				locale:        locale.Locale{DecimalSeparator: ",", DateLayouts: []string{"02/01/2006 15:04"}},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Error csvReader is nil",
 			args: args{
@@ -275,7 +323,7 @@ func TestNewSystemParser(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := NewSystemParser(tt.args.dataStruct, tt.args.csvReader, tt.args.isHaveHeader)
+			got, err := NewSystemParser(tt.args.dataStruct, tt.args.csvReader, tt.args.isHaveHeader, tt.args.decimalPlaces, tt.args.loc)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewSystemParser() error = %v, wantErr %v", err, tt.wantErr)
@@ -286,7 +334,9 @@ func TestNewSystemParser(t *testing.T) {
 				if !reflect.DeepEqual(got.parser, tt.want.parser) ||
 					!reflect.DeepEqual(got.csvReader, tt.want.csvReader) ||
 					!reflect.DeepEqual(got.dataStruct, tt.want.dataStruct) ||
-					!reflect.DeepEqual(got.isHaveHeader, tt.want.isHaveHeader) {
+					!reflect.DeepEqual(got.isHaveHeader, tt.want.isHaveHeader) ||
+					!reflect.DeepEqual(got.decimalPlaces, tt.want.decimalPlaces) ||
+					!reflect.DeepEqual(got.locale, tt.want.locale) {
 					t.Errorf("NewSystemParser() got = %v, want %v", got, tt.want)
 				}
 
@@ -306,10 +356,12 @@ func TestSystemParserToSystemTrxData(t *testing.T) {
 	layoutTime := "2006-01-02 15:04:05"
 
 	type fields struct {
-		dataStruct   systems.SystemTrxDataInterface
-		csvReader    *csv.Reader
-		parser       systems.SystemParserType
-		isHaveHeader bool
+		dataStruct    systems.SystemTrxDataInterface
+		csvReader     *csv.Reader
+		parser        systems.SystemParserType
+		locale        locale.Locale
+		isHaveHeader  bool
+		decimalPlaces int
 	}
 
 	type args struct {
@@ -330,13 +382,14 @@ func TestSystemParserToSystemTrxData(t *testing.T) {
 				csvReader: func() *csv.Reader {
 					f := bytes.NewBufferString(
 						`TrxID,TransactionTime,Type,Amount
-0012d068c53eb0971fc8563343c5d81f,2025-03-15 10:51:52,CREDIT,20500
+0012d068c53eb0971fc8563343c5d81f,2025-03-15 10:51:52,CREDIT,20500.25
 005dcbc9e27365a072be5393ea8d0f37,2025-03-14 18:29:01,CREDIT,42100`,
 					)
 					return csv.NewReader(f)
 				}(),
-				parser:       "",
-				isHaveHeader: true,
+				parser:        "",
+				isHaveHeader:  true,
+				decimalPlaces: 2,
 			},
 			args: args{
 				filePath: FileCSVPath,
@@ -350,7 +403,7 @@ func TestSystemParserToSystemTrxData(t *testing.T) {
 					}(),
 					Type:     "CREDIT",
 					FilePath: FileCSVPath,
-					Amount:   20500,
+					Amount:   2050025,
 				},
 				{
 					TrxID: "005dcbc9e27365a072be5393ea8d0f37",
@@ -360,7 +413,133 @@ func TestSystemParserToSystemTrxData(t *testing.T) {
 					}(),
 					Type:     "CREDIT",
 					FilePath: FileCSVPath,
-					Amount:   42100,
+					Amount:   4210000,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok with header and account",
+			fields: fields{
+				dataStruct: &CSVSystemTrxData{},
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`TrxID,TransactionTime,Type,Amount,Currency,Account
+0012d068c53eb0971fc8563343c5d81f,2025-03-15 10:51:52,CREDIT,20500.25,, 1234567890
+005dcbc9e27365a072be5393ea8d0f37,2025-03-14 18:29:01,CREDIT,42100,,`,
+					)
+					return csv.NewReader(f)
+				}(),
+				parser:        "",
+				isHaveHeader:  true,
+				decimalPlaces: 2,
+			},
+			args: args{
+				filePath: FileCSVPath,
+			},
+			wantReturnData: []*systems.SystemTrxData{
+				{
+					TrxID: "0012d068c53eb0971fc8563343c5d81f",
+					TransactionTime: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-15 10:51:52")
+						return t
+					}(),
+					Type:     "CREDIT",
+					FilePath: FileCSVPath,
+					Account:  "1234567890",
+					Amount:   2050025,
+				},
+				{
+					TrxID: "005dcbc9e27365a072be5393ea8d0f37",
+					TransactionTime: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-14 18:29:01")
+						return t
+					}(),
+					Type:     "CREDIT",
+					FilePath: FileCSVPath,
+					Amount:   4210000,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok with header and reference",
+			fields: fields{
+				dataStruct: &CSVSystemTrxData{},
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`TrxID,TransactionTime,Type,Amount,Currency,Account,Reference
+0012d068c53eb0971fc8563343c5d81f,2025-03-15 10:51:52,DEBIT,42100,,,
+005dcbc9e27365a072be5393ea8d0f37,2025-03-15 18:29:01,CREDIT,42100,,, 0012d068c53eb0971fc8563343c5d81f`,
+					)
+					return csv.NewReader(f)
+				}(),
+				parser:        "",
+				isHaveHeader:  true,
+				decimalPlaces: 2,
+			},
+			args: args{
+				filePath: FileCSVPath,
+			},
+			wantReturnData: []*systems.SystemTrxData{
+				{
+					TrxID: "0012d068c53eb0971fc8563343c5d81f",
+					TransactionTime: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-15 10:51:52")
+						return t
+					}(),
+					Type:     "DEBIT",
+					FilePath: FileCSVPath,
+					Amount:   4210000,
+				},
+				{
+					TrxID:     "005dcbc9e27365a072be5393ea8d0f37",
+					Reference: "0012d068c53eb0971fc8563343c5d81f",
+					TransactionTime: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-15 18:29:01")
+						return t
+					}(),
+					Type:     "CREDIT",
+					FilePath: FileCSVPath,
+					Amount:   4210000,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ok with header and locale",
+			fields: fields{
+				dataStruct: &CSVSystemTrxData{},
+				csvReader: func() *csv.Reader {
+					f := bytes.NewBufferString(
+						`TrxID;TransactionTime;Type;Amount
+0012d068c53eb0971fc8563343c5d81f;15/03/2025 10:51;CREDIT;1.020.500,25`,
+					)
+					return csv.NewReader(f)
+				}(),
+				parser: "",
+				locale: locale.Locale{
+					DecimalSeparator:   ",",
+					ThousandsSeparator: ".",
+					DateLayouts:        []string{"02/01/2006 15:04"},
+					Delimiter:          ';',
+				},
+				isHaveHeader:  true,
+				decimalPlaces: 2,
+			},
+			args: args{
+				filePath: FileCSVPath,
+			},
+			wantReturnData: []*systems.SystemTrxData{
+				{
+					TrxID: "0012d068c53eb0971fc8563343c5d81f",
+					TransactionTime: func() time.Time {
+						t, _ := time.Parse(layoutTime, "2025-03-15 10:51:00")
+						return t
+					}(),
+					Type:     "CREDIT",
+					FilePath: FileCSVPath,
+					Amount:   102050025,
 				},
 			},
 			wantErr: false,
@@ -501,10 +680,12 @@ func TestSystemParserToSystemTrxData(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := &SystemParser{
-				dataStruct:   tt.fields.dataStruct,
-				csvReader:    tt.fields.csvReader,
-				parser:       tt.fields.parser,
-				isHaveHeader: tt.fields.isHaveHeader,
+				dataStruct:    tt.fields.dataStruct,
+				csvReader:     tt.fields.csvReader,
+				parser:        tt.fields.parser,
+				locale:        tt.fields.locale,
+				isHaveHeader:  tt.fields.isHaveHeader,
+				decimalPlaces: tt.fields.decimalPlaces,
 				poolSystemTrxData: &sync.Pool{
 					New: func() interface{} {
 						return &systems.SystemTrxData{}
@@ -520,7 +701,7 @@ func TestSystemParserToSystemTrxData(t *testing.T) {
 			}
 
 			if !reflect.DeepEqual(gotReturnData, tt.wantReturnData) {
-				t.Errorf("ToSystemTrxData() gotReturnData = %v, want %v", gotReturnData, tt.wantReturnData)
+				t.Errorf("ToSystemTrxData() gotReturnData = %+v, want %+v", *gotReturnData[0], *tt.wantReturnData[0])
 			}
 		})
 	}
@@ -1,6 +1,10 @@
 package systems
 
-import "time"
+import (
+	"time"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+)
 
 type SystemParserType string
 
@@ -10,10 +14,19 @@ const (
 
 type TrxType string
 
+// SystemTrxData Amount is in the base currency, OriginalAmount is the amount booked in Currency. IsMissingFXRate tells
+// no rate converts Currency to the base currency, Amount is then left unconverted. Account is the bank account the trx
+// is expected to settle on, empty when any. Reference links a reversal or a refund to the trx it cancels, by its TrxID
+// or a reference both share, empty when none
 type SystemTrxData struct {
 	TrxID           string
+	Reference       string
 	TransactionTime time.Time
 	Type            TrxType
 	FilePath        string
-	Amount          float64
+	Currency        string
+	Account         string
+	Amount          money.Amount
+	OriginalAmount  money.Amount
+	IsMissingFXRate bool
 }
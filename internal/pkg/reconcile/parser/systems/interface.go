@@ -1,6 +1,11 @@
 package systems
 
-import "context"
+import (
+	"context"
+
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/locale"
+	"github.com/oprekable/bank-reconcile/internal/pkg/reconcile/money"
+)
 
 //go:generate mockery --name "SystemDataConverter" --output "./_mock" --outpkg "_mock"
 type SystemDataConverter interface {
@@ -11,7 +16,7 @@ type SystemDataConverter interface {
 type SystemTrxDataInterface interface {
 	GetTrxID() string
 	GetTransactionTime() string
-	GetAmount() float64
+	GetAmount() money.Amount
 	GetType() TrxType
-	ToSystemTrxData() (returnData *SystemTrxData, err error)
+	ToSystemTrxData(loc locale.Locale) (returnData *SystemTrxData, err error)
 }
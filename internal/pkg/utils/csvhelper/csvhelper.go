@@ -1,7 +1,9 @@
 package csvhelper
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"path/filepath"
 
 	"github.com/aaronjan/hunch"
@@ -9,7 +11,8 @@ import (
 	"github.com/spf13/afero"
 )
 
-func StructToCSVFile(ctx context.Context, fs afero.Fs, filePath string, structData interface{}, isDeleteDirectory bool) error {
+// StructToCSVFile writes the slice of struct as CSV file with header, marshalers customize how field types are written
+func StructToCSVFile(ctx context.Context, fs afero.Fs, filePath string, structData interface{}, isDeleteDirectory bool, marshalers ...*csvutil.Marshalers) error {
 	_, err := hunch.Waterfall(
 		ctx,
 		func(c context.Context, i interface{}) (interface{}, error) {
@@ -23,7 +26,21 @@ func StructToCSVFile(ctx context.Context, fs afero.Fs, filePath string, structDa
 			return nil, fs.MkdirAll(filepath.Dir(filePath), 0755)
 		},
 		func(c context.Context, i interface{}) (interface{}, error) {
-			return csvutil.Marshal(structData)
+			buf := bytes.Buffer{}
+			w := csv.NewWriter(&buf)
+			enc := csvutil.NewEncoder(w)
+			enc.WithMarshalers(csvutil.NewMarshalers(marshalers...))
+
+			if e := enc.EncodeHeader(structData); e != nil {
+				return nil, e
+			}
+
+			if e := enc.Encode(structData); e != nil {
+				return nil, e
+			}
+
+			w.Flush()
+			return buf.Bytes(), w.Error()
 		},
 		func(c context.Context, i interface{}) (interface{}, error) {
 			marshal := i.([]byte)
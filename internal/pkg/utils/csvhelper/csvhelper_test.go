@@ -3,8 +3,10 @@ package csvhelper
 import (
 	"context"
 	"os"
+	"strconv"
 	"testing"
 
+	"github.com/jszwec/csvutil"
 	"github.com/spf13/afero"
 )
 
@@ -62,18 +64,21 @@ func TestDeleteDirectory(t *testing.T) {
 func TestStructToCSVFile(t *testing.T) {
 	type T struct {
 		Name string
+		Age  int
 	}
 
 	type args struct {
 		fs                afero.Fs
 		structData        interface{}
 		filePath          string
+		marshalers        []*csvutil.Marshalers
 		isDeleteDirectory bool
 	}
 
 	tests := []struct {
 		name    string
 		args    args
+		want    string
 		wantErr bool
 	}{
 		{
@@ -84,10 +89,32 @@ func TestStructToCSVFile(t *testing.T) {
 				structData: []T{
 					{
 						Name: "test",
+						Age:  7,
 					},
 				},
 				isDeleteDirectory: true,
 			},
+			want:    "Name,Age\ntest,7\n",
+			wantErr: false,
+		},
+		{
+			name: "structData with marshalers - Success",
+			args: args{
+				fs:       afero.NewMemMapFs(),
+				filePath: "/test/test.csv",
+				structData: []T{
+					{
+						Name: "test",
+						Age:  7,
+					},
+				},
+				marshalers: []*csvutil.Marshalers{
+					csvutil.MarshalFunc(func(i int) ([]byte, error) {
+						return []byte("0" + strconv.Itoa(i)), nil
+					}),
+				},
+			},
+			want:    "Name,Age\ntest,07\n",
 			wantErr: false,
 		},
 		{
@@ -98,14 +125,34 @@ func TestStructToCSVFile(t *testing.T) {
 				structData:        []T{},
 				isDeleteDirectory: false,
 			},
+			want:    "Name,Age\n",
 			wantErr: false,
 		},
+		{
+			name: "structData is not a slice of struct - Error",
+			args: args{
+				fs:         afero.NewMemMapFs(),
+				filePath:   "/test/test.csv",
+				structData: []string{"test"},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := StructToCSVFile(context.Background(), tt.args.fs, tt.args.filePath, tt.args.structData, tt.args.isDeleteDirectory); (err != nil) != tt.wantErr {
+			err := StructToCSVFile(context.Background(), tt.args.fs, tt.args.filePath, tt.args.structData, tt.args.isDeleteDirectory, tt.args.marshalers...)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("StructToCSVFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got, _ := afero.ReadFile(tt.args.fs, tt.args.filePath); string(got) != tt.want {
+				t.Errorf("StructToCSVFile() got = %q, want %q", got, tt.want)
 			}
 		})
 	}